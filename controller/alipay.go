@@ -12,6 +12,7 @@ import (
 
 type AlipayController struct {
 	PaymentMethodService *service.PaymentMethodService
+	OrderService         *service.OrderService
 }
 
 // return 回调
@@ -117,6 +118,17 @@ func (a *AlipayController) Notify(ctx *app.Context) {
 
 	ctx.Logger.Info("VerifySignWithCert success")
 
+	// 交易成功，更新订单状态
+	tradeStatus := notifyReq.GetString("trade_status")
+	if tradeStatus == "TRADE_SUCCESS" || tradeStatus == "TRADE_FINISHED" {
+		err = a.OrderService.MarkOrderPaid(ctx, notifyReq.GetString("out_trade_no"), "alipay", notifyReq.GetString("trade_no"))
+		if err != nil {
+			ctx.Logger.Error("Failed to mark order paid", err)
+			ctx.JSONError(http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
 	// 如果需要，可将 BodyMap 内数据，Unmarshal 到指定结构体指针 ptr
 	//err = notifyReq.Unmarshal(ptr)
 
@@ -119,3 +119,35 @@ func (a *APIController) GetAPIList(ctx *app.Context) {
 
 	ctx.JSONSuccess(apis)
 }
+
+// @Summary 同步API
+// @Description 根据已注册的路由同步API，新增的API为未启用状态，路由中不存在的API标记为删除
+// @Tags API
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} model.APISyncResponse
+// @Router /api/v1/sys_api/sync [post]
+func (a *APIController) SyncAPI(ctx *app.Context) {
+	res, err := a.APIService.SyncRoutes(ctx)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSONSuccess(res)
+}
+
+// @Summary 获取API差异
+// @Description 对比已注册的路由和API表，返回新增和删除的API
+// @Tags API
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} model.APIDiffResponse
+// @Router /api/v1/sys_api/diff [post]
+func (a *APIController) GetAPIDiff(ctx *app.Context) {
+	res, err := a.APIService.GetAPIDiff(ctx)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSONSuccess(res)
+}
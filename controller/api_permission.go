@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"net/http"
+	"sgin/model"
 	"sgin/pkg/app"
 	"sgin/service"
 )
@@ -9,15 +11,51 @@ type ApiPermissionController struct {
 	APIPermissionService *service.AppPermissionService
 }
 
-// @Summary Get API Permission List
-// @Description Get API Permission List
+// List 获取app的接口权限
+// @Summary 获取app的接口权限
+// @Description app可以调用的开放接口
 // @Tags API Permission
 // @Accept json
 // @Produce json
-// @Param Authorization header string true "With the bearer started" default(Bearer <Token>)
 // @Param params body model.ReqApiPermissionParam true "查询参数"
-// @Success 200 {object} model.PagedResponse
+// @Success 200 {object} model.APIListResponse
 // @Router /api/v1/app/api/permissions/list [post]
-func (ac *ApiPermissionController) List(c *app.Context) {
-	// api 权限列表
+func (ac *ApiPermissionController) List(ctx *app.Context) {
+	param := &model.ReqApiPermissionParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	apis, err := ac.APIPermissionService.GetAppAPIPermissions(ctx, param.AppId)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(apis)
+}
+
+// Set 设置app的接口权限
+// @Summary 设置app的接口权限
+// @Description 替换app原有的接口权限
+// @Tags API Permission
+// @Accept json
+// @Produce json
+// @Param params body model.ReqAppPermissionSet true "权限参数"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/app/api/permissions/set [post]
+func (ac *ApiPermissionController) Set(ctx *app.Context) {
+	param := &model.ReqAppPermissionSet{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := ac.APIPermissionService.SetAppAPIPermissions(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
 }
@@ -62,17 +62,17 @@ func (ac *AppController) CreateApp(c *app.Context) {
 // @Description 更新应用
 // @Accept  json
 // @Produce  json
-// @Param params body model.App true "Update app"
+// @Param params body model.ReqAppUpdate true "Update app"
 // @Success 200 {object} model.AppInfoResponse
 // @Router /api/v1/app/update [post]
 func (ac *AppController) UpdateApp(c *app.Context) {
-	var app model.App
-	if err := c.ShouldBindJSON(&app); err != nil {
+	param := &model.ReqAppUpdate{}
+	if err := c.ShouldBindJSON(param); err != nil {
 		c.JSONError(http.StatusBadRequest, err.Error())
 		return
 	}
 
-	err := ac.AppService.UpdateApp(c, &app)
+	app, err := ac.AppService.UpdateApp(c, param)
 	if err != nil {
 		c.JSONError(http.StatusInternalServerError, err.Error())
 		return
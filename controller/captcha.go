@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type CaptchaController struct {
+	CaptchaService *service.CaptchaService
+}
+
+// CreateCaptcha 获取图形验证码
+// @Summary 获取图形验证码
+// @Description 登录失败次数过多时需要图形验证码，登录时提交captcha_id和captcha_code，验证码只能使用一次
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.CaptchaResponse
+// @Router /api/v1/captcha/create [post]
+func (c *CaptchaController) CreateCaptcha(ctx *app.Context) {
+	res, err := c.CaptchaService.CreateCaptcha(ctx)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
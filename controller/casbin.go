@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type CasbinController struct {
+	CasbinService *service.CasbinService
+}
+
+// GetPolicyList 查询casbin策略
+// @Summary 查询casbin策略
+// @Description generated为true的策略由角色配置生成
+// @Tags 权限策略
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCasbinPolicyQuery true "查询参数"
+// @Success 200 {object} model.CasbinRuleListResponse
+// @Router /api/v1/casbin/policy/list [post]
+func (c *CasbinController) GetPolicyList(ctx *app.Context) {
+	param := &model.ReqCasbinPolicyQuery{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rules, err := c.CasbinService.GetPolicyList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(rules)
+}
+
+// AddPolicy 添加casbin策略
+// @Summary 添加casbin策略
+// @Description 立即生效，p: 主体, 团队, 路径, 方法；g: 用户, 角色, 团队
+// @Tags 权限策略
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCasbinPolicy true "策略"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/casbin/policy/create [post]
+func (c *CasbinController) AddPolicy(ctx *app.Context) {
+	param := &model.ReqCasbinPolicy{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CasbinService.AddPolicy(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// RemovePolicy 删除casbin策略
+// @Summary 删除casbin策略
+// @Description 立即生效，由角色配置生成的策略在下次同步时会重新生成
+// @Tags 权限策略
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCasbinPolicy true "策略"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/casbin/policy/delete [post]
+func (c *CasbinController) RemovePolicy(ctx *app.Context) {
+	param := &model.ReqCasbinPolicy{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CasbinService.RemovePolicy(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// Explain 查询权限校验结果
+// @Summary 查询权限校验结果
+// @Description 返回是否允许、用户在团队中的角色和策略，以及被拒绝的原因
+// @Tags 权限策略
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCasbinExplain true "请求信息"
+// @Success 200 {object} model.CasbinExplainResponse
+// @Router /api/v1/casbin/explain [post]
+func (c *CasbinController) Explain(ctx *app.Context) {
+	param := &model.ReqCasbinExplain{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := c.CasbinService.Explain(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// SyncPolicies 同步casbin策略
+// @Summary 同步casbin策略
+// @Description 根据角色、菜单和接口的关联重新生成策略，手工添加的策略保留，返回生成的策略数量
+// @Tags 权限策略
+// @Produce json
+// @Success 200 {object} model.IntDataResponse
+// @Router /api/v1/casbin/policy/sync [post]
+func (c *CasbinController) SyncPolicies(ctx *app.Context) {
+	count, err := c.CasbinService.SyncPolicies(ctx)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(count)
+}
+
+// Reload 重新加载casbin策略
+// @Summary 重新加载casbin策略
+// @Description 从数据库重新加载策略，多实例部署时其他实例在下次请求时重新加载
+// @Tags 权限策略
+// @Produce json
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/casbin/reload [post]
+func (c *CasbinController) Reload(ctx *app.Context) {
+	if err := c.CasbinService.Reload(ctx); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
@@ -0,0 +1,260 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type CustomerController struct {
+	CustomerService *service.CustomerService
+}
+
+// Register 客户注册
+// @Summary 客户注册
+// @Description 邮箱验证码通过 /api/v1/verification_code/create 发送
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerRegister true "注册参数"
+// @Success 200 {object} model.CustomerInfoResponse
+// @Router /api/v1/f/customer/register [post]
+func (c *CustomerController) Register(ctx *app.Context) {
+	param := &model.ReqCustomerRegister{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	customer, err := c.CustomerService.Register(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(customer)
+}
+
+// Login 客户登录
+// @Summary 客户登录
+// @Description 返回客户受众的token，用于商城接口，不能用于后台用户登录
+// @Description 失败次数过多时返回428，需要通过 /api/v1/f/captcha/create 获取图形验证码后重新提交；账号或IP被锁定时返回423
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerLogin true "登录参数"
+// @Success 200 {object} model.ResUserLogin
+// @Router /api/v1/f/customer/login [post]
+func (c *CustomerController) Login(ctx *app.Context) {
+	param := &model.ReqCustomerLogin{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := c.CustomerService.Login(ctx, param)
+	if err != nil {
+		loginLockError(ctx, err)
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// SendPasswordResetCode 发送重置密码验证码
+// @Summary 发送重置密码验证码
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerEmailParam true "邮箱"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/f/customer/password/forgot [post]
+func (c *CustomerController) SendPasswordResetCode(ctx *app.Context) {
+	param := &model.ReqCustomerEmailParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerService.SendPasswordResetCode(ctx, param.Email); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// ResetPassword 通过邮箱验证码重置密码
+// @Summary 通过邮箱验证码重置密码
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerPasswordReset true "重置密码参数"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/f/customer/password/reset [post]
+func (c *CustomerController) ResetPassword(ctx *app.Context) {
+	param := &model.ReqCustomerPasswordReset{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerService.ResetPassword(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// UpdateProfile 更新客户资料
+// @Summary 更新客户资料
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerProfileUpdate true "客户资料"
+// @Success 200 {object} model.CustomerInfoResponse
+// @Router /api/v1/f/customer/profile/update [post]
+func (c *CustomerController) UpdateProfile(ctx *app.Context) {
+	param := &model.ReqCustomerProfileUpdate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	customer, err := c.CustomerService.UpdateProfile(ctx, ctx.GetString("user_id"), param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(customer)
+}
+
+// UpdatePassword 修改密码
+// @Summary 修改密码
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerPasswordUpdate true "密码参数"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/f/customer/password/update [post]
+func (c *CustomerController) UpdatePassword(ctx *app.Context) {
+	param := &model.ReqCustomerPasswordUpdate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerService.UpdatePassword(ctx, ctx.GetString("user_id"), param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// RequestEmailChange 申请修改邮箱
+// @Summary 申请修改邮箱
+// @Description 验证码发送到新邮箱，确认后才会修改
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerEmailChange true "新邮箱"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/f/customer/email/change [post]
+func (c *CustomerController) RequestEmailChange(ctx *app.Context) {
+	param := &model.ReqCustomerEmailChange{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerService.RequestEmailChange(ctx, ctx.GetString("user_id"), param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// ConfirmEmailChange 确认修改邮箱
+// @Summary 确认修改邮箱
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerEmailConfirm true "新邮箱和验证码"
+// @Success 200 {object} model.CustomerInfoResponse
+// @Router /api/v1/f/customer/email/confirm [post]
+func (c *CustomerController) ConfirmEmailChange(ctx *app.Context) {
+	param := &model.ReqCustomerEmailConfirm{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	customer, err := c.CustomerService.ConfirmEmailChange(ctx, ctx.GetString("user_id"), param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(customer)
+}
+
+// DeleteAccount 注销账号
+// @Summary 注销账号
+// @Description 删除地址、购物车等个人数据并匿名化账号，订单等交易记录按法律要求保留
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerDelete true "当前密码"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/f/customer/delete [post]
+func (c *CustomerController) DeleteAccount(ctx *app.Context) {
+	param := &model.ReqCustomerDelete{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerService.DeleteAccount(ctx, ctx.GetString("user_id"), param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// GetProfile 获取客户资料
+// @Summary 获取客户资料
+// @Tags 客户
+// @Produce json
+// @Success 200 {object} model.CustomerInfoResponse
+// @Router /api/v1/f/customer/profile [get]
+func (c *CustomerController) GetProfile(ctx *app.Context) {
+	customer, err := c.CustomerService.GetCustomerByUUID(ctx, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(customer)
+}
+
+// ExportData 导出个人数据
+// @Summary 导出个人数据
+// @Description 导出客户资料、地址、订单、付款、订阅和退货记录，JSON格式
+// @Tags 客户
+// @Produce application/json
+// @Router /api/v1/f/customer/export [get]
+func (c *CustomerController) ExportData(ctx *app.Context) {
+	data, err := c.CustomerService.ExportData(ctx, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	sendAttachment(ctx, "customer-data.json", "application/json", data)
+}
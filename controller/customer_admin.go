@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"time"
+)
+
+// GetCustomerList 后台获取客户列表
+// @Summary 后台获取客户列表
+// @Description 返回客户的订单数、消费金额、平均订单金额、首次和最近下单时间、默认地址和标签，指定segment_uuid时使用客户分群的筛选条件
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerQueryParam true "查询参数"
+// @Success 200 {object} model.PagedResponse
+// @Router /api/v1/customer/list [post]
+func (c *CustomerController) GetCustomerList(ctx *app.Context) {
+	param := &model.ReqCustomerQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := c.CustomerService.GetCustomerList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// GetCustomerInfo 后台获取客户详情
+// @Summary 后台获取客户详情
+// @Description 返回客户的消费统计、收货地址和最近10个订单
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "客户uuid"
+// @Success 200 {object} model.CustomerDetailResponse
+// @Router /api/v1/customer/info [post]
+func (c *CustomerController) GetCustomerInfo(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := c.CustomerService.GetCustomerDetail(ctx, param.Uuid)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// ExportCustomers 导出客户
+// @Summary 导出客户
+// @Description 按筛选条件导出全部客户为CSV，忽略分页参数
+// @Tags 客户
+// @Accept json
+// @Produce text/csv
+// @Param params body model.ReqCustomerQueryParam true "查询参数"
+// @Router /api/v1/customer/export [post]
+func (c *CustomerController) ExportCustomers(ctx *app.Context) {
+	param := &model.ReqCustomerQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, err := c.CustomerService.ExportCustomers(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	sendAttachment(ctx, "customers-"+time.Now().Format("20060102150405")+".csv", "text/csv; charset=utf-8", data)
+}
+
+// AddCustomerTags 给客户添加标签
+// @Summary 给客户添加标签
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerTagParam true "客户和标签"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/customer/tag/add [post]
+func (c *CustomerController) AddCustomerTags(ctx *app.Context) {
+	param := &model.ReqCustomerTagParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerService.AddCustomerTags(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// RemoveCustomerTags 删除客户标签
+// @Summary 删除客户标签
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerTagParam true "客户和标签"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/customer/tag/remove [post]
+func (c *CustomerController) RemoveCustomerTags(ctx *app.Context) {
+	param := &model.ReqCustomerTagParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerService.RemoveCustomerTags(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// GetCustomerTagList 获取客户标签列表
+// @Summary 获取客户标签列表
+// @Description 返回所有标签及使用的客户数
+// @Tags 客户
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.CustomerTagListResponse
+// @Router /api/v1/customer/tag/list [post]
+func (c *CustomerController) GetCustomerTagList(ctx *app.Context) {
+	res, err := c.CustomerService.GetCustomerTagList(ctx)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type CustomerGroupController struct {
+	CustomerGroupService *service.CustomerGroupService
+}
+
+// CreateCustomerGroup 创建客户分组
+// @Summary 创建客户分组
+// @Tags 客户分组
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerGroupCreate true "客户分组"
+// @Success 200 {object} model.CustomerGroupInfoResponse
+// @Router /api/v1/customer/group/create [post]
+func (c *CustomerGroupController) CreateCustomerGroup(ctx *app.Context) {
+	param := &model.ReqCustomerGroupCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	group, err := c.CustomerGroupService.CreateCustomerGroup(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(group)
+}
+
+// UpdateCustomerGroup 更新客户分组
+// @Summary 更新客户分组
+// @Tags 客户分组
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerGroupUpdate true "客户分组"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/customer/group/update [post]
+func (c *CustomerGroupController) UpdateCustomerGroup(ctx *app.Context) {
+	param := &model.ReqCustomerGroupUpdate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerGroupService.UpdateCustomerGroup(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// DeleteCustomerGroup 删除客户分组
+// @Summary 删除客户分组
+// @Description 分组的价格表会被禁用
+// @Tags 客户分组
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "客户分组UUID"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/customer/group/delete [post]
+func (c *CustomerGroupController) DeleteCustomerGroup(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerGroupService.DeleteCustomerGroup(ctx, param.Uuid); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// GetCustomerGroupList 获取客户分组列表
+// @Summary 获取客户分组列表
+// @Tags 客户分组
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerGroupQueryParam true "查询参数"
+// @Success 200 {object} model.CustomerGroupQueryResponse
+// @Router /api/v1/customer/group/list [post]
+func (c *CustomerGroupController) GetCustomerGroupList(ctx *app.Context) {
+	param := &model.ReqCustomerGroupQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	groups, err := c.CustomerGroupService.GetCustomerGroupList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(groups)
+}
+
+// AddGroupUsers 添加客户到分组
+// @Summary 添加客户到分组
+// @Tags 客户分组
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerGroupUserParam true "分组和用户"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/customer/group/user/add [post]
+func (c *CustomerGroupController) AddGroupUsers(ctx *app.Context) {
+	param := &model.ReqCustomerGroupUserParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerGroupService.AddGroupUsers(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// RemoveGroupUsers 从分组移除客户
+// @Summary 从分组移除客户
+// @Tags 客户分组
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerGroupUserParam true "分组和用户"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/customer/group/user/remove [post]
+func (c *CustomerGroupController) RemoveGroupUsers(ctx *app.Context) {
+	param := &model.ReqCustomerGroupUserParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerGroupService.RemoveGroupUsers(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
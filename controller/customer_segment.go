@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type CustomerSegmentController struct {
+	CustomerSegmentService *service.CustomerSegmentService
+}
+
+// CreateCustomerSegment 创建客户分群
+// @Summary 创建客户分群
+// @Description 保存客户筛选条件，例如最近90天消费超过1000：{"days":90,"min_spent":1000}
+// @Tags 客户分群
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerSegmentCreate true "客户分群"
+// @Success 200 {object} model.CustomerSegmentInfoResponse
+// @Router /api/v1/customer/segment/create [post]
+func (c *CustomerSegmentController) CreateCustomerSegment(ctx *app.Context) {
+	param := &model.ReqCustomerSegmentCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	segment, err := c.CustomerSegmentService.CreateCustomerSegment(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(segment)
+}
+
+// UpdateCustomerSegment 更新客户分群
+// @Summary 更新客户分群
+// @Tags 客户分群
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerSegmentUpdate true "客户分群"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/customer/segment/update [post]
+func (c *CustomerSegmentController) UpdateCustomerSegment(ctx *app.Context) {
+	param := &model.ReqCustomerSegmentUpdate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerSegmentService.UpdateCustomerSegment(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// DeleteCustomerSegment 删除客户分群
+// @Summary 删除客户分群
+// @Tags 客户分群
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "客户分群uuid"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/customer/segment/delete [post]
+func (c *CustomerSegmentController) DeleteCustomerSegment(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.CustomerSegmentService.DeleteCustomerSegment(ctx, param.Uuid); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// GetCustomerSegmentList 获取客户分群列表
+// @Summary 获取客户分群列表
+// @Tags 客户分群
+// @Accept json
+// @Produce json
+// @Param params body model.ReqCustomerSegmentQueryParam true "查询参数"
+// @Success 200 {object} model.PagedResponse
+// @Router /api/v1/customer/segment/list [post]
+func (c *CustomerSegmentController) GetCustomerSegmentList(ctx *app.Context) {
+	param := &model.ReqCustomerSegmentQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := c.CustomerSegmentService.GetCustomerSegmentList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type DigitalController struct {
+	DigitalService *service.DigitalService
+}
+
+// CreateDigitalFile 上传虚拟产品文件
+// @Summary 上传虚拟产品文件
+// @Description 文件保存在私有目录，只能通过签名下载链接访问
+// @Tags 虚拟产品
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "文件"
+// @Param product_uuid formData string true "产品UUID"
+// @Param product_item_uuid formData string false "产品SKU UUID"
+// @Param max_downloads formData int false "最大下载次数"
+// @Param expire_days formData int false "下载有效天数"
+// @Success 200 {object} model.ProductDigitalFileInfoResponse
+// @Router /api/v1/product/digital/upload [post]
+func (d *DigitalController) CreateDigitalFile(ctx *app.Context) {
+	param := &model.ReqDigitalFileCreate{}
+	if err := ctx.ShouldBind(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	digitalFile, err := d.DigitalService.CreateDigitalFile(ctx, param, file)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(digitalFile)
+}
+
+// GetDigitalFileList 获取产品虚拟文件列表
+// @Summary 获取产品虚拟文件列表
+// @Tags 虚拟产品
+// @Accept json
+// @Produce json
+// @Param params body model.ReqDigitalFileQueryParam true "查询参数"
+// @Success 200 {object} model.ProductDigitalFileListResponse
+// @Router /api/v1/product/digital/list [post]
+func (d *DigitalController) GetDigitalFileList(ctx *app.Context) {
+	param := &model.ReqDigitalFileQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	files, err := d.DigitalService.GetDigitalFileList(ctx, param.ProductUuid)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(files)
+}
+
+// DeleteDigitalFile 删除虚拟文件
+// @Summary 删除虚拟文件
+// @Tags 虚拟产品
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "虚拟文件UUID"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/product/digital/delete [post]
+func (d *DigitalController) DeleteDigitalFile(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := d.DigitalService.DeleteDigitalFile(ctx, param.Uuid); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// ImportLicenseKeys 导入许可证
+// @Summary 导入许可证
+// @Tags 虚拟产品
+// @Accept json
+// @Produce json
+// @Param params body model.ReqLicenseKeyImport true "许可证"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/product/license/import [post]
+func (d *DigitalController) ImportLicenseKeys(ctx *app.Context) {
+	param := &model.ReqLicenseKeyImport{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	count, err := d.DigitalService.ImportLicenseKeys(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(count)
+}
+
+// GetLicenseKeyList 获取许可证列表
+// @Summary 获取许可证列表
+// @Tags 虚拟产品
+// @Accept json
+// @Produce json
+// @Param params body model.ReqLicenseKeyQueryParam true "查询参数"
+// @Success 200 {object} model.LicenseKeyQueryResponse
+// @Router /api/v1/product/license/list [post]
+func (d *DigitalController) GetLicenseKeyList(ctx *app.Context) {
+	param := &model.ReqLicenseKeyQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	keys, err := d.DigitalService.GetLicenseKeyList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(keys)
+}
+
+// GetDigitalOrder 获取订单的下载链接和许可证
+// @Summary 获取订单的下载链接和许可证
+// @Tags 虚拟产品
+// @Accept json
+// @Produce json
+// @Param params body model.ReqDigitalOrderParam true "订单编号"
+// @Success 200 {object} model.DigitalOrderInfoResponse
+// @Router /api/v1/f/digital/order [post]
+func (d *DigitalController) GetDigitalOrder(ctx *app.Context) {
+	param := &model.ReqDigitalOrderParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := d.DigitalService.GetDigitalOrder(ctx, param.OrderNo, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// Download 下载虚拟商品
+// @Summary 下载虚拟商品
+// @Tags 虚拟产品
+// @Param id query string true "下载授权UUID"
+// @Param expires query int true "过期时间戳"
+// @Param sign query string true "签名"
+// @Router /api/v1/f/digital/download [get]
+func (d *DigitalController) Download(ctx *app.Context) {
+	param := &model.ReqDigitalDownloadParam{}
+	if err := ctx.ShouldBindQuery(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	path, name, err := d.DigitalService.Download(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusForbidden, err.Error())
+		return
+	}
+
+	ctx.FileAttachment(path, name)
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/utils"
+	"sgin/service"
+
+	"github.com/gorilla/websocket"
+)
+
+type InventoryController struct {
+	InventoryService *service.InventoryService
+}
+
+// GetInventoryLogList 获取库存流水列表
+// @Summary 获取库存流水列表
+// @Tags 库存
+// @Accept json
+// @Produce json
+// @Param params body model.ReqInventoryLogQueryParam true "查询参数"
+// @Success 200 {object} model.InventoryLogQueryResponse
+// @Router /api/v1/inventory/log/list [post]
+func (i *InventoryController) GetInventoryLogList(ctx *app.Context) {
+	param := &model.ReqInventoryLogQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	logs, err := i.InventoryService.GetInventoryLogList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(logs)
+}
+
+// AdjustStock 调整库存
+// @Summary 调整库存
+// @Tags 库存
+// @Accept json
+// @Produce json
+// @Param params body model.ReqInventoryAdjustParam true "调整参数"
+// @Success 200 {object} model.InventoryLogInfoResponse
+// @Router /api/v1/inventory/adjust [post]
+func (i *InventoryController) AdjustStock(ctx *app.Context) {
+	param := &model.ReqInventoryAdjustParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	log, err := i.InventoryService.AdjustStockByParam(ctx, param, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(log)
+}
+
+// GetLowStockList 获取库存预警列表
+// @Summary 获取库存预警列表
+// @Tags 库存
+// @Accept json
+// @Produce json
+// @Param params body model.ReqLowStockQueryParam true "查询参数"
+// @Success 200 {object} model.LowStockQueryResponse
+// @Router /api/v1/inventory/low_stock [post]
+func (i *InventoryController) GetLowStockList(ctx *app.Context) {
+	param := &model.ReqLowStockQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	items, err := i.InventoryService.GetLowStockList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(items)
+}
+
+// LowStockWs 库存预警推送，token通过query参数传递
+// @Summary 库存预警推送
+// @Tags 库存
+// @Param token query string true "token"
+// @Router /api/v1/ws/inventory [get]
+func (i *InventoryController) LowStockWs(ctx *app.WSContext) {
+	tenantUuid, err := i.authorizeWs(ctx.Context(), ctx.Request.URL.Query().Get("token"))
+	if err != nil {
+		ctx.Conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, err.Error()))
+		ctx.Conn.Close()
+		return
+	}
+
+	service.InventoryWsHub.Add(tenantUuid, ctx.Conn)
+	defer service.InventoryWsHub.Remove(tenantUuid, ctx.Conn)
+
+	// 只读取以感知连接关闭
+	for {
+		if _, _, err := ctx.Conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// 校验websocket连接的token和接口权限，返回连接所属的租户
+// 与LoginCheck、UserPermission中间件一致：会话撤销后token失效，商城客户不能连接
+func (i *InventoryController) authorizeWs(ctx *app.Context, token string) (string, error) {
+	claims, err := service.NewSessionService().ParseToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if claims.Audience == utils.TokenAudienceCustomer {
+		return "", errors.New("没有权限")
+	}
+
+	tenantUuid := ""
+	if ctx.Config.Tenant.Enable {
+		tenantUuid, err = service.NewTenantService().GetTenantByUser(ctx, claims.UserID)
+		if err != nil {
+			return "", err
+		}
+		ctx.SetTenant(tenantUuid)
+	}
+	ctx.Set("user_id", claims.UserID)
+
+	var allowed bool
+	if ctx.Config.Auth.Authorizer == service.AuthorizerCasbin {
+		casbinService := service.NewCasbinService()
+		if err := casbinService.ReloadIfChanged(ctx); err != nil {
+			return "", err
+		}
+		allowed, err = service.CasbinEnforcer().Enforce(claims.UserID, casbinService.GetDomain(ctx), ctx.Request.URL.Path, ctx.Request.Method)
+	} else {
+		allowed, err = service.NewRbacService().CheckPermission(ctx, claims.UserID, claims.Audience, ctx.Request.Method, ctx.Request.URL.Path)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !allowed {
+		return "", errors.New("没有权限")
+	}
+	return tenantUuid, nil
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type InvoiceController struct {
+	InvoiceService *service.InvoiceService
+}
+
+// GetInvoiceList 获取发票列表
+// @Summary 获取发票列表
+// @Tags 发票
+// @Accept json
+// @Produce json
+// @Param params body model.ReqInvoiceQueryParam true "查询参数"
+// @Success 200 {object} model.InvoiceQueryResponse
+// @Router /api/v1/invoice/list [post]
+func (i *InvoiceController) GetInvoiceList(ctx *app.Context) {
+	param := &model.ReqInvoiceQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	invoices, err := i.InvoiceService.GetInvoiceList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(invoices)
+}
+
+// DownloadInvoice 下载订单发票
+// @Summary 下载订单发票
+// @Description 订单首次下载时开具发票，发票号按年连续编号
+// @Tags 发票
+// @Produce application/pdf
+// @Param order_no query string true "订单编号"
+// @Router /api/v1/invoice/download [get]
+func (i *InvoiceController) DownloadInvoice(ctx *app.Context) {
+	param := &model.ReqInvoiceDownloadParam{}
+	if err := ctx.ShouldBindQuery(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, name, err := i.InvoiceService.RenderInvoice(ctx, param.OrderNo, "")
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	sendAttachment(ctx, name, "application/pdf", data)
+}
+
+// DownloadPackingSlip 下载订单装箱单
+// @Summary 下载订单装箱单
+// @Tags 发票
+// @Produce application/pdf
+// @Param order_no query string true "订单编号"
+// @Router /api/v1/invoice/packing_slip [get]
+func (i *InvoiceController) DownloadPackingSlip(ctx *app.Context) {
+	param := &model.ReqInvoiceDownloadParam{}
+	if err := ctx.ShouldBindQuery(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, name, err := i.InvoiceService.RenderPackingSlip(ctx, param.OrderNo)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	sendAttachment(ctx, name, "application/pdf", data)
+}
+
+// DownloadBulk 批量下载发票或装箱单
+// @Summary 批量下载发票或装箱单
+// @Description 打包为ZIP下载，生成失败的订单记录在errors.txt中
+// @Tags 发票
+// @Accept json
+// @Produce application/zip
+// @Param params body model.ReqInvoiceBulkParam true "订单列表"
+// @Router /api/v1/invoice/bulk [post]
+func (i *InvoiceController) DownloadBulk(ctx *app.Context) {
+	param := &model.ReqInvoiceBulkParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, err := i.InvoiceService.RenderBulkZip(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	name := "invoices.zip"
+	if param.Type == model.InvoiceDocPackingSlip {
+		name = "packing-slips.zip"
+	}
+	sendAttachment(ctx, name, "application/zip", data)
+}
+
+// DownloadMyInvoice 下载我的订单发票
+// @Summary 下载我的订单发票
+// @Tags 发票
+// @Produce application/pdf
+// @Param order_no query string true "订单编号"
+// @Router /api/v1/f/invoice/download [get]
+func (i *InvoiceController) DownloadMyInvoice(ctx *app.Context) {
+	param := &model.ReqInvoiceDownloadParam{}
+	if err := ctx.ShouldBindQuery(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data, name, err := i.InvoiceService.RenderInvoice(ctx, param.OrderNo, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	sendAttachment(ctx, name, "application/pdf", data)
+}
+
+// 以附件形式返回生成的文件
+func sendAttachment(ctx *app.Context, name, contentType string, data []byte) {
+	ctx.Header("Content-Disposition", `attachment; filename="`+name+`"`)
+	ctx.Data(http.StatusOK, contentType, data)
+}
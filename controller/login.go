@@ -1,11 +1,14 @@
 package controller
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"sgin/model"
 	"sgin/pkg/app"
 	"sgin/pkg/utils"
 	"sgin/service"
+	"strconv"
 
 	"github.com/mileusna/useragent"
 )
@@ -13,10 +16,15 @@ import (
 type LoginController struct {
 	UserService        *service.UserService
 	SysLoginLogService *service.SysLoginLogService
+	SessionService     *service.SessionService
+	TwoFactorService   *service.TwoFactorService
+	LoginLockService   *service.LoginLockService
 }
 
 // 用户登录
 // @Summary 用户登录
+// @Description 启用两步验证或角色要求两步验证时返回step为two_factor或setup的登录挑战，通过 /api/v1/login/2fa/verify 完成登录
+// @Description 失败次数过多时返回428，需要通过 /api/v1/captcha/create 获取图形验证码后重新提交；账号或IP被锁定时返回423
 // @Tags 用户
 // @Accept json
 // @Produce json
@@ -30,33 +38,158 @@ func (c *LoginController) Login(ctx *app.Context) {
 		return
 	}
 
+	// 使用用户名或邮箱登录时都按用户名统计失败次数
 	user, err := c.UserService.GetUserByUsernameOrEmail(ctx, param.Username)
-	if err != nil {
-		ctx.JSONError(http.StatusInternalServerError, err.Error())
+	account := param.Username
+	if err == nil {
+		account = user.Username
+	}
 
+	lockErr := c.LoginLockService.CheckLogin(ctx, utils.TokenAudienceAdmin, account, param.CaptchaId, param.CaptchaCode)
+	if lockErr != nil {
+		loginLockError(ctx, lockErr)
+		c.CreateSysLoginLog(ctx, model.LoginStatusFail, param.Username, lockErr.Error())
+		return
+	}
+
+	if err != nil {
+		// 用户不存在时与密码错误返回相同的结果，避免枚举用户名
+		ctx.JSONError(http.StatusBadRequest, "用户名或密码错误")
 		c.CreateSysLoginLog(ctx, model.LoginStatusFail, param.Username, err.Error())
+		c.LoginLockService.RecordFailure(ctx, utils.TokenAudienceAdmin, account)
 		return
 	}
 
 	if utils.CheckPasswordHashWithSalt(param.Password, user.Password, ctx.Config.PasswdKey) == false {
 		ctx.JSONError(http.StatusBadRequest, "用户名或密码错误")
 		c.CreateSysLoginLog(ctx, model.LoginStatusFail, param.Username, "密码错误")
+		c.LoginLockService.RecordFailure(ctx, utils.TokenAudienceAdmin, account)
 		return
 	}
 
-	token, err := utils.GenerateToken(user.Uuid)
+	challenge, err := c.TwoFactorService.CreateChallenge(ctx, user)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		c.CreateSysLoginLog(ctx, model.LoginStatusFail, param.Username, "两步验证失败: "+err.Error())
+		return
+	}
+	if challenge != nil {
+		step := model.LoginStepTwoFactor
+		if challenge.Method == model.TwoFactorMethodSetup {
+			step = model.LoginStepSetup
+		}
+		ctx.JSONSuccess(model.ResUserLogin{Step: step, Challenge: challenge})
+		return
+	}
+
+	res, err := c.SessionService.CreateSession(ctx, user.Uuid, utils.TokenAudienceAdmin)
 	if err != nil {
 		ctx.JSONError(http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	ctx.JSONSuccess(model.ResUserLogin{
-		Token: token,
-	})
+	ctx.JSONSuccess(res)
 	c.CreateSysLoginLog(ctx, model.LoginStatusSuccess, param.Username, "登录成功")
+	c.LoginLockService.RecordSuccess(ctx, utils.TokenAudienceAdmin, account)
+}
+
+// 登录两步验证
+// @Summary 登录两步验证
+// @Description 提交身份验证器密码、邮箱验证码或恢复码，setup挑战提交绑定的验证码，成功后返回token，首次绑定时同时返回恢复码
+// @Description 与登录共用失败次数，需要图形验证码时返回428，账号或IP被锁定时返回423
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqLoginTwoFactorVerify true "验证参数"
+// @Success 200 {object} model.ResUserLogin
+// @Router /api/v1/login/2fa/verify [post]
+func (c *LoginController) VerifyTwoFactor(ctx *app.Context) {
+	param := &model.ReqLoginTwoFactorVerify{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// 两步验证失败也计入登录失败次数，账号或IP被锁定后不能继续尝试
+	_, user, err := c.TwoFactorService.GetChallengeUser(ctx, param.ChallengeUuid)
+	if err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+	lockErr := c.LoginLockService.CheckLogin(ctx, utils.TokenAudienceAdmin, user.Username, param.CaptchaId, param.CaptchaCode)
+	if lockErr != nil {
+		loginLockError(ctx, lockErr)
+		c.CreateSysLoginLog(ctx, model.LoginStatusFail, user.Username, lockErr.Error())
+		return
+	}
+
+	user, recoveryCodes, err := c.TwoFactorService.VerifyChallenge(ctx, param.ChallengeUuid, param.Code)
+	if err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		if user != nil {
+			c.CreateSysLoginLog(ctx, model.LoginStatusFail, user.Username, "两步验证失败: "+err.Error())
+			c.LoginLockService.RecordFailure(ctx, utils.TokenAudienceAdmin, user.Username)
+		}
+		return
+	}
+
+	res, err := c.SessionService.CreateSession(ctx, user.Uuid, utils.TokenAudienceAdmin)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+	res.RecoveryCodes = recoveryCodes
+
+	ctx.JSONSuccess(res)
+	c.CreateSysLoginLog(ctx, model.LoginStatusSuccess, user.Username, "两步验证成功，登录成功")
+	c.LoginLockService.RecordSuccess(ctx, utils.TokenAudienceAdmin, user.Username)
+}
+
+// 登录时绑定两步验证
+// @Summary 登录时绑定两步验证
+// @Description 角色要求两步验证但未绑定时使用，totp返回密钥和二维码链接，email发送验证码，再调用 /api/v1/login/2fa/verify 确认
+// @Tags 用户
+// @Accept json
+// @Produce json
+// @Param params body model.ReqLoginTwoFactorSetup true "绑定参数"
+// @Success 200 {object} model.TotpSetupResponse
+// @Router /api/v1/login/2fa/setup [post]
+func (c *LoginController) SetupTwoFactor(ctx *app.Context) {
+	param := &model.ReqLoginTwoFactorSetup{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := c.TwoFactorService.SetupByChallenge(ctx, param.ChallengeUuid, param.Method)
+	if err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// 账号或IP被锁定时返回423并通过Retry-After返回剩余锁定秒数，需要图形验证码时返回428
+func loginLockError(ctx *app.Context, err error) {
+	lockedErr := &service.LoginLockedError{}
+	if errors.As(err, &lockedErr) {
+		ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+		ctx.JSONError(http.StatusLocked, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrCaptchaRequired) || errors.Is(err, service.ErrCaptchaInvalid) {
+		ctx.JSONError(http.StatusPreconditionRequired, err.Error())
+		return
+	}
+	ctx.JSONError(http.StatusInternalServerError, err.Error())
 }
 
 func (c *LoginController) CreateSysLoginLog(ctx *app.Context, status int, username string, msg string) {
+	createSysLoginLog(ctx, c.SysLoginLogService, status, username, msg)
+}
+
+func createSysLoginLog(ctx *app.Context, s *service.SysLoginLogService, status int, username string, msg string) {
 	uaString := ctx.GetHeader("User-Agent")
 	ua := useragent.Parse(uaString)
 	sysLoginLog := model.SysLoginLog{
@@ -69,7 +202,7 @@ func (c *LoginController) CreateSysLoginLog(ctx *app.Context, status int, userna
 		Device:    ua.Device,
 		Message:   msg,
 	}
-	if err := c.SysLoginLogService.CreateLoginLog(ctx, &sysLoginLog); err != nil {
+	if err := s.CreateLoginLog(ctx, &sysLoginLog); err != nil {
 		ctx.Logger.Error(err)
 	}
 }
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type LoginLockController struct {
+	LoginLockService *service.LoginLockService
+}
+
+// GetLoginLockList 获取登录锁定列表
+// @Summary 获取登录锁定列表
+// @Description 默认只返回锁定中的账号和IP，all为true时包含已解除锁定的记录
+// @Tags 登录日志
+// @Accept json
+// @Produce json
+// @Param params body model.ReqLoginLockQueryParam true "查询参数"
+// @Success 200 {object} model.PagedResponse
+// @Router /api/v1/login_lock/list [post]
+func (c *LoginLockController) GetLoginLockList(ctx *app.Context) {
+	param := &model.ReqLoginLockQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := c.LoginLockService.GetLoginLockList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// Unlock 解除登录锁定
+// @Summary 解除登录锁定
+// @Description 解除账号或IP的锁定，同时清除失败次数和连续锁定次数
+// @Tags 登录日志
+// @Accept json
+// @Produce json
+// @Param params body model.ReqIdParam true "锁定记录ID"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/login_lock/unlock [post]
+func (c *LoginLockController) Unlock(ctx *app.Context) {
+	param := &model.ReqIdParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := c.LoginLockService.Unlock(ctx, param.Id)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
@@ -0,0 +1,277 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+// 开放接口，提供给外部系统调用，使用app的ApiKey认证，SecKey签名
+type OpenController struct {
+	ProductService   *service.ProductService
+	InventoryService *service.InventoryService
+	OrderService     *service.OrderService
+	ShipmentService  *service.ShipmentService
+}
+
+// GetProductList 开放接口获取产品列表
+// @Summary 开放接口获取产品列表
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Param X-Api-Key header string true "app的ApiKey"
+// @Param X-Timestamp header string true "unix时间戳（秒）"
+// @Param X-Nonce header string true "随机字符串，有效期内不能重复"
+// @Param X-Signature header string true "utils.SignRequest签名"
+// @Param params body model.ReqProductQueryParam true "查询参数"
+// @Success 200 {object} model.ProductListPageResponse
+// @Router /api/open/v1/product/list [post]
+func (o *OpenController) GetProductList(ctx *app.Context) {
+	param := &model.ReqProductQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := o.ProductService.ProductList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// GetProductInfo 开放接口获取产品信息
+// @Summary 开放接口获取产品信息
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Param X-Api-Key header string true "app的ApiKey"
+// @Param X-Timestamp header string true "unix时间戳（秒）"
+// @Param X-Nonce header string true "随机字符串，有效期内不能重复"
+// @Param X-Signature header string true "utils.SignRequest签名"
+// @Param params body model.ReqUuidParam true "产品uuid"
+// @Success 200 {object} model.ProductRes
+// @Router /api/open/v1/product/info [post]
+func (o *OpenController) GetProductInfo(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	info, err := o.ProductService.GetProductInfo(ctx, param.Uuid)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(info)
+}
+
+// GetProductItemList 开放接口获取产品SKU列表
+// @Summary 开放接口获取产品SKU列表
+// @Description 包含SKU的价格和库存
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Param X-Api-Key header string true "app的ApiKey"
+// @Param X-Timestamp header string true "unix时间戳（秒）"
+// @Param X-Nonce header string true "随机字符串，有效期内不能重复"
+// @Param X-Signature header string true "utils.SignRequest签名"
+// @Param params body model.ReqProductQueryParam true "查询参数"
+// @Success 200 {object} model.ProductItemListPageResponse
+// @Router /api/open/v1/product/item/list [post]
+func (o *OpenController) GetProductItemList(ctx *app.Context) {
+	param := &model.ReqProductQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := o.ProductService.GetProductSkuList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// AdjustStock 开放接口调整库存
+// @Summary 开放接口调整库存
+// @Description 按变动数量增减库存，操作人记录为app:<app uuid>
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Param X-Api-Key header string true "app的ApiKey"
+// @Param X-Timestamp header string true "unix时间戳（秒）"
+// @Param X-Nonce header string true "随机字符串，有效期内不能重复"
+// @Param X-Signature header string true "utils.SignRequest签名"
+// @Param params body model.ReqInventoryAdjustParam true "调整参数"
+// @Success 200 {object} model.InventoryLogInfoResponse
+// @Router /api/open/v1/inventory/adjust [post]
+func (o *OpenController) AdjustStock(ctx *app.Context) {
+	param := &model.ReqInventoryAdjustParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	log, err := o.InventoryService.AdjustStockByParam(ctx, param, o.operator(ctx))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(log)
+}
+
+// GetInventoryLogList 开放接口获取库存流水
+// @Summary 开放接口获取库存流水
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Param X-Api-Key header string true "app的ApiKey"
+// @Param X-Timestamp header string true "unix时间戳（秒）"
+// @Param X-Nonce header string true "随机字符串，有效期内不能重复"
+// @Param X-Signature header string true "utils.SignRequest签名"
+// @Param params body model.ReqInventoryLogQueryParam true "查询参数"
+// @Success 200 {object} model.InventoryLogQueryResponse
+// @Router /api/open/v1/inventory/log/list [post]
+func (o *OpenController) GetInventoryLogList(ctx *app.Context) {
+	param := &model.ReqInventoryLogQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := o.InventoryService.GetInventoryLogList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// GetOrderList 开放接口获取订单列表
+// @Summary 开放接口获取订单列表
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Param X-Api-Key header string true "app的ApiKey"
+// @Param X-Timestamp header string true "unix时间戳（秒）"
+// @Param X-Nonce header string true "随机字符串，有效期内不能重复"
+// @Param X-Signature header string true "utils.SignRequest签名"
+// @Param params body model.ReqOrderQueryParam true "查询参数"
+// @Success 200 {object} model.OrderListPageResponse
+// @Router /api/open/v1/order/list [post]
+func (o *OpenController) GetOrderList(ctx *app.Context) {
+	param := &model.ReqOrderQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := o.OrderService.GetOrderList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// GetOrderInfo 开放接口获取订单信息
+// @Summary 开放接口获取订单信息
+// @Description 包含订单商品
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Param X-Api-Key header string true "app的ApiKey"
+// @Param X-Timestamp header string true "unix时间戳（秒）"
+// @Param X-Nonce header string true "随机字符串，有效期内不能重复"
+// @Param X-Signature header string true "utils.SignRequest签名"
+// @Param params body model.ReqUuidParam true "订单编号"
+// @Success 200 {object} model.OrderResInfoResponse
+// @Router /api/open/v1/order/info [post]
+func (o *OpenController) GetOrderInfo(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	order, err := o.OrderService.GetOrderRes(ctx, param.Uuid)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(order)
+}
+
+// GetShipmentList 开放接口获取包裹列表
+// @Summary 开放接口获取包裹列表
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Param X-Api-Key header string true "app的ApiKey"
+// @Param X-Timestamp header string true "unix时间戳（秒）"
+// @Param X-Nonce header string true "随机字符串，有效期内不能重复"
+// @Param X-Signature header string true "utils.SignRequest签名"
+// @Param params body model.ReqShipmentQueryParam true "查询参数"
+// @Success 200 {object} model.ShipmentQueryResponse
+// @Router /api/open/v1/shipment/list [post]
+func (o *OpenController) GetShipmentList(ctx *app.Context) {
+	param := &model.ReqShipmentQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := o.ShipmentService.GetShipmentList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// CreateShipment 开放接口创建包裹
+// @Summary 开放接口创建包裹
+// @Description 外部系统发货后回传物流单号，操作人记录为app:<app uuid>
+// @Tags 开放接口
+// @Accept json
+// @Produce json
+// @Param X-Api-Key header string true "app的ApiKey"
+// @Param X-Timestamp header string true "unix时间戳（秒）"
+// @Param X-Nonce header string true "随机字符串，有效期内不能重复"
+// @Param X-Signature header string true "utils.SignRequest签名"
+// @Param params body model.ReqShipmentCreate true "包裹信息"
+// @Success 200 {object} model.ShipmentInfoResponse
+// @Router /api/open/v1/shipment/create [post]
+func (o *OpenController) CreateShipment(ctx *app.Context) {
+	param := &model.ReqShipmentCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shipment, err := o.ShipmentService.CreateShipment(ctx, param, o.operator(ctx))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(shipment)
+}
+
+// 操作人记录为调用的app
+func (o *OpenController) operator(ctx *app.Context) string {
+	return "app:" + ctx.GetString("app_id")
+}
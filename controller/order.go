@@ -91,6 +91,7 @@ func (c *OrderController) CreateOrder(ctx *app.Context) {
 
 // 删除订单
 // @Summary 删除订单
+// @Description 订单为软删除，待支付订单会先关闭并退回库存，已支付未完成的订单不能删除
 // @Tags 订单
 // @Accept json
 // @Produce json
@@ -105,7 +106,7 @@ func (c *OrderController) DeleteOrder(ctx *app.Context) {
 		return
 	}
 
-	err := c.OrderService.DeleteOrder(ctx, param.Uuid)
+	err := c.OrderService.DeleteOrder(ctx, param.Uuid, ctx.GetString("user_id"))
 	if err != nil {
 		ctx.JSONError(http.StatusInternalServerError, err.Error())
 		return
@@ -163,3 +164,198 @@ func (c *OrderController) GetOrderItemList(ctx *app.Context) {
 
 	ctx.JSONSuccess(items)
 }
+
+// UpdateOrderReceiver 修改订单收货信息
+// @Summary 修改订单收货信息
+// @Description 未发货、未开票的订单可以修改
+// @Tags 订单
+// @Accept json
+// @Produce json
+// @Param params body model.ReqOrderReceiverUpdate true "收货信息"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/order/receiver/update [post]
+func (c *OrderController) UpdateOrderReceiver(ctx *app.Context) {
+	param := &model.ReqOrderReceiverUpdate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.OrderService.UpdateOrderReceiver(ctx, param, ctx.GetString("user_id")); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// UpdateOrderItems 修改订单商品数量
+// @Summary 修改订单商品数量
+// @Description 只能修改待支付订单，数量为0时删除商品，重新计算订单金额并调整库存，买家需要重新支付
+// @Tags 订单
+// @Accept json
+// @Produce json
+// @Param params body model.ReqOrderItemsUpdate true "商品数量"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/order/item/update [post]
+func (c *OrderController) UpdateOrderItems(ctx *app.Context) {
+	param := &model.ReqOrderItemsUpdate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.OrderService.UpdateOrderItems(ctx, param, ctx.GetString("user_id")); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// SplitOrder 拆分订单
+// @Summary 拆分订单
+// @Description 已支付未发货的订单，指定的商品数量移到新订单
+// @Tags 订单
+// @Accept json
+// @Produce json
+// @Param params body model.ReqOrderSplit true "拆分参数"
+// @Success 200 {object} model.OrderInfoResponse
+// @Router /api/v1/order/split [post]
+func (c *OrderController) SplitOrder(ctx *app.Context) {
+	param := &model.ReqOrderSplit{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	order, err := c.OrderService.SplitOrder(ctx, param, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(order)
+}
+
+// MergeOrders 合并订单
+// @Summary 合并订单
+// @Description 同一买家已支付未发货的订单合并到目标订单，被合并的订单关闭
+// @Tags 订单
+// @Accept json
+// @Produce json
+// @Param params body model.ReqOrderMerge true "合并参数"
+// @Success 200 {object} model.OrderInfoResponse
+// @Router /api/v1/order/merge [post]
+func (c *OrderController) MergeOrders(ctx *app.Context) {
+	param := &model.ReqOrderMerge{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	order, err := c.OrderService.MergeOrders(ctx, param, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(order)
+}
+
+// AddOrderNote 添加订单备注
+// @Summary 添加订单备注
+// @Tags 订单
+// @Accept json
+// @Produce json
+// @Param params body model.ReqOrderNoteCreate true "备注"
+// @Success 200 {object} model.OrderNoteInfoResponse
+// @Router /api/v1/order/note/create [post]
+func (c *OrderController) AddOrderNote(ctx *app.Context) {
+	param := &model.ReqOrderNoteCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	note, err := c.OrderService.AddOrderNote(ctx, param, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(note)
+}
+
+// GetOrderNotes 获取订单备注
+// @Summary 获取订单备注
+// @Tags 订单
+// @Accept json
+// @Produce json
+// @Param params body model.ReqOrderTrackingParam true "订单编号"
+// @Success 200 {object} model.OrderNoteListResponse
+// @Router /api/v1/order/note/list [post]
+func (c *OrderController) GetOrderNotes(ctx *app.Context) {
+	param := &model.ReqOrderTrackingParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	notes, err := c.OrderService.GetOrderNotes(ctx, param.OrderNo, "")
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(notes)
+}
+
+// GetOrderAuditList 获取订单修改记录
+// @Summary 获取订单修改记录
+// @Tags 订单
+// @Accept json
+// @Produce json
+// @Param params body model.ReqOrderAuditQueryParam true "查询参数"
+// @Success 200 {object} model.OrderAuditQueryResponse
+// @Router /api/v1/order/audit/list [post]
+func (c *OrderController) GetOrderAuditList(ctx *app.Context) {
+	param := &model.ReqOrderAuditQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	audits, err := c.OrderService.GetOrderAuditList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(audits)
+}
+
+// GetMyOrderNotes 获取我的订单备注
+// @Summary 获取我的订单备注
+// @Description 只返回买家可见的备注
+// @Tags 订单
+// @Accept json
+// @Produce json
+// @Param params body model.ReqOrderTrackingParam true "订单编号"
+// @Success 200 {object} model.OrderNoteListResponse
+// @Router /api/v1/f/order/notes [post]
+func (c *OrderController) GetMyOrderNotes(ctx *app.Context) {
+	param := &model.ReqOrderTrackingParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	notes, err := c.OrderService.GetOrderNotes(ctx, param.OrderNo, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(notes)
+}
@@ -668,7 +668,7 @@ func (p *PaymentMethodController) SetAlipayConfig(ctx *app.Context) {
 // @Produce  json
 // @Param serial_no formData string true "serial_no"
 // @Param mch_id formData string true "mch_id"
-// @Param api_key formData string true
+// @Param api_key formData string true "api_key"
 // @Param key formData file true "key"
 // @Success 200 {object} model.StringDataResponse "ok"
 // @Router /api/v1/payment-method/wechat/config [post]
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type PriceController struct {
+	PriceService *service.PriceService
+}
+
+// CreatePriceList 创建价格表
+// @Summary 创建价格表
+// @Description 未指定客户分组的价格表对所有客户生效，可设置开始和结束时间用于限时促销
+// @Tags 价格表
+// @Accept json
+// @Produce json
+// @Param params body model.ReqPriceListCreate true "价格表"
+// @Success 200 {object} model.PriceListInfoResponse
+// @Router /api/v1/price/list/create [post]
+func (p *PriceController) CreatePriceList(ctx *app.Context) {
+	param := &model.ReqPriceListCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	priceList, err := p.PriceService.CreatePriceList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(priceList)
+}
+
+// UpdatePriceList 更新价格表
+// @Summary 更新价格表
+// @Tags 价格表
+// @Accept json
+// @Produce json
+// @Param params body model.ReqPriceListUpdate true "价格表"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/price/list/update [post]
+func (p *PriceController) UpdatePriceList(ctx *app.Context) {
+	param := &model.ReqPriceListUpdate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := p.PriceService.UpdatePriceList(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// DeletePriceList 删除价格表
+// @Summary 删除价格表
+// @Tags 价格表
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "价格表UUID"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/price/list/delete [post]
+func (p *PriceController) DeletePriceList(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := p.PriceService.DeletePriceList(ctx, param.Uuid); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// GetPriceListInfo 获取价格表详情
+// @Summary 获取价格表详情
+// @Tags 价格表
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "价格表UUID"
+// @Success 200 {object} model.PriceListInfoResponse
+// @Router /api/v1/price/list/info [post]
+func (p *PriceController) GetPriceListInfo(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	priceList, err := p.PriceService.GetPriceListInfo(ctx, param.Uuid)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(priceList)
+}
+
+// GetPriceListList 获取价格表列表
+// @Summary 获取价格表列表
+// @Tags 价格表
+// @Accept json
+// @Produce json
+// @Param params body model.ReqPriceListQueryParam true "查询参数"
+// @Success 200 {object} model.PriceListQueryResponse
+// @Router /api/v1/price/list/list [post]
+func (p *PriceController) GetPriceListList(ctx *app.Context) {
+	param := &model.ReqPriceListQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	priceLists, err := p.PriceService.GetPriceListList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(priceLists)
+}
@@ -8,7 +8,8 @@ import (
 )
 
 type ProductController struct {
-	ProductService *service.ProductService
+	ProductService      *service.ProductService
+	ProductGroupService *service.ProductGroupService
 }
 
 // ProductCreate 创建产品
@@ -349,3 +350,53 @@ func (p *ProductController) GetProductItemVariantInfo(ctx *app.Context) {
 	}
 	ctx.JSONSuccess(info)
 }
+
+// GetProductGroupInfo
+// @Summary 获取组合产品信息
+// @Description 获取组合产品的组件、价格和可售数量
+// @Tags 产品
+// @Accept  json
+// @Produce  json
+// @Param param body model.ReqUuidParam true "产品UUID"
+// @Success 200 {object} model.ProductGroupInfoResponse "组合产品信息"
+// @Router /api/v1/product/group/info [post]
+func (p *ProductController) GetProductGroupInfo(ctx *app.Context) {
+	params := &model.ReqUuidParam{}
+	if err := ctx.Bind(params); err != nil {
+		ctx.Logger.Error("Failed to bind params", err)
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+	info, err := p.ProductGroupService.GetProductGroupInfo(ctx, params.Uuid)
+	if err != nil {
+		ctx.Logger.Error("Failed to get product group info", err)
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSONSuccess(info)
+}
+
+// UpdateProductGroup
+// @Summary 更新组合产品
+// @Description 更新组合产品的组件和定价方式
+// @Tags 产品
+// @Accept  json
+// @Produce  json
+// @Param param body model.ReqProductGroupUpdate true "组合产品参数"
+// @Success 200 {object} model.StringDataResponse "Updated product group"
+// @Router /api/v1/product/group/update [post]
+func (p *ProductController) UpdateProductGroup(ctx *app.Context) {
+	params := &model.ReqProductGroupUpdate{}
+	if err := ctx.Bind(params); err != nil {
+		ctx.Logger.Error("Failed to bind params", err)
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+	err := p.ProductGroupService.UpdateProductGroup(ctx, params)
+	if err != nil {
+		ctx.Logger.Error("Failed to update product group", err)
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSONSuccess("Updated product group")
+}
@@ -31,21 +31,10 @@ func (rc *RegisterController) Register(c *app.Context) {
 		return
 	}
 
-	// 验证验证码
-	ok, err := rc.VerificationCodeService.CheckVerificationCode(c, params.Code, params.Email, params.Phone)
+	// 验证验证码，通过后标记为已使用
+	err := rc.VerificationCodeService.CheckVerificationCode(c, model.VerificationPurposeRegister, params.Code, params.Email, params.Phone, true)
 	if err != nil {
-		c.JSONError(http.StatusInternalServerError, err.Error())
-		return
-	}
-
-	if ok == false {
-		c.JSONError(http.StatusBadRequest, "验证码错误")
-		return
-	}
-	// 更新验证码状态
-	err = rc.VerificationCodeService.UpdateVerificationCode(c, params.Code, params.Email, params.Phone)
-	if err != nil {
-		c.JSONError(http.StatusInternalServerError, err.Error())
+		c.JSONError(http.StatusBadRequest, err.Error())
 		return
 	}
 
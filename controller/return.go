@@ -0,0 +1,301 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type ReturnController struct {
+	ReturnService *service.ReturnService
+}
+
+// CreateReturn 申请退货
+// @Summary 申请退货
+// @Description 选择已发货的订单商品和数量，图片先通过上传接口上传
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqReturnCreate true "退货申请"
+// @Success 200 {object} model.ReturnInfoResponse
+// @Router /api/v1/f/return/create [post]
+func (r *ReturnController) CreateReturn(ctx *app.Context) {
+	param := &model.ReqReturnCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ret, err := r.ReturnService.CreateReturn(ctx, param, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(ret)
+}
+
+// GetMyReturnList 获取我的退货申请
+// @Summary 获取我的退货申请
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqReturnQueryParam true "查询参数"
+// @Success 200 {object} model.ReturnQueryResponse
+// @Router /api/v1/f/return/list [post]
+func (r *ReturnController) GetMyReturnList(ctx *app.Context) {
+	param := &model.ReqReturnQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+	param.UserID = ctx.GetString("user_id")
+
+	returns, err := r.ReturnService.GetReturnList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(returns)
+}
+
+// GetMyReturnInfo 获取我的退货申请详情
+// @Summary 获取我的退货申请详情
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "退货申请uuid"
+// @Success 200 {object} model.ReturnInfoResponse
+// @Router /api/v1/f/return/info [post]
+func (r *ReturnController) GetMyReturnInfo(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ret, err := r.ReturnService.GetReturnInfo(ctx, param.Uuid, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(ret)
+}
+
+// CancelReturn 取消退货申请
+// @Summary 取消退货申请
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "退货申请uuid"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/f/return/cancel [post]
+func (r *ReturnController) CancelReturn(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ReturnService.CancelReturn(ctx, param.Uuid, ctx.GetString("user_id")); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// GetReturnList 获取退货申请列表
+// @Summary 获取退货申请列表
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqReturnQueryParam true "查询参数"
+// @Success 200 {object} model.ReturnQueryResponse
+// @Router /api/v1/return/list [post]
+func (r *ReturnController) GetReturnList(ctx *app.Context) {
+	param := &model.ReqReturnQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	returns, err := r.ReturnService.GetReturnList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(returns)
+}
+
+// GetReturnInfo 获取退货申请详情
+// @Summary 获取退货申请详情
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "退货申请uuid"
+// @Success 200 {object} model.ReturnInfoResponse
+// @Router /api/v1/return/info [post]
+func (r *ReturnController) GetReturnInfo(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ret, err := r.ReturnService.GetReturnInfo(ctx, param.Uuid, "")
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(ret)
+}
+
+// ApproveReturn 同意退货申请
+// @Summary 同意退货申请
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqReturnReviewParam true "审核参数"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/return/approve [post]
+func (r *ReturnController) ApproveReturn(ctx *app.Context) {
+	param := &model.ReqReturnReviewParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ReturnService.ApproveReturn(ctx, param, ctx.GetString("user_id")); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// RejectReturn 拒绝退货申请
+// @Summary 拒绝退货申请
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqReturnReviewParam true "审核参数"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/return/reject [post]
+func (r *ReturnController) RejectReturn(ctx *app.Context) {
+	param := &model.ReqReturnReviewParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ReturnService.RejectReturn(ctx, param, ctx.GetString("user_id")); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// ReceiveReturn 确认收到退货
+// @Summary 确认收到退货
+// @Description restock为true时退货商品退回库存
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqReturnReceiveParam true "收货参数"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/return/receive [post]
+func (r *ReturnController) ReceiveReturn(ctx *app.Context) {
+	param := &model.ReqReturnReceiveParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ReturnService.ReceiveReturn(ctx, param, ctx.GetString("user_id")); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// RefundReturn 退货退款
+// @Summary 退货退款
+// @Description 支持原路退款的支付方式直接退款，其他支付方式记录为线下退款
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqReturnRefundParam true "退款参数"
+// @Success 200 {object} model.RefundInfoResponse
+// @Router /api/v1/return/refund [post]
+func (r *ReturnController) RefundReturn(ctx *app.Context) {
+	param := &model.ReqReturnRefundParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	refund, err := r.ReturnService.RefundReturn(ctx, param, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(refund)
+}
+
+// ExchangeReturn 退货换货
+// @Summary 退货换货
+// @Description 为退货商品创建已支付的零元换货订单，按正常流程发货
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqReturnReviewParam true "换货参数"
+// @Success 200 {object} model.OrderInfoResponse
+// @Router /api/v1/return/exchange [post]
+func (r *ReturnController) ExchangeReturn(ctx *app.Context) {
+	param := &model.ReqReturnReviewParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	order, err := r.ReturnService.ExchangeReturn(ctx, param, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(order)
+}
+
+// GetReturnReasonReport 退货原因统计
+// @Summary 退货原因统计
+// @Tags 退货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqReturnReportParam true "统计参数"
+// @Success 200 {object} model.ReturnReasonReportResponse
+// @Router /api/v1/return/report/reason [post]
+func (r *ReturnController) GetReturnReasonReport(ctx *app.Context) {
+	param := &model.ReqReturnReportParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reports, err := r.ReturnService.GetReturnReasonReport(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(reports)
+}
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type SessionController struct {
+	SessionService *service.SessionService
+}
+
+// RefreshToken 刷新token
+// @Summary 刷新token
+// @Description 返回新的访问token和刷新token，旧的刷新token失效，后台用户和商城客户通用
+// @Tags 会话
+// @Accept json
+// @Produce json
+// @Param params body model.ReqRefreshToken true "刷新token"
+// @Success 200 {object} model.ResUserLogin
+// @Router /api/v1/token/refresh [post]
+func (c *SessionController) RefreshToken(ctx *app.Context) {
+	param := &model.ReqRefreshToken{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := c.SessionService.RefreshSession(ctx, param.RefreshToken)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// RevokeSession 撤销会话
+// @Summary 撤销会话
+// @Tags 会话
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "会话uuid"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/user/session/revoke [post]
+func (c *SessionController) RevokeSession(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.SessionService.RevokeSession(ctx, ctx.GetString("user_id"), param.Uuid); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// GetSessionList 获取我的登录会话
+// @Summary 获取我的登录会话
+// @Description 商城客户使用 /api/v1/f/customer/session/list
+// @Tags 会话
+// @Produce json
+// @Success 200 {object} model.UserSessionListResponse
+// @Router /api/v1/user/session/list [post]
+func (c *SessionController) GetSessionList(ctx *app.Context) {
+	sessions, err := c.SessionService.GetSessionList(ctx, ctx.GetString("user_id"), ctx.GetString("session_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(sessions)
+}
+
+// RevokeAllSessions 撤销所有会话
+// @Summary 撤销所有会话
+// @Description 包括当前会话，撤销后需要重新登录
+// @Tags 会话
+// @Produce json
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/user/session/revoke_all [post]
+func (c *SessionController) RevokeAllSessions(ctx *app.Context) {
+	if err := c.SessionService.RevokeAllSessions(ctx, ctx.GetString("user_id")); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// Logout 退出登录
+// @Summary 退出登录
+// @Description 撤销当前会话
+// @Tags 会话
+// @Produce json
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/logout [post]
+func (c *SessionController) Logout(ctx *app.Context) {
+	if err := c.SessionService.RevokeSession(ctx, ctx.GetString("user_id"), ctx.GetString("session_id")); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
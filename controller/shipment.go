@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type ShipmentController struct {
+	ShipmentService *service.ShipmentService
+	CarrierService  *service.CarrierService
+}
+
+// CreateShipment 创建发货包裹
+// @Summary 创建发货包裹
+// @Description 订单可以分多个包裹发货，商品全部发出后订单变为已发货，并邮件通知买家
+// @Tags 发货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqShipmentCreate true "包裹信息"
+// @Success 200 {object} model.ShipmentInfoResponse
+// @Router /api/v1/shipment/create [post]
+func (s *ShipmentController) CreateShipment(ctx *app.Context) {
+	param := &model.ReqShipmentCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shipment, err := s.ShipmentService.CreateShipment(ctx, param, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(shipment)
+}
+
+// GetShipmentList 获取发货包裹列表
+// @Summary 获取发货包裹列表
+// @Tags 发货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqShipmentQueryParam true "查询参数"
+// @Success 200 {object} model.ShipmentQueryResponse
+// @Router /api/v1/shipment/list [post]
+func (s *ShipmentController) GetShipmentList(ctx *app.Context) {
+	param := &model.ReqShipmentQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shipments, err := s.ShipmentService.GetShipmentList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(shipments)
+}
+
+// GetOrderShipments 获取订单的发货包裹
+// @Summary 获取订单的发货包裹
+// @Tags 发货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqOrderTrackingParam true "订单编号"
+// @Success 200 {object} model.ShipmentListResponse
+// @Router /api/v1/shipment/order [post]
+func (s *ShipmentController) GetOrderShipments(ctx *app.Context) {
+	param := &model.ReqOrderTrackingParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shipments, err := s.ShipmentService.GetShipmentsByOrderNo(ctx, param.OrderNo)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(shipments)
+}
+
+// GetOrderTracking 查询订单物流
+// @Summary 查询订单物流
+// @Tags 发货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqOrderTrackingParam true "订单编号"
+// @Success 200 {object} model.OrderTrackingInfoResponse
+// @Router /api/v1/f/order/tracking [post]
+func (s *ShipmentController) GetOrderTracking(ctx *app.Context) {
+	param := &model.ReqOrderTrackingParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tracking, err := s.ShipmentService.GetOrderTracking(ctx, param.OrderNo, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(tracking)
+}
+
+// GetCarrierList 获取已配置的承运商
+// @Summary 获取已配置的承运商
+// @Tags 发货
+// @Produce json
+// @Success 200 {object} model.StringListResponse
+// @Router /api/v1/shipment/carrier/list [get]
+func (s *ShipmentController) GetCarrierList(ctx *app.Context) {
+	ctx.JSONSuccess(s.CarrierService.GetCarrierNames(ctx))
+}
+
+// GetShipmentRates 订单运费询价
+// @Summary 订单运费询价
+// @Tags 发货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqShipmentRateParam true "询价参数"
+// @Success 200 {object} model.BaseResponse
+// @Router /api/v1/shipment/rates [post]
+func (s *ShipmentController) GetShipmentRates(ctx *app.Context) {
+	param := &model.ReqShipmentRateParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rates, err := s.CarrierService.GetRates(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(rates)
+}
+
+// CreateLabelShipment 创建承运商面单并发货
+// @Summary 创建承运商面单并发货
+// @Description 向承运商下单获取运单号和面单，面单保存为私有资源，之后定时同步物流轨迹
+// @Tags 发货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqShipmentLabelCreate true "面单信息"
+// @Success 200 {object} model.ShipmentInfoResponse
+// @Router /api/v1/shipment/label/create [post]
+func (s *ShipmentController) CreateLabelShipment(ctx *app.Context) {
+	param := &model.ReqShipmentLabelCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shipment, err := s.CarrierService.CreateLabelShipment(ctx, param, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(shipment)
+}
+
+// DownloadLabel 下载包裹面单
+// @Summary 下载包裹面单
+// @Tags 发货
+// @Param uuid query string true "包裹UUID"
+// @Router /api/v1/shipment/label/download [get]
+func (s *ShipmentController) DownloadLabel(ctx *app.Context) {
+	path, name, err := s.CarrierService.GetLabelFile(ctx, ctx.Query("uuid"))
+	if err != nil {
+		ctx.JSONError(http.StatusNotFound, err.Error())
+		return
+	}
+
+	ctx.FileAttachment(path, name)
+}
+
+// TrackShipment 立即同步包裹物流轨迹
+// @Summary 立即同步包裹物流轨迹
+// @Tags 发货
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "包裹UUID"
+// @Success 200 {object} model.ShipmentInfoResponse
+// @Router /api/v1/shipment/track [post]
+func (s *ShipmentController) TrackShipment(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	shipment, err := s.CarrierService.TrackShipmentByUUID(ctx, param.Uuid)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(shipment)
+}
@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type SubscriptionController struct {
+	SubscriptionService *service.SubscriptionService
+}
+
+// CreateSubscriptionPlan 创建订阅计划
+// @Summary 创建订阅计划
+// @Tags 订阅
+// @Accept json
+// @Produce json
+// @Param params body model.ReqSubscriptionPlanCreate true "订阅计划"
+// @Success 200 {object} model.SubscriptionPlanInfoResponse
+// @Router /api/v1/subscription/plan/create [post]
+func (s *SubscriptionController) CreateSubscriptionPlan(ctx *app.Context) {
+	param := &model.ReqSubscriptionPlanCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	plan, err := s.SubscriptionService.CreateSubscriptionPlan(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(plan)
+}
+
+// UpdateSubscriptionPlan 更新订阅计划
+// @Summary 更新订阅计划
+// @Tags 订阅
+// @Accept json
+// @Produce json
+// @Param params body model.ReqSubscriptionPlanUpdate true "订阅计划"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/subscription/plan/update [post]
+func (s *SubscriptionController) UpdateSubscriptionPlan(ctx *app.Context) {
+	param := &model.ReqSubscriptionPlanUpdate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.SubscriptionService.UpdateSubscriptionPlan(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// GetSubscriptionPlanList 获取订阅计划列表
+// @Summary 获取订阅计划列表
+// @Tags 订阅
+// @Accept json
+// @Produce json
+// @Param params body model.ReqSubscriptionPlanQueryParam true "查询参数"
+// @Success 200 {object} model.SubscriptionPlanQueryResponse
+// @Router /api/v1/subscription/plan/list [post]
+func (s *SubscriptionController) GetSubscriptionPlanList(ctx *app.Context) {
+	param := &model.ReqSubscriptionPlanQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	plans, err := s.SubscriptionService.GetSubscriptionPlanList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(plans)
+}
+
+// GetSubscriptionList 获取订阅列表
+// @Summary 获取订阅列表
+// @Tags 订阅
+// @Accept json
+// @Produce json
+// @Param params body model.ReqSubscriptionQueryParam true "查询参数"
+// @Success 200 {object} model.SubscriptionQueryResponse
+// @Router /api/v1/subscription/list [post]
+func (s *SubscriptionController) GetSubscriptionList(ctx *app.Context) {
+	param := &model.ReqSubscriptionQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	subscriptions, err := s.SubscriptionService.GetSubscriptionList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(subscriptions)
+}
+
+// GetAvailablePlanList 获取产品可订阅的计划
+// @Summary 获取产品可订阅的计划
+// @Tags 订阅
+// @Accept json
+// @Produce json
+// @Param params body model.ReqSubscriptionPlanQueryParam true "查询参数"
+// @Success 200 {object} model.SubscriptionPlanQueryResponse
+// @Router /api/v1/f/subscription/plans [post]
+func (s *SubscriptionController) GetAvailablePlanList(ctx *app.Context) {
+	param := &model.ReqSubscriptionPlanQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+	param.Status = model.SubscriptionPlanStatusEnabled
+
+	plans, err := s.SubscriptionService.GetSubscriptionPlanList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(plans)
+}
+
+// Subscribe 订阅
+// @Summary 订阅
+// @Description 有试用期的计划在试用结束后首次扣款
+// @Tags 订阅
+// @Accept json
+// @Produce json
+// @Param params body model.ReqSubscriptionCreate true "订阅信息"
+// @Success 200 {object} model.SubscriptionInfoResponse
+// @Router /api/v1/f/subscription/create [post]
+func (s *SubscriptionController) Subscribe(ctx *app.Context) {
+	param := &model.ReqSubscriptionCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	subscription, err := s.SubscriptionService.Subscribe(ctx, ctx.GetString("user_id"), param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(subscription)
+}
+
+// GetMySubscriptionList 获取我的订阅列表
+// @Summary 获取我的订阅列表
+// @Tags 订阅
+// @Accept json
+// @Produce json
+// @Param params body model.ReqSubscriptionQueryParam true "查询参数"
+// @Success 200 {object} model.SubscriptionQueryResponse
+// @Router /api/v1/f/subscription/list [post]
+func (s *SubscriptionController) GetMySubscriptionList(ctx *app.Context) {
+	param := &model.ReqSubscriptionQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+	param.UserID = ctx.GetString("user_id")
+
+	subscriptions, err := s.SubscriptionService.GetSubscriptionList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(subscriptions)
+}
+
+// PauseSubscription 暂停订阅
+// @Summary 暂停订阅
+// @Tags 订阅
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "订阅UUID"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/f/subscription/pause [post]
+func (s *SubscriptionController) PauseSubscription(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.SubscriptionService.PauseSubscription(ctx, ctx.GetString("user_id"), param.Uuid); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// ResumeSubscription 恢复订阅
+// @Summary 恢复订阅
+// @Tags 订阅
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "订阅UUID"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/f/subscription/resume [post]
+func (s *SubscriptionController) ResumeSubscription(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.SubscriptionService.ResumeSubscription(ctx, ctx.GetString("user_id"), param.Uuid); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// CancelSubscription 取消订阅
+// @Summary 取消订阅
+// @Tags 订阅
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "订阅UUID"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/f/subscription/cancel [post]
+func (s *SubscriptionController) CancelSubscription(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.SubscriptionService.CancelSubscription(ctx, ctx.GetString("user_id"), param.Uuid); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
@@ -16,41 +16,43 @@ type TeamController struct {
 // @Tags 团队
 // @Accept  json
 // @Produce  json
-// @Param param body model.Team true "团队参数"
+// @Param param body model.ReqTeamCreate true "团队参数"
 // @Success 200 {object} model.TeamInfoResponse
 // @Router /api/v1/team/create [post]
 func (t *TeamController) CreateTeam(ctx *app.Context) {
-	var param model.Team
-	if err := ctx.ShouldBindJSON(&param); err != nil {
+	param := &model.ReqTeamCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
 		ctx.JSONError(http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := t.TeamService.CreateTeam(ctx, &param); err != nil {
+	team, err := t.TeamService.CreateTeam(ctx, param)
+	if err != nil {
 		ctx.JSONError(http.StatusInternalServerError, err.Error())
 		return
 	}
-	ctx.JSONSuccess(param)
+	ctx.JSONSuccess(team)
 }
 
 // @Summary 更新团队
-// @Description 更新团队
+// @Description 更新当前团队，拥有者通过转让修改
 // @Tags 团队
 // @Accept  json
 // @Produce  json
-// @Param param body model.Team true "团队参数"
+// @Param param body model.ReqTeamUpdate true "团队参数"
 // @Success 200 {object} model.TeamInfoResponse
 // @Router /api/v1/team/update [post]
 func (t *TeamController) UpdateTeam(ctx *app.Context) {
-	var param model.Team
-	if err := ctx.ShouldBindJSON(&param); err != nil {
+	param := &model.ReqTeamUpdate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
 		ctx.JSONError(http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := t.TeamService.UpdateTeam(ctx, &param); err != nil {
+	team, err := t.TeamService.UpdateTeam(ctx, param)
+	if err != nil {
 		ctx.JSONError(http.StatusInternalServerError, err.Error())
 		return
 	}
-	ctx.JSONSuccess(param)
+	ctx.JSONSuccess(team)
 }
 
 // @Summary 删除团队
@@ -119,3 +121,24 @@ func (t *TeamController) GetTeamList(ctx *app.Context) {
 
 	ctx.JSONSuccess(teams)
 }
+
+// @Summary 转让团队
+// @Description 将团队拥有者转让给团队成员，只有当前拥有者可以转让
+// @Tags 团队
+// @Accept  json
+// @Produce  json
+// @Param param body model.ReqTeamOwnerTransfer true "转让参数"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/team/owner/transfer [post]
+func (t *TeamController) TransferOwner(ctx *app.Context) {
+	var param model.ReqTeamOwnerTransfer
+	if err := ctx.ShouldBindJSON(&param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := t.TeamService.TransferOwner(ctx, &param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.JSONSuccess("ok")
+}
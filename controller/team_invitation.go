@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type TeamInvitationController struct {
+	TeamInvitationService *service.TeamInvitationService
+}
+
+// CreateInvitation 创建团队邀请
+// @Summary 创建团队邀请
+// @Description 通过邮件邀请加入团队，邮箱没有账号时接受邀请会创建账号
+// @Tags 团队邀请
+// @Accept json
+// @Produce json
+// @Param params body model.ReqTeamInvitationCreate true "邀请参数"
+// @Success 200 {object} model.TeamInvitationResponse
+// @Router /api/v1/team_invitation/create [post]
+func (t *TeamInvitationController) CreateInvitation(ctx *app.Context) {
+	param := &model.ReqTeamInvitationCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	invitation, err := t.TeamInvitationService.CreateInvitation(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(invitation)
+}
+
+// GetInvitationList 获取团队邀请列表
+// @Summary 获取团队邀请列表
+// @Tags 团队邀请
+// @Accept json
+// @Produce json
+// @Param params body model.ReqTeamInvitationQueryParam true "查询参数"
+// @Success 200 {object} model.PagedResponse
+// @Router /api/v1/team_invitation/list [post]
+func (t *TeamInvitationController) GetInvitationList(ctx *app.Context) {
+	param := &model.ReqTeamInvitationQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := t.TeamInvitationService.GetInvitationList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// RevokeInvitation 撤销团队邀请
+// @Summary 撤销团队邀请
+// @Tags 团队邀请
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "邀请uuid"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/team_invitation/revoke [post]
+func (t *TeamInvitationController) RevokeInvitation(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := t.TeamInvitationService.RevokeInvitation(ctx, param.Uuid); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// GetInvitationInfo 查看团队邀请
+// @Summary 查看团队邀请
+// @Description 通过邮件中的邀请token查看邀请，不需要登录
+// @Tags 团队邀请
+// @Accept json
+// @Produce json
+// @Param params body model.ReqTeamInvitationToken true "邀请token"
+// @Success 200 {object} model.TeamInvitationInfoResponse
+// @Router /api/v1/team_invitation/info [post]
+func (t *TeamInvitationController) GetInvitationInfo(ctx *app.Context) {
+	param := &model.ReqTeamInvitationToken{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	info, err := t.TeamInvitationService.GetInvitationInfo(ctx, param.Token)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(info)
+}
+
+// AcceptInvitation 接受团队邀请
+// @Summary 接受团队邀请
+// @Description 接受邀请加入团队，邮箱没有账号时使用用户名和密码创建账号
+// @Tags 团队邀请
+// @Accept json
+// @Produce json
+// @Param params body model.ReqTeamInvitationAccept true "接受参数"
+// @Success 200 {object} model.UserInfoResponse
+// @Router /api/v1/team_invitation/accept [post]
+func (t *TeamInvitationController) AcceptInvitation(ctx *app.Context) {
+	param := &model.ReqTeamInvitationAccept{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := t.TeamInvitationService.AcceptInvitation(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(user)
+}
+
+// DeclineInvitation 拒绝团队邀请
+// @Summary 拒绝团队邀请
+// @Tags 团队邀请
+// @Accept json
+// @Produce json
+// @Param params body model.ReqTeamInvitationToken true "邀请token"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/team_invitation/decline [post]
+func (t *TeamInvitationController) DeclineInvitation(ctx *app.Context) {
+	param := &model.ReqTeamInvitationToken{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := t.TeamInvitationService.DeclineInvitation(ctx, param.Token); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/utils"
+	"sgin/service"
+)
+
+type TwoFactorController struct {
+	UserService        *service.UserService
+	TwoFactorService   *service.TwoFactorService
+	SysLoginLogService *service.SysLoginLogService
+}
+
+// GetStatus 获取两步验证状态
+// @Summary 获取两步验证状态
+// @Tags 两步验证
+// @Produce json
+// @Success 200 {object} model.TwoFactorStatusResponse
+// @Router /api/v1/user/2fa/status [post]
+func (c *TwoFactorController) GetStatus(ctx *app.Context) {
+	user := c.currentUser(ctx)
+	if user == nil {
+		return
+	}
+
+	res, err := c.TwoFactorService.GetStatus(ctx, user.Uuid)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// Setup 绑定两步验证
+// @Summary 绑定两步验证
+// @Description totp返回密钥和二维码链接，email发送验证码到用户邮箱，再调用 /api/v1/user/2fa/enable 确认
+// @Tags 两步验证
+// @Accept json
+// @Produce json
+// @Param params body model.ReqTwoFactorSetup true "验证方式"
+// @Success 200 {object} model.TotpSetupResponse
+// @Router /api/v1/user/2fa/setup [post]
+func (c *TwoFactorController) Setup(ctx *app.Context) {
+	param := &model.ReqTwoFactorSetup{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user := c.currentUser(ctx)
+	if user == nil {
+		return
+	}
+
+	res, err := c.TwoFactorService.Setup(ctx, user, param.Method)
+	if err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// Enable 启用两步验证
+// @Summary 启用两步验证
+// @Description 提交绑定方式的验证码，返回恢复码，恢复码只返回一次
+// @Tags 两步验证
+// @Accept json
+// @Produce json
+// @Param params body model.ReqTwoFactorCode true "验证码"
+// @Success 200 {object} model.StringListResponse
+// @Router /api/v1/user/2fa/enable [post]
+func (c *TwoFactorController) Enable(ctx *app.Context) {
+	param := &model.ReqTwoFactorCode{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user := c.currentUser(ctx)
+	if user == nil {
+		return
+	}
+
+	codes, err := c.TwoFactorService.Enable(ctx, user, param.Code)
+	if err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		c.createLog(ctx, model.LoginStatusFail, user.Username, "启用两步验证失败: "+err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(codes)
+	c.createLog(ctx, model.LoginStatusSuccess, user.Username, "启用两步验证")
+}
+
+// Disable 关闭两步验证
+// @Summary 关闭两步验证
+// @Description 角色要求两步验证时不能关闭
+// @Tags 两步验证
+// @Accept json
+// @Produce json
+// @Param params body model.ReqTwoFactorDisable true "密码和验证码"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/user/2fa/disable [post]
+func (c *TwoFactorController) Disable(ctx *app.Context) {
+	param := &model.ReqTwoFactorDisable{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user := c.currentUser(ctx)
+	if user == nil {
+		return
+	}
+
+	err := c.TwoFactorService.Disable(ctx, user, param.Password, param.Code)
+	if err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		c.createLog(ctx, model.LoginStatusFail, user.Username, "关闭两步验证失败: "+err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+	c.createLog(ctx, model.LoginStatusSuccess, user.Username, "关闭两步验证")
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+// @Summary 重新生成恢复码
+// @Description 提交验证码，旧的恢复码全部失效
+// @Tags 两步验证
+// @Accept json
+// @Produce json
+// @Param params body model.ReqTwoFactorCode true "验证码"
+// @Success 200 {object} model.StringListResponse
+// @Router /api/v1/user/2fa/recovery_codes [post]
+func (c *TwoFactorController) RegenerateRecoveryCodes(ctx *app.Context) {
+	param := &model.ReqTwoFactorCode{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user := c.currentUser(ctx)
+	if user == nil {
+		return
+	}
+
+	codes, err := c.TwoFactorService.RegenerateRecoveryCodes(ctx, user, param.Code)
+	if err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		c.createLog(ctx, model.LoginStatusFail, user.Username, "重新生成恢复码失败: "+err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(codes)
+	c.createLog(ctx, model.LoginStatusSuccess, user.Username, "重新生成恢复码")
+}
+
+// SendEmailCode 发送邮箱验证码
+// @Summary 发送邮箱验证码
+// @Description 使用邮箱验证时，关闭两步验证或重新生成恢复码前获取验证码
+// @Tags 两步验证
+// @Produce json
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/user/2fa/email/send [post]
+func (c *TwoFactorController) SendEmailCode(ctx *app.Context) {
+	user := c.currentUser(ctx)
+	if user == nil {
+		return
+	}
+
+	if err := c.TwoFactorService.SendEmailCode(ctx, user); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// 两步验证只用于后台用户
+func (c *TwoFactorController) currentUser(ctx *app.Context) *model.User {
+	if ctx.GetString("token_audience") != utils.TokenAudienceAdmin {
+		ctx.JSONError(http.StatusForbidden, "forbidden")
+		return nil
+	}
+
+	user, err := c.UserService.GetUserByUUID(ctx, ctx.GetString("user_id"))
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return nil
+	}
+	return user
+}
+
+func (c *TwoFactorController) createLog(ctx *app.Context, status int, username string, msg string) {
+	createSysLoginLog(ctx, c.SysLoginLogService, status, username, msg)
+}
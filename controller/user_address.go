@@ -25,6 +25,8 @@ func (u *UserAddressController) CreateUserAddress(ctx *app.Context) {
 		ctx.JSONError(http.StatusBadRequest, err.Error())
 		return
 	}
+	// 地址归属当前登录用户
+	param.UserID = ctx.GetString("user_id")
 	if err := u.UserAddressService.CreateUserAddress(ctx, &param); err != nil {
 		ctx.JSONError(http.StatusInternalServerError, err.Error())
 		return
@@ -46,6 +48,8 @@ func (u *UserAddressController) UpdateUserAddress(ctx *app.Context) {
 		ctx.JSONError(http.StatusBadRequest, err.Error())
 		return
 	}
+	// 地址归属当前登录用户
+	param.UserID = ctx.GetString("user_id")
 	if err := u.UserAddressService.UpdateUserAddress(ctx, &param); err != nil {
 		ctx.JSONError(http.StatusInternalServerError, err.Error())
 		return
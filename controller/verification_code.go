@@ -45,7 +45,16 @@ func (v *VerificationCodeController) CreateVerificationCode(ctx *app.Context) {
 		return
 	}
 
-	code, err := v.VerificationCodeService.CreateVerificationCode(ctx, param.Email, param.Phone)
+	// 重置密码、修改邮箱的验证码由对应接口发送
+	if param.Purpose == "" {
+		param.Purpose = model.VerificationPurposeRegister
+	}
+	if param.Purpose != model.VerificationPurposeRegister && param.Purpose != model.VerificationPurposeLogin {
+		ctx.JSONError(http.StatusBadRequest, "不支持的验证码用途")
+		return
+	}
+
+	code, err := v.VerificationCodeService.CreateVerificationCode(ctx, param.Purpose, param.Email, param.Phone)
 	if err != nil {
 		ctx.JSONError(http.StatusInternalServerError, err.Error())
 		return
@@ -102,14 +111,14 @@ func (v *VerificationCodeController) CheckVerificationCode(ctx *app.Context) {
 		return
 	}
 
-	ok, err := v.VerificationCodeService.CheckVerificationCode(ctx, param.Code, param.Email, param.Phone)
-	if err != nil {
-		ctx.JSONError(http.StatusInternalServerError, err.Error())
-		return
+	if param.Purpose == "" {
+		param.Purpose = model.VerificationPurposeRegister
 	}
 
-	if ok == false {
-		ctx.JSONError(http.StatusBadRequest, "验证码错误")
+	// 只检查不标记为已使用，错误次数同样计入
+	err := v.VerificationCodeService.CheckVerificationCode(ctx, param.Purpose, param.Code, param.Email, param.Phone, false)
+	if err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"net/http"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/service"
+)
+
+type WebhookController struct {
+	WebhookService *service.WebhookService
+}
+
+// CreateWebhook 创建webhook
+// @Summary 创建webhook
+// @Description 订阅商城事件，事件发生时使用App的SecKey签名后推送到指定地址
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param params body model.ReqWebhookCreate true "webhook参数"
+// @Success 200 {object} model.WebhookResponse
+// @Router /api/v1/webhook/create [post]
+func (w *WebhookController) CreateWebhook(ctx *app.Context) {
+	param := &model.ReqWebhookCreate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := w.WebhookService.CreateWebhook(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(webhook)
+}
+
+// UpdateWebhook 更新webhook
+// @Summary 更新webhook
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param params body model.ReqWebhookUpdate true "webhook参数"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/webhook/update [post]
+func (w *WebhookController) UpdateWebhook(ctx *app.Context) {
+	param := &model.ReqWebhookUpdate{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := w.WebhookService.UpdateWebhook(ctx, param); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// DeleteWebhook 删除webhook
+// @Summary 删除webhook
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "webhook uuid"
+// @Success 200 {object} model.StringDataResponse
+// @Router /api/v1/webhook/delete [post]
+func (w *WebhookController) DeleteWebhook(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := w.WebhookService.DeleteWebhook(ctx, param.Uuid); err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess("ok")
+}
+
+// GetWebhookList 获取webhook列表
+// @Summary 获取webhook列表
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param params body model.ReqWebhookQueryParam true "查询参数"
+// @Success 200 {object} model.PagedResponse
+// @Router /api/v1/webhook/list [post]
+func (w *WebhookController) GetWebhookList(ctx *app.Context) {
+	param := &model.ReqWebhookQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := w.WebhookService.GetWebhookList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// GetDeliveryList 获取webhook投递记录
+// @Summary 获取webhook投递记录
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param params body model.ReqWebhookDeliveryQueryParam true "查询参数"
+// @Success 200 {object} model.PagedResponse
+// @Router /api/v1/webhook/delivery/list [post]
+func (w *WebhookController) GetDeliveryList(ctx *app.Context) {
+	param := &model.ReqWebhookDeliveryQueryParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := w.WebhookService.GetDeliveryList(ctx, param)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// GetDeliveryInfo 获取webhook投递详情
+// @Summary 获取webhook投递详情
+// @Description 包含每次投递的响应状态码、响应内容和错误信息
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "投递uuid"
+// @Success 200 {object} model.WebhookDeliveryResponse
+// @Router /api/v1/webhook/delivery/info [post]
+func (w *WebhookController) GetDeliveryInfo(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := w.WebhookService.GetDeliveryInfo(ctx, param.Uuid)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// Redeliver 重新投递webhook
+// @Summary 重新投递webhook
+// @Description 立即重新推送，重置重试次数，失败后按重试策略继续重试
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param params body model.ReqUuidParam true "投递uuid"
+// @Success 200 {object} model.WebhookDeliveryResponse
+// @Router /api/v1/webhook/delivery/redeliver [post]
+func (w *WebhookController) Redeliver(ctx *app.Context) {
+	param := &model.ReqUuidParam{}
+	if err := ctx.ShouldBindJSON(param); err != nil {
+		ctx.JSONError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := w.WebhookService.Redeliver(ctx, param.Uuid)
+	if err != nil {
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSONSuccess(res)
+}
+
+// GetWebhookEvents 获取支持订阅的事件
+// @Summary 获取webhook事件
+// @Tags Webhook
+// @Produce json
+// @Success 200 {object} model.StringListResponse
+// @Router /api/v1/webhook/events [get]
+func (w *WebhookController) GetWebhookEvents(ctx *app.Context) {
+	ctx.JSONSuccess(model.WebhookEvents)
+}
@@ -13,6 +13,7 @@ import (
 
 type WechatPayController struct {
 	PaymentMethodService *service.PaymentMethodService
+	OrderService         *service.OrderService
 }
 
 // return 回调
@@ -60,6 +61,23 @@ func (w *WechatPayController) Return(ctx *app.Context) {
 		return
 	}
 
+	// 解密支付结果，交易成功时更新订单状态
+	result, err := notifyReq.DecryptPayCipherText(wechatClient.ApiV3Key)
+	if err != nil {
+		ctx.Logger.Error("DecryptPayCipherText err:", err)
+		ctx.JSONError(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if result.TradeState == "SUCCESS" {
+		err = w.OrderService.MarkOrderPaid(ctx, result.OutTradeNo, "wechat", result.TransactionId)
+		if err != nil {
+			ctx.Logger.Error("Failed to mark order paid", err)
+			ctx.JSONError(http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+
 	// ====↓↓↓====异步通知应答====↓↓↓====
 	// 退款通知http应答码为200且返回状态码为SUCCESS才会当做商户接收成功，否则会重试。
 	// 注意：重试过多会导致微信支付端积压过多通知而堵塞，影响其他正常通知。
@@ -0,0 +1,19265 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "url": "http://www.swagger.io/support",
+            "email": "support@swagger.io"
+        },
+        "license": {
+            "name": "Apache 2.0",
+            "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/api/open/v1/inventory/adjust": {
+            "post": {
+                "description": "按变动数量增减库存，操作人记录为app:\u003capp uuid\u003e",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "开放接口调整库存",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app的ApiKey",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix时间戳（秒）",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "随机字符串，有效期内不能重复",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "utils.SignRequest签名",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "调整参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqInventoryAdjustParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InventoryLogInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/open/v1/inventory/log/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "开放接口获取库存流水",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app的ApiKey",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix时间戳（秒）",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "随机字符串，有效期内不能重复",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "utils.SignRequest签名",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqInventoryLogQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InventoryLogQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/open/v1/order/info": {
+            "post": {
+                "description": "包含订单商品",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "开放接口获取订单信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app的ApiKey",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix时间戳（秒）",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "随机字符串，有效期内不能重复",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "utils.SignRequest签名",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "订单编号",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderResInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/open/v1/order/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "开放接口获取订单列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app的ApiKey",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix时间戳（秒）",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "随机字符串，有效期内不能重复",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "utils.SignRequest签名",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderListPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/open/v1/product/info": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "开放接口获取产品信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app的ApiKey",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix时间戳（秒）",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "随机字符串，有效期内不能重复",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "utils.SignRequest签名",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "产品uuid",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/open/v1/product/item/list": {
+            "post": {
+                "description": "包含SKU的价格和库存",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "开放接口获取产品SKU列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app的ApiKey",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix时间戳（秒）",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "随机字符串，有效期内不能重复",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "utils.SignRequest签名",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqProductQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductItemListPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/open/v1/product/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "开放接口获取产品列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app的ApiKey",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix时间戳（秒）",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "随机字符串，有效期内不能重复",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "utils.SignRequest签名",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqProductQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductListPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/open/v1/shipment/create": {
+            "post": {
+                "description": "外部系统发货后回传物流单号，操作人记录为app:\u003capp uuid\u003e",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "开放接口创建包裹",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app的ApiKey",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix时间戳（秒）",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "随机字符串，有效期内不能重复",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "utils.SignRequest签名",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "包裹信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqShipmentCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ShipmentInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/open/v1/shipment/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "开放接口"
+                ],
+                "summary": "开放接口获取包裹列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "app的ApiKey",
+                        "name": "X-Api-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "unix时间戳（秒）",
+                        "name": "X-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "随机字符串，有效期内不能重复",
+                        "name": "X-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "utils.SignRequest签名",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqShipmentQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ShipmentQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/api/create": {
+            "post": {
+                "description": "创建API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API"
+                ],
+                "summary": "创建API",
+                "parameters": [
+                    {
+                        "description": "API参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.API"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.API"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/api/delete": {
+            "post": {
+                "description": "删除API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API"
+                ],
+                "summary": "删除API",
+                "parameters": [
+                    {
+                        "description": "API UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/api/info": {
+            "post": {
+                "description": "获取API信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API"
+                ],
+                "summary": "获取API信息",
+                "parameters": [
+                    {
+                        "description": "API UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.API"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/api/list": {
+            "post": {
+                "description": "获取API列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API"
+                ],
+                "summary": "获取API列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqAPIQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PagedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/api/update": {
+            "post": {
+                "description": "更新API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API"
+                ],
+                "summary": "更新API",
+                "parameters": [
+                    {
+                        "description": "API参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.API"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.API"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/app/api/permissions/list": {
+            "post": {
+                "description": "app可以调用的开放接口",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Permission"
+                ],
+                "summary": "获取app的接口权限",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqApiPermissionParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/app/api/permissions/set": {
+            "post": {
+                "description": "替换app原有的接口权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Permission"
+                ],
+                "summary": "设置app的接口权限",
+                "parameters": [
+                    {
+                        "description": "权限参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqAppPermissionSet"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/app/create": {
+            "post": {
+                "description": "创建应用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "App"
+                ],
+                "summary": "创建应用",
+                "parameters": [
+                    {
+                        "description": "Create app",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.App"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AppInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/app/delete": {
+            "post": {
+                "description": "删除应用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "App"
+                ],
+                "summary": "删除应用",
+                "parameters": [
+                    {
+                        "description": "Delete app",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully delete user data",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/app/list": {
+            "post": {
+                "description": "获取应用列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "App"
+                ],
+                "summary": "获取应用列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqAppQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AppQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/app/update": {
+            "post": {
+                "description": "更新应用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "App"
+                ],
+                "summary": "更新应用",
+                "parameters": [
+                    {
+                        "description": "Update app",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqAppUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AppInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/captcha/create": {
+            "post": {
+                "description": "登录失败次数过多时需要图形验证码，登录时提交captcha_id和captcha_code，验证码只能使用一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "获取图形验证码",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CaptchaResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cart/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物车"
+                ],
+                "summary": "创建购物车",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "购物车信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Cart"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Cart"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cart/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物车"
+                ],
+                "summary": "删除购物车",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "删除参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cart/info": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物车"
+                ],
+                "summary": "查询购物车详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Cart"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cart/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物车"
+                ],
+                "summary": "查询购物车列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCartQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Cart"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cart/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物车"
+                ],
+                "summary": "更新购物车",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "购物车信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Cart"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Cart"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/cart/update/count": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "购物车"
+                ],
+                "summary": "更新购物车商品数量",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "更新参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCartItemCountParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/casbin/explain": {
+            "post": {
+                "description": "返回是否允许、用户在团队中的角色和策略，以及被拒绝的原因",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限策略"
+                ],
+                "summary": "查询权限校验结果",
+                "parameters": [
+                    {
+                        "description": "请求信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCasbinExplain"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CasbinExplainResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/casbin/policy/create": {
+            "post": {
+                "description": "立即生效，p: 主体, 团队, 路径, 方法；g: 用户, 角色, 团队",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限策略"
+                ],
+                "summary": "添加casbin策略",
+                "parameters": [
+                    {
+                        "description": "策略",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCasbinPolicy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/casbin/policy/delete": {
+            "post": {
+                "description": "立即生效，由角色配置生成的策略在下次同步时会重新生成",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限策略"
+                ],
+                "summary": "删除casbin策略",
+                "parameters": [
+                    {
+                        "description": "策略",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCasbinPolicy"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/casbin/policy/list": {
+            "post": {
+                "description": "generated为true的策略由角色配置生成",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限策略"
+                ],
+                "summary": "查询casbin策略",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCasbinPolicyQuery"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CasbinRuleListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/casbin/policy/sync": {
+            "post": {
+                "description": "根据角色、菜单和接口的关联重新生成策略，手工添加的策略保留，返回生成的策略数量",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限策略"
+                ],
+                "summary": "同步casbin策略",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.IntDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/casbin/reload": {
+            "post": {
+                "description": "从数据库重新加载策略，多实例部署时其他实例在下次请求时重新加载",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限策略"
+                ],
+                "summary": "重新加载casbin策略",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/configuration/category_create_map": {
+            "post": {
+                "description": "创建配置Map",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "创建配置Map",
+                "parameters": [
+                    {
+                        "description": "配置Map参数, key为配置名称, value为配置值, category为配置分类(必须)",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/configuration/category_map": {
+            "post": {
+                "description": "获取配置Map",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "获取配置Map",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqConfigCategoryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ConfigurationMapResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/configuration/create": {
+            "post": {
+                "description": "创建配置",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "创建配置",
+                "parameters": [
+                    {
+                        "description": "配置参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqConfigCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ConfigurationInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/configuration/info": {
+            "post": {
+                "description": "获取配置信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "获取配置信息",
+                "parameters": [
+                    {
+                        "description": "配置ID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqConfigQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ConfigurationInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/configuration/list": {
+            "post": {
+                "description": "获取配置列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "获取配置列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqConfigQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ConfigurationPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/configuration/update": {
+            "post": {
+                "description": "更新配置",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "配置"
+                ],
+                "summary": "更新配置",
+                "parameters": [
+                    {
+                        "description": "配置参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Configuration"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ConfigurationInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/currency/all": {
+            "post": {
+                "description": "获取全部可用的币种",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "币种"
+                ],
+                "summary": "获取全部可用的币种",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CurrencyListResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/currency/create": {
+            "post": {
+                "description": "创建币种",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "币种"
+                ],
+                "summary": "创建币种",
+                "parameters": [
+                    {
+                        "description": "币种参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCurrencyCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CurrencyInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/currency/delete": {
+            "post": {
+                "description": "删除币种",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "币种"
+                ],
+                "summary": "删除币种",
+                "parameters": [
+                    {
+                        "description": "币种UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/currency/info": {
+            "post": {
+                "description": "获取币种信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "币种"
+                ],
+                "summary": "获取币种信息",
+                "parameters": [
+                    {
+                        "description": "币种UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CurrencyInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/currency/list": {
+            "post": {
+                "description": "获取币种列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "币种"
+                ],
+                "summary": "获取币种列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCurrencyQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CurrencyPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/currency/update": {
+            "post": {
+                "description": "更新币种",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "币种"
+                ],
+                "summary": "更新币种",
+                "parameters": [
+                    {
+                        "description": "币种参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Currency"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CurrencyInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/export": {
+            "post": {
+                "description": "按筛选条件导出全部客户为CSV，忽略分页参数",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "导出客户",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerQueryParam"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/v1/customer/group/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户分组"
+                ],
+                "summary": "创建客户分组",
+                "parameters": [
+                    {
+                        "description": "客户分组",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerGroupCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerGroupInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/group/delete": {
+            "post": {
+                "description": "分组的价格表会被禁用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户分组"
+                ],
+                "summary": "删除客户分组",
+                "parameters": [
+                    {
+                        "description": "客户分组UUID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/group/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户分组"
+                ],
+                "summary": "获取客户分组列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerGroupQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerGroupQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/group/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户分组"
+                ],
+                "summary": "更新客户分组",
+                "parameters": [
+                    {
+                        "description": "客户分组",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerGroupUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/group/user/add": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户分组"
+                ],
+                "summary": "添加客户到分组",
+                "parameters": [
+                    {
+                        "description": "分组和用户",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerGroupUserParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/group/user/remove": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户分组"
+                ],
+                "summary": "从分组移除客户",
+                "parameters": [
+                    {
+                        "description": "分组和用户",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerGroupUserParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/info": {
+            "post": {
+                "description": "返回客户的消费统计、收货地址和最近10个订单",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "后台获取客户详情",
+                "parameters": [
+                    {
+                        "description": "客户uuid",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/list": {
+            "post": {
+                "description": "返回客户的订单数、消费金额、平均订单金额、首次和最近下单时间、默认地址和标签，指定segment_uuid时使用客户分群的筛选条件",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "后台获取客户列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PagedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/segment/create": {
+            "post": {
+                "description": "保存客户筛选条件，例如最近90天消费超过1000：{\"days\":90,\"min_spent\":1000}",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户分群"
+                ],
+                "summary": "创建客户分群",
+                "parameters": [
+                    {
+                        "description": "客户分群",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerSegmentCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerSegmentInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/segment/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户分群"
+                ],
+                "summary": "删除客户分群",
+                "parameters": [
+                    {
+                        "description": "客户分群uuid",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/segment/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户分群"
+                ],
+                "summary": "获取客户分群列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerSegmentQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PagedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/segment/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户分群"
+                ],
+                "summary": "更新客户分群",
+                "parameters": [
+                    {
+                        "description": "客户分群",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerSegmentUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/tag/add": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "给客户添加标签",
+                "parameters": [
+                    {
+                        "description": "客户和标签",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerTagParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/tag/list": {
+            "post": {
+                "description": "返回所有标签及使用的客户数",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "获取客户标签列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerTagListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customer/tag/remove": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "删除客户标签",
+                "parameters": [
+                    {
+                        "description": "客户和标签",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerTagParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/customer/delete": {
+            "post": {
+                "description": "删除地址、购物车等个人数据并匿名化账号，订单等交易记录按法律要求保留",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "注销账号",
+                "parameters": [
+                    {
+                        "description": "当前密码",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerDelete"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/customer/email/change": {
+            "post": {
+                "description": "验证码发送到新邮箱，确认后才会修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "申请修改邮箱",
+                "parameters": [
+                    {
+                        "description": "新邮箱",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerEmailChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/customer/email/confirm": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "确认修改邮箱",
+                "parameters": [
+                    {
+                        "description": "新邮箱和验证码",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerEmailConfirm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/customer/export": {
+            "get": {
+                "description": "导出客户资料、地址、订单、付款、订阅和退货记录，JSON格式",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "导出个人数据",
+                "responses": {}
+            }
+        },
+        "/api/v1/f/customer/login": {
+            "post": {
+                "description": "返回客户受众的token，用于商城接口，不能用于后台用户登录\n失败次数过多时返回428，需要通过 /api/v1/f/captcha/create 获取图形验证码后重新提交；账号或IP被锁定时返回423",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "客户登录",
+                "parameters": [
+                    {
+                        "description": "登录参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerLogin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ResUserLogin"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/customer/password/forgot": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "发送重置密码验证码",
+                "parameters": [
+                    {
+                        "description": "邮箱",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerEmailParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/customer/password/reset": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "通过邮箱验证码重置密码",
+                "parameters": [
+                    {
+                        "description": "重置密码参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerPasswordReset"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/customer/password/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "修改密码",
+                "parameters": [
+                    {
+                        "description": "密码参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerPasswordUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/customer/profile": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "获取客户资料",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/customer/profile/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "更新客户资料",
+                "parameters": [
+                    {
+                        "description": "客户资料",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerProfileUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/customer/register": {
+            "post": {
+                "description": "邮箱验证码通过 /api/v1/verification_code/create 发送",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "客户"
+                ],
+                "summary": "客户注册",
+                "parameters": [
+                    {
+                        "description": "注册参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqCustomerRegister"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.CustomerInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/digital/download": {
+            "get": {
+                "tags": [
+                    "虚拟产品"
+                ],
+                "summary": "下载虚拟商品",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "下载授权UUID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "过期时间戳",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "签名",
+                        "name": "sign",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/v1/f/digital/order": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "虚拟产品"
+                ],
+                "summary": "获取订单的下载链接和许可证",
+                "parameters": [
+                    {
+                        "description": "订单编号",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqDigitalOrderParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.DigitalOrderInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/invoice/download": {
+            "get": {
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "发票"
+                ],
+                "summary": "下载我的订单发票",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "订单编号",
+                        "name": "order_no",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/v1/f/order/notes": {
+            "post": {
+                "description": "只返回买家可见的备注",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "获取我的订单备注",
+                "parameters": [
+                    {
+                        "description": "订单编号",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderTrackingParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderNoteListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/order/tracking": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发货"
+                ],
+                "summary": "查询订单物流",
+                "parameters": [
+                    {
+                        "description": "订单编号",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderTrackingParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderTrackingInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/payment_method/all": {
+            "post": {
+                "description": "获取所有可用支付方式",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付方式"
+                ],
+                "summary": "获取所有可用支付方式",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaymentMethod"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/product/info": {
+            "post": {
+                "description": "获取产品前端展示信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "获取产品前端展示信息",
+                "parameters": [
+                    {
+                        "description": "产品UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "产品信息",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductShowItemInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/product/list": {
+            "post": {
+                "description": "获取产品前端展示列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "获取产品前端展示列表",
+                "parameters": [
+                    {
+                        "description": "产品参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqProductQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "产品列表",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductShowListPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/product_category/all": {
+            "post": {
+                "description": "用户前端获取所有分类",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品分类"
+                ],
+                "summary": "用户前端获取所有分类",
+                "responses": {
+                    "200": {
+                        "description": "分类列表",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductCategoryListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/return/cancel": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "取消退货申请",
+                "parameters": [
+                    {
+                        "description": "退货申请uuid",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/return/create": {
+            "post": {
+                "description": "选择已发货的订单商品和数量，图片先通过上传接口上传",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "申请退货",
+                "parameters": [
+                    {
+                        "description": "退货申请",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqReturnCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReturnInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/return/info": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "获取我的退货申请详情",
+                "parameters": [
+                    {
+                        "description": "退货申请uuid",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReturnInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/return/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "获取我的退货申请",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqReturnQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReturnQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/subscription/cancel": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅"
+                ],
+                "summary": "取消订阅",
+                "parameters": [
+                    {
+                        "description": "订阅UUID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/subscription/create": {
+            "post": {
+                "description": "有试用期的计划在试用结束后首次扣款",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅"
+                ],
+                "summary": "订阅",
+                "parameters": [
+                    {
+                        "description": "订阅信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqSubscriptionCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SubscriptionInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/subscription/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅"
+                ],
+                "summary": "获取我的订阅列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqSubscriptionQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SubscriptionQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/subscription/pause": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅"
+                ],
+                "summary": "暂停订阅",
+                "parameters": [
+                    {
+                        "description": "订阅UUID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/subscription/plans": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅"
+                ],
+                "summary": "获取产品可订阅的计划",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqSubscriptionPlanQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SubscriptionPlanQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/f/subscription/resume": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅"
+                ],
+                "summary": "恢复订阅",
+                "parameters": [
+                    {
+                        "description": "订阅UUID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/inventory/adjust": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "库存"
+                ],
+                "summary": "调整库存",
+                "parameters": [
+                    {
+                        "description": "调整参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqInventoryAdjustParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InventoryLogInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/inventory/log/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "库存"
+                ],
+                "summary": "获取库存流水列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqInventoryLogQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InventoryLogQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/inventory/low_stock": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "库存"
+                ],
+                "summary": "获取库存预警列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqLowStockQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LowStockQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/invoice/bulk": {
+            "post": {
+                "description": "打包为ZIP下载，生成失败的订单记录在errors.txt中",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "发票"
+                ],
+                "summary": "批量下载发票或装箱单",
+                "parameters": [
+                    {
+                        "description": "订单列表",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqInvoiceBulkParam"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/v1/invoice/download": {
+            "get": {
+                "description": "订单首次下载时开具发票，发票号按年连续编号",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "发票"
+                ],
+                "summary": "下载订单发票",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "订单编号",
+                        "name": "order_no",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/v1/invoice/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发票"
+                ],
+                "summary": "获取发票列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqInvoiceQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.InvoiceQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/invoice/packing_slip": {
+            "get": {
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "发票"
+                ],
+                "summary": "下载订单装箱单",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "订单编号",
+                        "name": "order_no",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/v1/login": {
+            "post": {
+                "description": "启用两步验证或角色要求两步验证时返回step为two_factor或setup的登录挑战，通过 /api/v1/login/2fa/verify 完成登录\n失败次数过多时返回428，需要通过 /api/v1/captcha/create 获取图形验证码后重新提交；账号或IP被锁定时返回423",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "用户登录",
+                "parameters": [
+                    {
+                        "description": "登录参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUserLogin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ResUserLogin"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/login/2fa/setup": {
+            "post": {
+                "description": "角色要求两步验证但未绑定时使用，totp返回密钥和二维码链接，email发送验证码，再调用 /api/v1/login/2fa/verify 确认",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "登录时绑定两步验证",
+                "parameters": [
+                    {
+                        "description": "绑定参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqLoginTwoFactorSetup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TotpSetupResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/login/2fa/verify": {
+            "post": {
+                "description": "提交身份验证器密码、邮箱验证码或恢复码，setup挑战提交绑定的验证码，成功后返回token，首次绑定时同时返回恢复码\n与登录共用失败次数，需要图形验证码时返回428，账号或IP被锁定时返回423",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "登录两步验证",
+                "parameters": [
+                    {
+                        "description": "验证参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqLoginTwoFactorVerify"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ResUserLogin"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/login_lock/list": {
+            "post": {
+                "description": "默认只返回锁定中的账号和IP，all为true时包含已解除锁定的记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "登录日志"
+                ],
+                "summary": "获取登录锁定列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqLoginLockQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PagedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/login_lock/unlock": {
+            "post": {
+                "description": "解除账号或IP的锁定，同时清除失败次数和连续锁定次数",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "登录日志"
+                ],
+                "summary": "解除登录锁定",
+                "parameters": [
+                    {
+                        "description": "锁定记录ID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqIdParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loginlog/list": {
+            "post": {
+                "description": "获取登录日志列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "登录日志"
+                ],
+                "summary": "获取登录日志列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqLoginLogQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PagedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/loginlog/{id}": {
+            "post": {
+                "description": "获取登录日志",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "登录日志"
+                ],
+                "summary": "获取登录日志",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "登录日志ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SysLoginLog"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/logout": {
+            "post": {
+                "description": "撤销当前会话",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会话"
+                ],
+                "summary": "退出登录",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/menu/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "创建菜单",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "菜单信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Menu"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MenuPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/menu/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "删除菜单",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "删除参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqMenuDeleteParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/menu/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "查询菜单列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqMenuQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MenuQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/menu/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单"
+                ],
+                "summary": "更新菜单",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "菜单信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Menu"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MenuPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/audit/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "获取订单修改记录",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderAuditQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderAuditQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "创建订单",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "订单信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/delete": {
+            "post": {
+                "description": "订单为软删除，待支付订单会先关闭并退回库存，已支付未完成的订单不能删除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "删除订单",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "删除参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/info": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "查询订单详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/item/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "获取订单商品列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderItemListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/item/update": {
+            "post": {
+                "description": "只能修改待支付订单，数量为0时删除商品，重新计算订单金额并调整库存，买家需要重新支付",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "修改订单商品数量",
+                "parameters": [
+                    {
+                        "description": "商品数量",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderItemsUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "查询订单列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderListPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/merge": {
+            "post": {
+                "description": "同一买家已支付未发货的订单合并到目标订单，被合并的订单关闭",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "合并订单",
+                "parameters": [
+                    {
+                        "description": "合并参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderMerge"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/note/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "添加订单备注",
+                "parameters": [
+                    {
+                        "description": "备注",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderNoteCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderNoteInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/note/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "获取订单备注",
+                "parameters": [
+                    {
+                        "description": "订单编号",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderTrackingParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderNoteListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/receiver/update": {
+            "post": {
+                "description": "未发货、未开票的订单可以修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "修改订单收货信息",
+                "parameters": [
+                    {
+                        "description": "收货信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderReceiverUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order/split": {
+            "post": {
+                "description": "已支付未发货的订单，指定的商品数量移到新订单",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订单"
+                ],
+                "summary": "拆分订单",
+                "parameters": [
+                    {
+                        "description": "拆分参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderSplit"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/page/create": {
+            "post": {
+                "description": "创建页面",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Page"
+                ],
+                "summary": "创建页面",
+                "parameters": [
+                    {
+                        "description": "Create page",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Page"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PageInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/page/delete": {
+            "post": {
+                "description": "删除页面",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Page"
+                ],
+                "summary": "删除页面",
+                "parameters": [
+                    {
+                        "description": "Delete page",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted page data",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/page/info": {
+            "post": {
+                "description": "获取页面详情",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Page"
+                ],
+                "summary": "获取页面详情",
+                "parameters": [
+                    {
+                        "description": "Get page info",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PageInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/page/list": {
+            "post": {
+                "description": "获取页面列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Page"
+                ],
+                "summary": "获取页面列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPageQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PageQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/page/update": {
+            "post": {
+                "description": "更新页面",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Page"
+                ],
+                "summary": "更新页面",
+                "parameters": [
+                    {
+                        "description": "Update page",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Page"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PageInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/payment-method/alipay/config": {
+            "post": {
+                "description": "设置支付宝配置",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付方式"
+                ],
+                "summary": "设置支付宝配置",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "支付宝私钥",
+                        "name": "private_key",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "支付宝公钥",
+                        "name": "app_public_key",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "支付宝公钥证书",
+                        "name": "alipay_public_cert",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "支付宝根证书",
+                        "name": "alipay_root_cert",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "app_id",
+                        "name": "app_id",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/payment-method/create": {
+            "post": {
+                "description": "创建支付方式",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付方式"
+                ],
+                "summary": "创建支付方式",
+                "parameters": [
+                    {
+                        "description": "支付方式参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PaymentMethod"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaymentMethod"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/payment-method/delete": {
+            "post": {
+                "description": "删除支付方式",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付方式"
+                ],
+                "summary": "删除支付方式",
+                "parameters": [
+                    {
+                        "description": "支付方式uuid",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/payment-method/info": {
+            "post": {
+                "description": "获取支付方式信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付方式"
+                ],
+                "summary": "获取支付方式信息",
+                "parameters": [
+                    {
+                        "description": "支付方式uuid",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaymentMethod"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/payment-method/list": {
+            "post": {
+                "description": "获取支付方式列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付方式"
+                ],
+                "summary": "获取支付方式列表",
+                "parameters": [
+                    {
+                        "description": "支付方式查询参数",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPaymentMethodQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/payment-method/update": {
+            "post": {
+                "description": "更新支付方式",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付方式"
+                ],
+                "summary": "更新支付方式",
+                "parameters": [
+                    {
+                        "description": "支付方式参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PaymentMethod"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaymentMethod"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/payment-method/wechat/config": {
+            "post": {
+                "description": "设置微信支付配置",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付方式"
+                ],
+                "summary": "设置微信支付配置",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "serial_no",
+                        "name": "serial_no",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "mch_id",
+                        "name": "mch_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "api_key",
+                        "name": "api_key",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "key",
+                        "name": "key",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/payment_method/alipay/create": {
+            "post": {
+                "description": "创建支付宝支付",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付方式"
+                ],
+                "summary": "创建支付宝支付",
+                "parameters": [
+                    {
+                        "description": "支付订单参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPaymentOrderCreateParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AlipayOrderDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/payment_method/paypal/create": {
+            "post": {
+                "description": "创建Paypal支付",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付方式"
+                ],
+                "summary": "创建Paypal支付",
+                "parameters": [
+                    {
+                        "description": "支付订单参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPaymentOrderCreateParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaypalOrderDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/payment_method/wechat/create": {
+            "post": {
+                "description": "创建微信支付",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "支付方式"
+                ],
+                "summary": "创建微信支付",
+                "parameters": [
+                    {
+                        "description": "支付订单参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPaymentOrderCreateParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WechatOrderDetailResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限"
+                ],
+                "summary": "创建新的权限",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "权限信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Permission"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限"
+                ],
+                "summary": "删除权限",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "权限信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission/info": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限"
+                ],
+                "summary": "获取权限信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "权限信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限"
+                ],
+                "summary": "获取权限列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "权限查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPermissionQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限"
+                ],
+                "summary": "更新权限信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "权限信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Permission"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission_menu/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限菜单关联"
+                ],
+                "summary": "创建新的权限菜单关联",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "权限菜单关联信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPermissionMenuCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionMenuCreateResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission_menu/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限菜单关联"
+                ],
+                "summary": "删除权限菜单关联",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "权限菜单关联信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission_menu/info": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限菜单关联"
+                ],
+                "summary": "获取权限菜单关联信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "权限菜单关联信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionMenuListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission_menu/info_menu": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限菜单关联"
+                ],
+                "summary": "根据权限UUID获取权限菜单关联列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "权限UUID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionMenuListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission_menu/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限菜单关联"
+                ],
+                "summary": "获取权限菜单关联列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPermissionMenuQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionMenuPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission_menu/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "权限菜单关联"
+                ],
+                "summary": "更新权限菜单关联信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "权限菜单关联信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionMenu"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PermissionMenuInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission_user/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户权限关联"
+                ],
+                "summary": "创建新的用户权限关联",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "用户权限关联信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPermissionUserCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission_user/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户权限关联"
+                ],
+                "summary": "删除用户权限关联",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "用户权限关联信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission_user/info": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户权限关联"
+                ],
+                "summary": "获取用户权限关联信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "用户权限关联信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserPermissionListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission_user/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户权限关联"
+                ],
+                "summary": "获取用户权限关联列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "用户权限关联查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUserPermissionQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserPermissionPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/permission_user/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户权限关联"
+                ],
+                "summary": "更新用户权限关联信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "用户权限关联信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserPermission"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserPermissionInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/price/list/create": {
+            "post": {
+                "description": "未指定客户分组的价格表对所有客户生效，可设置开始和结束时间用于限时促销",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "价格表"
+                ],
+                "summary": "创建价格表",
+                "parameters": [
+                    {
+                        "description": "价格表",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPriceListCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PriceListInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/price/list/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "价格表"
+                ],
+                "summary": "删除价格表",
+                "parameters": [
+                    {
+                        "description": "价格表UUID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/price/list/info": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "价格表"
+                ],
+                "summary": "获取价格表详情",
+                "parameters": [
+                    {
+                        "description": "价格表UUID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PriceListInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/price/list/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "价格表"
+                ],
+                "summary": "获取价格表列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPriceListQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PriceListQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/price/list/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "价格表"
+                ],
+                "summary": "更新价格表",
+                "parameters": [
+                    {
+                        "description": "价格表",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqPriceListUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/create": {
+            "post": {
+                "description": "创建产品",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "创建产品",
+                "parameters": [
+                    {
+                        "description": "产品参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqProductCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created product",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/delete": {
+            "post": {
+                "description": "删除产品",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "删除产品",
+                "parameters": [
+                    {
+                        "description": "产品UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqProductDeleteParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted product",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/digital/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "虚拟产品"
+                ],
+                "summary": "删除虚拟文件",
+                "parameters": [
+                    {
+                        "description": "虚拟文件UUID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/digital/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "虚拟产品"
+                ],
+                "summary": "获取产品虚拟文件列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqDigitalFileQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductDigitalFileListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/digital/upload": {
+            "post": {
+                "description": "文件保存在私有目录，只能通过签名下载链接访问",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "虚拟产品"
+                ],
+                "summary": "上传虚拟产品文件",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "产品UUID",
+                        "name": "product_uuid",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "产品SKU UUID",
+                        "name": "product_item_uuid",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最大下载次数",
+                        "name": "max_downloads",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "下载有效天数",
+                        "name": "expire_days",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductDigitalFileInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/group/info": {
+            "post": {
+                "description": "获取组合产品的组件、价格和可售数量",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "获取组合产品信息",
+                "parameters": [
+                    {
+                        "description": "产品UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "组合产品信息",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductGroupInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/group/update": {
+            "post": {
+                "description": "更新组合产品的组件和定价方式",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "更新组合产品",
+                "parameters": [
+                    {
+                        "description": "组合产品参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqProductGroupUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated product group",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/info": {
+            "post": {
+                "description": "获取产品信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "获取产品信息",
+                "parameters": [
+                    {
+                        "description": "产品UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "产品信息",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/item/delete": {
+            "post": {
+                "description": "删除产品SKU",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "删除产品SKU",
+                "parameters": [
+                    {
+                        "description": "产品SKU UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqProductDeleteParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted product",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/item/info": {
+            "post": {
+                "description": "获取产品SKU信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "获取产品SKU信息",
+                "parameters": [
+                    {
+                        "description": "产品SKU UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "产品SKU信息",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductItemInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/item/list": {
+            "post": {
+                "description": "获取产品SKU列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "获取产品SKU列表",
+                "parameters": [
+                    {
+                        "description": "产品参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqProductQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "产品SKU列表",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductItemListPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/item/update": {
+            "post": {
+                "description": "更新产品SKU",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "更新产品SKU",
+                "parameters": [
+                    {
+                        "description": "产品SKU参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqProductItemUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated product",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/license/import": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "虚拟产品"
+                ],
+                "summary": "导入许可证",
+                "parameters": [
+                    {
+                        "description": "许可证",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqLicenseKeyImport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/license/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "虚拟产品"
+                ],
+                "summary": "获取许可证列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqLicenseKeyQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.LicenseKeyQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/list": {
+            "post": {
+                "description": "获取产品列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "获取产品列表",
+                "parameters": [
+                    {
+                        "description": "产品参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqProductQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "产品列表",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductListPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/update": {
+            "post": {
+                "description": "更新产品",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "更新产品",
+                "parameters": [
+                    {
+                        "description": "产品参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqProductUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated product",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product/variant/info": {
+            "post": {
+                "description": "获取产品变体信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品"
+                ],
+                "summary": "获取产品变体信息",
+                "parameters": [
+                    {
+                        "description": "产品变体UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "产品变体信息",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductVariantsListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product_category/create": {
+            "post": {
+                "description": "创建分类",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品分类"
+                ],
+                "summary": "创建分类",
+                "parameters": [
+                    {
+                        "description": "分类参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created category",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductCategoryInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product_category/delete": {
+            "post": {
+                "description": "删除分类",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品分类"
+                ],
+                "summary": "删除分类",
+                "parameters": [
+                    {
+                        "description": "分类参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product_category/info": {
+            "post": {
+                "description": "获取分类详情",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品分类"
+                ],
+                "summary": "获取分类详情",
+                "parameters": [
+                    {
+                        "description": "分类UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "分类详情",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductCategoryInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product_category/list": {
+            "post": {
+                "description": "获取分类列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品分类"
+                ],
+                "summary": "获取分类列表",
+                "responses": {
+                    "200": {
+                        "description": "分类列表",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductCategoryListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/product_category/update": {
+            "post": {
+                "description": "更新分类",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "产品分类"
+                ],
+                "summary": "更新分类",
+                "parameters": [
+                    {
+                        "description": "分类参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductCategory"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated category",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductCategoryInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/register": {
+            "post": {
+                "description": "注册",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "注册",
+                "parameters": [
+                    {
+                        "description": "Register",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqRegisterParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resource/create": {
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "资源"
+                ],
+                "summary": "创建资源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "目标路径",
+                        "name": "path",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "上传文件的数组，支持多文件上传",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "返回上传的资源信息数组",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Resource"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resource/create_folder": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "资源"
+                ],
+                "summary": "创建文件夹",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "创建文件夹参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqResourceCreateFolder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resource/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "资源"
+                ],
+                "summary": "删除资源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "删除参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqResourceDeleteParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resource/folder_list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "资源"
+                ],
+                "summary": "获取文件夹列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ResourceRes"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resource/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "资源"
+                ],
+                "summary": "查询资源列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqResourceQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ResourceRes"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resource/move": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "资源"
+                ],
+                "summary": "移动资源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "移动参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqResourceMove"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/resource/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "资源"
+                ],
+                "summary": "更新资源",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "资源信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Resource"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Resource"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/return/approve": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "同意退货申请",
+                "parameters": [
+                    {
+                        "description": "审核参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqReturnReviewParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/return/exchange": {
+            "post": {
+                "description": "为退货商品创建已支付的零元换货订单，按正常流程发货",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "退货换货",
+                "parameters": [
+                    {
+                        "description": "换货参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqReturnReviewParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/return/info": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "获取退货申请详情",
+                "parameters": [
+                    {
+                        "description": "退货申请uuid",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReturnInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/return/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "获取退货申请列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqReturnQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReturnQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/return/receive": {
+            "post": {
+                "description": "restock为true时退货商品退回库存",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "确认收到退货",
+                "parameters": [
+                    {
+                        "description": "收货参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqReturnReceiveParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/return/refund": {
+            "post": {
+                "description": "支持原路退款的支付方式直接退款，其他支付方式记录为线下退款",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "退货退款",
+                "parameters": [
+                    {
+                        "description": "退款参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqReturnRefundParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RefundInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/return/reject": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "拒绝退货申请",
+                "parameters": [
+                    {
+                        "description": "审核参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqReturnReviewParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/return/report/reason": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "退货"
+                ],
+                "summary": "退货原因统计",
+                "parameters": [
+                    {
+                        "description": "统计参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqReturnReportParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReturnReasonReportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色"
+                ],
+                "summary": "创建角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "角色信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Role"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色"
+                ],
+                "summary": "删除角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "角色ID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Role"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted role",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色"
+                ],
+                "summary": "查询角色列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqRoleQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RoleQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色"
+                ],
+                "summary": "更新角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "角色信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Role"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated role",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/server/create": {
+            "post": {
+                "description": "创建服务",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务"
+                ],
+                "summary": "创建服务",
+                "parameters": [
+                    {
+                        "description": "服务参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Server"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ServerInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/server/delete": {
+            "post": {
+                "description": "删除服务",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务"
+                ],
+                "summary": "删除服务",
+                "parameters": [
+                    {
+                        "description": "服务uuid",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/server/info": {
+            "post": {
+                "description": "获取服务信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务"
+                ],
+                "summary": "获取服务信息",
+                "parameters": [
+                    {
+                        "description": "服务uuid",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ServerInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/server/list": {
+            "post": {
+                "description": "获取服务列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务"
+                ],
+                "summary": "获取服务列表",
+                "parameters": [
+                    {
+                        "description": "服务参数",
+                        "name": "param",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqServerQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ServerQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/server/update": {
+            "post": {
+                "description": "更新服务",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "服务"
+                ],
+                "summary": "更新服务",
+                "parameters": [
+                    {
+                        "description": "服务参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Server"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ServerInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shipment/carrier/list": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发货"
+                ],
+                "summary": "获取已配置的承运商",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shipment/create": {
+            "post": {
+                "description": "订单可以分多个包裹发货，商品全部发出后订单变为已发货，并邮件通知买家",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发货"
+                ],
+                "summary": "创建发货包裹",
+                "parameters": [
+                    {
+                        "description": "包裹信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqShipmentCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ShipmentInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shipment/label/create": {
+            "post": {
+                "description": "向承运商下单获取运单号和面单，面单保存为私有资源，之后定时同步物流轨迹",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发货"
+                ],
+                "summary": "创建承运商面单并发货",
+                "parameters": [
+                    {
+                        "description": "面单信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqShipmentLabelCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ShipmentInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shipment/label/download": {
+            "get": {
+                "tags": [
+                    "发货"
+                ],
+                "summary": "下载包裹面单",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "包裹UUID",
+                        "name": "uuid",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/v1/shipment/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发货"
+                ],
+                "summary": "获取发货包裹列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqShipmentQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ShipmentQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shipment/order": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发货"
+                ],
+                "summary": "获取订单的发货包裹",
+                "parameters": [
+                    {
+                        "description": "订单编号",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderTrackingParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ShipmentListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shipment/rates": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发货"
+                ],
+                "summary": "订单运费询价",
+                "parameters": [
+                    {
+                        "description": "询价参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqShipmentRateParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.BaseResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shipment/track": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发货"
+                ],
+                "summary": "立即同步包裹物流轨迹",
+                "parameters": [
+                    {
+                        "description": "包裹UUID",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ShipmentInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscription/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅"
+                ],
+                "summary": "获取订阅列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqSubscriptionQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SubscriptionQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscription/plan/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅"
+                ],
+                "summary": "创建订阅计划",
+                "parameters": [
+                    {
+                        "description": "订阅计划",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqSubscriptionPlanCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SubscriptionPlanInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscription/plan/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅"
+                ],
+                "summary": "获取订阅计划列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqSubscriptionPlanQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SubscriptionPlanQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscription/plan/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "订阅"
+                ],
+                "summary": "更新订阅计划",
+                "parameters": [
+                    {
+                        "description": "订阅计划",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqSubscriptionPlanUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sys_api/diff": {
+            "post": {
+                "description": "对比已注册的路由和API表，返回新增和删除的API",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API"
+                ],
+                "summary": "获取API差异",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.APIDiffResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sys_api/sync": {
+            "post": {
+                "description": "根据已注册的路由同步API，新增的API为未启用状态，路由中不存在的API标记为删除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API"
+                ],
+                "summary": "同步API",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.APISyncResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sysoplog/delete": {
+            "post": {
+                "description": "删除操作日志",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "操作日志"
+                ],
+                "summary": "删除操作日志",
+                "parameters": [
+                    {
+                        "description": "操作日志ID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqIdParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sysoplog/info": {
+            "post": {
+                "description": "获取操作日志信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "操作日志"
+                ],
+                "summary": "获取操作日志信息",
+                "parameters": [
+                    {
+                        "description": "操作日志ID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqIdParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.SysOpLog"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sysoplog/list": {
+            "post": {
+                "description": "获取操作日志列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "操作日志"
+                ],
+                "summary": "获取操作日志列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOpLogQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PagedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team/create": {
+            "post": {
+                "description": "创建团队",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队"
+                ],
+                "summary": "创建团队",
+                "parameters": [
+                    {
+                        "description": "团队参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTeamCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TeamInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team/delete": {
+            "post": {
+                "description": "删除团队",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队"
+                ],
+                "summary": "删除团队",
+                "parameters": [
+                    {
+                        "description": "团队UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team/info": {
+            "post": {
+                "description": "获取团队信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队"
+                ],
+                "summary": "获取团队信息",
+                "parameters": [
+                    {
+                        "description": "团队UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TeamInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team/list": {
+            "post": {
+                "description": "获取团队列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队"
+                ],
+                "summary": "获取团队列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTeamQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TeamQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team/member/create": {
+            "post": {
+                "description": "创建团队成员",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队成员"
+                ],
+                "summary": "创建团队成员",
+                "parameters": [
+                    {
+                        "description": "团队成员参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTeamMemberCreateParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TeamMemberInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team/member/delete": {
+            "post": {
+                "description": "删除团队成员",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队成员"
+                ],
+                "summary": "删除团队成员",
+                "parameters": [
+                    {
+                        "description": "团队成员UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team/member/list": {
+            "post": {
+                "description": "查询团队成员列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队成员"
+                ],
+                "summary": "查询团队成员列表",
+                "parameters": [
+                    {
+                        "description": "团队成员查询参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTeamMemberQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TeamMemberPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team/owner/transfer": {
+            "post": {
+                "description": "将团队拥有者转让给团队成员，只有当前拥有者可以转让",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队"
+                ],
+                "summary": "转让团队",
+                "parameters": [
+                    {
+                        "description": "转让参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTeamOwnerTransfer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team/update": {
+            "post": {
+                "description": "更新当前团队，拥有者通过转让修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队"
+                ],
+                "summary": "更新团队",
+                "parameters": [
+                    {
+                        "description": "团队参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTeamUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TeamInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team_invitation/accept": {
+            "post": {
+                "description": "接受邀请加入团队，邮箱没有账号时使用用户名和密码创建账号",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队邀请"
+                ],
+                "summary": "接受团队邀请",
+                "parameters": [
+                    {
+                        "description": "接受参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTeamInvitationAccept"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team_invitation/create": {
+            "post": {
+                "description": "通过邮件邀请加入团队，邮箱没有账号时接受邀请会创建账号",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队邀请"
+                ],
+                "summary": "创建团队邀请",
+                "parameters": [
+                    {
+                        "description": "邀请参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTeamInvitationCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TeamInvitationResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team_invitation/decline": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队邀请"
+                ],
+                "summary": "拒绝团队邀请",
+                "parameters": [
+                    {
+                        "description": "邀请token",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTeamInvitationToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team_invitation/info": {
+            "post": {
+                "description": "通过邮件中的邀请token查看邀请，不需要登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队邀请"
+                ],
+                "summary": "查看团队邀请",
+                "parameters": [
+                    {
+                        "description": "邀请token",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTeamInvitationToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TeamInvitationInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team_invitation/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队邀请"
+                ],
+                "summary": "获取团队邀请列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTeamInvitationQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PagedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/team_invitation/revoke": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "团队邀请"
+                ],
+                "summary": "撤销团队邀请",
+                "parameters": [
+                    {
+                        "description": "邀请uuid",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/token/refresh": {
+            "post": {
+                "description": "返回新的访问token和刷新token，旧的刷新token失效，后台用户和商城客户通用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会话"
+                ],
+                "summary": "刷新token",
+                "parameters": [
+                    {
+                        "description": "刷新token",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqRefreshToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ResUserLogin"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/upload": {
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "上传"
+                ],
+                "summary": "文件上传",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"code\":200,\"data\":{},\"msg\":\"ok\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/upload/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "上传"
+                ],
+                "summary": "删除文件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "文件路径",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "{\"code\":200,\"data\":{},\"msg\":\"ok\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/2fa/disable": {
+            "post": {
+                "description": "角色要求两步验证时不能关闭",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "两步验证"
+                ],
+                "summary": "关闭两步验证",
+                "parameters": [
+                    {
+                        "description": "密码和验证码",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTwoFactorDisable"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/2fa/email/send": {
+            "post": {
+                "description": "使用邮箱验证时，关闭两步验证或重新生成恢复码前获取验证码",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "两步验证"
+                ],
+                "summary": "发送邮箱验证码",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/2fa/enable": {
+            "post": {
+                "description": "提交绑定方式的验证码，返回恢复码，恢复码只返回一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "两步验证"
+                ],
+                "summary": "启用两步验证",
+                "parameters": [
+                    {
+                        "description": "验证码",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTwoFactorCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/2fa/recovery_codes": {
+            "post": {
+                "description": "提交验证码，旧的恢复码全部失效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "两步验证"
+                ],
+                "summary": "重新生成恢复码",
+                "parameters": [
+                    {
+                        "description": "验证码",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTwoFactorCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/2fa/setup": {
+            "post": {
+                "description": "totp返回密钥和二维码链接，email发送验证码到用户邮箱，再调用 /api/v1/user/2fa/enable 确认",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "两步验证"
+                ],
+                "summary": "绑定两步验证",
+                "parameters": [
+                    {
+                        "description": "验证方式",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqTwoFactorSetup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TotpSetupResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/2fa/status": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "两步验证"
+                ],
+                "summary": "获取两步验证状态",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TwoFactorStatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/avatar": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "修改头像",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "头像文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/create": {
+            "post": {
+                "description": "Create a new user with the input payload",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "创建用户",
+                "parameters": [
+                    {
+                        "description": "Create user",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/delete": {
+            "post": {
+                "description": "Delete a user by its UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "删除用户",
+                "parameters": [
+                    {
+                        "description": "Delete user",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUserDeleteParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/app.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/info": {
+            "post": {
+                "description": "Get a user by its UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "获取用户信息",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUserQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "获取用户列表",
+                "parameters": [
+                    {
+                        "description": "获取用户列表参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUserQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserQueryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/menus": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "获取用户菜单",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.MenuListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/myinfo": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "获取自己的信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/orders": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "获取用户订单列表",
+                "parameters": [
+                    {
+                        "description": "获取用户订单列表参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqOrderQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OrderListPageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/session/list": {
+            "post": {
+                "description": "商城客户使用 /api/v1/f/customer/session/list",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会话"
+                ],
+                "summary": "获取我的登录会话",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserSessionListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/session/revoke": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会话"
+                ],
+                "summary": "撤销会话",
+                "parameters": [
+                    {
+                        "description": "会话uuid",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/session/revoke_all": {
+            "post": {
+                "description": "包括当前会话，撤销后需要重新登录",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "会话"
+                ],
+                "summary": "撤销所有会话",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/team/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "获取用户团队列表",
+                "parameters": [
+                    {
+                        "description": "获取用户团队列表参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUserTeamQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TeamListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/team/switch": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "切换团队",
+                "parameters": [
+                    {
+                        "description": "切换团队参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqSwitchTeamParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/teams": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "获取我的团队列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TeamListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/update": {
+            "post": {
+                "description": "Update a user with the input payload",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户"
+                ],
+                "summary": "更新用户",
+                "parameters": [
+                    {
+                        "description": "Update user",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user_address/create": {
+            "post": {
+                "description": "创建用户地址",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户地址"
+                ],
+                "summary": "创建用户地址",
+                "parameters": [
+                    {
+                        "description": "用户地址参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserAddress"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserAddress"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user_address/delete": {
+            "post": {
+                "description": "删除用户地址",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户地址"
+                ],
+                "summary": "删除用户地址",
+                "parameters": [
+                    {
+                        "description": "用户地址 UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user_address/info": {
+            "post": {
+                "description": "获取用户地址信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户地址"
+                ],
+                "summary": "获取用户地址信息",
+                "parameters": [
+                    {
+                        "description": "用户地址 UUID",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserAddress"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user_address/list": {
+            "post": {
+                "description": "获取用户地址列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户地址"
+                ],
+                "summary": "获取用户地址列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PagedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user_address/update": {
+            "post": {
+                "description": "更新用户地址",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户地址"
+                ],
+                "summary": "更新用户地址",
+                "parameters": [
+                    {
+                        "description": "用户地址参数",
+                        "name": "param",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UserAddress"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserAddress"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/verification_code/check": {
+            "post": {
+                "description": "检查验证码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "验证码"
+                ],
+                "summary": "检查验证码",
+                "parameters": [
+                    {
+                        "description": "验证码信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetched user data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/verification_code/create": {
+            "post": {
+                "description": "创建验证码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "验证码"
+                ],
+                "summary": "创建验证码",
+                "parameters": [
+                    {
+                        "description": "验证码信息",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqVerificationCodeParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully fetched user data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook/create": {
+            "post": {
+                "description": "订阅商城事件，事件发生时使用App的SecKey签名后推送到指定地址",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "创建webhook",
+                "parameters": [
+                    {
+                        "description": "webhook参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqWebhookCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "删除webhook",
+                "parameters": [
+                    {
+                        "description": "webhook uuid",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook/delivery/info": {
+            "post": {
+                "description": "包含每次投递的响应状态码、响应内容和错误信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "获取webhook投递详情",
+                "parameters": [
+                    {
+                        "description": "投递uuid",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookDeliveryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook/delivery/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "获取webhook投递记录",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqWebhookDeliveryQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PagedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook/delivery/redeliver": {
+            "post": {
+                "description": "立即重新推送，重置重试次数，失败后按重试策略继续重试",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "重新投递webhook",
+                "parameters": [
+                    {
+                        "description": "投递uuid",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqUuidParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookDeliveryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook/events": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "获取webhook事件",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook/list": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "获取webhook列表",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqWebhookQueryParam"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PagedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook/update": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "更新webhook",
+                "parameters": [
+                    {
+                        "description": "webhook参数",
+                        "name": "params",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReqWebhookUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StringDataResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/ws/inventory": {
+            "get": {
+                "tags": [
+                    "库存"
+                ],
+                "summary": "库存预警推送",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        }
+    },
+    "definitions": {
+        "app.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "data": {},
+                "message": {
+                    "type": "string"
+                },
+                "trace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.CasbinRule": {
+            "type": "object",
+            "properties": {
+                "generated": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ptype": {
+                    "type": "string"
+                },
+                "v0": {
+                    "type": "string"
+                },
+                "v1": {
+                    "type": "string"
+                },
+                "v2": {
+                    "type": "string"
+                },
+                "v3": {
+                    "type": "string"
+                },
+                "v4": {
+                    "type": "string"
+                },
+                "v5": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.API": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了API创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID 是API的主键",
+                    "type": "integer"
+                },
+                "method": {
+                    "description": "Method 是API的HTTP方法",
+                    "type": "string"
+                },
+                "module": {
+                    "description": "Module 是API所属的模块",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name 是API的名称",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path 是API的路径",
+                    "type": "string"
+                },
+                "permission_level": {
+                    "description": "权限等级",
+                    "type": "integer"
+                },
+                "server_uuid": {
+                    "description": "ServerUUID 是API所属的服务器的UUID",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status 0:未启用 1:启用 2:删除",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了API信息最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "UUID 是API的唯一标识符",
+                    "type": "string"
+                }
+            }
+        },
+        "model.APIDiffResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ResAPIDiff"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.APIListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.API"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.APISyncResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ResAPISync"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.AlipayOrderDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.TradePay"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.App": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "description": "ApiKey 是调用方的API Key",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了调用方创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID 是调用方的主键",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name 是调用方的名称",
+                    "type": "string"
+                },
+                "rate_burst": {
+                    "description": "RateBurst 突发请求数，0使用默认配置",
+                    "type": "integer"
+                },
+                "rate_limit": {
+                    "description": "RateLimit 每秒请求数，0使用默认配置",
+                    "type": "number"
+                },
+                "sec_key": {
+                    "description": "SecKey 是调用方的Sec Key",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status 0:未启用 1:启用 2:删除",
+                    "type": "integer"
+                },
+                "team_uuid": {
+                    "description": "TeamUuid 是调用方所属的团队，开放接口使用该团队的数据",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了调用方信息最后更新的时间",
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "description": "UserUUID 是用户的UUID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "UUID 是调用方的唯一标识符",
+                    "type": "string"
+                }
+            }
+        },
+        "model.AppInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.App"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.AppPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.App"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AppQueryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.AppPageResponse"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.BaseResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CaptchaRes": {
+            "type": "object",
+            "properties": {
+                "captcha_id": {
+                    "description": "验证码ID，登录时与验证码一起提交",
+                    "type": "string"
+                },
+                "expire": {
+                    "description": "有效期（秒）",
+                    "type": "integer"
+                },
+                "image": {
+                    "description": "验证码图片，data:image/png;base64格式",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CaptchaResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.CaptchaRes"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Cart": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product_item_uuid": {
+                    "description": "产品ID",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "数量",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CasbinExplainResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ResCasbinExplain"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CasbinRuleListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/auth.CasbinRule"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Configuration": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "配置分类",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "配置名称",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "配置值",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ConfigurationInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Configuration"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ConfigurationMapResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ConfigurationPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Configuration"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Currency": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "货币代码",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "货币名称",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 1:启用 2:禁用",
+                    "type": "integer"
+                },
+                "symbol": {
+                    "description": "货币符号",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "货币uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CurrencyInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Currency"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CurrencyListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Currency"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CurrencyPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Currency"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Customer": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "description": "头像",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "注销时间",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "邮箱，用于登录",
+                    "type": "string"
+                },
+                "email_verified_at": {
+                    "description": "邮箱验证时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_login_at": {
+                    "description": "最近登录时间",
+                    "type": "string"
+                },
+                "last_login_ip": {
+                    "description": "最近登录IP",
+                    "type": "string"
+                },
+                "nickname": {
+                    "description": "昵称",
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "手机号",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 0:禁用 1:启用 2:已注销",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerDetail": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "description": "收货地址",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserAddress"
+                    }
+                },
+                "avatar": {
+                    "description": "头像",
+                    "type": "string"
+                },
+                "average_order_value": {
+                    "description": "平均订单金额",
+                    "type": "number"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "default_address": {
+                    "$ref": "#/definitions/model.UserAddress"
+                },
+                "deleted_at": {
+                    "description": "注销时间",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "邮箱，用于登录",
+                    "type": "string"
+                },
+                "email_verified_at": {
+                    "description": "邮箱验证时间",
+                    "type": "string"
+                },
+                "first_order_at": {
+                    "description": "首次下单时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_login_at": {
+                    "description": "最近登录时间",
+                    "type": "string"
+                },
+                "last_login_ip": {
+                    "description": "最近登录IP",
+                    "type": "string"
+                },
+                "last_order_at": {
+                    "description": "最近下单时间",
+                    "type": "string"
+                },
+                "nickname": {
+                    "description": "昵称",
+                    "type": "string"
+                },
+                "order_count": {
+                    "description": "已支付订单数",
+                    "type": "integer"
+                },
+                "orders": {
+                    "description": "最近订单",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderRes"
+                    }
+                },
+                "phone": {
+                    "description": "手机号",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 0:禁用 1:启用 2:已注销",
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "total_spent": {
+                    "description": "消费金额，已支付金额减去退款金额",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.CustomerDetail"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerFilter": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "description": "默认地址国家",
+                    "type": "string"
+                },
+                "created_after": {
+                    "description": "注册时间晚于",
+                    "type": "string"
+                },
+                "created_before": {
+                    "description": "注册时间早于",
+                    "type": "string"
+                },
+                "days": {
+                    "description": "只统计最近天数内的订单和付款，为0时统计全部",
+                    "type": "integer"
+                },
+                "group_uuid": {
+                    "description": "客户分组uuid",
+                    "type": "string"
+                },
+                "keyword": {
+                    "description": "邮箱、昵称或手机号",
+                    "type": "string"
+                },
+                "last_order_after": {
+                    "description": "最近下单时间晚于",
+                    "type": "string"
+                },
+                "last_order_before": {
+                    "description": "最近下单时间早于",
+                    "type": "string"
+                },
+                "max_orders": {
+                    "description": "最多订单数",
+                    "type": "integer"
+                },
+                "max_spent": {
+                    "description": "最多消费金额",
+                    "type": "number"
+                },
+                "min_orders": {
+                    "description": "最少订单数",
+                    "type": "integer"
+                },
+                "min_spent": {
+                    "description": "最少消费金额",
+                    "type": "number"
+                },
+                "status": {
+                    "description": "客户状态 0:禁用 1:启用，为空时不包含已注销的客户",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "包含任一标签",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.CustomerGroup": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "分组描述",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "分组名称",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerGroupInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.CustomerGroup"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerGroupQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerGroup"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CustomerInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Customer"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerSegment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "分群描述",
+                    "type": "string"
+                },
+                "filter": {
+                    "description": "筛选条件，CustomerFilter的json",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "分群名称",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerSegmentInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.CustomerSegment"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerTagCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "客户数",
+                    "type": "integer"
+                },
+                "tag": {
+                    "description": "标签",
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomerTagListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CustomerTagCount"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.DigitalDownloadRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "downloads": {
+                    "description": "已下载次数",
+                    "type": "integer"
+                },
+                "expired_at": {
+                    "description": "过期时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_downloads": {
+                    "description": "最大下载次数",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "文件名称",
+                    "type": "string"
+                },
+                "order_item_id": {
+                    "description": "订单商品ID",
+                    "type": "integer"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "resource_uuid": {
+                    "description": "资源uuid",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "签名下载链接",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DigitalOrderInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.DigitalOrderRes"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.DigitalOrderRes": {
+            "type": "object",
+            "properties": {
+                "downloads": {
+                    "description": "下载列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DigitalDownloadRes"
+                    }
+                },
+                "license_keys": {
+                    "description": "许可证列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LicenseKey"
+                    }
+                },
+                "order_no": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.IntDataResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.InventoryLog": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "description": "操作人",
+                    "type": "string"
+                },
+                "after": {
+                    "description": "变动后库存",
+                    "type": "integer"
+                },
+                "before": {
+                    "description": "变动前库存",
+                    "type": "integer"
+                },
+                "change": {
+                    "description": "变动数量，正数入库，负数出库",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "description": "产品uuid",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "变动来源 order、manual、import、refund",
+                    "type": "string"
+                },
+                "source_no": {
+                    "description": "来源单号，例如订单号",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.InventoryLogInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.InventoryLog"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.InventoryLogQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.InventoryLog"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Invoice": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "currency_code": {
+                    "description": "货币代码",
+                    "type": "string"
+                },
+                "currency_symbol": {
+                    "description": "货币符号",
+                    "type": "string"
+                },
+                "discount_amount": {
+                    "description": "优惠金额",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "invoice_no": {
+                    "description": "发票号",
+                    "type": "string"
+                },
+                "issued_at": {
+                    "description": "开票时间",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "seq": {
+                    "description": "年内序号",
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "description": "不含税金额",
+                    "type": "number"
+                },
+                "tax_amount": {
+                    "description": "税额",
+                    "type": "number"
+                },
+                "tax_name": {
+                    "description": "税种名称",
+                    "type": "string"
+                },
+                "tax_rate": {
+                    "description": "税率（百分比）",
+                    "type": "number"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "total_amount": {
+                    "description": "含税总金额，与订单金额一致",
+                    "type": "number"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "year": {
+                    "description": "年份",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.InvoiceQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Invoice"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.LicenseKey": {
+            "type": "object",
+            "properties": {
+                "assigned_at": {
+                    "description": "分配时间",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "许可证",
+                    "type": "string"
+                },
+                "order_item_id": {
+                    "description": "订单商品ID",
+                    "type": "integer"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 available、assigned、revoked",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LicenseKeyQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LicenseKey"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.LowStockItem": {
+            "type": "object",
+            "properties": {
+                "product_item_uuid": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "type": "string"
+                },
+                "stock": {
+                    "description": "当前库存",
+                    "type": "integer"
+                },
+                "stock_warning": {
+                    "description": "警戒库存",
+                    "type": "integer"
+                },
+                "variants": {
+                    "description": "产品变体",
+                    "type": "string"
+                }
+            }
+        },
+        "model.LowStockQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LowStockItem"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Menu": {
+            "type": "object",
+            "properties": {
+                "icon": {
+                    "description": "Icon 是菜单的图标",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID 是菜单的主键",
+                    "type": "integer"
+                },
+                "is_show": {
+                    "description": "IsShow 是菜单是否显示",
+                    "type": "boolean"
+                },
+                "link": {
+                    "description": "Link 是菜单的链接",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name 是菜单的名称",
+                    "type": "string"
+                },
+                "order": {
+                    "description": "Order 是菜单的排序",
+                    "type": "integer"
+                },
+                "parent_uuid": {
+                    "description": "ParentUUID 是父菜单的UUID",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type 是菜单的类型  1:目录 2、菜单、 3:按钮  4:链接",
+                    "type": "integer"
+                },
+                "uuid": {
+                    "description": "UUID 是菜单的唯一标识符",
+                    "type": "string"
+                }
+            }
+        },
+        "model.MenuListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Menu"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.MenuPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Menu"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.MenuQueryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.MenuPageResponse"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Order": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "description": "关闭时间",
+                    "type": "string"
+                },
+                "completed_at": {
+                    "description": "完成时间",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "description": "发货时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_virtual": {
+                    "description": "虚拟订单，只包含虚拟产品，无需发货",
+                    "type": "boolean"
+                },
+                "merged_into": {
+                    "description": "合并到的订单编号，合并后本订单关闭",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号，按配置规则生成，例如 SG-20261017-000123",
+                    "type": "string"
+                },
+                "paid_at": {
+                    "description": "支付时间",
+                    "type": "string"
+                },
+                "receiver_address": {
+                    "description": "收货人地址",
+                    "type": "string"
+                },
+                "receiver_city": {
+                    "description": "收货人城市",
+                    "type": "string"
+                },
+                "receiver_country": {
+                    "description": "收货人国家",
+                    "type": "string"
+                },
+                "receiver_email": {
+                    "description": "收货人邮箱",
+                    "type": "string"
+                },
+                "receiver_name": {
+                    "description": "收货人姓名",
+                    "type": "string"
+                },
+                "receiver_phone": {
+                    "description": "收货人电话",
+                    "type": "string"
+                },
+                "receiver_province": {
+                    "description": "收货人省份",
+                    "type": "string"
+                },
+                "receiver_remark": {
+                    "description": "收货人备注",
+                    "type": "string"
+                },
+                "receiver_zip": {
+                    "description": "收货人邮编",
+                    "type": "string"
+                },
+                "split_from": {
+                    "description": "拆分来源订单编号",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "订单状态 1:待支付 2:已支付 3:已发货 4:已完成 5:已关闭",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "total_amount": {
+                    "description": "订单总金额",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "内部uuid，与对外展示的订单编号分开",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderAudit": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "修改类型",
+                    "type": "string"
+                },
+                "after": {
+                    "description": "修改后，json",
+                    "type": "string"
+                },
+                "before": {
+                    "description": "修改前，json",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "operator": {
+                    "description": "操作人",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderAuditQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderAudit"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.OrderInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Order"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderItemListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderItemRes"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderItemRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "discount": {
+                    "description": "折扣",
+                    "type": "number"
+                },
+                "discount_amount": {
+                    "description": "折扣金额",
+                    "type": "number"
+                },
+                "discount_price": {
+                    "description": "折扣价",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "description": "订单ID",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "商品单价",
+                    "type": "number"
+                },
+                "product_item": {
+                    "description": "商品信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ProductItemRes"
+                        }
+                    ]
+                },
+                "product_item_id": {
+                    "description": "商品ID",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "商品数量",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "total_amount": {
+                    "description": "商品总价",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderListPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderRes"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.OrderNote": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "备注内容",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_public": {
+                    "description": "买家可见，否则为内部备注",
+                    "type": "boolean"
+                },
+                "operator": {
+                    "description": "添加人",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderNoteInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.OrderNote"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderNoteListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderNote"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderReceiver": {
+            "type": "object",
+            "properties": {
+                "receiver_address": {
+                    "description": "收货人地址",
+                    "type": "string"
+                },
+                "receiver_city": {
+                    "description": "收货人城市",
+                    "type": "string"
+                },
+                "receiver_country": {
+                    "description": "收货人国家",
+                    "type": "string"
+                },
+                "receiver_email": {
+                    "description": "收货人邮箱",
+                    "type": "string"
+                },
+                "receiver_name": {
+                    "description": "收货人姓名",
+                    "type": "string"
+                },
+                "receiver_phone": {
+                    "description": "收货人电话",
+                    "type": "string"
+                },
+                "receiver_province": {
+                    "description": "收货人省份",
+                    "type": "string"
+                },
+                "receiver_remark": {
+                    "description": "收货人备注",
+                    "type": "string"
+                },
+                "receiver_zip": {
+                    "description": "收货人邮编",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderRes": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "description": "关闭时间",
+                    "type": "string"
+                },
+                "completed_at": {
+                    "description": "完成时间",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "description": "发货时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_virtual": {
+                    "description": "虚拟订单，只包含虚拟产品，无需发货",
+                    "type": "boolean"
+                },
+                "items": {
+                    "description": "订单商品",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderItemRes"
+                    }
+                },
+                "merged_into": {
+                    "description": "合并到的订单编号，合并后本订单关闭",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号，按配置规则生成，例如 SG-20261017-000123",
+                    "type": "string"
+                },
+                "paid_at": {
+                    "description": "支付时间",
+                    "type": "string"
+                },
+                "receiver_address": {
+                    "description": "收货人地址",
+                    "type": "string"
+                },
+                "receiver_city": {
+                    "description": "收货人城市",
+                    "type": "string"
+                },
+                "receiver_country": {
+                    "description": "收货人国家",
+                    "type": "string"
+                },
+                "receiver_email": {
+                    "description": "收货人邮箱",
+                    "type": "string"
+                },
+                "receiver_name": {
+                    "description": "收货人姓名",
+                    "type": "string"
+                },
+                "receiver_phone": {
+                    "description": "收货人电话",
+                    "type": "string"
+                },
+                "receiver_province": {
+                    "description": "收货人省份",
+                    "type": "string"
+                },
+                "receiver_remark": {
+                    "description": "收货人备注",
+                    "type": "string"
+                },
+                "receiver_zip": {
+                    "description": "收货人邮编",
+                    "type": "string"
+                },
+                "split_from": {
+                    "description": "拆分来源订单编号",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "订单状态 1:待支付 2:已支付 3:已发货 4:已完成 5:已关闭",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "total_amount": {
+                    "description": "订单总金额",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "内部uuid，与对外展示的订单编号分开",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderResInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.OrderRes"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderTrackingInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.OrderTrackingRes"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderTrackingRes": {
+            "type": "object",
+            "properties": {
+                "delivered_at": {
+                    "description": "发货时间",
+                    "type": "string"
+                },
+                "order_no": {
+                    "type": "string"
+                },
+                "shipments": {
+                    "description": "包裹列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ShipmentRes"
+                    }
+                },
+                "status": {
+                    "description": "订单状态",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Page": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 页面创建时间",
+                    "type": "string"
+                },
+                "data": {
+                    "description": "Data 存储页面的详细内容，较大字符串",
+                    "type": "string"
+                },
+                "ext": {
+                    "description": "Ext 存储页面的扩展内容，较大字符串",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID 是页面的主键",
+                    "type": "integer"
+                },
+                "slug": {
+                    "description": "Slug 是页面的路径 (URL)",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status 是页面的状态 (如 \"draft\", \"published\")",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "TenantUuid 是页面所属的租户，即团队uuid",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title 是页面的标题",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 页面最后更新时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "UUID 是页面的唯一标识符",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PageInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Page"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PageQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Page"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PagedResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {},
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaymentMethod": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "支付方式code",
+                    "type": "string"
+                },
+                "config": {
+                    "description": "Config 支付方式配置",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "支付方式描述",
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "支付方式图标",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "支付方式名称",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "支付方式状态",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "支付方式url",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PaypalOrderDetail": {
+            "type": "object",
+            "properties": {
+                "create_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "intent": {
+                    "type": "string"
+                },
+                "processing_instruction": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "CREATED、SAVED、APPROVED、VOIDED、COMPLETED、PAYER_ACTION_REQUIRED",
+                    "type": "string"
+                },
+                "update_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PaypalOrderDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.PaypalOrderDetail"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Permission": {
+            "type": "object",
+            "properties": {
+                "bit": {
+                    "description": "Bit 是权限的位",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了权限创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID 是权限的主键",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name 是权限的名称",
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "description": "ParentUuid 是权限的父级 UUID",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了权限信息最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "UUID 是权限的唯一标识符",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PermissionInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Permission"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PermissionListResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Permission"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PermissionMenu": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "ID 是权限菜单的主键",
+                    "type": "integer"
+                },
+                "menu_uuid": {
+                    "description": "MenuUuid 是菜单的 UUID",
+                    "type": "string"
+                },
+                "permission_uuid": {
+                    "description": "PermissionUuid 是权限的 UUID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "UUID 是权限菜单的唯一标识符",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PermissionMenuCreateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ReqPermissionMenuCreate"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PermissionMenuInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.PermissionMenu"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PermissionMenuListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PermissionMenu"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PermissionMenuPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PermissionMenu"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PriceList": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "customer_group_uuid": {
+                    "description": "客户分组uuid，为空时对所有客户生效",
+                    "type": "string"
+                },
+                "end_at": {
+                    "description": "结束时间，为空时长期有效",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "价格表名称",
+                    "type": "string"
+                },
+                "start_at": {
+                    "description": "开始时间，为空时立即生效",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 1:启用 2:禁用",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PriceListInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.PriceListRes"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PriceListItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "description": "价格",
+                    "type": "number"
+                },
+                "price_list_uuid": {
+                    "description": "价格表uuid",
+                    "type": "string"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.PriceListQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PriceList"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PriceListRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "customer_group_uuid": {
+                    "description": "客户分组uuid，为空时对所有客户生效",
+                    "type": "string"
+                },
+                "end_at": {
+                    "description": "结束时间，为空时长期有效",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "商品价格",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PriceListItem"
+                    }
+                },
+                "name": {
+                    "description": "价格表名称",
+                    "type": "string"
+                },
+                "start_at": {
+                    "description": "开始时间，为空时立即生效",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 1:启用 2:禁用",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductCategory": {
+            "type": "object",
+            "properties": {
+                "alias_name": {
+                    "description": "别名",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "分类描述",
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "分类图标",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "分类名称",
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "description": "父级分类",
+                    "type": "string"
+                },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态 1:启用 2:禁用",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductCategoryInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ProductCategory"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductCategoryListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductCategory"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductDigitalFile": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "expire_days": {
+                    "description": "下载有效天数",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_downloads": {
+                    "description": "最大下载次数",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "文件名称",
+                    "type": "string"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid，为空时对产品所有SKU生效",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "description": "产品uuid",
+                    "type": "string"
+                },
+                "resource_uuid": {
+                    "description": "资源uuid",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductDigitalFileInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ProductDigitalFile"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductDigitalFileListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductDigitalFile"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductGroupInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ProductGroupRes"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductGroupItemRes": {
+            "type": "object",
+            "properties": {
+                "component_item": {
+                    "description": "组件SKU信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ProductItemRes"
+                        }
+                    ]
+                },
+                "component_item_uuid": {
+                    "description": "组件SKU uuid",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product_item_uuid": {
+                    "description": "组合产品的SKU uuid",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "description": "组合产品uuid",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "组件数量",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductGroupRes": {
+            "type": "object",
+            "properties": {
+                "group_price_type": {
+                    "description": "定价方式 sum、fixed",
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductGroupItemRes"
+                    }
+                },
+                "price": {
+                    "description": "组合价格",
+                    "type": "number"
+                },
+                "product_item_uuid": {
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "type": "string"
+                },
+                "stock": {
+                    "description": "可售数量，由组件库存计算",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProductItemInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ProductItemRes"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductItemListPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductItemRes"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProductItemRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "currency_code": {
+                    "description": "货币代码",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "产品描述",
+                    "type": "string"
+                },
+                "discount": {
+                    "description": "产品折扣",
+                    "type": "number"
+                },
+                "discount_end_at": {
+                    "description": "当前折扣价的结束时间，由价格表计算，不存储",
+                    "type": "string"
+                },
+                "discount_price": {
+                    "description": "产品折扣价",
+                    "type": "number"
+                },
+                "height": {
+                    "description": "高度",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_list": {
+                    "description": "产品图片地址",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "images": {
+                    "type": "string"
+                },
+                "length": {
+                    "description": "长度",
+                    "type": "number"
+                },
+                "name": {
+                    "description": "产品名称",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "产品价格",
+                    "type": "number"
+                },
+                "product_info": {
+                    "description": "产品信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ProductRes"
+                        }
+                    ]
+                },
+                "product_uuid": {
+                    "description": "产品uuid",
+                    "type": "string"
+                },
+                "product_variants_option_uuid": {
+                    "description": "变体optionuuid",
+                    "type": "string"
+                },
+                "product_variants_option_value_uuid": {
+                    "description": "变体option值uuid",
+                    "type": "string"
+                },
+                "product_variants_uuid": {
+                    "description": "产品变体uuid",
+                    "type": "string"
+                },
+                "stock": {
+                    "description": "产品库存",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "unit": {
+                    "description": "单位 例如: 个、件、套、箱",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "variants": {
+                    "description": "产品变体",
+                    "type": "string"
+                },
+                "variants_info": {
+                    "description": "产品变体信息",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductVariantsItem"
+                    }
+                },
+                "videos": {
+                    "description": "产品视频",
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "重量",
+                    "type": "number"
+                },
+                "width": {
+                    "description": "宽度",
+                    "type": "number"
+                }
+            }
+        },
+        "model.ProductListPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductRes"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProductRes": {
+            "type": "object",
+            "properties": {
+                "alias_name": {
+                    "description": "产品别名",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "currency_code": {
+                    "description": "货币代码",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "产品描述",
+                    "type": "string"
+                },
+                "group_price_type": {
+                    "description": "组合产品定价方式 sum、fixed",
+                    "type": "string"
+                },
+                "height": {
+                    "description": "高度",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_list": {
+                    "description": "产品图片地址",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "images": {
+                    "type": "string"
+                },
+                "length": {
+                    "description": "长度",
+                    "type": "number"
+                },
+                "name": {
+                    "description": "产品名称",
+                    "type": "string"
+                },
+                "product_category": {
+                    "description": "产品分类",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ProductCategory"
+                        }
+                    ]
+                },
+                "product_category_uuid": {
+                    "description": "产品分类",
+                    "type": "string"
+                },
+                "product_type": {
+                    "description": "单个产品、变体产品、组合产品",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "产品状态",
+                    "type": "string"
+                },
+                "stock_warning": {
+                    "description": "产品警戒库存",
+                    "type": "integer"
+                },
+                "stock_warning_sell": {
+                    "description": "低于警戒库存是否可售",
+                    "type": "boolean"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "产品类型",
+                    "type": "string"
+                },
+                "unit": {
+                    "description": "单位 例如: 个、件、套、箱",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "videos": {
+                    "description": "产品视频",
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "重量",
+                    "type": "number"
+                },
+                "width": {
+                    "description": "宽度",
+                    "type": "number"
+                }
+            }
+        },
+        "model.ProductShow": {
+            "type": "object",
+            "properties": {
+                "alias_name": {
+                    "description": "产品别名",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "产品描述",
+                    "type": "string"
+                },
+                "discount": {
+                    "description": "产品折扣",
+                    "type": "number"
+                },
+                "discount_price": {
+                    "description": "产品折扣价",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "description": "产品图片",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "产品名称",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "产品价格",
+                    "type": "number"
+                },
+                "product_category_uuid": {
+                    "description": "产品分类",
+                    "type": "string"
+                },
+                "product_item_uuid": {
+                    "type": "string"
+                },
+                "product_type": {
+                    "description": "产品类型",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "type": "string"
+                },
+                "stock": {
+                    "description": "产品库存",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "产品类型",
+                    "type": "string"
+                },
+                "videos": {
+                    "description": "产品视频",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ProductShowItem": {
+            "type": "object",
+            "properties": {
+                "alias_name": {
+                    "description": "产品别名",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "产品描述",
+                    "type": "string"
+                },
+                "discount": {
+                    "description": "产品折扣",
+                    "type": "number"
+                },
+                "discount_price": {
+                    "description": "产品折扣价",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "description": "产品图片",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "产品名称",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "产品价格",
+                    "type": "number"
+                },
+                "product_category_uuid": {
+                    "description": "产品分类",
+                    "type": "string"
+                },
+                "product_item_uuid": {
+                    "type": "string"
+                },
+                "product_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductItemRes"
+                    }
+                },
+                "product_type": {
+                    "description": "产品类型",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "type": "string"
+                },
+                "product_variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductVariants"
+                    }
+                },
+                "product_variants_option": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductVariantsOption"
+                    }
+                },
+                "stock": {
+                    "description": "产品库存",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "产品类型",
+                    "type": "string"
+                },
+                "videos": {
+                    "description": "产品视频",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ProductShowItemInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ProductShowItem"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductShowListPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductShow"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProductVariants": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "产品变体描述",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "产品变体名称",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductVariantsItem": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "产品变体名称",
+                    "type": "string"
+                },
+                "option": {
+                    "description": "产品变体option",
+                    "type": "string"
+                },
+                "option_uuid": {
+                    "description": "产品变体option uuid",
+                    "type": "string"
+                },
+                "variant_uuid": {
+                    "description": "产品变体uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductVariantsListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductVariantsRes"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductVariantsOption": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "产品变体Option描述",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "产品变体Option名称",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "type": "string"
+                },
+                "product_variants_uuid": {
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "unit": {
+                    "description": "单位 例如: 个、件、套、箱",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductVariantsRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "产品变体描述",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "产品变体名称",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "type": "string"
+                },
+                "product_variants_options": {
+                    "description": "产品变体Option列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProductVariantsOption"
+                    }
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Refund": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "退款金额",
+                    "type": "number"
+                },
+                "channel_refund_no": {
+                    "description": "付款渠道退款交易号",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "description": "退款方式，与付款方式一致",
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "payment_uuid": {
+                    "description": "付款uuid",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "退款原因",
+                    "type": "string"
+                },
+                "return_uuid": {
+                    "description": "退货申请uuid",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 succeeded、failed、manual",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.RefundInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Refund"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqAPIQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "module": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqApiPermissionParam": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqAppPermissionSet": {
+            "type": "object",
+            "required": [
+                "app_uuid"
+            ],
+            "properties": {
+                "api_uuids": {
+                    "description": "授权的接口uuid列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "app_uuid": {
+                    "description": "app uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqAppQueryParam": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "description": "api_key",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqAppUpdate": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "name": {
+                    "description": "名称",
+                    "type": "string"
+                },
+                "rate_burst": {
+                    "description": "突发请求数，0使用默认配置",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "rate_limit": {
+                    "description": "每秒请求数，0使用默认配置",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "integer"
+                },
+                "uuid": {
+                    "description": "app uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCartItemCountParam": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "description": "数量",
+                    "type": "integer"
+                },
+                "uuid": {
+                    "description": "购物车UUID",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCartQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCasbinExplain": {
+            "type": "object",
+            "required": [
+                "method",
+                "path"
+            ],
+            "properties": {
+                "method": {
+                    "description": "请求方法",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "接口路径",
+                    "type": "string"
+                },
+                "team_uuid": {
+                    "description": "团队uuid，只能为当前团队",
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "description": "用户uuid，默认为当前用户，需要是当前团队的成员",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCasbinPolicy": {
+            "type": "object",
+            "required": [
+                "ptype",
+                "rule"
+            ],
+            "properties": {
+                "ptype": {
+                    "description": "策略类型 p:权限策略 g:角色分配",
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "p: 主体(当前团队的角色或成员uuid), 团队uuid(固定为当前团队), 路径, 方法(*表示所有方法)\ng: 用户uuid(当前团队成员), 角色uuid(当前团队的角色), 团队uuid(固定为当前团队)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ReqCasbinPolicyQuery": {
+            "type": "object",
+            "properties": {
+                "ptype": {
+                    "description": "策略类型 p、g，为空时查询全部",
+                    "type": "string"
+                },
+                "sub": {
+                    "description": "主体",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqConfigCategoryParam": {
+            "type": "object",
+            "required": [
+                "category"
+            ],
+            "properties": {
+                "category": {
+                    "description": "配置分类",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqConfigCreate": {
+            "type": "object",
+            "required": [
+                "category",
+                "name",
+                "value"
+            ],
+            "properties": {
+                "category": {
+                    "description": "配置分类",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "配置名称",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "配置值",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqConfigQueryParam": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "配置分类",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "配置名称",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCurrencyCreate": {
+            "type": "object",
+            "required": [
+                "code",
+                "name",
+                "symbol"
+            ],
+            "properties": {
+                "code": {
+                    "description": "货币代码",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "货币名称",
+                    "type": "string"
+                },
+                "symbol": {
+                    "description": "货币符号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCurrencyQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "货币名称",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerDelete": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "description": "当前密码",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerEmailChange": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "新邮箱",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "当前密码",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerEmailConfirm": {
+            "type": "object",
+            "required": [
+                "code",
+                "email"
+            ],
+            "properties": {
+                "code": {
+                    "description": "新邮箱收到的验证码",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "新邮箱",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerEmailParam": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "邮箱",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerGroupCreate": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "分组描述",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "分组名称",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerGroupQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "分组名称",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerGroupUpdate": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "description": {
+                    "description": "分组描述",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "分组名称",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "分组uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerGroupUserParam": {
+            "type": "object",
+            "required": [
+                "group_uuid",
+                "user_ids"
+            ],
+            "properties": {
+                "group_uuid": {
+                    "description": "分组uuid",
+                    "type": "string"
+                },
+                "user_ids": {
+                    "description": "用户ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ReqCustomerLogin": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "description": "图形验证码",
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "description": "图形验证码ID，失败次数较多时需要",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "邮箱",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "密码",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerPasswordReset": {
+            "type": "object",
+            "required": [
+                "code",
+                "email",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "description": "邮箱验证码",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "邮箱",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "新密码",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerPasswordUpdate": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "description": "新密码",
+                    "type": "string"
+                },
+                "old_password": {
+                    "description": "原密码",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerProfileUpdate": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "description": "头像",
+                    "type": "string"
+                },
+                "nickname": {
+                    "description": "昵称",
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "手机号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerQueryParam": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "description": "默认地址国家",
+                    "type": "string"
+                },
+                "created_after": {
+                    "description": "注册时间晚于",
+                    "type": "string"
+                },
+                "created_before": {
+                    "description": "注册时间早于",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "days": {
+                    "description": "只统计最近天数内的订单和付款，为0时统计全部",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "group_uuid": {
+                    "description": "客户分组uuid",
+                    "type": "string"
+                },
+                "keyword": {
+                    "description": "邮箱、昵称或手机号",
+                    "type": "string"
+                },
+                "last_order_after": {
+                    "description": "最近下单时间晚于",
+                    "type": "string"
+                },
+                "last_order_before": {
+                    "description": "最近下单时间早于",
+                    "type": "string"
+                },
+                "max_orders": {
+                    "description": "最多订单数",
+                    "type": "integer"
+                },
+                "max_spent": {
+                    "description": "最多消费金额",
+                    "type": "number"
+                },
+                "min_orders": {
+                    "description": "最少订单数",
+                    "type": "integer"
+                },
+                "min_spent": {
+                    "description": "最少消费金额",
+                    "type": "number"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "segment_uuid": {
+                    "description": "客户分群uuid，不为空时使用分群的筛选条件",
+                    "type": "string"
+                },
+                "sort_by": {
+                    "description": "排序字段 order_count、total_spent、last_order_at、created_at，默认created_at",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "排序方式 asc、desc，默认desc",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "客户状态 0:禁用 1:启用，为空时不包含已注销的客户",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "包含任一标签",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ReqCustomerRegister": {
+            "type": "object",
+            "required": [
+                "code",
+                "email",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "description": "邮箱验证码",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "邮箱",
+                    "type": "string"
+                },
+                "nickname": {
+                    "description": "昵称",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "密码",
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "手机号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerSegmentCreate": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "分群描述",
+                    "type": "string"
+                },
+                "filter": {
+                    "description": "筛选条件",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.CustomerFilter"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "分群名称",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerSegmentQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "分群名称",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerSegmentUpdate": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "description": {
+                    "description": "分群描述",
+                    "type": "string"
+                },
+                "filter": {
+                    "description": "筛选条件，为空时不修改",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.CustomerFilter"
+                        }
+                    ]
+                },
+                "name": {
+                    "description": "分群名称",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "分群uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqCustomerTagParam": {
+            "type": "object",
+            "required": [
+                "customer_uuids",
+                "tags"
+            ],
+            "properties": {
+                "customer_uuids": {
+                    "description": "客户uuid列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tags": {
+                    "description": "标签列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ReqDigitalFileQueryParam": {
+            "type": "object",
+            "required": [
+                "product_uuid"
+            ],
+            "properties": {
+                "product_uuid": {
+                    "description": "产品uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqDigitalOrderParam": {
+            "type": "object",
+            "required": [
+                "order_no"
+            ],
+            "properties": {
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqIdParam": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqInventoryAdjustParam": {
+            "type": "object",
+            "required": [
+                "change",
+                "product_item_uuid"
+            ],
+            "properties": {
+                "change": {
+                    "description": "变动数量",
+                    "type": "integer"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "变动来源 manual、import，默认manual",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqInventoryLogQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "description": "产品uuid",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "变动来源",
+                    "type": "string"
+                },
+                "source_no": {
+                    "description": "来源单号",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqInvoiceBulkParam": {
+            "type": "object",
+            "required": [
+                "order_nos"
+            ],
+            "properties": {
+                "order_nos": {
+                    "description": "订单编号列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "单据类型 invoice、packing_slip，默认invoice",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqInvoiceQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "invoice_no": {
+                    "description": "发票号",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "year": {
+                    "description": "年份",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqLicenseKeyImport": {
+            "type": "object",
+            "required": [
+                "keys",
+                "product_item_uuid"
+            ],
+            "properties": {
+                "keys": {
+                    "description": "许可证列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqLicenseKeyQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqLoginLockQueryParam": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "description": "为true时包含已解除锁定的记录，默认只返回锁定中的记录",
+                    "type": "boolean"
+                },
+                "audience": {
+                    "description": "登录受众 admin、customer",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "subject": {
+                    "description": "用户名、邮箱或IP",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "锁定类型 account、ip",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqLoginLogQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqLoginTwoFactorSetup": {
+            "type": "object",
+            "required": [
+                "challenge_uuid",
+                "method"
+            ],
+            "properties": {
+                "challenge_uuid": {
+                    "description": "登录挑战uuid",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "验证方式 totp、email",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqLoginTwoFactorVerify": {
+            "type": "object",
+            "required": [
+                "challenge_uuid",
+                "code"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "description": "图形验证码",
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "description": "图形验证码ID，失败次数较多时需要",
+                    "type": "string"
+                },
+                "challenge_uuid": {
+                    "description": "登录挑战uuid",
+                    "type": "string"
+                },
+                "code": {
+                    "description": "验证码或恢复码",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqLowStockQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "产品名称",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqMenuDeleteParam": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "uuid": {
+                    "description": "菜单uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqMenuQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqOpLogQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqOrderAuditQueryParam": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "修改类型",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqOrderCreate": {
+            "type": "object",
+            "properties": {
+                "cart_uuids": {
+                    "description": "购物车ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "items": {
+                    "description": "订单商品列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReqOrderItemCreate"
+                    }
+                },
+                "receiver": {
+                    "description": "收货人信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.OrderReceiver"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqOrderItemCreate": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "product_item_id": {
+                    "description": "商品ID",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "商品数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqOrderItemQuantity": {
+            "type": "object",
+            "required": [
+                "order_item_id"
+            ],
+            "properties": {
+                "order_item_id": {
+                    "description": "订单商品ID",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqOrderItemsUpdate": {
+            "type": "object",
+            "required": [
+                "items",
+                "order_no"
+            ],
+            "properties": {
+                "items": {
+                    "description": "商品数量",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReqOrderItemQuantity"
+                    }
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqOrderMerge": {
+            "type": "object",
+            "required": [
+                "order_nos",
+                "target_order_no"
+            ],
+            "properties": {
+                "order_nos": {
+                    "description": "合并的订单编号",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target_order_no": {
+                    "description": "目标订单编号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqOrderNoteCreate": {
+            "type": "object",
+            "required": [
+                "content",
+                "order_no"
+            ],
+            "properties": {
+                "content": {
+                    "description": "备注内容",
+                    "type": "string"
+                },
+                "is_public": {
+                    "description": "买家可见",
+                    "type": "boolean"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqOrderQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号，用于过滤",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "订单状态，用于过滤",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID，用于过滤",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqOrderReceiverUpdate": {
+            "type": "object",
+            "required": [
+                "order_no"
+            ],
+            "properties": {
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "receiver": {
+                    "description": "收货人信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.OrderReceiver"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.ReqOrderSplit": {
+            "type": "object",
+            "required": [
+                "items",
+                "order_no"
+            ],
+            "properties": {
+                "items": {
+                    "description": "移到新订单的商品数量",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReqOrderItemQuantity"
+                    }
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqOrderTrackingParam": {
+            "type": "object",
+            "required": [
+                "order_no"
+            ],
+            "properties": {
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqPageQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Title 是页面的标题",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqPaymentMethodQueryParam": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "支付方式code，用于过滤",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "支付方式名称，用于过滤",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "支付方式状态，用于过滤",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqPaymentOrderCreateParam": {
+            "type": "object",
+            "required": [
+                "order_id"
+            ],
+            "properties": {
+                "order_id": {
+                    "description": "订单ID",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqPermissionMenuCreate": {
+            "type": "object",
+            "required": [
+                "menu_uuids",
+                "permission_uuid"
+            ],
+            "properties": {
+                "menu_uuids": {
+                    "description": "MenuUuids 是菜单的 UUID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "permission_uuid": {
+                    "description": "PermissionUuid 是权限的 UUID",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqPermissionMenuQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "menu_uuid": {
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "permission_uuid": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqPermissionQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqPermissionUserCreate": {
+            "type": "object",
+            "required": [
+                "permission_uuids",
+                "user_uuid"
+            ],
+            "properties": {
+                "permission_uuids": {
+                    "description": "PermissionUuids 是权限的 UUID 列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_uuid": {
+                    "description": "UserUuid 是用户的 UUID",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqPriceListCreate": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "customer_group_uuid": {
+                    "description": "客户分组uuid",
+                    "type": "string"
+                },
+                "end_at": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "商品价格",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReqPriceListItem"
+                    }
+                },
+                "name": {
+                    "description": "价格表名称",
+                    "type": "string"
+                },
+                "start_at": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqPriceListItem": {
+            "type": "object",
+            "required": [
+                "price",
+                "product_item_uuid"
+            ],
+            "properties": {
+                "price": {
+                    "description": "价格",
+                    "type": "number"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqPriceListQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "customer_group_uuid": {
+                    "description": "客户分组uuid",
+                    "type": "string"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "价格表名称",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqPriceListUpdate": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "customer_group_uuid": {
+                    "description": "客户分组uuid，为空时改为对所有客户生效",
+                    "type": "string"
+                },
+                "end_at": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "商品价格，不为空时整体替换",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReqPriceListItem"
+                    }
+                },
+                "name": {
+                    "description": "价格表名称",
+                    "type": "string"
+                },
+                "start_at": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 1:启用 2:禁用",
+                    "type": "integer"
+                },
+                "uuid": {
+                    "description": "价格表uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqProductCreate": {
+            "type": "object",
+            "required": [
+                "alias_name",
+                "name"
+            ],
+            "properties": {
+                "alias_name": {
+                    "description": "产品别名",
+                    "type": "string"
+                },
+                "currency_code": {
+                    "description": "货币代码",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "产品描述",
+                    "type": "string"
+                },
+                "discount": {
+                    "description": "产品折扣",
+                    "type": "number"
+                },
+                "discount_price": {
+                    "description": "产品折扣价",
+                    "type": "number"
+                },
+                "group_items": {
+                    "description": "组合产品组件",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReqProductGroupItemCreate"
+                    }
+                },
+                "group_price_type": {
+                    "description": "组合产品定价方式 sum、fixed",
+                    "type": "string"
+                },
+                "height": {
+                    "description": "高度",
+                    "type": "number"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "length": {
+                    "description": "长度",
+                    "type": "number"
+                },
+                "name": {
+                    "description": "产品名称",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "产品价格",
+                    "type": "number"
+                },
+                "product_category_uuid": {
+                    "description": "产品分类",
+                    "type": "string"
+                },
+                "product_status": {
+                    "description": "产品上架状态\n上架、下架、售罄",
+                    "type": "string"
+                },
+                "product_type": {
+                    "description": "单个产品、变体产品、组合产品",
+                    "type": "string"
+                },
+                "stock": {
+                    "description": "产品库存",
+                    "type": "integer"
+                },
+                "stock_warning": {
+                    "description": "预警库存",
+                    "type": "integer"
+                },
+                "stock_warning_sell": {
+                    "description": "低于警戒库存是否可售",
+                    "type": "boolean"
+                },
+                "unit": {
+                    "description": "单位 例如: 个、件、套、箱",
+                    "type": "string"
+                },
+                "variants": {
+                    "description": "产品变体",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReqProductVariantsCreate"
+                    }
+                },
+                "variants_vals": {
+                    "description": "产品变体值",
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
+                },
+                "videos": {
+                    "description": "产品视频",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "weight": {
+                    "description": "重量",
+                    "type": "number"
+                },
+                "width": {
+                    "description": "宽度",
+                    "type": "number"
+                }
+            }
+        },
+        "model.ReqProductDeleteParam": {
+            "type": "object",
+            "properties": {
+                "uuids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ReqProductGroupItemCreate": {
+            "type": "object",
+            "required": [
+                "product_item_uuid",
+                "quantity"
+            ],
+            "properties": {
+                "product_item_uuid": {
+                    "description": "组件SKU uuid",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "组件数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqProductGroupUpdate": {
+            "type": "object",
+            "required": [
+                "items",
+                "product_uuid"
+            ],
+            "properties": {
+                "group_price_type": {
+                    "description": "定价方式 sum、fixed",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "组件列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReqProductGroupItemCreate"
+                    }
+                },
+                "price": {
+                    "description": "固定价格",
+                    "type": "number"
+                },
+                "product_uuid": {
+                    "description": "组合产品uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqProductItemUpdate": {
+            "type": "object",
+            "required": [
+                "description",
+                "discount",
+                "discount_price",
+                "images",
+                "name",
+                "price",
+                "uuid",
+                "videos"
+            ],
+            "properties": {
+                "description": {
+                    "description": "产品描述",
+                    "type": "string"
+                },
+                "discount": {
+                    "description": "产品折扣",
+                    "type": "number"
+                },
+                "discount_price": {
+                    "description": "产品折扣价",
+                    "type": "number"
+                },
+                "images": {
+                    "description": "产品图片",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "产品名称",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "产品价格",
+                    "type": "number"
+                },
+                "stock": {
+                    "description": "产品库存，为空时不修改，可以设置为0",
+                    "type": "integer"
+                },
+                "uuid": {
+                    "description": "产品SKU UUID",
+                    "type": "string"
+                },
+                "videos": {
+                    "description": "产品视频",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ReqProductQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "product_uuid": {
+                    "description": "产品uuid",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqProductUpdate": {
+            "type": "object",
+            "required": [
+                "alias_name",
+                "name",
+                "uuid"
+            ],
+            "properties": {
+                "alias_name": {
+                    "description": "产品别名",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "产品描述",
+                    "type": "string"
+                },
+                "height": {
+                    "description": "高度",
+                    "type": "number"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "length": {
+                    "description": "长度",
+                    "type": "number"
+                },
+                "name": {
+                    "description": "产品名称",
+                    "type": "string"
+                },
+                "product_category_uuid": {
+                    "description": "产品分类",
+                    "type": "string"
+                },
+                "product_status": {
+                    "description": "产品上架状态\n上架、下架、售罄",
+                    "type": "string"
+                },
+                "stock_warning": {
+                    "description": "预警库存",
+                    "type": "integer"
+                },
+                "stock_warning_sell": {
+                    "description": "低于警戒库存是否可售",
+                    "type": "boolean"
+                },
+                "unit": {
+                    "description": "单位 例如: 个、件、套、箱",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "产品UUID",
+                    "type": "string"
+                },
+                "videos": {
+                    "description": "产品视频",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "weight": {
+                    "description": "重量",
+                    "type": "number"
+                },
+                "width": {
+                    "description": "宽度",
+                    "type": "number"
+                }
+            }
+        },
+        "model.ReqProductVariantsCreate": {
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "description": "产品变体描述",
+                    "type": "string"
+                },
+                "discount": {
+                    "description": "产品折扣",
+                    "type": "number"
+                },
+                "discount_price": {
+                    "description": "产品折扣价",
+                    "type": "number"
+                },
+                "name": {
+                    "description": "产品变体名称",
+                    "type": "string"
+                },
+                "options": {
+                    "description": "产品变体Option",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "description": "产品价格",
+                    "type": "number"
+                },
+                "stock": {
+                    "description": "产品库存",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqRefreshToken": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "刷新token",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqRegisterParam": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqResourceCreateFolder": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqResourceDeleteParam": {
+            "type": "object",
+            "properties": {
+                "uuid": {
+                    "description": "资源uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqResourceMove": {
+            "type": "object",
+            "properties": {
+                "parent_uuid": {
+                    "description": "父级资源",
+                    "type": "string"
+                },
+                "uuid_list": {
+                    "description": "资源uuid",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ReqResourceQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "mime_type": {
+                    "description": "文件类型",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "parent_uuid": {
+                    "description": "父级资源",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "资源名称",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqReturnCreate": {
+            "type": "object",
+            "required": [
+                "items",
+                "order_no",
+                "reason"
+            ],
+            "properties": {
+                "description": {
+                    "description": "问题描述",
+                    "type": "string"
+                },
+                "images": {
+                    "description": "图片，通过上传接口上传后的文件路径",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "items": {
+                    "description": "退货商品",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReqReturnItem"
+                    }
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "退货原因",
+                    "type": "string"
+                },
+                "resolution": {
+                    "description": "期望处理方式 refund、exchange，默认refund",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqReturnItem": {
+            "type": "object",
+            "required": [
+                "order_item_id",
+                "quantity"
+            ],
+            "properties": {
+                "order_item_id": {
+                    "description": "订单商品ID",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "退货数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqReturnQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "退货原因",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqReturnReceiveParam": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "remark": {
+                    "description": "处理备注",
+                    "type": "string"
+                },
+                "restock": {
+                    "description": "是否退回库存",
+                    "type": "boolean"
+                },
+                "uuid": {
+                    "description": "退货申请uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqReturnRefundParam": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "退款金额，为0时按退货商品金额退款",
+                    "type": "number"
+                },
+                "remark": {
+                    "description": "处理备注",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "退货申请uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqReturnReportParam": {
+            "type": "object",
+            "properties": {
+                "end_at": {
+                    "description": "申请时间结束",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "description": "产品uuid",
+                    "type": "string"
+                },
+                "start_at": {
+                    "description": "申请时间开始",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqReturnReviewParam": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "remark": {
+                    "description": "处理备注",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "退货申请uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqRoleQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "is_active": {
+                    "description": "是否激活 true:激活 false:未激活",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "角色名称",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "team_uuid": {
+                    "description": "团队uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqServerQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqShipmentCreate": {
+            "type": "object",
+            "required": [
+                "carrier",
+                "order_no",
+                "tracking_no"
+            ],
+            "properties": {
+                "carrier": {
+                    "description": "承运商",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "发货商品，为空时发出所有未发货商品",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReqShipmentItem"
+                    }
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "tracking_no": {
+                    "description": "物流单号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqShipmentItem": {
+            "type": "object",
+            "required": [
+                "order_item_id",
+                "quantity"
+            ],
+            "properties": {
+                "order_item_id": {
+                    "description": "订单商品ID",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "发货数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqShipmentLabelCreate": {
+            "type": "object",
+            "required": [
+                "carrier",
+                "order_no"
+            ],
+            "properties": {
+                "carrier": {
+                    "description": "承运商",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "面单格式 pdf、zpl",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "发货商品，为空时发出所有未发货商品",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReqShipmentItem"
+                    }
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "service": {
+                    "description": "物流服务类型",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqShipmentQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "string"
+                },
+                "tracking_no": {
+                    "description": "物流单号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqShipmentRateParam": {
+            "type": "object",
+            "required": [
+                "carrier",
+                "order_no"
+            ],
+            "properties": {
+                "carrier": {
+                    "description": "承运商",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqSubscriptionCreate": {
+            "type": "object",
+            "required": [
+                "agreement_no",
+                "payment_method",
+                "plan_uuid"
+            ],
+            "properties": {
+                "agreement_no": {
+                    "description": "代扣协议号",
+                    "type": "string"
+                },
+                "payment_method": {
+                    "description": "扣款方式",
+                    "type": "string"
+                },
+                "plan_uuid": {
+                    "description": "订阅计划uuid",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "每期数量，默认1",
+                    "type": "integer"
+                },
+                "receiver": {
+                    "description": "收货人信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.OrderReceiver"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.ReqSubscriptionPlanCreate": {
+            "type": "object",
+            "required": [
+                "interval",
+                "name",
+                "price",
+                "product_item_uuid",
+                "product_uuid"
+            ],
+            "properties": {
+                "currency_code": {
+                    "description": "货币代码",
+                    "type": "string"
+                },
+                "interval": {
+                    "description": "周期单位 day、week、month、year",
+                    "type": "string"
+                },
+                "interval_count": {
+                    "description": "周期数量",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "计划名称",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "每期价格",
+                    "type": "number"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "description": "产品uuid",
+                    "type": "string"
+                },
+                "trial_days": {
+                    "description": "试用天数",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqSubscriptionPlanQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "product_uuid": {
+                    "description": "产品uuid",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqSubscriptionPlanUpdate": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "interval": {
+                    "description": "周期单位",
+                    "type": "string"
+                },
+                "interval_count": {
+                    "description": "周期数量",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "计划名称",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "每期价格",
+                    "type": "number"
+                },
+                "status": {
+                    "description": "状态 1:启用 2:禁用",
+                    "type": "integer"
+                },
+                "trial_days": {
+                    "description": "试用天数",
+                    "type": "integer"
+                },
+                "uuid": {
+                    "description": "计划uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqSubscriptionQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "plan_uuid": {
+                    "description": "订阅计划uuid",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqSwitchTeamParam": {
+            "type": "object",
+            "properties": {
+                "team_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTeamCreate": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "desc": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "domain": {
+                    "description": "商城域名",
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "图标",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "团队名称",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTeamInvitationAccept": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "description": "密码",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "邀请token",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "用户名",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTeamInvitationCreate": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "被邀请人邮箱",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "角色uuid",
+                    "type": "string"
+                },
+                "team_uuid": {
+                    "description": "团队uuid，为空时使用当前团队",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTeamInvitationQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "email": {
+                    "description": "邮箱",
+                    "type": "string"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "string"
+                },
+                "team_uuid": {
+                    "description": "团队uuid，为空时使用当前团队",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTeamInvitationToken": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "description": "邀请token",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTeamMemberCreateParam": {
+            "type": "object",
+            "properties": {
+                "team_uuid": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTeamMemberQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "team_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTeamOwnerTransfer": {
+            "type": "object",
+            "required": [
+                "team_uuid",
+                "user_uuid"
+            ],
+            "properties": {
+                "team_uuid": {
+                    "description": "团队uuid",
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "description": "新拥有者uuid，需要是团队成员",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTeamQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTeamUpdate": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "desc": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "domain": {
+                    "description": "商城域名",
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "图标",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "团队名称",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTwoFactorCode": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "验证码或恢复码",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTwoFactorDisable": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "description": "验证码或恢复码",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "当前密码",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqTwoFactorSetup": {
+            "type": "object",
+            "required": [
+                "method"
+            ],
+            "properties": {
+                "method": {
+                    "description": "验证方式 totp、email",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqUserDeleteParam": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "uuid": {
+                    "description": "用户uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqUserLogin": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "captcha_code": {
+                    "description": "图形验证码",
+                    "type": "string"
+                },
+                "captcha_id": {
+                    "description": "图形验证码ID，失败次数较多时需要",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "密码",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "用户名或邮箱",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqUserPermissionQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "permission_uuid": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqUserQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "email": {
+                    "description": "邮箱",
+                    "type": "string"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "nickname": {
+                    "description": "昵称",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "phone": {
+                    "description": "手机号",
+                    "type": "string"
+                },
+                "sex": {
+                    "description": "性别",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "integer"
+                },
+                "username": {
+                    "description": "用户名",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqUserTeamQueryParam": {
+            "type": "object",
+            "properties": {
+                "user_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqUuidParam": {
+            "type": "object",
+            "properties": {
+                "uuid": {
+                    "type": "string"
+                },
+                "uuids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ReqVerificationCodeParam": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "purpose": {
+                    "description": "用途 register、login，默认register",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqWebhookCreate": {
+            "type": "object",
+            "required": [
+                "app_uuid",
+                "events",
+                "url"
+            ],
+            "properties": {
+                "app_uuid": {
+                    "description": "App uuid",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "订阅的事件",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "description": "推送地址",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqWebhookDeliveryQueryParam": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "event": {
+                    "description": "事件",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 pending、success、failed",
+                    "type": "string"
+                },
+                "webhook_uuid": {
+                    "description": "webhook uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReqWebhookQueryParam": {
+            "type": "object",
+            "properties": {
+                "app_uuid": {
+                    "description": "App uuid",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "当前页码",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "description": "结束时间",
+                    "type": "string"
+                },
+                "event": {
+                    "description": "事件",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "description": "每页显示个数",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "description": "开始时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReqWebhookUpdate": {
+            "type": "object",
+            "required": [
+                "uuid"
+            ],
+            "properties": {
+                "description": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "订阅的事件",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "description": "状态 1:启用 2:禁用",
+                    "type": "integer"
+                },
+                "url": {
+                    "description": "推送地址",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "webhook uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResAPIDiff": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "新增或未启用的接口",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.API"
+                    }
+                },
+                "removed": {
+                    "description": "已删除的接口",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.API"
+                    }
+                }
+            }
+        },
+        "model.ResAPISync": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "description": "新增的接口数量，新增接口为未启用状态",
+                    "type": "integer"
+                },
+                "removed": {
+                    "description": "标记删除的接口数量",
+                    "type": "integer"
+                },
+                "restored": {
+                    "description": "重新出现并恢复为未启用的接口数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ResCasbinExplain": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "description": "是否允许",
+                    "type": "boolean"
+                },
+                "matched_policy": {
+                    "description": "允许时匹配的策略",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "policies": {
+                    "description": "用户和角色在团队中的全部策略",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "reason": {
+                    "description": "说明",
+                    "type": "string"
+                },
+                "roles": {
+                    "description": "用户在团队中的角色",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "team_uuid": {
+                    "description": "团队uuid",
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "description": "用户uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResLoginChallenge": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "有效期（秒）",
+                    "type": "integer"
+                },
+                "method": {
+                    "description": "验证方式 totp、email、setup",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "挑战uuid，验证时提交",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResTotpSetup": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "description": "密钥，无法扫码时手动输入",
+                    "type": "string"
+                },
+                "uri": {
+                    "description": "otpauth链接，用于生成二维码",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResTwoFactorStatus": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "是否已启用",
+                    "type": "boolean"
+                },
+                "method": {
+                    "description": "验证方式",
+                    "type": "string"
+                },
+                "recovery_codes_count": {
+                    "description": "剩余恢复码数量",
+                    "type": "integer"
+                },
+                "required": {
+                    "description": "角色是否要求两步验证",
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.ResUserLogin": {
+            "type": "object",
+            "properties": {
+                "challenge": {
+                    "description": "需要两步验证时返回，此时不返回token",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ResLoginChallenge"
+                        }
+                    ]
+                },
+                "expires_in": {
+                    "description": "访问token有效期（秒）",
+                    "type": "integer"
+                },
+                "recovery_codes": {
+                    "description": "登录时绑定两步验证后返回的恢复码，只显示一次",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "refresh_token": {
+                    "description": "刷新token，每次刷新后更换",
+                    "type": "string"
+                },
+                "step": {
+                    "description": "登录步骤 done、two_factor、setup",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "访问token",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Resource": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "文件路径",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "资源描述",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_private": {
+                    "description": "私有文件，保存在Digital.Dir下，不能通过/public访问",
+                    "type": "boolean"
+                },
+                "md5": {
+                    "description": "文件MD5",
+                    "type": "string"
+                },
+                "mime_type": {
+                    "description": "文件类型",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "资源名称",
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "description": "父级资源",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "文件路径",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "文件大小",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "资源类型",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResourceRes": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "文件路径",
+                    "type": "string"
+                },
+                "children": {
+                    "description": "子资源",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ResourceRes"
+                    }
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "资源描述",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_private": {
+                    "description": "私有文件，保存在Digital.Dir下，不能通过/public访问",
+                    "type": "boolean"
+                },
+                "md5": {
+                    "description": "文件MD5",
+                    "type": "string"
+                },
+                "mime_type": {
+                    "description": "文件类型",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "资源名称",
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "description": "父级资源",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "文件路径",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "文件大小",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "资源类型",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReturnInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ReturnRequestRes"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReturnItemRes": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "退款金额，按订单商品实付单价计算",
+                    "type": "number"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_item_id": {
+                    "description": "订单商品ID",
+                    "type": "integer"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "product_item": {
+                    "description": "商品信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ProductItemRes"
+                        }
+                    ]
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "退货数量",
+                    "type": "integer"
+                },
+                "return_uuid": {
+                    "description": "退货申请uuid",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReturnQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReturnRequestRes"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReturnReasonReport": {
+            "type": "object",
+            "properties": {
+                "product_item_uuid": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "退货商品数量",
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "return_count": {
+                    "description": "退货申请数",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReturnReasonReportResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReturnReasonReport"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReturnRequestRes": {
+            "type": "object",
+            "properties": {
+                "admin_remark": {
+                    "description": "处理备注，会通知买家",
+                    "type": "string"
+                },
+                "completed_at": {
+                    "description": "完成时间",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "问题描述",
+                    "type": "string"
+                },
+                "exchange_order_no": {
+                    "description": "换货订单编号",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "description": "图片，上传接口返回的文件路径，json数组",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "退货商品",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReturnItemRes"
+                    }
+                },
+                "operator": {
+                    "description": "处理人",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "退货原因",
+                    "type": "string"
+                },
+                "received_at": {
+                    "description": "收货时间",
+                    "type": "string"
+                },
+                "refund_amount": {
+                    "description": "退款金额",
+                    "type": "number"
+                },
+                "refunds": {
+                    "description": "退款记录",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Refund"
+                    }
+                },
+                "resolution": {
+                    "description": "期望处理方式 refund、exchange",
+                    "type": "string"
+                },
+                "restocked": {
+                    "description": "收货后是否已退回库存",
+                    "type": "boolean"
+                },
+                "reviewed_at": {
+                    "description": "审核时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 requested、approved、rejected、received、refunded、exchanged、canceled",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Role": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了角色创建的时间",
+                    "type": "string"
+                },
+                "desc": {
+                    "description": "Desc 是对角色的描述",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID 是角色的主键",
+                    "type": "integer"
+                },
+                "is_active": {
+                    "description": "IsActive 标识角色是否是活跃的",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Name 是角色的名称，它在系统中是唯一的",
+                    "type": "string"
+                },
+                "require_2fa": {
+                    "description": "Require2FA 标识拥有该角色的用户登录时必须通过两步验证",
+                    "type": "boolean"
+                },
+                "team_uuid": {
+                    "description": "TeamUuid 是团队的UUID",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了角色最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "Uuid 是角色的唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.RoleInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Role"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.RolePageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Role"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.RoleQueryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.RolePageResponse"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Server": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "create_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "update_at": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ServerInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Server"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ServerPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Server"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ServerQueryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ServerPageResponse"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ShipmentEvent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "description": "地点",
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "description": "发生时间",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "shipment_uuid": {
+                    "description": "包裹uuid",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ShipmentInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ShipmentRes"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ShipmentItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_item_id": {
+                    "description": "订单商品ID",
+                    "type": "integer"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "发货数量",
+                    "type": "integer"
+                },
+                "shipment_uuid": {
+                    "description": "包裹uuid",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ShipmentListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ShipmentRes"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ShipmentQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ShipmentRes"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ShipmentRes": {
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "description": "承运商",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "description": "签收时间",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "物流轨迹",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ShipmentEvent"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "包裹商品",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ShipmentItem"
+                    }
+                },
+                "label_format": {
+                    "description": "面单格式 pdf、zpl",
+                    "type": "string"
+                },
+                "label_resource_uuid": {
+                    "description": "面单资源uuid",
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "发货人",
+                    "type": "string"
+                },
+                "order_no": {
+                    "description": "订单编号",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "service": {
+                    "description": "物流服务类型",
+                    "type": "string"
+                },
+                "shipped_at": {
+                    "description": "发货时间",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 shipped、in_transit、delivered、exception",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "tracked_at": {
+                    "description": "最近一次查询物流轨迹的时间",
+                    "type": "string"
+                },
+                "tracking_no": {
+                    "description": "物流单号",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.StringDataResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.StringListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Subscription": {
+            "type": "object",
+            "properties": {
+                "agreement_no": {
+                    "description": "代扣协议号",
+                    "type": "string"
+                },
+                "canceled_at": {
+                    "description": "取消时间",
+                    "type": "string"
+                },
+                "charged_order_no": {
+                    "description": "已扣款但还未标记支付的续费订单编号，标记完成后清空",
+                    "type": "string"
+                },
+                "charged_transaction_no": {
+                    "description": "已扣款的支付流水号",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "current_period_end": {
+                    "description": "当前周期结束时间",
+                    "type": "string"
+                },
+                "current_period_start": {
+                    "description": "当前周期开始时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_order_no": {
+                    "description": "最近一次续费订单编号",
+                    "type": "string"
+                },
+                "next_billing_at": {
+                    "description": "下次扣款时间",
+                    "type": "string"
+                },
+                "paused_at": {
+                    "description": "暂停时间",
+                    "type": "string"
+                },
+                "payment_method": {
+                    "description": "扣款方式，支付方式code",
+                    "type": "string"
+                },
+                "plan_uuid": {
+                    "description": "订阅计划uuid",
+                    "type": "string"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "每期数量",
+                    "type": "integer"
+                },
+                "receiver_address": {
+                    "description": "收货人地址",
+                    "type": "string"
+                },
+                "receiver_city": {
+                    "description": "收货人城市",
+                    "type": "string"
+                },
+                "receiver_country": {
+                    "description": "收货人国家",
+                    "type": "string"
+                },
+                "receiver_email": {
+                    "description": "收货人邮箱",
+                    "type": "string"
+                },
+                "receiver_name": {
+                    "description": "收货人姓名",
+                    "type": "string"
+                },
+                "receiver_phone": {
+                    "description": "收货人电话",
+                    "type": "string"
+                },
+                "receiver_province": {
+                    "description": "收货人省份",
+                    "type": "string"
+                },
+                "receiver_remark": {
+                    "description": "收货人备注",
+                    "type": "string"
+                },
+                "receiver_zip": {
+                    "description": "收货人邮编",
+                    "type": "string"
+                },
+                "retry_count": {
+                    "description": "扣款失败重试次数",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态 trialing、active、past_due、paused、canceled",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "trial_end_at": {
+                    "description": "试用结束时间",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SubscriptionInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Subscription"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.SubscriptionPlan": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "currency_code": {
+                    "description": "货币代码",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interval": {
+                    "description": "周期单位 day、week、month、year",
+                    "type": "string"
+                },
+                "interval_count": {
+                    "description": "周期数量，如每3个月",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "计划名称",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "每期价格",
+                    "type": "number"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "product_uuid": {
+                    "description": "产品uuid",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 1:启用 2:禁用",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "trial_days": {
+                    "description": "试用天数",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SubscriptionPlanInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.SubscriptionPlan"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.SubscriptionPlanQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SubscriptionPlan"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SubscriptionQueryResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SubscriptionRes"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.SubscriptionRes": {
+            "type": "object",
+            "properties": {
+                "agreement_no": {
+                    "description": "代扣协议号",
+                    "type": "string"
+                },
+                "canceled_at": {
+                    "description": "取消时间",
+                    "type": "string"
+                },
+                "charged_order_no": {
+                    "description": "已扣款但还未标记支付的续费订单编号，标记完成后清空",
+                    "type": "string"
+                },
+                "charged_transaction_no": {
+                    "description": "已扣款的支付流水号",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "current_period_end": {
+                    "description": "当前周期结束时间",
+                    "type": "string"
+                },
+                "current_period_start": {
+                    "description": "当前周期开始时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_order_no": {
+                    "description": "最近一次续费订单编号",
+                    "type": "string"
+                },
+                "next_billing_at": {
+                    "description": "下次扣款时间",
+                    "type": "string"
+                },
+                "paused_at": {
+                    "description": "暂停时间",
+                    "type": "string"
+                },
+                "payment_method": {
+                    "description": "扣款方式，支付方式code",
+                    "type": "string"
+                },
+                "plan": {
+                    "description": "订阅计划",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.SubscriptionPlan"
+                        }
+                    ]
+                },
+                "plan_uuid": {
+                    "description": "订阅计划uuid",
+                    "type": "string"
+                },
+                "product_item_uuid": {
+                    "description": "产品SKU uuid",
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "每期数量",
+                    "type": "integer"
+                },
+                "receiver_address": {
+                    "description": "收货人地址",
+                    "type": "string"
+                },
+                "receiver_city": {
+                    "description": "收货人城市",
+                    "type": "string"
+                },
+                "receiver_country": {
+                    "description": "收货人国家",
+                    "type": "string"
+                },
+                "receiver_email": {
+                    "description": "收货人邮箱",
+                    "type": "string"
+                },
+                "receiver_name": {
+                    "description": "收货人姓名",
+                    "type": "string"
+                },
+                "receiver_phone": {
+                    "description": "收货人电话",
+                    "type": "string"
+                },
+                "receiver_province": {
+                    "description": "收货人省份",
+                    "type": "string"
+                },
+                "receiver_remark": {
+                    "description": "收货人备注",
+                    "type": "string"
+                },
+                "receiver_zip": {
+                    "description": "收货人邮编",
+                    "type": "string"
+                },
+                "retry_count": {
+                    "description": "扣款失败重试次数",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态 trialing、active、past_due、paused、canceled",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "trial_end_at": {
+                    "description": "试用结束时间",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SysLoginLog": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "地址",
+                    "type": "string"
+                },
+                "browser": {
+                    "description": "浏览器",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "device": {
+                    "description": "登录设备",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "message": {
+                    "description": "消息",
+                    "type": "string"
+                },
+                "os": {
+                    "description": "操作系统",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "登录状态",
+                    "type": "integer"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SysOpLog": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "请求耗时",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "主键ID",
+                    "type": "integer"
+                },
+                "ip": {
+                    "description": "请求IP",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "消息",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "请求方法",
+                    "type": "string"
+                },
+                "params": {
+                    "description": "请求参数",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "请求路径",
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "请求ID",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "integer"
+                },
+                "user_uuid": {
+                    "description": "用户UUID",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Team": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了团队创建的时间",
+                    "type": "string"
+                },
+                "creater": {
+                    "description": "Creater 标识团队的创建者",
+                    "type": "string"
+                },
+                "desc": {
+                    "description": "Desc 是对团队的描述",
+                    "type": "string"
+                },
+                "domain": {
+                    "description": "Domain 是团队商城的域名，开启多租户时按域名确定商城所属的团队",
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "Icon 是团队的图标",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID 是团队的主键",
+                    "type": "integer"
+                },
+                "is_active": {
+                    "description": "IsActive 标识团队是否是活跃的",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Name 是团队的名称，它在系统中是唯一的",
+                    "type": "string"
+                },
+                "owner_uuid": {
+                    "description": "拥有者",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了团队最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "UUID 是团队的唯一标识符",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Team"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamInvitation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "被邀请人邮箱",
+                    "type": "string"
+                },
+                "expired_at": {
+                    "description": "过期时间",
+                    "type": "string"
+                },
+                "handled_at": {
+                    "description": "接受、拒绝或撤销的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inviter_uuid": {
+                    "description": "邀请人uuid",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "加入团队后的角色uuid",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 pending、accepted、declined、revoked",
+                    "type": "string"
+                },
+                "team_uuid": {
+                    "description": "团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "description": "接受邀请的用户uuid",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamInvitationInfo": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "被邀请人邮箱",
+                    "type": "string"
+                },
+                "expired_at": {
+                    "description": "过期时间",
+                    "type": "string"
+                },
+                "has_account": {
+                    "description": "邮箱是否已有账号，没有账号时接受邀请需要填写用户名和密码",
+                    "type": "boolean"
+                },
+                "role_name": {
+                    "description": "角色名称",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "string"
+                },
+                "team_name": {
+                    "description": "团队名称",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamInvitationInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.TeamInvitationInfo"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.TeamInvitation"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserTeamRes"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了团队成员加入的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID 是团队成员的主键",
+                    "type": "integer"
+                },
+                "is_current_team": {
+                    "description": "用户当前选择这个团队",
+                    "type": "boolean"
+                },
+                "role": {
+                    "description": "Role 是成员在团队中的角色",
+                    "type": "string"
+                },
+                "team_uuid": {
+                    "description": "TeamUUID 是团队的UUID",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了团队成员信息最后更新的时间",
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "description": "UserUUID 是用户的UUID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "UUID 是团队成员的唯一标识符",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamMemberInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.TeamMember"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamMemberPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TeamUserRes"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TeamPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Team"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TeamQueryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.TeamPageResponse"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TeamUserRes": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "年龄",
+                    "type": "integer"
+                },
+                "avatar": {
+                    "description": "头像",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "邮箱",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_deleted": {
+                    "description": "是否删除 1:删除 0:未删除",
+                    "type": "integer"
+                },
+                "nickname": {
+                    "description": "昵称",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "密码",
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "手机号",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "Role 是用户在团队中的角色",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Role"
+                        }
+                    ]
+                },
+                "sex": {
+                    "description": "性别 0:未知 1:男 2:女",
+                    "type": "string"
+                },
+                "signed": {
+                    "description": "个性签名",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 0:禁用 1:启用 2:删除",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "用户名",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "用户唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TotpSetupResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ResTotpSetup"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.TradeFundBill": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "fund_channel": {
+                    "description": "同步通知里是 fund_channel",
+                    "type": "string"
+                },
+                "fund_type": {
+                    "type": "string"
+                },
+                "real_amount": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TradePay": {
+            "type": "object",
+            "properties": {
+                "advance_amount": {
+                    "type": "string"
+                },
+                "async_payment_mode": {
+                    "type": "string"
+                },
+                "auth_trade_pay_mode": {
+                    "type": "string"
+                },
+                "buyer_logon_id": {
+                    "type": "string"
+                },
+                "buyer_open_id": {
+                    "type": "string"
+                },
+                "buyer_pay_amount": {
+                    "type": "string"
+                },
+                "buyer_user_id": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "credit_biz_order_id": {
+                    "type": "string"
+                },
+                "credit_pay_mode": {
+                    "type": "string"
+                },
+                "discount_amount": {
+                    "type": "string"
+                },
+                "discount_goods_detail": {
+                    "type": "string"
+                },
+                "fund_bill_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TradeFundBill"
+                    }
+                },
+                "invoice_amount": {
+                    "type": "string"
+                },
+                "mdiscount_amount": {
+                    "type": "string"
+                },
+                "msg": {
+                    "type": "string"
+                },
+                "out_trade_no": {
+                    "type": "string"
+                },
+                "point_amount": {
+                    "type": "string"
+                },
+                "receipt_amount": {
+                    "type": "string"
+                },
+                "store_name": {
+                    "type": "string"
+                },
+                "sub_code": {
+                    "type": "string"
+                },
+                "sub_msg": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "string"
+                },
+                "trade_no": {
+                    "type": "string"
+                },
+                "voucher_detail_list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.VoucherDetail"
+                    }
+                }
+            }
+        },
+        "model.TwoFactorStatusResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.ResTwoFactorStatus"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.User": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "年龄",
+                    "type": "integer"
+                },
+                "avatar": {
+                    "description": "头像",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "邮箱",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_deleted": {
+                    "description": "是否删除 1:删除 0:未删除",
+                    "type": "integer"
+                },
+                "nickname": {
+                    "description": "昵称",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "密码",
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "手机号",
+                    "type": "string"
+                },
+                "sex": {
+                    "description": "性别 0:未知 1:男 2:女",
+                    "type": "string"
+                },
+                "signed": {
+                    "description": "个性签名",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态 0:禁用 1:启用 2:删除",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "description": "更新时间",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "用户名",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "用户唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.UserAddress": {
+            "type": "object",
+            "properties": {
+                "address_type": {
+                    "description": "地址类型",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "description": "是否默认地址",
+                    "type": "boolean"
+                },
+                "receiver_address": {
+                    "description": "收货人地址",
+                    "type": "string"
+                },
+                "receiver_city": {
+                    "description": "收货人城市",
+                    "type": "string"
+                },
+                "receiver_country": {
+                    "description": "收货人国家",
+                    "type": "string"
+                },
+                "receiver_email": {
+                    "description": "收货人邮箱",
+                    "type": "string"
+                },
+                "receiver_name": {
+                    "description": "收货人姓名",
+                    "type": "string"
+                },
+                "receiver_phone": {
+                    "description": "收货人电话",
+                    "type": "string"
+                },
+                "receiver_province": {
+                    "description": "收货人省份",
+                    "type": "string"
+                },
+                "receiver_zip": {
+                    "description": "收货人邮编",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "用户地址唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.UserInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.UserPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.User"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.UserPermission": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了用户权限关联创建的时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID 是用户权限关联的主键",
+                    "type": "integer"
+                },
+                "permission_uuid": {
+                    "description": "PermissionUuid 是权限的 UUID",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了用户权限关联信息最后更新的时间",
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "description": "UserUuid 是用户的 UUID",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "UUID 是用户权限关联的唯一标识符",
+                    "type": "string"
+                }
+            }
+        },
+        "model.UserPermissionInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.UserPermission"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.UserPermissionListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserPermission"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.UserPermissionPageResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserPermission"
+                    }
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.UserQueryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.UserPageResponse"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.UserSessionListResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserSessionRes"
+                    }
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.UserSessionRes": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "description": "token受众 admin、customer",
+                    "type": "string"
+                },
+                "browser": {
+                    "description": "浏览器",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "是否为当前会话",
+                    "type": "boolean"
+                },
+                "device": {
+                    "description": "登录设备",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "刷新token过期时间",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "description": "登录IP",
+                    "type": "string"
+                },
+                "last_active_at": {
+                    "description": "最近活跃时间，刷新token时更新",
+                    "type": "string"
+                },
+                "os": {
+                    "description": "操作系统",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "description": "撤销时间，为空表示有效",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "用户ID，后台用户或商城客户的uuid",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UserTeamRes": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "CreatedAt 记录了团队创建的时间",
+                    "type": "string"
+                },
+                "creater": {
+                    "description": "Creater 标识团队的创建者",
+                    "type": "string"
+                },
+                "desc": {
+                    "description": "Desc 是对团队的描述",
+                    "type": "string"
+                },
+                "domain": {
+                    "description": "Domain 是团队商城的域名，开启多租户时按域名确定商城所属的团队",
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "Icon 是团队的图标",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID 是团队的主键",
+                    "type": "integer"
+                },
+                "is_active": {
+                    "description": "IsActive 标识团队是否是活跃的",
+                    "type": "boolean"
+                },
+                "is_current_team": {
+                    "description": "IsCurrentTeam 标识用户当前选择这个团队",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Name 是团队的名称，它在系统中是唯一的",
+                    "type": "string"
+                },
+                "owner_uuid": {
+                    "description": "拥有者",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "Role 是用户在团队中的角色",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Role"
+                        }
+                    ]
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了团队最后更新的时间",
+                    "type": "string"
+                },
+                "uuid": {
+                    "description": "UUID 是团队的唯一标识符",
+                    "type": "string"
+                }
+            }
+        },
+        "model.VoucherDetail": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "memo": {
+                    "type": "string"
+                },
+                "merchant_contribute": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "other_contribute": {
+                    "type": "string"
+                },
+                "purchase_ant_contribute": {
+                    "type": "string"
+                },
+                "purchase_buyer_contribute": {
+                    "type": "string"
+                },
+                "purchase_merchant_contribute": {
+                    "type": "string"
+                },
+                "template_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Webhook": {
+            "type": "object",
+            "properties": {
+                "app_uuid": {
+                    "description": "App uuid，使用App的SecKey签名",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "描述",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "订阅的事件，逗号分隔",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态 1:启用 2:禁用",
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "推送地址",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.WebhookDeliveryAttempt": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "description": "第几次投递",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "delivery_uuid": {
+                    "description": "投递记录uuid",
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "耗时（毫秒）",
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "错误信息",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "response_body": {
+                    "description": "响应内容，最多保存2KB",
+                    "type": "string"
+                },
+                "response_status": {
+                    "description": "响应状态码，请求失败时为0",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.WebhookDeliveryRes": {
+            "type": "object",
+            "properties": {
+                "app_uuid": {
+                    "description": "App uuid",
+                    "type": "string"
+                },
+                "attempt_list": {
+                    "description": "每次投递的结果",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WebhookDeliveryAttempt"
+                    }
+                },
+                "attempts": {
+                    "description": "已投递次数",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "CreatedAt 记录了创建的时间",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "最近一次错误",
+                    "type": "string"
+                },
+                "event": {
+                    "description": "事件",
+                    "type": "string"
+                },
+                "event_id": {
+                    "description": "事件id，同一事件推送到多个webhook时相同，接收方可用于去重",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_attempt_at": {
+                    "description": "最近一次投递时间",
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "description": "下次投递时间",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "推送内容",
+                    "type": "string"
+                },
+                "response_status": {
+                    "description": "最近一次响应状态码",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态 pending、success、failed",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "description": "租户，所属团队uuid",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "UpdatedAt 记录了最后更新的时间",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "推送地址",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                },
+                "webhook_uuid": {
+                    "description": "webhook uuid",
+                    "type": "string"
+                }
+            }
+        },
+        "model.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.WebhookDeliveryRes"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "$ref": "#/definitions/model.Webhook"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        },
+        "model.WechatOrderDetailResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码",
+                    "type": "integer"
+                },
+                "data": {
+                    "description": "TODO 微信支付订单详情",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                },
+                "trace_id": {
+                    "description": "请求唯一标识",
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BasicAuth": {
+            "type": "basic"
+        }
+    },
+    "externalDocs": {
+        "description": "OpenAPI",
+        "url": "https://swagger.io/resources/open-api/"
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8080",
+	BasePath:         "/api/v1",
+	Schemes:          []string{},
+	Title:            "Swagger Example API",
+	Description:      "This is a sample server celler server.",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
@@ -5,10 +5,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sgin/middleware"
 	"sgin/model"
 	"sgin/pkg/app"
 	"sgin/pkg/config"
 	"sgin/routers"
+	"sgin/service"
 	"syscall"
 	"time"
 )
@@ -38,8 +40,11 @@ func main() {
 	model.MigrateDbTable(serverApp.DB)
 	serverApp.Use(app.RecoveryWithWriter(serverApp.Logger))
 	serverApp.Use(app.Cors())
+	serverApp.Use(middleware.Tenant())
 
 	routers.InitRouter(serverApp)
+	service.SyncAPIRoutes(serverApp)
+	service.StartScheduler(serverApp)
 
 	serverApp.GET("/ping", func(ctx *app.Context) {
 		panic("test panic")
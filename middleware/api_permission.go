@@ -7,16 +7,16 @@ import (
 	"sgin/service"
 )
 
-// API权限校验中间件
+// API权限校验中间件，app只能调用AppPermission中授权的接口
 func ApiPermission() app.HandlerFunc {
 	return func(c *app.Context) {
 
-		// 获取api path
+		// 获取api path，使用路由路径与接口表一致
 		// 获取api method
 		// 获取api key
 
 		apikey := c.GetHeader("X-Api-Key")
-		apiPath := c.Request.URL.Path
+		apiPath := c.FullPath()
 		apiMethod := c.Request.Method
 
 		// 根据apikey获取app信息
@@ -41,12 +41,12 @@ func ApiPermission() app.HandlerFunc {
 
 		// 根据app信息获取app权限
 
-		appPermission, err := service.NewAppPermissionService().GetAPIPermissionByNamePathMethod(c, appinfo.UUID, apiPath, apiMethod)
-		if err != nil || appPermission == nil {
+		_, err = service.NewAppPermissionService().GetAPIPermissionByNamePathMethod(c, appinfo.UUID, apiPath, apiMethod)
+		if err != nil {
 			c.JSONError(http.StatusForbidden, err.Error())
 			c.Abort()
 			return
 		}
-		_ = appPermission
+		c.Next()
 	}
 }
@@ -1,16 +1,24 @@
 package middleware
 
 import (
+	"errors"
+	"sgin/model"
 	"sgin/pkg/app"
 	"sgin/service"
 )
 
 // app中间件，检查app的key是否有效
+// 通过后设置app_info和app_id，开启多租户时使用app所属团队的数据
 func AppKeyCheck() app.HandlerFunc {
 	return func(c *app.Context) {
 
 		// 获取apikey
 		apikey := c.GetHeader("X-Api-Key")
+		if apikey == "" {
+			c.JSONError(403, "X-Api-Key is empty")
+			c.Abort()
+			return
+		}
 
 		// 根据apikey获取app信息
 
@@ -20,6 +28,30 @@ func AppKeyCheck() app.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if appInfo.Status != model.AppStatusEnabled {
+			c.JSONError(403, "app is disabled")
+			c.Abort()
+			return
+		}
 		c.Set("app_info", appInfo)
+		c.Set("app_id", appInfo.UUID)
+
+		if c.Config.Tenant.Enable {
+			c.SetTenant(appInfo.TeamUuid)
+		}
+		c.Next()
+	}
+}
+
+// 获取AppKeyCheck设置的app信息，没有时根据X-App-Id获取
+func getAppInfo(c *app.Context) (*model.App, error) {
+	if appInfo, ok := c.Get("app_info"); ok {
+		return appInfo.(*model.App), nil
+	}
+
+	appId := c.GetHeader("X-App-Id")
+	if appId == "" {
+		return nil, errors.New("X-App-Id is empty")
 	}
+	return service.NewAppService().GetAppByUUID(c, appId)
 }
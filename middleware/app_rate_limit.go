@@ -1,41 +1,26 @@
 package middleware
 
 import (
+	"sgin/model"
 	"sgin/pkg/app"
-	"sync"
-
-	"golang.org/x/time/rate"
+	"sgin/pkg/redisop"
+	"time"
 )
 
-// APP 限流中间件
+// APP 限流中间件，使用共享的限流器，多个实例的限流一致
 type AppRateLimit struct {
-	appLimit map[string]*rate.Limiter
-	mu       *sync.RWMutex // 读写锁
-	r        rate.Limit    // 令牌桶每秒填充的令牌数
-	b        int           // 令牌桶的容量
+	r float64 // 每秒请求数
+	b int     // 突发请求数
 }
 
-func NewAppRateLimit(r rate.Limit, b int) *AppRateLimit {
+func NewAppRateLimit(r float64, b int) *AppRateLimit {
 	return &AppRateLimit{
-		appLimit: make(map[string]*rate.Limiter),
-		mu:       &sync.RWMutex{},
-		r:        r,
-		b:        b,
-	}
-}
-
-// 获取令牌桶
-func (a *AppRateLimit) GetLimit(appId string) *rate.Limiter {
-	a.mu.Lock()
-	defer a.mu.Unlock()
-	l, ok := a.appLimit[appId]
-	if !ok {
-		l = rate.NewLimiter(a.r, a.b)
-		a.appLimit[appId] = l
+		r: r,
+		b: b,
 	}
-	return l
 }
 
+// 在AppKeyCheck之后使用，app设置了限流时使用app的设置
 func (a *AppRateLimit) HandleRateLimit() app.HandlerFunc {
 	return func(c *app.Context) {
 		// 获取app id
@@ -46,13 +31,23 @@ func (a *AppRateLimit) HandleRateLimit() app.HandlerFunc {
 			return
 		}
 
-		// 获取令牌桶
-		l := a.GetLimit(appId)
+		r, b := a.r, a.b
+		if v, ok := c.Get("app_info"); ok {
+			appInfo := v.(*model.App)
+			if appInfo.RateLimit > 0 {
+				r = appInfo.RateLimit
+			}
+			if appInfo.RateBurst > 0 {
+				b = appInfo.RateBurst
+			}
+		}
 
-		// 获取令牌
-		if !l.Allow() {
-			c.JSONError(429, "too many requests")
-			c.Abort()
+		limit := redisop.Limit{
+			Rate:   r,
+			Period: time.Second,
+			Burst:  b,
+		}
+		if !allowRate(c, "app:"+appId, limit) {
 			return
 		}
 		c.Next()
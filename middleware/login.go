@@ -4,24 +4,100 @@ import (
 	"net/http"
 	"sgin/pkg/app"
 	"sgin/pkg/utils"
+	"sgin/service"
+	"strings"
 )
 
-// 登录中间件
+// 商城客户token可以调用的路径，其余使用LoginCheck的接口都是后台接口
+// 路径不含接口前缀，匹配时去掉配置的ApiPrefix
+var customerPath = []string{
+	"/v1/f/*",
+	"/v1/cart/*",
+	"/v1/user_address/*",
+	"/v1/order/create",
+	"/v1/user/orders",
+	"/v1/payment_method/paypal/create",
+	"/v1/payment_method/alipay/create",
+	"/v1/payment_method/wechat/create",
+}
+
+// 请求路径是否匹配接口路径列表，列表中的路径不含接口前缀
+func matchAPIPath(c *app.Context, paths []string) bool {
+	path := c.Request.URL.Path
+	if !strings.HasPrefix(path, c.Config.ApiPrefix+"/") {
+		return false
+	}
+	return matchPath(paths, strings.TrimPrefix(path, c.Config.ApiPrefix))
+}
+
+// 登录中间件，商城客户token只能调用商城接口
 func LoginCheck() app.HandlerFunc {
 	return func(c *app.Context) {
 
 		// 获取token
 		token := c.GetHeader("X-Token")
 
-		// 根据token获取用户信息
-		userId, err := utils.ParseTokenGetUserID(token)
+		// 根据token获取用户信息，会话撤销后token失效
+		claims, err := service.NewSessionService().ParseToken(c, token)
 		if err != nil {
 			c.JSONError(http.StatusUnauthorized, err.Error())
 			c.Abort()
 			return
 		}
 
+		if claims.Audience == utils.TokenAudienceCustomer && !matchAPIPath(c, customerPath) {
+			c.JSONError(http.StatusForbidden, "没有权限")
+			c.Abort()
+			return
+		}
+
 		// 将用户信息放入上下文
-		c.Set("user_id", userId)
+		setTokenClaims(c, claims)
+		setUserTenant(c, claims)
 	}
 }
+
+// 商城客户登录中间件，只接受客户登录接口签发的token
+func CustomerLoginCheck() app.HandlerFunc {
+	return func(c *app.Context) {
+		token := c.GetHeader("X-Token")
+
+		claims, err := service.NewSessionService().ParseToken(c, token)
+		if err != nil {
+			c.JSONError(http.StatusUnauthorized, err.Error())
+			c.Abort()
+			return
+		}
+
+		if claims.Audience != utils.TokenAudienceCustomer {
+			c.JSONError(http.StatusUnauthorized, "invalid token audience")
+			c.Abort()
+			return
+		}
+
+		setTokenClaims(c, claims)
+	}
+}
+
+// 可选登录中间件，token有效时将用户信息放入上下文，用于前台按客户分组展示价格
+func OptionalLoginCheck() app.HandlerFunc {
+	return func(c *app.Context) {
+		token := c.GetHeader("X-Token")
+		if token == "" {
+			return
+		}
+
+		claims, err := service.NewSessionService().ParseToken(c, token)
+		if err != nil {
+			return
+		}
+
+		setTokenClaims(c, claims)
+	}
+}
+
+func setTokenClaims(c *app.Context, claims *utils.TokenClaims) {
+	c.Set("user_id", claims.UserID)
+	c.Set("token_audience", claims.Audience)
+	c.Set("session_id", claims.SessionID)
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"sgin/pkg/app"
+	"sgin/pkg/testutil"
+	"sgin/pkg/utils"
+	"sgin/service"
+)
+
+// 接口前缀不是默认值时，商城客户路径和免权限路径按配置的前缀匹配
+func TestPathListsWithCustomPrefix(t *testing.T) {
+	a := testutil.NewApp(t)
+	a.Config.ApiPrefix = "/shop-api"
+	g := a.Group(a.Config.ApiPrefix + "/v1")
+	g.Use(LoginCheck())
+	g.Use(UserPermission())
+	for _, path := range []string{"/cart/list", "/user/myinfo", "/product/delete"} {
+		g.POST(path, func(c *app.Context) {
+			c.JSONSuccess("ok")
+		})
+	}
+	// 其他前缀下的同名路径不在列表中
+	o := a.Group("/api/v1")
+	o.Use(LoginCheck())
+	o.POST("/cart/list", func(c *app.Context) {
+		c.JSONSuccess("ok")
+	})
+
+	ctx := testutil.Context(a)
+	customer, err := service.NewSessionService().CreateSession(ctx, "customer1", utils.TokenAudienceCustomer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin, err := service.NewSessionService().CreateSession(ctx, "user1", utils.TokenAudienceAdmin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		path  string
+		want  int
+	}{
+		{"customer on customer path", customer.Token, "/shop-api/v1/cart/list", http.StatusOK},
+		{"customer on admin path", customer.Token, "/shop-api/v1/product/delete", http.StatusForbidden},
+		{"customer on other prefix", customer.Token, "/api/v1/cart/list", http.StatusForbidden},
+		{"admin on own info", admin.Token, "/shop-api/v1/user/myinfo", http.StatusOK},
+		{"admin on unregistered path", admin.Token, "/shop-api/v1/product/delete", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.Header.Set("X-Token", tt.token)
+			w := httptest.NewRecorder()
+			a.Router.ServeHTTP(w, req)
+
+			res := &app.Response{}
+			err := json.Unmarshal(w.Body.Bytes(), res)
+			if err != nil {
+				t.Fatalf("%s: %s", tt.path, w.Body.String())
+			}
+			if res.Code != tt.want {
+				t.Errorf("code = %d %s, want %d", res.Code, res.Message, tt.want)
+			}
+		})
+	}
+}
@@ -5,15 +5,29 @@ import (
 	"net/http"
 	"sgin/pkg/app"
 	"strconv"
+	"sync"
 	"time"
 )
 
-// 防重放攻击中间件
+const defaultTimestampSkew = 60
+
+// 没有配置redis时在本地记录nonce，只对单实例有效
+var (
+	nonceMu     sync.Mutex
+	localNonces = make(map[string]time.Time)
+)
+
+// 防重放攻击中间件，时间戳超出允许误差或nonce在有效期内重复使用时拒绝
 func NonceHandler() app.HandlerFunc {
 	return func(c *app.Context) {
 
 		nonce := c.GetHeader("X-Nonce")
 		timestamp := c.GetHeader("X-Timestamp")
+		if nonce == "" {
+			c.JSONError(http.StatusForbidden, "nonce error")
+			c.Abort()
+			return
+		}
 
 		timestampInt, err := strconv.ParseInt(timestamp, 10, 64)
 		if err != nil {
@@ -23,32 +37,34 @@ func NonceHandler() app.HandlerFunc {
 		}
 
 		// 检查时间戳是否过期
-
-		if time.Now().Unix()-timestampInt > 60 {
+		skew := int64(c.Config.OpenAPI.TimestampSkew)
+		if skew <= 0 {
+			skew = defaultTimestampSkew
+		}
+		diff := time.Now().Unix() - timestampInt
+		if diff > skew || diff < -skew {
 			c.Logger.Error("timestamp expired", "server time", time.Now().Unix(), "client time", timestampInt)
 			c.JSONError(http.StatusForbidden, "timestamp expired")
 			c.Abort()
 			return
 		}
 
-		// 检查nonce是否已经存在
-		noncestr := fmt.Sprintf("Nonce_%s", nonce)
+		// 检查nonce是否已经存在，有效期覆盖时间戳允许的范围
+		noncestr := fmt.Sprintf("Nonce_%s_%s", c.GetString("app_id"), nonce)
+		expire := time.Duration(2*skew) * time.Second
 
-		rval, err := c.Redis.Get(c, noncestr)
-		if err != nil {
-			c.JSONError(http.StatusForbidden, "nonce error")
-			c.Abort()
-			return
+		ok := true
+		if c.Redis != nil {
+			ok, err = c.Redis.SetNX(c.Ctx, noncestr, timestamp, expire)
+			if err != nil {
+				c.JSONError(http.StatusForbidden, "nonce error")
+				c.Abort()
+				return
+			}
+		} else {
+			ok = setLocalNonce(noncestr, expire)
 		}
-
-		if rval != "" {
-			c.JSONError(http.StatusForbidden, "nonce error")
-			c.Abort()
-			return
-		}
-
-		err = c.Redis.Set(c, noncestr, timestamp, 60*time.Second)
-		if err != nil {
+		if !ok {
 			c.JSONError(http.StatusForbidden, "nonce error")
 			c.Abort()
 			return
@@ -57,3 +73,24 @@ func NonceHandler() app.HandlerFunc {
 
 	}
 }
+
+func setLocalNonce(key string, expire time.Duration) bool {
+	nonceMu.Lock()
+	defer nonceMu.Unlock()
+
+	now := time.Now()
+	if expireAt, ok := localNonces[key]; ok && now.Before(expireAt) {
+		return false
+	}
+
+	// 数量较多时清理过期的nonce
+	if len(localNonces) >= 10000 {
+		for k, expireAt := range localNonces {
+			if !now.Before(expireAt) {
+				delete(localNonces, k)
+			}
+		}
+	}
+	localNonces[key] = now.Add(expire)
+	return true
+}
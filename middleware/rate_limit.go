@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"sgin/pkg/app"
+	"sgin/pkg/config"
+	"sgin/pkg/redisop"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const rateLimitKeyPrefix = "sgin:ratelimit:"
+
+// 没有配置时使用的默认规则
+var defaultRateLimitRules = []config.RateLimitRule{
+	{Name: "login", By: "ip", Rate: 10, Period: 60},
+	{Name: "two_factor", By: "ip", Rate: 10, Period: 60},
+	{Name: "captcha", By: "ip", Rate: 20, Period: 60},
+	{Name: "register", By: "ip", Rate: 5, Period: 60},
+	{Name: "verification_code", By: "ip", Rate: 5, Period: 60},
+	{Name: "checkout", By: "user", Rate: 20, Period: 60},
+}
+
+var (
+	limiterOnce sync.Once
+	limiter     *redisop.Limiter
+
+	// redis不可用时每分钟最多记录一次错误
+	limiterErrorLoggedAt int64
+)
+
+// 所有请求共用一个限流器，redis客户端在启动时创建
+func getLimiter(c *app.Context) *redisop.Limiter {
+	limiterOnce.Do(func() {
+		limiter = redisop.NewLimiter(c.Redis)
+	})
+	return limiter
+}
+
+// RateLimit 限流中间件，按名称使用配置的限流规则，按用户限流时放在LoginCheck之后
+func RateLimit(name string) app.HandlerFunc {
+	return func(c *app.Context) {
+		rule, ok := getRateLimitRule(c, name)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		period := rule.Period
+		if period <= 0 {
+			period = 60
+		}
+		burst := rule.Burst
+		if burst <= 0 {
+			burst = int(math.Ceil(rule.Rate))
+		}
+		limit := redisop.Limit{
+			Rate:   rule.Rate,
+			Period: time.Duration(period) * time.Second,
+			Burst:  burst,
+		}
+
+		if !allowRate(c, name+":"+rateLimitSubject(c, rule.By), limit) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// 配置中的规则优先，没有配置时使用默认规则
+func getRateLimitRule(c *app.Context, name string) (config.RateLimitRule, bool) {
+	for _, rule := range c.Config.RateLimit.Rules {
+		if rule.Name == name {
+			return rule, rule.Rate > 0
+		}
+	}
+	for _, rule := range defaultRateLimitRules {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return config.RateLimitRule{}, false
+}
+
+// 限流维度的值，用户和app未登录时按ip限流
+func rateLimitSubject(c *app.Context, by string) string {
+	switch by {
+	case "user":
+		if userId := c.GetString("user_id"); userId != "" {
+			return "user:" + userId
+		}
+	case "app":
+		if appId := c.GetString("app_id"); appId != "" {
+			return "app:" + appId
+		}
+	case "route":
+		return "route:" + c.Request.Method + ":" + c.FullPath()
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// 检查限流并设置X-RateLimit-*响应头，超出限制时设置Retry-After并返回429
+func allowRate(c *app.Context, key string, limit redisop.Limit) bool {
+	res, err := getLimiter(c).Allow(c.Ctx, rateLimitKeyPrefix+key, limit)
+	if err != nil {
+		now := time.Now().Unix()
+		last := atomic.LoadInt64(&limiterErrorLoggedAt)
+		if now-last >= 60 && atomic.CompareAndSwapInt64(&limiterErrorLoggedAt, last, now) {
+			c.Logger.Error("Failed to check rate limit with redis, using local limiter", err)
+		}
+	}
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+	if !res.Allowed {
+		c.Header("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+		c.JSONError(http.StatusTooManyRequests, "too many requests")
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -2,29 +2,24 @@ package middleware
 
 import (
 	"bytes"
+	"crypto/hmac"
 	"io/ioutil"
 	"sgin/pkg/app"
 	"sgin/pkg/utils"
-	"sgin/service"
 )
 
-// 签名校验中间件
+// 签名校验中间件，在AppKeyCheck之后使用
+// 签名为 utils.SignRequest(请求方法, 请求路径和参数, X-Timestamp, X-Nonce, body, SecKey)，通过X-Signature传递
 func Signature() app.HandlerFunc {
 	return func(c *app.Context) {
 		signature := c.GetHeader("X-Signature")
 		if signature == "" {
-			c.Next()
-			return
-		}
-
-		appId := c.GetHeader("X-App-Id")
-		if appId == "" {
-			c.JSONError(403, "X-App-Id is empty")
+			c.JSONError(403, "X-Signature is empty")
 			c.Abort()
 			return
 		}
 
-		appInfo, err := service.NewAppService().GetAppByUUID(c, appId)
+		appInfo, err := getAppInfo(c)
 		if err != nil {
 			c.JSONError(403, err.Error())
 			c.Abort()
@@ -34,16 +29,17 @@ func Signature() app.HandlerFunc {
 		body, err := ioutil.ReadAll(c.Request.Body)
 		if err != nil {
 			c.JSONError(403, err.Error())
+			c.Abort()
 			return
 		}
 
 		// 将 body 内容写回
 		c.Request.Body = ioutil.NopCloser(bytes.NewBuffer(body))
 
-		serverSign := utils.SignBody(body, []byte(appInfo.ApiKey))
+		serverSign := utils.SignRequest(c.Request.Method, c.Request.URL.RequestURI(), c.GetHeader("X-Timestamp"), c.GetHeader("X-Nonce"), body, []byte(appInfo.SecKey))
 
-		if serverSign != signature {
-			c.Logger.Error("signature is invalid", "serverSign:", serverSign, "signature:", signature)
+		if !hmac.Equal([]byte(serverSign), []byte(signature)) {
+			c.Logger.Error("signature is invalid", "app:", appInfo.UUID)
 			c.JSONError(403, "signature is invalid")
 			c.Abort()
 			return
@@ -21,6 +21,7 @@ var opFilterPath = []string{
 	"/swagger/",
 	"/api/v1/sysoplog/list",
 	"/api/v1/user/myinfo",
+	"/api/v1/user/2fa/*",
 }
 
 // SysOpLogMiddleware 记录操作日志的中间件
@@ -33,19 +34,9 @@ func SysOpLogMiddleware(logservice *service.SysOpLogService) app.HandlerFunc {
 		method := c.Request.Method
 
 		// 过滤不需要记录的路径
-		for _, filterPath := range opFilterPath {
-			// 获取最后一个字符是否为*，如果是则表示前缀匹配
-			if filterPath[len(filterPath)-1] == '*' {
-				if len(path) >= len(filterPath)-1 && path[:len(filterPath)-1] == filterPath[:len(filterPath)-1] {
-					c.Next()
-					return
-				}
-			} else {
-				if path == filterPath {
-					c.Next()
-					return
-				}
-			}
+		if matchPath(opFilterPath, path) {
+			c.Next()
+			return
 		}
 
 		bodyBytes := []byte{}
@@ -100,3 +91,17 @@ func SysOpLogMiddleware(logservice *service.SysOpLogService) app.HandlerFunc {
 		}
 	}
 }
+
+// 路径是否在列表中，最后一个字符为*时表示前缀匹配
+func matchPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p[len(p)-1] == '*' {
+			if strings.HasPrefix(path, p[:len(p)-1]) {
+				return true
+			}
+		} else if path == p {
+			return true
+		}
+	}
+	return false
+}
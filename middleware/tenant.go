@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"sgin/pkg/app"
+	"sgin/pkg/utils"
+	"sgin/service"
+)
+
+// 多租户中间件，按请求的域名确定商城所属的团队
+// 后台用户登录后在LoginCheck中改为用户当前选择的团队
+func Tenant() app.HandlerFunc {
+	return func(c *app.Context) {
+		if !c.Config.Tenant.Enable {
+			return
+		}
+
+		tenantUuid, err := service.NewTenantService().GetTenantByHost(c, c.Request.Host)
+		if err != nil {
+			c.JSONError(http.StatusInternalServerError, err.Error())
+			c.Abort()
+			return
+		}
+		c.SetTenant(tenantUuid)
+	}
+}
+
+// 后台用户使用当前团队作为租户，商城客户使用域名对应的租户
+func setUserTenant(c *app.Context, claims *utils.TokenClaims) {
+	if !c.Config.Tenant.Enable || claims.Audience == utils.TokenAudienceCustomer {
+		return
+	}
+
+	tenantUuid, err := service.NewTenantService().GetTenantByUser(c, claims.UserID)
+	if err != nil {
+		c.JSONError(http.StatusInternalServerError, err.Error())
+		c.Abort()
+		return
+	}
+	c.SetTenant(tenantUuid)
+}
@@ -1,18 +1,76 @@
 package middleware
 
-import "sgin/pkg/app"
+import (
+	"net/http"
+	"sgin/pkg/app"
+	"sgin/pkg/auth"
+	"sgin/pkg/utils"
+	"sgin/service"
+	"sync"
+)
 
-// 用户权限中间件
+// 不需要角色权限的路径，商城客户可以调用的接口和登录用户自己的接口，不含接口前缀
+var userPermissionFilterPath = append([]string{
+	"/v1/user/myinfo",
+	"/v1/user/avatar",
+	"/v1/user/menus",
+	"/v1/user/teams",
+	"/v1/user/team/switch",
+	"/v1/user/session/*",
+	"/v1/user/2fa/*",
+	"/v1/logout",
+}, customerPath...)
 
+var (
+	casbinAuthorizer     app.HandlerFunc
+	casbinAuthorizerOnce sync.Once
+)
+
+// UserPermission 用户权限中间件，在LoginCheck之后使用
+// 根据用户在当前团队的角色和接口关联的菜单检查权限位，配置为casbin时使用casbin策略
 func UserPermission() app.HandlerFunc {
 	return func(c *app.Context) {
+		if matchAPIPath(c, userPermissionFilterPath) {
+			return
+		}
+
+		if c.Config.Auth.Authorizer == service.AuthorizerCasbin {
+			casbinPermission(c)
+			return
+		}
 
-		// 获取用户id
-		userId := c.GetString("user_id")
+		allowed, err := service.NewRbacService().CheckPermission(c, c.GetString("user_id"), c.GetString("token_audience"), c.Request.Method, c.Request.URL.Path)
+		if err != nil {
+			c.JSONError(http.StatusInternalServerError, err.Error())
+			c.Abort()
+			return
+		}
+		if !allowed {
+			c.JSONError(http.StatusForbidden, "没有权限")
+			c.Abort()
+			return
+		}
+	}
+}
 
-		// 获取api path
-		// 获取api method
+// 使用casbin策略检查权限，主体为当前用户，域为当前团队
+// 商城客户不能调用后台接口，不交给casbin判断
+func casbinPermission(c *app.Context) {
+	if c.GetString("token_audience") == utils.TokenAudienceCustomer {
+		c.JSONError(http.StatusForbidden, "没有权限")
+		c.Abort()
+		return
+	}
 
-		_ = userId
+	casbinService := service.NewCasbinService()
+	if err := casbinService.ReloadIfChanged(c); err != nil {
+		c.JSONError(http.StatusInternalServerError, err.Error())
+		c.Abort()
+		return
 	}
+
+	casbinAuthorizerOnce.Do(func() {
+		casbinAuthorizer = auth.NewAuthorizer(service.CasbinEnforcer(), casbinService.GetDomain)
+	})
+	casbinAuthorizer(c)
 }
@@ -17,3 +17,16 @@ type API struct {
 	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了API信息最后更新的时间
 	Status          int       `gorm:"type:int(1)" json:"status"`        // Status 0:未启用 1:启用 2:删除
 }
+
+// 接口同步结果
+type ResAPISync struct {
+	Added    int `json:"added"`    // 新增的接口数量，新增接口为未启用状态
+	Restored int `json:"restored"` // 重新出现并恢复为未启用的接口数量
+	Removed  int `json:"removed"`  // 标记删除的接口数量
+}
+
+// 路由和接口表的差异
+type ResAPIDiff struct {
+	Added   []*API `json:"added"`   // 新增或未启用的接口
+	Removed []*API `json:"removed"` // 已删除的接口
+}
@@ -4,6 +4,8 @@ package model
 type Cart struct {
 	ID   int64  `json:"id" gorm:"primary_key"`
 	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
 	// 用户ID
 	UserID string `json:"user_id" gorm:"index"`
 	// 产品ID
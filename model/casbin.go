@@ -0,0 +1,34 @@
+package model
+
+// 添加或删除casbin策略
+type ReqCasbinPolicy struct {
+	// 策略类型 p:权限策略 g:角色分配
+	Ptype string `json:"ptype" binding:"required"`
+	// p: 主体(当前团队的角色或成员uuid), 团队uuid(固定为当前团队), 路径, 方法(*表示所有方法)
+	// g: 用户uuid(当前团队成员), 角色uuid(当前团队的角色), 团队uuid(固定为当前团队)
+	Rule []string `json:"rule" binding:"required"`
+}
+
+// 查询当前团队的casbin策略
+type ReqCasbinPolicyQuery struct {
+	Ptype string `json:"ptype"` // 策略类型 p、g，为空时查询全部
+	Sub   string `json:"sub"`   // 主体
+}
+
+// 查询请求被拒绝的原因
+type ReqCasbinExplain struct {
+	UserUuid string `json:"user_uuid"`                 // 用户uuid，默认为当前用户，需要是当前团队的成员
+	TeamUuid string `json:"team_uuid"`                 // 团队uuid，只能为当前团队
+	Path     string `json:"path" binding:"required"`   // 接口路径
+	Method   string `json:"method" binding:"required"` // 请求方法
+}
+
+type ResCasbinExplain struct {
+	Allowed       bool       `json:"allowed"`        // 是否允许
+	UserUuid      string     `json:"user_uuid"`      // 用户uuid
+	TeamUuid      string     `json:"team_uuid"`      // 团队uuid
+	Roles         []string   `json:"roles"`          // 用户在团队中的角色
+	MatchedPolicy []string   `json:"matched_policy"` // 允许时匹配的策略
+	Policies      [][]string `json:"policies"`       // 用户和角色在团队中的全部策略
+	Reason        string     `json:"reason"`         // 说明
+}
@@ -41,15 +41,18 @@ const (
 	ConfigNameEmailSmtpUser = "email_smtp_user"
 	// SMTP 密码
 	ConfigNameEmailSmtpPass = "email_smtp_pass"
+	// 库存预警通知邮箱 多个用,分割
+	ConfigNameEmailStockWarning = "email_stock_warning"
 )
 
 type Configuration struct {
-	Id        int    `json:"id"`
-	Category  string `json:"category"`                                        // 配置分类
-	Name      string `json:"name"`                                            // 配置名称
-	Value     string `json:"value"`                                           // 配置值
-	CreatedAt string `json:"created_at" gorm:"autoCreateTime;comment:'创建时间'"` // 创建时间
-	UpdatedAt string `json:"updated_at" gorm:"autoUpdateTime;comment:'更新时间'"` // 更新时间
+	Id         int    `json:"id"`
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`       // 租户，所属团队uuid
+	Category   string `json:"category"`                                        // 配置分类
+	Name       string `json:"name"`                                            // 配置名称
+	Value      string `json:"value"`                                           // 配置值
+	CreatedAt  string `json:"created_at" gorm:"autoCreateTime;comment:'创建时间'"` // 创建时间
+	UpdatedAt  string `json:"updated_at" gorm:"autoUpdateTime;comment:'更新时间'"` // 更新时间
 }
 
 type ReqConfigCreate struct {
@@ -5,12 +5,13 @@ const (
 )
 
 type Currency struct {
-	ID     int64  `json:"id" gorm:"primary_key"`
-	Uuid   string `json:"uuid" gorm:"type:varchar(36);unique_index"` // 货币uuid
-	Name   string `json:"name" gorm:"type:varchar(100)"`             // 货币名称
-	Code   string `json:"code" gorm:"type:varchar(10)"`              // 货币代码
-	Symbol string `json:"symbol" gorm:"type:varchar(10)"`            // 货币符号
-	Status int    `json:"status"`                                    // 状态 1:启用 2:禁用
+	ID         int64  `json:"id" gorm:"primary_key"`
+	Uuid       string `json:"uuid" gorm:"type:varchar(36);unique_index"` // 货币uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"` // 租户，所属团队uuid
+	Name       string `json:"name" gorm:"type:varchar(100)"`             // 货币名称
+	Code       string `json:"code" gorm:"type:varchar(10)"`              // 货币代码
+	Symbol     string `json:"symbol" gorm:"type:varchar(10)"`            // 货币符号
+	Status     int    `json:"status"`                                    // 状态 1:启用 2:禁用
 }
 
 type ReqCurrencyCreate struct {
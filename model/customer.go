@@ -0,0 +1,105 @@
+package model
+
+const (
+	// 客户状态
+	CustomerStatusDisabled = 0 // 禁用
+	CustomerStatusEnabled  = 1 // 启用
+	CustomerStatusDeleted  = 2 // 已注销
+)
+
+// 商城客户，与后台用户分开存储，使用单独的登录接口和token受众
+type Customer struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:char(36);unique"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:char(36);uniqueIndex:idx_customer_tenant_email"`
+	// 邮箱，用于登录
+	Email    string `json:"email" gorm:"type:varchar(100);uniqueIndex:idx_customer_tenant_email"`
+	Password string `json:"-" gorm:"type:varchar(100)"`
+	// 昵称
+	Nickname string `json:"nickname" gorm:"type:varchar(50)"`
+	// 手机号
+	Phone string `json:"phone" gorm:"type:varchar(20)"`
+	// 头像
+	Avatar string `json:"avatar" gorm:"type:varchar(200)"`
+	// 状态 0:禁用 1:启用 2:已注销
+	Status int `json:"status" gorm:"type:int;index"`
+	// 邮箱验证时间
+	EmailVerifiedAt string `json:"email_verified_at"`
+	// 最近登录时间
+	LastLoginAt string `json:"last_login_at"`
+	// 最近登录IP
+	LastLoginIp string `json:"last_login_ip" gorm:"type:varchar(50)"`
+	// 注销时间
+	DeletedAt string `json:"deleted_at"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// 客户数据导出
+type CustomerExport struct {
+	Customer      *Customer        `json:"customer"`
+	Addresses     []*UserAddress   `json:"addresses"`
+	Carts         []*Cart          `json:"carts"`
+	Orders        []*Order         `json:"orders"`
+	OrderItems    []*OrderItem     `json:"order_items"`
+	Payments      []*Payment       `json:"payments"`
+	Subscriptions []*Subscription  `json:"subscriptions"`
+	Returns       []*ReturnRequest `json:"returns"`
+	ExportedAt    string           `json:"exported_at"`
+}
+
+// 客户注册，验证码通过验证码接口发送到邮箱
+type ReqCustomerRegister struct {
+	Email    string `json:"email" binding:"required"`    // 邮箱
+	Password string `json:"password" binding:"required"` // 密码
+	Code     string `json:"code" binding:"required"`     // 邮箱验证码
+	Nickname string `json:"nickname"`                    // 昵称
+	Phone    string `json:"phone"`                       // 手机号
+}
+
+type ReqCustomerLogin struct {
+	Email       string `json:"email" binding:"required"`    // 邮箱
+	Password    string `json:"password" binding:"required"` // 密码
+	CaptchaId   string `json:"captcha_id"`                  // 图形验证码ID，失败次数较多时需要
+	CaptchaCode string `json:"captcha_code"`                // 图形验证码
+}
+
+type ReqCustomerProfileUpdate struct {
+	Nickname string `json:"nickname"` // 昵称
+	Phone    string `json:"phone"`    // 手机号
+	Avatar   string `json:"avatar"`   // 头像
+}
+
+type ReqCustomerPasswordUpdate struct {
+	OldPassword string `json:"old_password" binding:"required"` // 原密码
+	NewPassword string `json:"new_password" binding:"required"` // 新密码
+}
+
+type ReqCustomerEmailParam struct {
+	Email string `json:"email" binding:"required"` // 邮箱
+}
+
+// 通过邮箱验证码重置密码
+type ReqCustomerPasswordReset struct {
+	Email    string `json:"email" binding:"required"`    // 邮箱
+	Code     string `json:"code" binding:"required"`     // 邮箱验证码
+	Password string `json:"password" binding:"required"` // 新密码
+}
+
+// 申请修改邮箱，验证码发送到新邮箱
+type ReqCustomerEmailChange struct {
+	Email    string `json:"email" binding:"required"`    // 新邮箱
+	Password string `json:"password" binding:"required"` // 当前密码
+}
+
+// 确认修改邮箱
+type ReqCustomerEmailConfirm struct {
+	Email string `json:"email" binding:"required"` // 新邮箱
+	Code  string `json:"code" binding:"required"`  // 新邮箱收到的验证码
+}
+
+// 注销账号
+type ReqCustomerDelete struct {
+	Password string `json:"password" binding:"required"` // 当前密码
+}
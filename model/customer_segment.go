@@ -0,0 +1,103 @@
+package model
+
+// 客户标签
+type CustomerTag struct {
+	ID int64 `json:"id" gorm:"primary_key"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:char(36);uniqueIndex:idx_customer_tag"`
+	// 客户uuid
+	CustomerUuid string `json:"customer_uuid" gorm:"type:char(36);uniqueIndex:idx_customer_tag"`
+	// 标签
+	Tag       string `json:"tag" gorm:"type:varchar(50);uniqueIndex:idx_customer_tag;index"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+}
+
+// 客户分群，保存常用的客户筛选条件，例如最近90天消费超过1000的客户
+type CustomerSegment struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:char(36);unique"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:char(36);index"`
+	// 分群名称
+	Name string `json:"name" gorm:"type:varchar(100)"`
+	// 分群描述
+	Description string `json:"description" gorm:"type:varchar(255)"`
+	// 筛选条件，CustomerFilter的json
+	Filter    string `json:"filter" gorm:"type:text"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// 客户筛选条件，消费统计只包含已支付的订单和付款，为空的条件不过滤
+type CustomerFilter struct {
+	Keyword         string   `json:"keyword"`           // 邮箱、昵称或手机号
+	Status          *int     `json:"status"`            // 客户状态 0:禁用 1:启用，为空时不包含已注销的客户
+	Tags            []string `json:"tags"`              // 包含任一标签
+	GroupUuid       string   `json:"group_uuid"`        // 客户分组uuid
+	Country         string   `json:"country"`           // 默认地址国家
+	Days            int      `json:"days"`              // 只统计最近天数内的订单和付款，为0时统计全部
+	MinOrders       *int     `json:"min_orders"`        // 最少订单数
+	MaxOrders       *int     `json:"max_orders"`        // 最多订单数
+	MinSpent        *float64 `json:"min_spent"`         // 最少消费金额
+	MaxSpent        *float64 `json:"max_spent"`         // 最多消费金额
+	LastOrderAfter  string   `json:"last_order_after"`  // 最近下单时间晚于
+	LastOrderBefore string   `json:"last_order_before"` // 最近下单时间早于
+	CreatedAfter    string   `json:"created_after"`     // 注册时间晚于
+	CreatedBefore   string   `json:"created_before"`    // 注册时间早于
+}
+
+// 客户及消费统计
+type CustomerSummary struct {
+	Customer
+	OrderCount        int64        `json:"order_count"`         // 已支付订单数
+	TotalSpent        float64      `json:"total_spent"`         // 消费金额，已支付金额减去退款金额
+	AverageOrderValue float64      `json:"average_order_value"` // 平均订单金额
+	FirstOrderAt      string       `json:"first_order_at"`      // 首次下单时间
+	LastOrderAt       string       `json:"last_order_at"`       // 最近下单时间
+	DefaultAddress    *UserAddress `json:"default_address" gorm:"-"`
+	Tags              []string     `json:"tags" gorm:"-"`
+}
+
+// 客户详情
+type CustomerDetail struct {
+	CustomerSummary
+	Addresses []*UserAddress `json:"addresses"` // 收货地址
+	Orders    []*OrderRes    `json:"orders"`    // 最近订单
+}
+
+// 标签及客户数
+type CustomerTagCount struct {
+	Tag   string `json:"tag"`   // 标签
+	Count int64  `json:"count"` // 客户数
+}
+
+type ReqCustomerQueryParam struct {
+	CustomerFilter
+	SegmentUuid string `json:"segment_uuid"` // 客户分群uuid，不为空时使用分群的筛选条件
+	SortBy      string `json:"sort_by"`      // 排序字段 order_count、total_spent、last_order_at、created_at，默认created_at
+	SortOrder   string `json:"sort_order"`   // 排序方式 asc、desc，默认desc
+	Pagination
+}
+
+type ReqCustomerTagParam struct {
+	CustomerUuids []string `json:"customer_uuids" binding:"required"` // 客户uuid列表
+	Tags          []string `json:"tags" binding:"required"`           // 标签列表
+}
+
+type ReqCustomerSegmentCreate struct {
+	Name        string         `json:"name" binding:"required"` // 分群名称
+	Description string         `json:"description"`             // 分群描述
+	Filter      CustomerFilter `json:"filter"`                  // 筛选条件
+}
+
+type ReqCustomerSegmentUpdate struct {
+	Uuid        string          `json:"uuid" binding:"required"` // 分群uuid
+	Name        string          `json:"name"`                    // 分群名称
+	Description string          `json:"description"`             // 分群描述
+	Filter      *CustomerFilter `json:"filter"`                  // 筛选条件，为空时不修改
+}
+
+type ReqCustomerSegmentQueryParam struct {
+	Name string `json:"name"` // 分群名称
+	Pagination
+}
@@ -0,0 +1,132 @@
+package model
+
+const (
+	// 虚拟产品，对应Product.Type
+	ProductKindVirtual = "virtual"
+)
+
+const (
+	// 许可证状态
+	LicenseKeyStatusAvailable = "available" // 可用
+	LicenseKeyStatusAssigned  = "assigned"  // 已分配
+	LicenseKeyStatusRevoked   = "revoked"   // 已作废
+)
+
+// 虚拟产品文件
+type ProductDigitalFile struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 产品uuid
+	ProductUuid string `json:"product_uuid" gorm:"type:varchar(36);index"`
+	// 产品SKU uuid，为空时对产品所有SKU生效
+	ProductItemUuid string `json:"product_item_uuid" gorm:"type:varchar(36);index"`
+	// 资源uuid
+	ResourceUuid string `json:"resource_uuid" gorm:"type:varchar(36);index"`
+	// 文件名称
+	Name string `json:"name" gorm:"type:varchar(100)"`
+	// 最大下载次数
+	MaxDownloads int `json:"max_downloads"`
+	// 下载有效天数
+	ExpireDays int    `json:"expire_days"`
+	CreatedAt  string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt  string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// 订单的下载授权
+type DigitalDownload struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 订单编号
+	OrderNo string `json:"order_no" gorm:"type:varchar(100);index"`
+	// 订单商品ID
+	OrderItemID int64 `json:"order_item_id" gorm:"index"`
+	// 用户ID
+	UserID string `json:"user_id" gorm:"index"`
+	// 产品SKU uuid
+	ProductItemUuid string `json:"product_item_uuid" gorm:"type:varchar(36);index"`
+	// 资源uuid
+	ResourceUuid string `json:"resource_uuid" gorm:"type:varchar(36)"`
+	// 文件名称
+	Name string `json:"name" gorm:"type:varchar(100)"`
+	// 最大下载次数
+	MaxDownloads int `json:"max_downloads"`
+	// 已下载次数
+	Downloads int `json:"downloads"`
+	// 过期时间
+	ExpiredAt string `json:"expired_at"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+type DigitalDownloadRes struct {
+	DigitalDownload
+	Url string `json:"url"` // 签名下载链接
+}
+
+// 许可证
+type LicenseKey struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 产品SKU uuid
+	ProductItemUuid string `json:"product_item_uuid" gorm:"type:varchar(36);index"`
+	// 许可证
+	Key string `json:"key" gorm:"type:varchar(255)"`
+	// 状态 available、assigned、revoked
+	Status string `json:"status" gorm:"type:varchar(20);index"`
+	// 订单编号
+	OrderNo string `json:"order_no" gorm:"type:varchar(100);index"`
+	// 订单商品ID
+	OrderItemID int64 `json:"order_item_id" gorm:"index"`
+	// 用户ID
+	UserID string `json:"user_id" gorm:"index"`
+	// 分配时间
+	AssignedAt string `json:"assigned_at"`
+	CreatedAt  string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt  string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// 订单的虚拟商品
+type DigitalOrderRes struct {
+	OrderNo     string                `json:"order_no"`
+	Downloads   []*DigitalDownloadRes `json:"downloads"`    // 下载列表
+	LicenseKeys []*LicenseKey         `json:"license_keys"` // 许可证列表
+}
+
+type ReqDigitalFileCreate struct {
+	ProductUuid     string `form:"product_uuid" binding:"required"` // 产品uuid
+	ProductItemUuid string `form:"product_item_uuid"`               // 产品SKU uuid
+	MaxDownloads    int    `form:"max_downloads"`                   // 最大下载次数
+	ExpireDays      int    `form:"expire_days"`                     // 下载有效天数
+}
+
+type ReqDigitalFileQueryParam struct {
+	ProductUuid string `json:"product_uuid" binding:"required"` // 产品uuid
+}
+
+type ReqLicenseKeyImport struct {
+	ProductItemUuid string   `json:"product_item_uuid" binding:"required"` // 产品SKU uuid
+	Keys            []string `json:"keys" binding:"required"`              // 许可证列表
+}
+
+type ReqLicenseKeyQueryParam struct {
+	ProductItemUuid string `json:"product_item_uuid"` // 产品SKU uuid
+	Status          string `json:"status"`            // 状态
+	OrderNo         string `json:"order_no"`          // 订单编号
+	Pagination
+}
+
+type ReqDigitalOrderParam struct {
+	OrderNo string `json:"order_no" binding:"required"` // 订单编号
+}
+
+type ReqDigitalDownloadParam struct {
+	Id      string `form:"id" binding:"required"`      // 下载授权uuid
+	Expires int64  `form:"expires" binding:"required"` // 过期时间戳
+	Sign    string `form:"sign" binding:"required"`    // 签名
+}
@@ -45,8 +45,49 @@ func MigrateDbTable(db *gorm.DB) {
 		&UserAddress{},
 		&Currency{},
 		&Page{},
+		&InventoryLog{},
+		&ProductGroupItem{},
+		&ProductDigitalFile{},
+		&DigitalDownload{},
+		&LicenseKey{},
+		&SubscriptionPlan{},
+		&Subscription{},
+		&CustomerGroup{},
+		&CustomerGroupUser{},
+		&PriceList{},
+		&PriceListItem{},
+		&Shipment{},
+		&ShipmentItem{},
+		&ShipmentEvent{},
+		&ReturnRequest{},
+		&ReturnItem{},
+		&Refund{},
+		&Invoice{},
+		&InvoiceSequence{},
+		&OrderNote{},
+		&OrderAudit{},
+		&OrderNoSequence{},
+		&Customer{},
+		&UserSession{},
+		&UserTwoFactor{},
+		&LoginChallenge{},
+		&TeamInvitation{},
+		&Webhook{},
+		&WebhookDelivery{},
+		&WebhookDeliveryAttempt{},
+		&LoginLock{},
+		&CustomerTag{},
+		&CustomerSegment{},
 	)
 
+	// 客户邮箱和页面路径改为在租户内唯一，删除原来的全局唯一索引
+	if db.Migrator().HasIndex(&Customer{}, "email") {
+		db.Migrator().DropIndex(&Customer{}, "email")
+	}
+	if db.Migrator().HasIndex(&Page{}, "slug") {
+		db.Migrator().DropIndex(&Page{}, "slug")
+	}
+
 	// 创建默认用户
 	var user User
 	// 查询用户是否存在
@@ -66,4 +107,35 @@ func MigrateDbTable(db *gorm.DB) {
 		}
 	}
 
+	// 没有团队时创建默认团队，拥有者为默认用户
+	// 接口权限按用户在当前团队的角色检查，默认用户需要有团队才能调用后台接口
+	var teamCount int64
+	err = db.Model(&Team{}).Count(&teamCount).Error
+	if err != nil {
+		log.Fatal("Failed to count teams", err)
+	}
+	if teamCount == 0 {
+		team := Team{
+			UUID:      uuid.New().String(),
+			OwnerUuid: user.Uuid,
+			Name:      "默认团队",
+			IsActive:  true,
+			Creater:   user.Uuid,
+		}
+		err = db.Transaction(func(tx *gorm.DB) error {
+			err := tx.Create(&team).Error
+			if err != nil {
+				return err
+			}
+			return tx.Create(&TeamMember{
+				UUID:          uuid.New().String(),
+				TeamUUID:      team.UUID,
+				UserUUID:      user.Uuid,
+				IsCurrentTeam: true,
+			}).Error
+		})
+		if err != nil {
+			log.Fatal("Failed to create default team", err)
+		}
+	}
 }
@@ -0,0 +1,70 @@
+package model
+
+const (
+	// 库存变动来源
+	InventorySourceOrder  = "order"  // 订单
+	InventorySourceManual = "manual" // 手动调整
+	InventorySourceImport = "import" // 导入
+	InventorySourceRefund = "refund" // 退款
+)
+
+// 库存流水，只追加不修改
+type InventoryLog struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 产品uuid
+	ProductUuid string `json:"product_uuid" gorm:"type:varchar(36);index"`
+	// 产品SKU uuid
+	ProductItemUuid string `json:"product_item_uuid" gorm:"type:varchar(36);index"`
+	// 变动来源 order、manual、import、refund
+	Source string `json:"source" gorm:"type:varchar(20);index"`
+	// 来源单号，例如订单号
+	SourceNo string `json:"source_no" gorm:"type:varchar(100);index"`
+	// 变动前库存
+	Before int64 `json:"before" gorm:"type:int"`
+	// 变动数量，正数入库，负数出库
+	Change int64 `json:"change" gorm:"type:int"`
+	// 变动后库存
+	After int64 `json:"after" gorm:"type:int"`
+	// 操作人
+	Actor string `json:"actor" gorm:"type:varchar(36);index"`
+	// 备注
+	Remark    string `json:"remark" gorm:"type:varchar(255)"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+}
+
+// 库存预警项
+type LowStockItem struct {
+	ProductUuid     string `json:"product_uuid"`
+	ProductName     string `json:"product_name"`
+	ProductItemUuid string `json:"product_item_uuid"`
+	// 产品变体
+	Variants string `json:"variants"`
+	// 当前库存
+	Stock int64 `json:"stock"`
+	// 警戒库存
+	StockWarning int64 `json:"stock_warning"`
+}
+
+type ReqInventoryLogQueryParam struct {
+	ProductUuid     string `json:"product_uuid"`      // 产品uuid
+	ProductItemUuid string `json:"product_item_uuid"` // 产品SKU uuid
+	Source          string `json:"source"`            // 变动来源
+	SourceNo        string `json:"source_no"`         // 来源单号
+	Pagination
+}
+
+// 库存调整
+type ReqInventoryAdjustParam struct {
+	ProductItemUuid string `json:"product_item_uuid" binding:"required"` // 产品SKU uuid
+	Change          int64  `json:"change" binding:"required"`            // 变动数量
+	Source          string `json:"source"`                               // 变动来源 manual、import，默认manual
+	Remark          string `json:"remark"`                               // 备注
+}
+
+type ReqLowStockQueryParam struct {
+	Name string `json:"name"` // 产品名称
+	Pagination
+}
@@ -0,0 +1,68 @@
+package model
+
+const (
+	// 打印单据类型
+	InvoiceDocInvoice     = "invoice"      // 发票
+	InvoiceDocPackingSlip = "packing_slip" // 装箱单
+)
+
+// 发票，每个订单一张，发票号按年连续编号
+type Invoice struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 发票号
+	InvoiceNo string `json:"invoice_no" gorm:"type:varchar(50);unique_index"`
+	// 年份
+	Year int `json:"year" gorm:"index"`
+	// 年内序号
+	Seq int64 `json:"seq"`
+	// 订单编号
+	OrderNo string `json:"order_no" gorm:"type:varchar(100);unique_index"`
+	// 用户ID
+	UserID string `json:"user_id" gorm:"index"`
+	// 货币代码
+	CurrencyCode string `json:"currency_code" gorm:"type:varchar(10)"`
+	// 货币符号
+	CurrencySymbol string `json:"currency_symbol" gorm:"type:varchar(10)"`
+	// 不含税金额
+	Subtotal float64 `json:"subtotal"`
+	// 优惠金额
+	DiscountAmount float64 `json:"discount_amount"`
+	// 税种名称
+	TaxName string `json:"tax_name" gorm:"type:varchar(50)"`
+	// 税率（百分比）
+	TaxRate float64 `json:"tax_rate"`
+	// 税额
+	TaxAmount float64 `json:"tax_amount"`
+	// 含税总金额，与订单金额一致
+	TotalAmount float64 `json:"total_amount"`
+	// 开票时间
+	IssuedAt  string `json:"issued_at"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+}
+
+// 发票号序列，每年一行，开票时加锁递增保证连续
+type InvoiceSequence struct {
+	Year      int    `json:"year" gorm:"primary_key;autoIncrement:false"`
+	LastSeq   int64  `json:"last_seq"`
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+type ReqInvoiceQueryParam struct {
+	InvoiceNo string `json:"invoice_no"` // 发票号
+	OrderNo   string `json:"order_no"`   // 订单编号
+	Year      int    `json:"year"`       // 年份
+	Pagination
+}
+
+type ReqInvoiceDownloadParam struct {
+	OrderNo string `form:"order_no" binding:"required"` // 订单编号
+}
+
+// 批量下载单据
+type ReqInvoiceBulkParam struct {
+	Type     string   `json:"type"`                         // 单据类型 invoice、packing_slip，默认invoice
+	OrderNos []string `json:"order_nos" binding:"required"` // 订单编号列表
+}
@@ -0,0 +1,50 @@
+package model
+
+const (
+	// 锁定类型
+	LoginLockTypeAccount = "account" // 账号
+	LoginLockTypeIp      = "ip"      // IP
+)
+
+// 登录锁定，记录账号或IP的连续登录失败和锁定状态
+// 后台用户和商城客户通过audience区分，商城客户按团队区分
+type LoginLock struct {
+	Id uint `gorm:"primary_key" json:"id"`
+	// 登录受众 admin:后台用户 customer:商城客户
+	Audience string `json:"audience" gorm:"type:varchar(20);uniqueIndex:idx_login_lock_subject"`
+	// 商城客户所属团队uuid，后台用户为空
+	TeamUuid string `json:"team_uuid" gorm:"type:char(36);uniqueIndex:idx_login_lock_subject"`
+	// 锁定类型 account:账号 ip:IP
+	Type string `json:"type" gorm:"type:varchar(20);uniqueIndex:idx_login_lock_subject"`
+	// 用户名、邮箱或IP
+	Subject string `json:"subject" gorm:"type:varchar(100);uniqueIndex:idx_login_lock_subject"`
+	// 统计窗口内的失败次数，没有配置redis时使用
+	Failures int `json:"failures" gorm:"type:int"`
+	// 统计窗口开始时间
+	WindowStart string `json:"window_start"`
+	// 连续锁定次数，用于计算锁定时长
+	LockCount int `json:"lock_count" gorm:"type:int"`
+	// 锁定截止时间，为空时未锁定
+	LockedUntil string `json:"locked_until" gorm:"index"`
+	// 最近失败时间
+	LastFailedAt string `json:"last_failed_at"`
+	// 最近失败IP
+	LastIp    string `json:"last_ip" gorm:"type:varchar(50)"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+type ReqLoginLockQueryParam struct {
+	Audience string `json:"audience"` // 登录受众 admin、customer
+	Type     string `json:"type"`     // 锁定类型 account、ip
+	Subject  string `json:"subject"`  // 用户名、邮箱或IP
+	All      bool   `json:"all"`      // 为true时包含已解除锁定的记录，默认只返回锁定中的记录
+	Pagination
+}
+
+// 图形验证码
+type CaptchaRes struct {
+	CaptchaId string `json:"captcha_id"` // 验证码ID，登录时与验证码一起提交
+	Image     string `json:"image"`      // 验证码图片，data:image/png;base64格式
+	Expire    int    `json:"expire"`     // 有效期（秒）
+}
@@ -1,5 +1,7 @@
 package model
 
+import "gorm.io/gorm"
+
 const (
 	// 订单状态
 	OrderStatusPending   = "pending"   // 待支付
@@ -12,7 +14,11 @@ const (
 // 订单
 type Order struct {
 	ID int64 `json:"id" gorm:"primary_key"`
-	// 订单编号
+	// 内部uuid，与对外展示的订单编号分开
+	Uuid string `json:"uuid" gorm:"type:varchar(36);index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 订单编号，按配置规则生成，例如 SG-20261017-000123
 	OrderNo string `json:"order_no" gorm:"type:varchar(100);unique_index"`
 	// 用户ID
 	UserID string `json:"user_id" gorm:"index"`
@@ -21,6 +27,9 @@ type Order struct {
 	// 订单状态 1:待支付 2:已支付 3:已发货 4:已完成 5:已关闭
 	Status string `json:"status" gorm:"default:1"`
 
+	// 虚拟订单，只包含虚拟产品，无需发货
+	IsVirtual bool `json:"is_virtual"`
+
 	// 收货人姓名
 	ReceiverName string `json:"receiver_name" gorm:"type:varchar(100)"`
 	// 收货人电话
@@ -50,9 +59,16 @@ type Order struct {
 	// 完成时间
 	CompletedAt string `json:"completed_at"`
 	// 关闭时间
-	ClosedAt  string `json:"closed_at"`
-	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
-	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+	ClosedAt string `json:"closed_at"`
+
+	// 拆分来源订单编号
+	SplitFrom string `json:"split_from" gorm:"type:varchar(100)"`
+	// 合并到的订单编号，合并后本订单关闭
+	MergedInto string `json:"merged_into" gorm:"type:varchar(100)"`
+
+	CreatedAt string         `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string         `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`                   // 软删除
 }
 
 type OrderRes struct {
@@ -60,6 +76,13 @@ type OrderRes struct {
 	Items []*OrderItemRes `json:"items"` // 订单商品
 }
 
+// 订单号序列，每个日期一行，全局序列为global
+type OrderNoSequence struct {
+	Name      string `json:"name" gorm:"type:varchar(50);primary_key"`
+	LastSeq   int64  `json:"last_seq"`
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
 // 收货人信息
 type OrderReceiver struct {
 	// 收货人姓名
@@ -88,6 +111,8 @@ type OrderReceiver struct {
 // 订单商品
 type OrderItem struct {
 	ID int64 `json:"id" gorm:"primary_key"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
 	// 订单ID
 	OrderID string `json:"order_id" gorm:"index"`
 	// 商品ID
@@ -118,14 +143,14 @@ type ReqOrderCreate struct {
 	UserId   string        `json:"user_id"`  // 用户ID
 	Receiver OrderReceiver `json:"receiver"` // 收货人信息
 
-	Items []ReqOrderItemCreate `json:"items"` // 订单商品列表
+	Items []ReqOrderItemCreate `json:"items" binding:"dive"` // 订单商品列表
 
 	CartUuids []string `json:"cart_uuids"` // 购物车ID列表
 }
 
 type ReqOrderItemCreate struct {
-	ProductItemID string `json:"product_item_id"` // 商品ID
-	Quantity      int    `json:"quantity"`        // 商品数量
+	ProductItemID string `json:"product_item_id"`                  // 商品ID
+	Quantity      int    `json:"quantity" binding:"required,gt=0"` // 商品数量
 }
 
 type ReqOrderQueryParam struct {
@@ -0,0 +1,87 @@
+package model
+
+const (
+	// 订单修改记录类型
+	OrderAuditUpdateReceiver = "update_receiver" // 修改收货信息
+	OrderAuditUpdateItems    = "update_items"    // 修改商品数量
+	OrderAuditSplit          = "split"           // 拆分订单
+	OrderAuditMerge          = "merge"           // 合并订单
+	OrderAuditNote           = "note"            // 添加备注
+	OrderAuditDelete         = "delete"          // 删除订单
+)
+
+// 订单备注
+type OrderNote struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 订单编号
+	OrderNo string `json:"order_no" gorm:"type:varchar(100);index"`
+	// 备注内容
+	Content string `json:"content" gorm:"type:varchar(1000)"`
+	// 买家可见，否则为内部备注
+	IsPublic bool `json:"is_public"`
+	// 添加人
+	Operator  string `json:"operator"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+}
+
+// 订单修改记录，只追加不修改
+type OrderAudit struct {
+	ID int64 `json:"id" gorm:"primary_key"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 订单编号
+	OrderNo string `json:"order_no" gorm:"type:varchar(100);index"`
+	// 修改类型
+	Action string `json:"action" gorm:"type:varchar(20)"`
+	// 修改前，json
+	Before string `json:"before" gorm:"type:text"`
+	// 修改后，json
+	After string `json:"after" gorm:"type:text"`
+	// 操作人
+	Operator  string `json:"operator"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+}
+
+// 修改收货信息
+type ReqOrderReceiverUpdate struct {
+	OrderNo  string        `json:"order_no" binding:"required"` // 订单编号
+	Receiver OrderReceiver `json:"receiver"`                    // 收货人信息
+}
+
+type ReqOrderItemQuantity struct {
+	OrderItemID int64 `json:"order_item_id" binding:"required"` // 订单商品ID
+	Quantity    int   `json:"quantity"`                         // 数量
+}
+
+// 修改商品数量，数量为0时删除该商品
+type ReqOrderItemsUpdate struct {
+	OrderNo string                  `json:"order_no" binding:"required"` // 订单编号
+	Items   []*ReqOrderItemQuantity `json:"items" binding:"required"`    // 商品数量
+}
+
+// 拆分订单，指定的商品数量移到新订单
+type ReqOrderSplit struct {
+	OrderNo string                  `json:"order_no" binding:"required"` // 订单编号
+	Items   []*ReqOrderItemQuantity `json:"items" binding:"required"`    // 移到新订单的商品数量
+}
+
+// 合并订单，其他订单的商品合并到目标订单
+type ReqOrderMerge struct {
+	TargetOrderNo string   `json:"target_order_no" binding:"required"` // 目标订单编号
+	OrderNos      []string `json:"order_nos" binding:"required"`       // 合并的订单编号
+}
+
+type ReqOrderNoteCreate struct {
+	OrderNo  string `json:"order_no" binding:"required"` // 订单编号
+	Content  string `json:"content" binding:"required"`  // 备注内容
+	IsPublic bool   `json:"is_public"`                   // 买家可见
+}
+
+type ReqOrderAuditQueryParam struct {
+	OrderNo string `json:"order_no"` // 订单编号
+	Action  string `json:"action"`   // 修改类型
+	Pagination
+}
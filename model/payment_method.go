@@ -4,6 +4,8 @@ package model
 type PaymentMethod struct {
 	ID   int64  `json:"id" gorm:"primary_key"`
 	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
 
 	// 支付方式名称
 	Name string `json:"name" gorm:"type:varchar(100)"`
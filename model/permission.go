@@ -1,13 +1,39 @@
 package model
 
+import "strings"
+
 // 定义权限位
 const (
 	Create = 1 << 3 // 创建权限: 1000 (8)
 	Read   = 1 << 2 // 查询权限: 100 (4)
 	Edit   = 1 << 1 // 编辑权限: 010 (2)
 	Delete = 1 << 0 // 删除权限: 001 (1)
+
+	// 全部权限
+	AllPermission = Create | Read | Edit | Delete
 )
 
+// ActionPermissionBit 根据接口路径的最后一段判断需要的权限位，GET请求和查询类接口为查询权限，无法判断时为编辑权限
+func ActionPermissionBit(method, path string) uint {
+	if method == "GET" {
+		return Read
+	}
+
+	action := path
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		action = path[i+1:]
+	}
+	switch action {
+	case "create", "add", "import", "upload":
+		return Create
+	case "delete", "remove":
+		return Delete
+	case "list", "info", "all", "download", "export", "report":
+		return Read
+	}
+	return Edit
+}
+
 // Permission 定义了权限的基础信息
 type Permission struct {
 	Id         uint   `gorm:"primary_key" json:"id"`                  // ID 是权限的主键
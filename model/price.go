@@ -0,0 +1,124 @@
+package model
+
+const (
+	// 价格表状态
+	PriceListStatusEnabled  = 1 // 启用
+	PriceListStatusDisabled = 2 // 禁用
+)
+
+// 客户分组，如批发、VIP
+type CustomerGroup struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 分组名称
+	Name string `json:"name" gorm:"type:varchar(100)"`
+	// 分组描述
+	Description string `json:"description" gorm:"type:varchar(255)"`
+	CreatedAt   string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt   string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// 客户分组成员
+type CustomerGroupUser struct {
+	ID int64 `json:"id" gorm:"primary_key"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 分组uuid
+	GroupUuid string `json:"group_uuid" gorm:"type:varchar(36);index"`
+	// 用户ID
+	UserID    string `json:"user_id" gorm:"index"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+}
+
+// 价格表，未指定客户分组时对所有客户生效，可用于限时促销
+type PriceList struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 价格表名称
+	Name string `json:"name" gorm:"type:varchar(100)"`
+	// 客户分组uuid，为空时对所有客户生效
+	CustomerGroupUuid string `json:"customer_group_uuid" gorm:"type:varchar(36);index"`
+	// 开始时间，为空时立即生效
+	StartAt string `json:"start_at"`
+	// 结束时间，为空时长期有效
+	EndAt string `json:"end_at"`
+	// 状态 1:启用 2:禁用
+	Status    int    `json:"status"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// 价格表商品价格
+type PriceListItem struct {
+	ID int64 `json:"id" gorm:"primary_key"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 价格表uuid
+	PriceListUuid string `json:"price_list_uuid" gorm:"type:varchar(36);index"`
+	// 产品SKU uuid
+	ProductItemUuid string `json:"product_item_uuid" gorm:"type:varchar(36);index"`
+	// 价格
+	Price     float64 `json:"price" gorm:"type:decimal(10,2)"`
+	CreatedAt string  `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string  `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+type PriceListRes struct {
+	PriceList
+	Items []*PriceListItem `json:"items"` // 商品价格
+}
+
+type ReqCustomerGroupCreate struct {
+	Name        string `json:"name" binding:"required"` // 分组名称
+	Description string `json:"description"`             // 分组描述
+}
+
+type ReqCustomerGroupUpdate struct {
+	Uuid        string `json:"uuid" binding:"required"` // 分组uuid
+	Name        string `json:"name"`                    // 分组名称
+	Description string `json:"description"`             // 分组描述
+}
+
+type ReqCustomerGroupQueryParam struct {
+	Name string `json:"name"` // 分组名称
+	Pagination
+}
+
+type ReqCustomerGroupUserParam struct {
+	GroupUuid string   `json:"group_uuid" binding:"required"` // 分组uuid
+	UserIds   []string `json:"user_ids" binding:"required"`   // 用户ID列表
+}
+
+type ReqPriceListItem struct {
+	ProductItemUuid string  `json:"product_item_uuid" binding:"required"` // 产品SKU uuid
+	Price           float64 `json:"price" binding:"required"`             // 价格
+}
+
+type ReqPriceListCreate struct {
+	Name              string              `json:"name" binding:"required"` // 价格表名称
+	CustomerGroupUuid string              `json:"customer_group_uuid"`     // 客户分组uuid
+	StartAt           string              `json:"start_at"`                // 开始时间
+	EndAt             string              `json:"end_at"`                  // 结束时间
+	Items             []*ReqPriceListItem `json:"items"`                   // 商品价格
+}
+
+type ReqPriceListUpdate struct {
+	Uuid              string              `json:"uuid" binding:"required"` // 价格表uuid
+	Name              string              `json:"name"`                    // 价格表名称
+	CustomerGroupUuid string              `json:"customer_group_uuid"`     // 客户分组uuid，为空时改为对所有客户生效
+	StartAt           string              `json:"start_at"`                // 开始时间
+	EndAt             string              `json:"end_at"`                  // 结束时间
+	Status            int                 `json:"status"`                  // 状态 1:启用 2:禁用
+	Items             []*ReqPriceListItem `json:"items"`                   // 商品价格，不为空时整体替换
+}
+
+type ReqPriceListQueryParam struct {
+	Name              string `json:"name"`                // 价格表名称
+	CustomerGroupUuid string `json:"customer_group_uuid"` // 客户分组uuid
+	Status            int    `json:"status"`              // 状态
+	Pagination
+}
@@ -25,6 +25,8 @@ type Product struct {
 	ProductBase
 	ID   int64  `json:"id" gorm:"primary_key"`
 	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
 	// 产品分类
 	ProductCategoryUuid string `json:"product_category_uuid" gorm:"type:varchar(36);index"`
 	// 产品名称
@@ -56,6 +58,9 @@ type Product struct {
 
 	CurrencyCode string `json:"currency_code" gorm:"type:varchar(10)"` // 货币代码
 
+	// 组合产品定价方式 sum、fixed
+	GroupPriceType string `json:"group_price_type" gorm:"type:varchar(20)"`
+
 	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
 	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
 
@@ -121,6 +126,7 @@ type ProductVariantsItem struct {
 type ProductVariants struct {
 	ID          int64  `json:"id" gorm:"primary_key"`
 	Uuid        string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	TenantUuid  string `json:"tenant_uuid" gorm:"type:varchar(36);index"` // 租户，所属团队uuid
 	ProductUuid string `json:"product_uuid" gorm:"index"`
 	// 产品变体名称
 	Name string `json:"name" gorm:"type:varchar(100)"`
@@ -139,6 +145,7 @@ type ProductVariantsRes struct {
 type ProductVariantsOption struct {
 	ID                  int64  `json:"id" gorm:"primary_key"`
 	Uuid                string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	TenantUuid          string `json:"tenant_uuid" gorm:"type:varchar(36);index"` // 租户，所属团队uuid
 	ProductUuid         string `json:"product_uuid" gorm:"index"`
 	ProductVariantsUuid string `json:"product_variants_uuid" gorm:"type:varchar(36);index"`
 	// 产品变体Option名称
@@ -155,6 +162,7 @@ type ProductVariantsOption struct {
 type ProductVariantsOptionValue struct {
 	ID                        int64  `json:"id" gorm:"primary_key"`
 	Uuid                      string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	TenantUuid                string `json:"tenant_uuid" gorm:"type:varchar(36);index"` // 租户，所属团队uuid
 	ProductVariantsOptionUuid string `json:"product_variants_option_uuid" gorm:"type:varchar(36);index"`
 	// 产品变体Option值名称
 	Name string `json:"name" gorm:"type:varchar(100)"`
@@ -171,6 +179,8 @@ type ProductItem struct {
 	ProductBase
 	ID   int64  `json:"id" gorm:"primary_key"`
 	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
 	// 产品名称
 	Name string `json:"name" gorm:"type:varchar(100)"`
 	// 产品uuid
@@ -202,15 +212,26 @@ type ProductItem struct {
 	// 产品库存
 	Stock int64 `json:"stock" gorm:"type:int"`
 
+	// 当前折扣价的结束时间，由价格表计算，不存储
+	DiscountEndAt string `json:"discount_end_at" gorm:"-"`
+
 	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
 	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
 }
 
+// SalePrice 实际售价，有折扣价时使用折扣价
+func (p *ProductItem) SalePrice() float64 {
+	if p.DiscountPrice > 0 && p.DiscountPrice < p.Price {
+		return p.DiscountPrice
+	}
+	return p.Price
+}
+
 type ProductItemByPrice []*ProductItem
 
 func (a ProductItemByPrice) Len() int           { return len(a) }
 func (a ProductItemByPrice) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a ProductItemByPrice) Less(i, j int) bool { return a[i].Price < a[j].Price }
+func (a ProductItemByPrice) Less(i, j int) bool { return a[i].SalePrice() < a[j].SalePrice() }
 
 type ProductItemRes struct {
 	ProductItem
@@ -219,12 +240,12 @@ type ProductItemRes struct {
 	VariantsInfo []ProductVariantsItem `json:"variants_info"` // 产品变体信息
 }
 
-// Implementing sort.Interface for []ProductItemRes based on the Price field
+// Implementing sort.Interface for []ProductItemRes based on the sale price
 type ProductItemResByPrice []*ProductItemRes
 
 func (a ProductItemResByPrice) Len() int           { return len(a) }
 func (a ProductItemResByPrice) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
-func (a ProductItemResByPrice) Less(i, j int) bool { return a[i].Price < a[j].Price }
+func (a ProductItemResByPrice) Less(i, j int) bool { return a[i].SalePrice() < a[j].SalePrice() }
 
 type ReqProdcutItemCommonCreate struct {
 	// 产品价格
@@ -290,8 +311,8 @@ type ReqProductItemUpdate struct {
 	Discount float64 `json:"discount" binding:"required"`
 	// 产品折扣价
 	DiscountPrice float64 `json:"discount_price" binding:"required"`
-	// 产品库存
-	Stock int64 `json:"stock" binding:"required"`
+	// 产品库存，为空时不修改，可以设置为0
+	Stock *int64 `json:"stock"`
 	// 产品图片
 	Images []string `json:"images" binding:"required"`
 	// 产品视频
@@ -328,6 +349,12 @@ type ReqProductCreate struct {
 	// 产品变体值
 	VariantsVals []map[string]interface{} `json:"variants_vals" binding:"-"`
 
+	// 组合产品定价方式 sum、fixed
+	GroupPriceType string `json:"group_price_type" binding:"-"`
+
+	// 组合产品组件
+	GroupItems []*ReqProductGroupItemCreate `json:"group_items" binding:"-"`
+
 	Unit string `json:"unit" binding:"-"` // 单位 例如: 个、件、套、箱
 
 	// 产品上架状态
@@ -0,0 +1,53 @@
+package model
+
+const (
+	// 组合产品定价方式
+	ProductGroupPriceSum   = "sum"   // 组件价格之和
+	ProductGroupPriceFixed = "fixed" // 固定价格
+)
+
+// 组合产品组件
+type ProductGroupItem struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 组合产品uuid
+	ProductUuid string `json:"product_uuid" gorm:"type:varchar(36);index"`
+	// 组合产品的SKU uuid
+	ProductItemUuid string `json:"product_item_uuid" gorm:"type:varchar(36);index"`
+	// 组件SKU uuid
+	ComponentItemUuid string `json:"component_item_uuid" gorm:"type:varchar(36);index"`
+	// 组件数量
+	Quantity  int64  `json:"quantity" gorm:"type:int"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+type ProductGroupItemRes struct {
+	ProductGroupItem
+	ComponentItem *ProductItemRes `json:"component_item"` // 组件SKU信息
+}
+
+// 组合产品信息
+type ProductGroupRes struct {
+	ProductUuid     string                 `json:"product_uuid"`
+	ProductItemUuid string                 `json:"product_item_uuid"`
+	GroupPriceType  string                 `json:"group_price_type"` // 定价方式 sum、fixed
+	Price           float64                `json:"price"`            // 组合价格
+	Stock           int64                  `json:"stock"`            // 可售数量，由组件库存计算
+	Items           []*ProductGroupItemRes `json:"items"`
+}
+
+type ReqProductGroupItemCreate struct {
+	ProductItemUuid string `json:"product_item_uuid" binding:"required"` // 组件SKU uuid
+	Quantity        int64  `json:"quantity" binding:"required"`          // 组件数量
+}
+
+// 更新组合产品组件
+type ReqProductGroupUpdate struct {
+	ProductUuid    string                       `json:"product_uuid" binding:"required"` // 组合产品uuid
+	GroupPriceType string                       `json:"group_price_type"`                // 定价方式 sum、fixed
+	Price          float64                      `json:"price"`                           // 固定价格
+	Items          []*ReqProductGroupItemCreate `json:"items" binding:"required"`        // 组件列表
+}
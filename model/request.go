@@ -24,6 +24,10 @@ type ReqUserLogin struct {
 	Username string `json:"username" binding:"required"`
 	// 密码
 	Password string `json:"password" binding:"required"`
+	// 图形验证码ID，失败次数较多时需要
+	CaptchaId string `json:"captcha_id"`
+	// 图形验证码
+	CaptchaCode string `json:"captcha_code"`
 }
 
 type ReqUserQueryParam struct {
@@ -69,6 +73,15 @@ type ReqAppQueryParam struct {
 	Pagination
 }
 
+// 更新app参数，所属团队和密钥不能修改
+type ReqAppUpdate struct {
+	Uuid      string  `json:"uuid" binding:"required"`    // app uuid
+	Name      string  `json:"name"`                       // 名称
+	Status    int     `json:"status"`                     // 状态
+	RateLimit float64 `json:"rate_limit" binding:"gte=0"` // 每秒请求数，0使用默认配置
+	RateBurst int     `json:"rate_burst" binding:"gte=0"` // 突发请求数，0使用默认配置
+}
+
 // uuid参数
 type ReqUuidParam struct {
 	Uuid  string   `json:"uuid"`
@@ -81,10 +94,17 @@ type ReqApiPermissionParam struct {
 	Pagination
 }
 
+// 设置app的接口权限
+type ReqAppPermissionSet struct {
+	AppUuid  string   `json:"app_uuid" binding:"required"` // app uuid
+	ApiUuids []string `json:"api_uuids"`                   // 授权的接口uuid列表
+}
+
 type ReqVerificationCodeParam struct {
-	Email string `json:"email"`
-	Phone string `json:"phone"`
-	Code  string `json:"code"`
+	Email   string `json:"email"`
+	Phone   string `json:"phone"`
+	Code    string `json:"code"`
+	Purpose string `json:"purpose"` // 用途 register、login，默认register
 }
 
 type ReqRegisterParam struct {
@@ -107,6 +127,22 @@ type ReqTeamQueryParam struct {
 	Pagination
 }
 
+// 创建团队参数，拥有者为当前用户
+type ReqTeamCreate struct {
+	Name   string `json:"name" binding:"required"` // 团队名称
+	Icon   string `json:"icon"`                    // 图标
+	Desc   string `json:"desc"`                    // 描述
+	Domain string `json:"domain"`                  // 商城域名
+}
+
+// 更新当前团队参数，拥有者通过转让修改
+type ReqTeamUpdate struct {
+	Name   string `json:"name" binding:"required"` // 团队名称
+	Icon   string `json:"icon"`                    // 图标
+	Desc   string `json:"desc"`                    // 描述
+	Domain string `json:"domain"`                  // 商城域名
+}
+
 // 创建团队成员参数
 type ReqTeamMemberCreateParam struct {
 	TeamUUID string `json:"team_uuid"`
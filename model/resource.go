@@ -9,6 +9,8 @@ const (
 type Resource struct {
 	ID   int64  `json:"id" gorm:"primary_key"`
 	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
 	// 资源名称
 	Name string `json:"name" gorm:"type:varchar(100)"`
 	// 资源描述
@@ -33,6 +35,9 @@ type Resource struct {
 	// 文件路径
 	Address string `json:"address" gorm:"type:varchar(255)"` // 文件路径
 
+	// 私有文件，保存在Digital.Dir下，不能通过/public访问
+	IsPrivate bool `json:"is_private" gorm:"default:false"`
+
 	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
 	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
 }
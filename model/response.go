@@ -1,5 +1,7 @@
 package model
 
+import "sgin/pkg/auth"
+
 type PagedResponse struct {
 	Data     interface{} `json:"data"`
 	Current  int         `json:"current"`
@@ -8,7 +10,14 @@ type PagedResponse struct {
 }
 
 type ResUserLogin struct {
-	Token string `json:"token"`
+	Step         string `json:"step"`          // 登录步骤 done、two_factor、setup
+	Token        string `json:"token"`         // 访问token
+	RefreshToken string `json:"refresh_token"` // 刷新token，每次刷新后更换
+	ExpiresIn    int64  `json:"expires_in"`    // 访问token有效期（秒）
+	// 需要两步验证时返回，此时不返回token
+	Challenge *ResLoginChallenge `json:"challenge,omitempty"`
+	// 登录时绑定两步验证后返回的恢复码，只显示一次
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
 }
 
 type BaseResponse struct {
@@ -332,3 +341,274 @@ type WechatOrderDetailResponse struct {
 	// TODO 微信支付订单详情
 	Data string `json:"data"`
 }
+
+// InventoryLogInfoResponse
+type InventoryLogInfoResponse struct {
+	BaseResponse
+	Data InventoryLog `json:"data"`
+}
+
+// InventoryLogQueryResponse
+type InventoryLogQueryResponse struct {
+	BasePageResponse
+	Data []InventoryLog `json:"data"`
+}
+
+// LowStockQueryResponse
+type LowStockQueryResponse struct {
+	BasePageResponse
+	Data []LowStockItem `json:"data"`
+}
+
+// ProductGroupInfoResponse
+type ProductGroupInfoResponse struct {
+	BaseResponse
+	Data ProductGroupRes `json:"data"`
+}
+
+// ProductDigitalFileInfoResponse
+type ProductDigitalFileInfoResponse struct {
+	BaseResponse
+	Data ProductDigitalFile `json:"data"`
+}
+
+// ProductDigitalFileListResponse
+type ProductDigitalFileListResponse struct {
+	BaseResponse
+	Data []ProductDigitalFile `json:"data"`
+}
+
+// LicenseKeyQueryResponse
+type LicenseKeyQueryResponse struct {
+	BasePageResponse
+	Data []LicenseKey `json:"data"`
+}
+
+// DigitalOrderInfoResponse
+type DigitalOrderInfoResponse struct {
+	BaseResponse
+	Data DigitalOrderRes `json:"data"`
+}
+
+// SubscriptionPlanInfoResponse
+type SubscriptionPlanInfoResponse struct {
+	BaseResponse
+	Data SubscriptionPlan `json:"data"`
+}
+
+// SubscriptionPlanQueryResponse
+type SubscriptionPlanQueryResponse struct {
+	BasePageResponse
+	Data []SubscriptionPlan `json:"data"`
+}
+
+// SubscriptionInfoResponse
+type SubscriptionInfoResponse struct {
+	BaseResponse
+	Data Subscription `json:"data"`
+}
+
+// SubscriptionQueryResponse
+type SubscriptionQueryResponse struct {
+	BasePageResponse
+	Data []SubscriptionRes `json:"data"`
+}
+
+// PriceListInfoResponse
+type PriceListInfoResponse struct {
+	BaseResponse
+	Data PriceListRes `json:"data"`
+}
+
+// PriceListQueryResponse
+type PriceListQueryResponse struct {
+	BasePageResponse
+	Data []PriceList `json:"data"`
+}
+
+// CustomerGroupInfoResponse
+type CustomerGroupInfoResponse struct {
+	BaseResponse
+	Data CustomerGroup `json:"data"`
+}
+
+// CustomerGroupQueryResponse
+type CustomerGroupQueryResponse struct {
+	BasePageResponse
+	Data []CustomerGroup `json:"data"`
+}
+
+// ShipmentInfoResponse
+type ShipmentInfoResponse struct {
+	BaseResponse
+	Data ShipmentRes `json:"data"`
+}
+
+// ShipmentListResponse
+type ShipmentListResponse struct {
+	BaseResponse
+	Data []ShipmentRes `json:"data"`
+}
+
+// ShipmentQueryResponse
+type ShipmentQueryResponse struct {
+	BasePageResponse
+	Data []ShipmentRes `json:"data"`
+}
+
+// OrderTrackingInfoResponse
+type OrderTrackingInfoResponse struct {
+	BaseResponse
+	Data OrderTrackingRes `json:"data"`
+}
+
+// StringListResponse
+type StringListResponse struct {
+	BaseResponse
+	Data []string `json:"data"`
+}
+
+// ReturnInfoResponse
+type ReturnInfoResponse struct {
+	BaseResponse
+	Data ReturnRequestRes `json:"data"`
+}
+
+// ReturnQueryResponse
+type ReturnQueryResponse struct {
+	BasePageResponse
+	Data []ReturnRequestRes `json:"data"`
+}
+
+// RefundInfoResponse
+type RefundInfoResponse struct {
+	BaseResponse
+	Data Refund `json:"data"`
+}
+
+// ReturnReasonReportResponse
+type ReturnReasonReportResponse struct {
+	BaseResponse
+	Data []ReturnReasonReport `json:"data"`
+}
+
+// InvoiceQueryResponse
+type InvoiceQueryResponse struct {
+	BasePageResponse
+	Data []Invoice `json:"data"`
+}
+
+// OrderNoteInfoResponse
+type OrderNoteInfoResponse struct {
+	BaseResponse
+	Data OrderNote `json:"data"`
+}
+
+// OrderNoteListResponse
+type OrderNoteListResponse struct {
+	BaseResponse
+	Data []OrderNote `json:"data"`
+}
+
+// OrderAuditQueryResponse
+type OrderAuditQueryResponse struct {
+	BasePageResponse
+	Data []OrderAudit `json:"data"`
+}
+
+type CustomerInfoResponse struct {
+	BaseResponse
+	Data Customer `json:"data"`
+}
+
+type UserSessionListResponse struct {
+	BaseResponse
+	Data []UserSessionRes `json:"data"`
+}
+
+// TwoFactorStatusResponse
+type TwoFactorStatusResponse struct {
+	BaseResponse
+	Data ResTwoFactorStatus `json:"data"`
+}
+
+// TotpSetupResponse
+type TotpSetupResponse struct {
+	BaseResponse
+	Data ResTotpSetup `json:"data"`
+}
+
+type IntDataResponse struct {
+	BaseResponse
+	Data int `json:"data"`
+}
+
+type CasbinRuleListResponse struct {
+	BaseResponse
+	Data []auth.CasbinRule `json:"data"`
+}
+
+type CasbinExplainResponse struct {
+	BaseResponse
+	Data ResCasbinExplain `json:"data"`
+}
+
+type APISyncResponse struct {
+	BaseResponse
+	Data ResAPISync `json:"data"`
+}
+
+type APIDiffResponse struct {
+	BaseResponse
+	Data ResAPIDiff `json:"data"`
+}
+
+type TeamInvitationResponse struct {
+	BaseResponse
+	Data TeamInvitation `json:"data"`
+}
+
+type TeamInvitationInfoResponse struct {
+	BaseResponse
+	Data TeamInvitationInfo `json:"data"`
+}
+
+type WebhookResponse struct {
+	BaseResponse
+	Data Webhook `json:"data"`
+}
+
+type WebhookDeliveryResponse struct {
+	BaseResponse
+	Data WebhookDeliveryRes `json:"data"`
+}
+
+type OrderResInfoResponse struct {
+	BaseResponse
+	Data OrderRes `json:"data"`
+}
+
+type APIListResponse struct {
+	BaseResponse
+	Data []API `json:"data"`
+}
+
+type CaptchaResponse struct {
+	BaseResponse
+	Data CaptchaRes `json:"data"`
+}
+
+type CustomerDetailResponse struct {
+	BaseResponse
+	Data CustomerDetail `json:"data"`
+}
+
+type CustomerTagListResponse struct {
+	BaseResponse
+	Data []CustomerTagCount `json:"data"`
+}
+
+type CustomerSegmentInfoResponse struct {
+	BaseResponse
+	Data CustomerSegment `json:"data"`
+}
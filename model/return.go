@@ -0,0 +1,183 @@
+package model
+
+const (
+	// 退货申请状态
+	ReturnStatusRequested = "requested" // 待审核
+	ReturnStatusApproved  = "approved"  // 已同意，等待买家寄回
+	ReturnStatusRejected  = "rejected"  // 已拒绝
+	ReturnStatusReceived  = "received"  // 已收到退货
+	ReturnStatusRefunded  = "refunded"  // 已退款
+	ReturnStatusExchanged = "exchanged" // 已换货
+	ReturnStatusCanceled  = "canceled"  // 买家已取消
+
+	// 退货处理方式
+	ReturnResolutionRefund   = "refund"   // 退款
+	ReturnResolutionExchange = "exchange" // 换货
+
+	// 退款状态
+	RefundStatusSucceeded = "succeeded" // 退款成功
+	RefundStatusFailed    = "failed"    // 退款失败
+	RefundStatusManual    = "manual"    // 支付方式不支持原路退款，需线下退款
+)
+
+// 退货申请(RMA)
+type ReturnRequest struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 订单编号
+	OrderNo string `json:"order_no" gorm:"type:varchar(100);index"`
+	// 用户ID
+	UserID string `json:"user_id" gorm:"index"`
+	// 退货原因
+	Reason string `json:"reason" gorm:"type:varchar(100);index"`
+	// 问题描述
+	Description string `json:"description" gorm:"type:varchar(1000)"`
+	// 图片，上传接口返回的文件路径，json数组
+	Images string `json:"images" gorm:"type:text"`
+	// 期望处理方式 refund、exchange
+	Resolution string `json:"resolution" gorm:"type:varchar(20)"`
+	// 状态 requested、approved、rejected、received、refunded、exchanged、canceled
+	Status string `json:"status" gorm:"type:varchar(20);index"`
+	// 退款金额
+	RefundAmount float64 `json:"refund_amount"`
+	// 换货订单编号
+	ExchangeOrderNo string `json:"exchange_order_no" gorm:"type:varchar(100)"`
+	// 收货后是否已退回库存
+	Restocked bool `json:"restocked"`
+	// 处理备注，会通知买家
+	AdminRemark string `json:"admin_remark" gorm:"type:varchar(255)"`
+	// 处理人
+	Operator string `json:"operator"`
+
+	// 审核时间
+	ReviewedAt string `json:"reviewed_at"`
+	// 收货时间
+	ReceivedAt string `json:"received_at"`
+	// 完成时间
+	CompletedAt string `json:"completed_at"`
+	CreatedAt   string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt   string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// 退货商品
+type ReturnItem struct {
+	ID int64 `json:"id" gorm:"primary_key"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 退货申请uuid
+	ReturnUuid string `json:"return_uuid" gorm:"type:varchar(36);index"`
+	// 订单编号
+	OrderNo string `json:"order_no" gorm:"type:varchar(100);index"`
+	// 订单商品ID
+	OrderItemID int64 `json:"order_item_id" gorm:"index"`
+	// 产品SKU uuid
+	ProductItemUuid string `json:"product_item_uuid" gorm:"type:varchar(36);index"`
+	// 退货数量
+	Quantity int `json:"quantity"`
+	// 退款金额，按订单商品实付单价计算
+	Amount    float64 `json:"amount"`
+	CreatedAt string  `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+}
+
+// 退款记录
+type Refund struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 用户ID
+	UserID string `json:"user_id" gorm:"index"`
+	// 订单编号
+	OrderID string `json:"order_id" gorm:"index"`
+	// 付款uuid
+	PaymentUuid string `json:"payment_uuid" gorm:"type:varchar(36)"`
+	// 退货申请uuid
+	ReturnUuid string `json:"return_uuid" gorm:"type:varchar(36);index"`
+	// 退款金额
+	Amount float64 `json:"amount"`
+	// 退款方式，与付款方式一致
+	Method string `json:"method"`
+	// 状态 succeeded、failed、manual
+	Status string `json:"status"`
+	// 付款渠道退款交易号
+	ChannelRefundNo string `json:"channel_refund_no"`
+	// 退款原因
+	Reason    string `json:"reason" gorm:"type:varchar(255)"`
+	Operator  string `json:"operator"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+type ReturnItemRes struct {
+	ReturnItem
+	ProductItem *ProductItemRes `json:"product_item"` // 商品信息
+}
+
+type ReturnRequestRes struct {
+	ReturnRequest
+	Items   []*ReturnItemRes `json:"items"`   // 退货商品
+	Refunds []*Refund        `json:"refunds"` // 退款记录
+}
+
+// 退货原因统计
+type ReturnReasonReport struct {
+	ProductUuid     string `json:"product_uuid"`
+	ProductName     string `json:"product_name"`
+	ProductItemUuid string `json:"product_item_uuid"`
+	Reason          string `json:"reason"`
+	// 退货申请数
+	ReturnCount int64 `json:"return_count"`
+	// 退货商品数量
+	Quantity int64 `json:"quantity"`
+}
+
+type ReqReturnItem struct {
+	OrderItemID int64 `json:"order_item_id" binding:"required"` // 订单商品ID
+	Quantity    int   `json:"quantity" binding:"required"`      // 退货数量
+}
+
+// 买家申请退货
+type ReqReturnCreate struct {
+	OrderNo     string           `json:"order_no" binding:"required"` // 订单编号
+	Reason      string           `json:"reason" binding:"required"`   // 退货原因
+	Description string           `json:"description"`                 // 问题描述
+	Images      []string         `json:"images"`                      // 图片，通过上传接口上传后的文件路径
+	Resolution  string           `json:"resolution"`                  // 期望处理方式 refund、exchange，默认refund
+	Items       []*ReqReturnItem `json:"items" binding:"required"`    // 退货商品
+}
+
+type ReqReturnQueryParam struct {
+	OrderNo string `json:"order_no"` // 订单编号
+	UserID  string `json:"user_id"`  // 用户ID
+	Status  string `json:"status"`   // 状态
+	Reason  string `json:"reason"`   // 退货原因
+	Pagination
+}
+
+// 审核退货申请
+type ReqReturnReviewParam struct {
+	Uuid   string `json:"uuid" binding:"required"` // 退货申请uuid
+	Remark string `json:"remark"`                  // 处理备注
+}
+
+// 确认收到退货
+type ReqReturnReceiveParam struct {
+	Uuid    string `json:"uuid" binding:"required"` // 退货申请uuid
+	Restock bool   `json:"restock"`                 // 是否退回库存
+	Remark  string `json:"remark"`                  // 处理备注
+}
+
+// 退货退款
+type ReqReturnRefundParam struct {
+	Uuid   string  `json:"uuid" binding:"required"` // 退货申请uuid
+	Amount float64 `json:"amount"`                  // 退款金额，为0时按退货商品金额退款
+	Remark string  `json:"remark"`                  // 处理备注
+}
+
+type ReqReturnReportParam struct {
+	StartAt     string `json:"start_at"`     // 申请时间开始
+	EndAt       string `json:"end_at"`       // 申请时间结束
+	ProductUuid string `json:"product_uuid"` // 产品uuid
+}
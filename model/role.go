@@ -12,4 +12,6 @@ type Role struct {
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`     // CreatedAt 记录了角色创建的时间
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`     // UpdatedAt 记录了角色最后更新的时间
 	IsActive  bool      `gorm:"default:true" json:"is_active"`        // IsActive 标识角色是否是活跃的
+	// Require2FA 标识拥有该角色的用户登录时必须通过两步验证
+	Require2FA bool `gorm:"column:require_2fa;default:false" json:"require_2fa"`
 }
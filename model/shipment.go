@@ -0,0 +1,134 @@
+package model
+
+const (
+	// 包裹状态
+	ShipmentStatusShipped   = "shipped"    // 已发出
+	ShipmentStatusInTransit = "in_transit" // 运输中
+	ShipmentStatusDelivered = "delivered"  // 已签收
+	ShipmentStatusException = "exception"  // 异常
+)
+
+// 发货包裹，一个订单可以分多个包裹发货
+type Shipment struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 订单编号
+	OrderNo string `json:"order_no" gorm:"type:varchar(100);index"`
+	// 承运商
+	Carrier string `json:"carrier" gorm:"type:varchar(100)"`
+	// 物流单号
+	TrackingNo string `json:"tracking_no" gorm:"type:varchar(100);index"`
+	// 物流服务类型
+	Service string `json:"service" gorm:"type:varchar(100)"`
+	// 状态 shipped、in_transit、delivered、exception
+	Status string `json:"status" gorm:"type:varchar(20);index"`
+	// 面单资源uuid
+	LabelResourceUuid string `json:"label_resource_uuid" gorm:"type:varchar(36)"`
+	// 面单格式 pdf、zpl
+	LabelFormat string `json:"label_format" gorm:"type:varchar(10)"`
+	// 备注
+	Remark string `json:"remark" gorm:"type:varchar(255)"`
+	// 发货人
+	Operator string `json:"operator"`
+	// 发货时间
+	ShippedAt string `json:"shipped_at"`
+	// 签收时间
+	DeliveredAt string `json:"delivered_at"`
+	// 最近一次查询物流轨迹的时间
+	TrackedAt string `json:"tracked_at"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// 包裹商品
+type ShipmentItem struct {
+	ID int64 `json:"id" gorm:"primary_key"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 包裹uuid
+	ShipmentUuid string `json:"shipment_uuid" gorm:"type:varchar(36);index"`
+	// 订单编号
+	OrderNo string `json:"order_no" gorm:"type:varchar(100);index"`
+	// 订单商品ID
+	OrderItemID int64 `json:"order_item_id" gorm:"index"`
+	// 产品SKU uuid
+	ProductItemUuid string `json:"product_item_uuid" gorm:"type:varchar(36)"`
+	// 发货数量
+	Quantity  int    `json:"quantity"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+}
+
+// 物流轨迹
+type ShipmentEvent struct {
+	ID int64 `json:"id" gorm:"primary_key"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 包裹uuid
+	ShipmentUuid string `json:"shipment_uuid" gorm:"type:varchar(36);index"`
+	// 订单编号
+	OrderNo string `json:"order_no" gorm:"type:varchar(100);index"`
+	// 状态
+	Status string `json:"status" gorm:"type:varchar(20)"`
+	// 描述
+	Description string `json:"description" gorm:"type:varchar(255)"`
+	// 地点
+	Location string `json:"location" gorm:"type:varchar(255)"`
+	// 发生时间
+	OccurredAt string `json:"occurred_at"`
+	CreatedAt  string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+}
+
+type ShipmentRes struct {
+	Shipment
+	Items  []*ShipmentItem  `json:"items"`  // 包裹商品
+	Events []*ShipmentEvent `json:"events"` // 物流轨迹
+}
+
+// 订单物流信息
+type OrderTrackingRes struct {
+	OrderNo     string         `json:"order_no"`
+	Status      string         `json:"status"`       // 订单状态
+	DeliveredAt string         `json:"delivered_at"` // 发货时间
+	Shipments   []*ShipmentRes `json:"shipments"`    // 包裹列表
+}
+
+type ReqShipmentItem struct {
+	OrderItemID int64 `json:"order_item_id" binding:"required"` // 订单商品ID
+	Quantity    int   `json:"quantity" binding:"required"`      // 发货数量
+}
+
+type ReqShipmentCreate struct {
+	OrderNo    string             `json:"order_no" binding:"required"`    // 订单编号
+	Carrier    string             `json:"carrier" binding:"required"`     // 承运商
+	TrackingNo string             `json:"tracking_no" binding:"required"` // 物流单号
+	Remark     string             `json:"remark"`                         // 备注
+	Items      []*ReqShipmentItem `json:"items"`                          // 发货商品，为空时发出所有未发货商品
+}
+
+type ReqShipmentQueryParam struct {
+	OrderNo    string `json:"order_no"`    // 订单编号
+	TrackingNo string `json:"tracking_no"` // 物流单号
+	Status     string `json:"status"`      // 状态
+	Pagination
+}
+
+type ReqOrderTrackingParam struct {
+	OrderNo string `json:"order_no" binding:"required"` // 订单编号
+}
+
+type ReqShipmentRateParam struct {
+	OrderNo string `json:"order_no" binding:"required"` // 订单编号
+	Carrier string `json:"carrier" binding:"required"`  // 承运商
+}
+
+// 通过承运商创建面单并发货
+type ReqShipmentLabelCreate struct {
+	OrderNo string             `json:"order_no" binding:"required"` // 订单编号
+	Carrier string             `json:"carrier" binding:"required"`  // 承运商
+	Service string             `json:"service"`                     // 物流服务类型
+	Format  string             `json:"format"`                      // 面单格式 pdf、zpl
+	Remark  string             `json:"remark"`                      // 备注
+	Items   []*ReqShipmentItem `json:"items"`                       // 发货商品，为空时发出所有未发货商品
+}
@@ -0,0 +1,167 @@
+package model
+
+import "time"
+
+const (
+	// 订阅周期
+	SubscriptionIntervalDay   = "day"
+	SubscriptionIntervalWeek  = "week"
+	SubscriptionIntervalMonth = "month"
+	SubscriptionIntervalYear  = "year"
+)
+
+const (
+	// 订阅计划状态
+	SubscriptionPlanStatusEnabled  = 1 // 启用
+	SubscriptionPlanStatusDisabled = 2 // 禁用
+)
+
+const (
+	// 订阅状态
+	SubscriptionStatusTrialing = "trialing" // 试用中
+	SubscriptionStatusActive   = "active"   // 生效中
+	SubscriptionStatusPastDue  = "past_due" // 扣款失败，等待重试
+	SubscriptionStatusPaused   = "paused"   // 已暂停
+	SubscriptionStatusCanceled = "canceled" // 已取消
+)
+
+// 订阅计划
+type SubscriptionPlan struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 产品uuid
+	ProductUuid string `json:"product_uuid" gorm:"type:varchar(36);index"`
+	// 产品SKU uuid
+	ProductItemUuid string `json:"product_item_uuid" gorm:"type:varchar(36);index"`
+	// 计划名称
+	Name string `json:"name" gorm:"type:varchar(100)"`
+	// 周期单位 day、week、month、year
+	Interval string `json:"interval" gorm:"type:varchar(20)"`
+	// 周期数量，如每3个月
+	IntervalCount int `json:"interval_count"`
+	// 试用天数
+	TrialDays int `json:"trial_days"`
+	// 每期价格
+	Price float64 `json:"price" gorm:"type:decimal(10,2)"`
+	// 货币代码
+	CurrencyCode string `json:"currency_code" gorm:"type:varchar(10)"`
+	// 状态 1:启用 2:禁用
+	Status    int    `json:"status"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// NextPeriod 计算从t开始的下一个计费时间
+func (p *SubscriptionPlan) NextPeriod(t time.Time) time.Time {
+	count := p.IntervalCount
+	if count <= 0 {
+		count = 1
+	}
+
+	switch p.Interval {
+	case SubscriptionIntervalDay:
+		return t.AddDate(0, 0, count)
+	case SubscriptionIntervalWeek:
+		return t.AddDate(0, 0, 7*count)
+	case SubscriptionIntervalYear:
+		return t.AddDate(count, 0, 0)
+	default:
+		return t.AddDate(0, count, 0)
+	}
+}
+
+// 客户订阅
+type Subscription struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// 用户ID
+	UserID string `json:"user_id" gorm:"index"`
+	// 订阅计划uuid
+	PlanUuid string `json:"plan_uuid" gorm:"type:varchar(36);index"`
+	// 产品SKU uuid
+	ProductItemUuid string `json:"product_item_uuid" gorm:"type:varchar(36);index"`
+	// 每期数量
+	Quantity int `json:"quantity"`
+	// 状态 trialing、active、past_due、paused、canceled
+	Status string `json:"status" gorm:"type:varchar(20);index"`
+	// 扣款方式，支付方式code
+	PaymentMethod string `json:"payment_method" gorm:"type:varchar(100)"`
+	// 代扣协议号
+	AgreementNo string `json:"agreement_no" gorm:"type:varchar(100)"`
+
+	OrderReceiver
+
+	// 当前周期开始时间
+	CurrentPeriodStart string `json:"current_period_start"`
+	// 当前周期结束时间
+	CurrentPeriodEnd string `json:"current_period_end"`
+	// 下次扣款时间
+	NextBillingAt string `json:"next_billing_at" gorm:"index"`
+	// 试用结束时间
+	TrialEndAt string `json:"trial_end_at"`
+	// 扣款失败重试次数
+	RetryCount int `json:"retry_count"`
+	// 最近一次续费订单编号
+	LastOrderNo string `json:"last_order_no" gorm:"type:varchar(100)"`
+	// 已扣款但还未标记支付的续费订单编号，标记完成后清空
+	ChargedOrderNo string `json:"charged_order_no" gorm:"type:varchar(100)"`
+	// 已扣款的支付流水号
+	ChargedTransactionNo string `json:"charged_transaction_no" gorm:"type:varchar(100)"`
+	// 暂停时间
+	PausedAt string `json:"paused_at"`
+	// 取消时间
+	CanceledAt string `json:"canceled_at"`
+	CreatedAt  string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt  string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+type SubscriptionRes struct {
+	Subscription
+	Plan *SubscriptionPlan `json:"plan"` // 订阅计划
+}
+
+type ReqSubscriptionPlanCreate struct {
+	ProductUuid     string  `json:"product_uuid" binding:"required"`      // 产品uuid
+	ProductItemUuid string  `json:"product_item_uuid" binding:"required"` // 产品SKU uuid
+	Name            string  `json:"name" binding:"required"`              // 计划名称
+	Interval        string  `json:"interval" binding:"required"`          // 周期单位 day、week、month、year
+	IntervalCount   int     `json:"interval_count"`                       // 周期数量
+	TrialDays       int     `json:"trial_days"`                           // 试用天数
+	Price           float64 `json:"price" binding:"required"`             // 每期价格
+	CurrencyCode    string  `json:"currency_code"`                        // 货币代码
+}
+
+type ReqSubscriptionPlanUpdate struct {
+	Uuid          string  `json:"uuid" binding:"required"` // 计划uuid
+	Name          string  `json:"name"`                    // 计划名称
+	Interval      string  `json:"interval"`                // 周期单位
+	IntervalCount int     `json:"interval_count"`          // 周期数量
+	TrialDays     *int    `json:"trial_days"`              // 试用天数
+	Price         float64 `json:"price"`                   // 每期价格
+	Status        int     `json:"status"`                  // 状态 1:启用 2:禁用
+}
+
+type ReqSubscriptionPlanQueryParam struct {
+	ProductUuid string `json:"product_uuid"` // 产品uuid
+	Status      int    `json:"status"`       // 状态
+	Pagination
+}
+
+type ReqSubscriptionCreate struct {
+	PlanUuid      string        `json:"plan_uuid" binding:"required"`      // 订阅计划uuid
+	Quantity      int           `json:"quantity"`                          // 每期数量，默认1
+	PaymentMethod string        `json:"payment_method" binding:"required"` // 扣款方式
+	AgreementNo   string        `json:"agreement_no" binding:"required"`   // 代扣协议号
+	Receiver      OrderReceiver `json:"receiver"`                          // 收货人信息
+}
+
+type ReqSubscriptionQueryParam struct {
+	UserID   string `json:"user_id"`   // 用户ID
+	PlanUuid string `json:"plan_uuid"` // 订阅计划uuid
+	Status   string `json:"status"`    // 状态
+	Pagination
+}
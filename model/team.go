@@ -11,6 +11,7 @@ type Team struct {
 	OwnerUuid string    `gorm:"type:char(36);index" json:"owner_uuid"` // 拥有者uuid
 	Name      string    `gorm:"type:varchar(100);unique" json:"name"`  // Name 是团队的名称，它在系统中是唯一的
 	Desc      string    `gorm:"type:varchar(255)" json:"desc"`         // Desc 是对团队的描述
+	Domain    string    `gorm:"type:varchar(255);index" json:"domain"` // Domain 是团队商城的域名，开启多租户时按域名确定商城所属的团队
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`      // CreatedAt 记录了团队创建的时间
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`      // UpdatedAt 记录了团队最后更新的时间
 	IsActive  bool      `gorm:"default:true" json:"is_active"`         // IsActive 标识团队是否是活跃的
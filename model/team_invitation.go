@@ -0,0 +1,79 @@
+package model
+
+const (
+	// 团队邀请状态
+	TeamInvitationStatusPending  = "pending"  // 待接受
+	TeamInvitationStatusAccepted = "accepted" // 已接受
+	TeamInvitationStatusDeclined = "declined" // 已拒绝
+	TeamInvitationStatusRevoked  = "revoked"  // 已撤销
+)
+
+// 团队邀请，通过邮件发送带签名的邀请链接，被邀请人没有账号时接受邀请会创建账号
+type TeamInvitation struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 团队uuid
+	TeamUuid string `json:"team_uuid" gorm:"type:char(36);index"`
+	// 被邀请人邮箱
+	Email string `json:"email" gorm:"type:varchar(100);index"`
+	// 加入团队后的角色uuid
+	Role string `json:"role" gorm:"type:varchar(100)"`
+	// 邀请人uuid
+	InviterUuid string `json:"inviter_uuid" gorm:"type:char(36)"`
+	// 状态 pending、accepted、declined、revoked
+	Status string `json:"status" gorm:"type:varchar(20);index"`
+	// 过期时间
+	ExpiredAt string `json:"expired_at"`
+	// 接受邀请的用户uuid
+	UserUuid string `json:"user_uuid" gorm:"type:char(36)"`
+	// 接受、拒绝或撤销的时间
+	HandledAt string `json:"handled_at"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+type TeamInvitationRes struct {
+	TeamInvitation
+	RoleName    string `json:"role_name"`    // 角色名称
+	InviterName string `json:"inviter_name"` // 邀请人用户名
+	Expired     bool   `json:"expired"`      // 是否已过期
+}
+
+// 通过邀请token查看的邀请信息
+type TeamInvitationInfo struct {
+	TeamName   string `json:"team_name"`   // 团队名称
+	Email      string `json:"email"`       // 被邀请人邮箱
+	RoleName   string `json:"role_name"`   // 角色名称
+	Status     string `json:"status"`      // 状态
+	ExpiredAt  string `json:"expired_at"`  // 过期时间
+	HasAccount bool   `json:"has_account"` // 邮箱是否已有账号，没有账号时接受邀请需要填写用户名和密码
+}
+
+type ReqTeamInvitationCreate struct {
+	TeamUuid string `json:"team_uuid"`                      // 团队uuid，为空时使用当前团队
+	Email    string `json:"email" binding:"required,email"` // 被邀请人邮箱
+	Role     string `json:"role"`                           // 角色uuid
+}
+
+type ReqTeamInvitationQueryParam struct {
+	TeamUuid string `json:"team_uuid"` // 团队uuid，为空时使用当前团队
+	Email    string `json:"email"`     // 邮箱
+	Status   string `json:"status"`    // 状态
+	Pagination
+}
+
+type ReqTeamInvitationToken struct {
+	Token string `json:"token" binding:"required"` // 邀请token
+}
+
+// 接受邀请，邮箱没有账号时使用用户名和密码创建账号
+type ReqTeamInvitationAccept struct {
+	Token    string `json:"token" binding:"required"` // 邀请token
+	Username string `json:"username"`                 // 用户名
+	Password string `json:"password"`                 // 密码
+}
+
+type ReqTeamOwnerTransfer struct {
+	TeamUuid string `json:"team_uuid" binding:"required"` // 团队uuid
+	UserUuid string `json:"user_uuid" binding:"required"` // 新拥有者uuid，需要是团队成员
+}
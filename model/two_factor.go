@@ -0,0 +1,99 @@
+package model
+
+const (
+	// 两步验证方式
+	TwoFactorMethodTotp  = "totp"  // 身份验证器应用
+	TwoFactorMethodEmail = "email" // 邮箱验证码
+	TwoFactorMethodSetup = "setup" // 角色要求两步验证但未绑定，登录时先绑定
+
+	// 登录步骤
+	LoginStepDone      = "done"       // 登录完成，已返回token
+	LoginStepTwoFactor = "two_factor" // 需要两步验证
+	LoginStepSetup     = "setup"      // 需要先绑定两步验证
+)
+
+// 后台用户两步验证设置
+type UserTwoFactor struct {
+	ID       int64  `json:"id" gorm:"primary_key"`
+	UserUuid string `json:"user_uuid" gorm:"type:char(36);unique"`
+	// 验证方式 totp、email
+	Method string `json:"method" gorm:"type:varchar(20)"`
+	// TOTP密钥，加密保存
+	TotpSecret string `json:"-" gorm:"type:varchar(255)"`
+	// 最后一次验证通过的TOTP时间步，同一时间步及之前的密码不能再次使用
+	TotpLastStep int64 `json:"-"`
+	// 是否已启用，绑定确认后启用
+	Enabled bool `json:"enabled"`
+	// 恢复码摘要，json数组，使用后删除
+	RecoveryCodes string `json:"-" gorm:"type:text"`
+	// 启用时间
+	EnabledAt string `json:"enabled_at"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// 登录两步验证挑战，密码验证通过后创建
+type LoginChallenge struct {
+	ID       int64  `json:"id" gorm:"primary_key"`
+	Uuid     string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	UserUuid string `json:"user_uuid" gorm:"type:char(36);index"`
+	// 验证方式 totp、email、setup
+	Method string `json:"method" gorm:"type:varchar(20)"`
+	// 验证失败次数
+	Attempts int `json:"attempts"`
+	// 过期时间
+	ExpiresAt string `json:"expires_at"`
+	// 完成时间，完成后不能再次使用
+	CompletedAt string `json:"completed_at"`
+	CreatedAt   string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+}
+
+// 登录挑战
+type ResLoginChallenge struct {
+	Uuid      string `json:"uuid"`       // 挑战uuid，验证时提交
+	Method    string `json:"method"`     // 验证方式 totp、email、setup
+	ExpiresIn int64  `json:"expires_in"` // 有效期（秒）
+}
+
+// 两步验证状态
+type ResTwoFactorStatus struct {
+	Enabled            bool   `json:"enabled"`              // 是否已启用
+	Method             string `json:"method"`               // 验证方式
+	Required           bool   `json:"required"`             // 角色是否要求两步验证
+	RecoveryCodesCount int    `json:"recovery_codes_count"` // 剩余恢复码数量
+}
+
+// TOTP绑定信息
+type ResTotpSetup struct {
+	Secret string `json:"secret"` // 密钥，无法扫码时手动输入
+	Uri    string `json:"uri"`    // otpauth链接，用于生成二维码
+}
+
+// 开始绑定两步验证，totp返回密钥和二维码链接，email发送验证码到用户邮箱
+type ReqTwoFactorSetup struct {
+	Method string `json:"method" binding:"required"` // 验证方式 totp、email
+}
+
+// 登录时绑定两步验证
+type ReqLoginTwoFactorSetup struct {
+	ChallengeUuid string `json:"challenge_uuid" binding:"required"` // 登录挑战uuid
+	Method        string `json:"method" binding:"required"`         // 验证方式 totp、email
+}
+
+type ReqTwoFactorCode struct {
+	Code string `json:"code" binding:"required"` // 验证码或恢复码
+}
+
+// 登录两步验证
+type ReqLoginTwoFactorVerify struct {
+	ChallengeUuid string `json:"challenge_uuid" binding:"required"` // 登录挑战uuid
+	Code          string `json:"code" binding:"required"`           // 验证码或恢复码
+	CaptchaId     string `json:"captcha_id"`                        // 图形验证码ID，失败次数较多时需要
+	CaptchaCode   string `json:"captcha_code"`                      // 图形验证码
+}
+
+// 关闭两步验证
+type ReqTwoFactorDisable struct {
+	Password string `json:"password" binding:"required"` // 当前密码
+	Code     string `json:"code" binding:"required"`     // 验证码或恢复码
+}
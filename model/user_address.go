@@ -1,9 +1,10 @@
 package model
 
 type UserAddress struct {
-	ID     uint   `json:"id" gorm:"primary_key"`
-	Uuid   string `json:"uuid" gorm:"type:char(36);unique" ` // 用户地址唯一标识
-	UserID uint   `json:"user_id" gorm:"index"`              // 用户ID
+	ID         uint   `json:"id" gorm:"primary_key"`
+	Uuid       string `json:"uuid" gorm:"type:char(36);unique" `      // 用户地址唯一标识
+	TenantUuid string `json:"tenant_uuid" gorm:"type:char(36);index"` // 租户，所属团队uuid
+	UserID     string `json:"user_id" gorm:"type:varchar(36);index"`  // 用户ID
 	// 收货人姓名
 	ReceiverName string `json:"receiver_name" gorm:"type:varchar(100)"`
 	// 收货人电话
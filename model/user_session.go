@@ -0,0 +1,39 @@
+package model
+
+// 登录会话，每次登录创建一个会话，刷新token时轮换
+type UserSession struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 用户ID，后台用户或商城客户的uuid
+	UserID string `json:"user_id" gorm:"type:varchar(36);index"`
+	// token受众 admin、customer
+	Audience string `json:"audience" gorm:"type:varchar(20)"`
+	// 当前刷新token的摘要
+	RefreshTokenHash string `json:"-" gorm:"type:varchar(64)"`
+	// 登录IP
+	Ip        string `json:"ip" gorm:"type:varchar(50)"`
+	UserAgent string `json:"user_agent" gorm:"type:varchar(500)"`
+	// 浏览器
+	Browser string `json:"browser" gorm:"type:varchar(100)"`
+	// 操作系统
+	Os string `json:"os" gorm:"type:varchar(100)"`
+	// 登录设备
+	Device string `json:"device" gorm:"type:varchar(100)"`
+	// 最近活跃时间，刷新token时更新
+	LastActiveAt string `json:"last_active_at"`
+	// 刷新token过期时间
+	ExpiresAt string `json:"expires_at" gorm:"index"`
+	// 撤销时间，为空表示有效
+	RevokedAt string `json:"revoked_at"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+type UserSessionRes struct {
+	UserSession
+	Current bool `json:"current"` // 是否为当前会话
+}
+
+type ReqRefreshToken struct {
+	RefreshToken string `json:"refresh_token" binding:"required"` // 刷新token
+}
@@ -2,14 +2,30 @@ package model
 
 import "time"
 
+const (
+	// 验证码用途
+	VerificationPurposeRegister    = "register"     // 注册
+	VerificationPurposeReset       = "reset"        // 重置密码
+	VerificationPurposeLogin       = "login"        // 登录
+	VerificationPurposeChangeEmail = "change_email" // 修改邮箱
+
+	// 验证码状态
+	VerificationCodeStatusUnused  = 0 // 未使用
+	VerificationCodeStatusUsed    = 1 // 已使用
+	VerificationCodeStatusExpired = 2 // 已失效，过期、错误次数过多或被新验证码替换
+)
+
 // 验证码
 type VerificationCode struct {
-	Id        uint      `gorm:"primary_key" json:"id"`            // ID 是验证码的主键
-	UUID      string    `gorm:"type:char(36);index" json:"uuid"`  // UUID 是验证码的唯一标识符
-	Code      string    `gorm:"type:varchar(6)" json:"code"`      // Code 是验证码的内容
-	Email     string    `gorm:"type:varchar(100)" json:"email"`   // Email 是验证码的接收者
-	Phone     string    `gorm:"type:varchar(11)" json:"phone"`    // Phone 是验证码的接收者
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了验证码创建的时间
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了验证码信息最后更新的时间
-	Status    int       `gorm:"type:int(1)" json:"status"`        // Status 0:未使用 1:已使用 2:已过期
+	Id        uint      `gorm:"primary_key" json:"id"`                  // ID 是验证码的主键
+	UUID      string    `gorm:"type:char(36);index" json:"uuid"`        // UUID 是验证码的唯一标识符
+	Code      string    `gorm:"type:varchar(64)" json:"-"`              // Code 是验证码的HMAC摘要，不保存明文
+	Purpose   string    `gorm:"type:varchar(20);index" json:"purpose"`  // Purpose 是验证码的用途
+	Email     string    `gorm:"type:varchar(100);index" json:"email"`   // Email 是验证码的接收者
+	Phone     string    `gorm:"type:varchar(11);index" json:"phone"`    // Phone 是验证码的接收者
+	Ip        string    `gorm:"type:varchar(50);index" json:"ip"`       // Ip 是请求发送验证码的IP
+	Attempts  int       `gorm:"type:int" json:"attempts"`               // Attempts 是校验失败的次数
+	CreatedAt time.Time `gorm:"autoCreateTime;index" json:"created_at"` // CreatedAt 记录了验证码创建的时间
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`       // UpdatedAt 记录了验证码信息最后更新的时间
+	Status    int       `gorm:"type:int(1)" json:"status"`              // Status 0:未使用 1:已使用 2:已失效
 }
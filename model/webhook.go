@@ -0,0 +1,148 @@
+package model
+
+const (
+	// webhook事件
+	WebhookEventOrderCreated    = "order.created"    // 订单创建
+	WebhookEventOrderPaid       = "order.paid"       // 订单支付
+	WebhookEventOrderShipped    = "order.shipped"    // 订单发货
+	WebhookEventProductUpdated  = "product.updated"  // 产品更新
+	WebhookEventRefundCreated   = "refund.created"   // 退款创建
+	WebhookEventCustomerCreated = "customer.created" // 客户注册
+)
+
+// WebhookEvents 支持订阅的事件
+var WebhookEvents = []string{
+	WebhookEventOrderCreated,
+	WebhookEventOrderPaid,
+	WebhookEventOrderShipped,
+	WebhookEventProductUpdated,
+	WebhookEventRefundCreated,
+	WebhookEventCustomerCreated,
+}
+
+const (
+	// webhook状态
+	WebhookStatusEnabled  = 1 // 启用
+	WebhookStatusDisabled = 2 // 禁用
+)
+
+const (
+	// 投递状态
+	WebhookDeliveryStatusPending = "pending" // 等待投递或重试
+	WebhookDeliveryStatusSuccess = "success" // 投递成功
+	WebhookDeliveryStatusFailed  = "failed"  // 超过重试次数
+)
+
+// webhook订阅，事件发生时向App的地址推送
+type Webhook struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// App uuid，使用App的SecKey签名
+	AppUuid string `json:"app_uuid" gorm:"type:varchar(36);index"`
+	// 推送地址
+	Url string `json:"url" gorm:"type:varchar(500)"`
+	// 订阅的事件，逗号分隔
+	Events string `json:"events" gorm:"type:varchar(500)"`
+	// 描述
+	Description string `json:"description" gorm:"type:varchar(255)"`
+	// 状态 1:启用 2:禁用
+	Status    int    `json:"status"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// webhook投递记录，也是重试队列
+type WebhookDelivery struct {
+	ID   int64  `json:"id" gorm:"primary_key"`
+	Uuid string `json:"uuid" gorm:"type:varchar(36);unique_index"`
+	// 租户，所属团队uuid
+	TenantUuid string `json:"tenant_uuid" gorm:"type:varchar(36);index"`
+	// webhook uuid
+	WebhookUuid string `json:"webhook_uuid" gorm:"type:varchar(36);index"`
+	// App uuid
+	AppUuid string `json:"app_uuid" gorm:"type:varchar(36)"`
+	// 事件id，同一事件推送到多个webhook时相同，接收方可用于去重
+	EventId string `json:"event_id" gorm:"type:varchar(36);index"`
+	// 事件
+	Event string `json:"event" gorm:"type:varchar(50);index"`
+	// 推送地址
+	Url string `json:"url" gorm:"type:varchar(500)"`
+	// 推送内容
+	Payload string `json:"payload" gorm:"type:text"`
+	// 状态 pending、success、failed
+	Status string `json:"status" gorm:"type:varchar(20);index"`
+	// 已投递次数
+	Attempts int `json:"attempts"`
+	// 下次投递时间
+	NextAttemptAt string `json:"next_attempt_at" gorm:"index"`
+	// 最近一次投递时间
+	LastAttemptAt string `json:"last_attempt_at"`
+	// 最近一次响应状态码
+	ResponseStatus int `json:"response_status"`
+	// 最近一次错误
+	Error     string `json:"error" gorm:"type:varchar(500)"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+	UpdatedAt string `gorm:"autoUpdateTime" json:"updated_at"` // UpdatedAt 记录了最后更新的时间
+}
+
+// webhook每次投递的结果
+type WebhookDeliveryAttempt struct {
+	ID int64 `json:"id" gorm:"primary_key"`
+	// 投递记录uuid
+	DeliveryUuid string `json:"delivery_uuid" gorm:"type:varchar(36);index"`
+	// 第几次投递
+	Attempt int `json:"attempt"`
+	// 响应状态码，请求失败时为0
+	ResponseStatus int `json:"response_status"`
+	// 响应内容，最多保存2KB
+	ResponseBody string `json:"response_body" gorm:"type:text"`
+	// 错误信息
+	Error string `json:"error" gorm:"type:varchar(500)"`
+	// 耗时（毫秒）
+	Duration  int64  `json:"duration"`
+	CreatedAt string `gorm:"autoCreateTime" json:"created_at"` // CreatedAt 记录了创建的时间
+}
+
+// 推送内容
+type WebhookPayload struct {
+	Id        string      `json:"id"`         // 事件id
+	Event     string      `json:"event"`      // 事件
+	CreatedAt string      `json:"created_at"` // 事件时间
+	Data      interface{} `json:"data"`       // 事件数据
+}
+
+type WebhookDeliveryRes struct {
+	WebhookDelivery
+	AttemptList []*WebhookDeliveryAttempt `json:"attempt_list"` // 每次投递的结果
+}
+
+type ReqWebhookCreate struct {
+	AppUuid     string   `json:"app_uuid" binding:"required"` // App uuid
+	Url         string   `json:"url" binding:"required,url"`  // 推送地址
+	Events      []string `json:"events" binding:"required"`   // 订阅的事件
+	Description string   `json:"description"`                 // 描述
+}
+
+type ReqWebhookUpdate struct {
+	Uuid        string   `json:"uuid" binding:"required"` // webhook uuid
+	Url         string   `json:"url"`                     // 推送地址
+	Events      []string `json:"events"`                  // 订阅的事件
+	Description string   `json:"description"`             // 描述
+	Status      int      `json:"status"`                  // 状态 1:启用 2:禁用
+}
+
+type ReqWebhookQueryParam struct {
+	AppUuid string `json:"app_uuid"` // App uuid
+	Event   string `json:"event"`    // 事件
+	Status  int    `json:"status"`   // 状态
+	Pagination
+}
+
+type ReqWebhookDeliveryQueryParam struct {
+	WebhookUuid string `json:"webhook_uuid"` // webhook uuid
+	Event       string `json:"event"`        // 事件
+	Status      string `json:"status"`       // 状态 pending、success、failed
+	Pagination
+}
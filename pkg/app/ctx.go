@@ -5,6 +5,7 @@ import (
 	"sgin/pkg/config"
 	"sgin/pkg/logger"
 	"sgin/pkg/redisop"
+	"sgin/pkg/tenant"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -32,16 +33,91 @@ func (app *App) Wrap(hf HandlerFunc) gin.HandlerFunc {
 			traceID = uuid.New().String()
 		}
 
+		// 数据库使用请求的context，中间件在context中设置的租户对后续的查询生效
+		reqCtx := c.Request.Context()
+		db := app.DB
+		if db != nil {
+			db = db.WithContext(reqCtx)
+		}
+
 		cc := &Context{
 			Context: c,
-			DB:      app.DB,
+			DB:      db,
 			Redis:   app.Redis,
 			Logger: app.Logger.With(
 				zap.String("traceID", traceID),
 			),
 			Config:  app.Config,
 			TraceID: traceID,
+			Ctx:     reqCtx,
 		}
 		hf(cc)
 	}
 }
+
+// NewContext 创建不依赖请求的上下文，用于后台任务
+func (app *App) NewContext() *Context {
+	traceID := uuid.New().String()
+	return &Context{
+		Context: &gin.Context{},
+		DB:      app.DB,
+		Redis:   app.Redis,
+		Logger: app.Logger.With(
+			zap.String("traceID", traceID),
+		),
+		Config:  app.Config,
+		TraceID: traceID,
+		Ctx:     context.Background(),
+	}
+}
+
+// SetTenant 设置请求的租户，中间件中调用后对后续的处理函数生效
+func (c *Context) SetTenant(tenantUuid string) {
+	c.Request = c.Request.WithContext(tenant.WithTenant(c.Request.Context(), tenantUuid))
+	c.Ctx = c.Request.Context()
+	if c.DB != nil {
+		c.DB = c.DB.WithContext(c.Ctx)
+	}
+}
+
+// WithTenant 返回使用指定租户的上下文，用于后台任务处理某个租户的数据
+func (c *Context) WithTenant(tenantUuid string) *Context {
+	parent := c.Ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	cc := *c
+	cc.Ctx = tenant.WithTenant(parent, tenantUuid)
+	if cc.DB != nil {
+		cc.DB = cc.DB.WithContext(cc.Ctx)
+	}
+	return &cc
+}
+
+// Tenant 当前租户，没有设置租户时ok为false
+func (c *Context) Tenant() (string, bool) {
+	return tenant.FromContext(c.Ctx)
+}
+
+// Detach 返回不随请求结束而取消的上下文，保留请求中的租户，用于请求中启动的异步任务
+func (c *Context) Detach() *Context {
+	parent := c.Ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	cc := &Context{
+		Context: &gin.Context{},
+		DB:      c.DB,
+		Redis:   c.Redis,
+		Logger:  c.Logger,
+		Config:  c.Config,
+		TraceID: c.TraceID,
+		Ctx:     context.WithoutCancel(parent),
+	}
+	if cc.DB != nil {
+		cc.DB = cc.DB.WithContext(cc.Ctx)
+	}
+	return cc
+}
@@ -5,6 +5,7 @@ import (
 	"sgin/pkg/logger"
 	"sgin/pkg/redisop"
 
+	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"gorm.io/gorm"
@@ -16,6 +17,7 @@ import (
 
 type WSContext struct {
 	Conn    *websocket.Conn
+	Request *http.Request
 	DB      *gorm.DB
 	Redis   *redisop.RedisClient
 	Logger  *logger.Logger
@@ -40,9 +42,10 @@ func (app *App) WrapWS(hf WsHandlerFunc) http.HandlerFunc {
 		}
 
 		cc := &WSContext{
-			Conn:  conn,
-			DB:    app.DB,
-			Redis: app.Redis,
+			Conn:    conn,
+			Request: r,
+			DB:      app.DB,
+			Redis:   app.Redis,
 			Logger: app.Logger.With(
 				zap.String("traceID", traceID),
 			),
@@ -52,3 +55,16 @@ func (app *App) WrapWS(hf WsHandlerFunc) http.HandlerFunc {
 		hf(cc)
 	}
 }
+
+// Context 返回websocket请求的上下文，用于调用service
+func (c *WSContext) Context() *Context {
+	return &Context{
+		Context: &gin.Context{Request: c.Request},
+		DB:      c.DB.WithContext(c.Request.Context()),
+		Redis:   c.Redis,
+		Logger:  c.Logger,
+		Config:  c.Config,
+		TraceID: c.TraceID,
+		Ctx:     c.Request.Context(),
+	}
+}
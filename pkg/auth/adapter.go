@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"gorm.io/gorm"
+)
+
+// CasbinRule 策略表，每行一条p或g策略
+// Generated 为true的策略由角色配置生成，重新生成时会被替换，手工添加的策略不受影响
+type CasbinRule struct {
+	ID        uint   `gorm:"primary_key" json:"id"`
+	Ptype     string `gorm:"type:varchar(10);index" json:"ptype"`
+	V0        string `gorm:"type:varchar(255);index" json:"v0"`
+	V1        string `gorm:"type:varchar(255);index" json:"v1"`
+	V2        string `gorm:"type:varchar(255)" json:"v2"`
+	V3        string `gorm:"type:varchar(255)" json:"v3"`
+	V4        string `gorm:"type:varchar(255)" json:"v4"`
+	V5        string `gorm:"type:varchar(255)" json:"v5"`
+	Generated bool   `gorm:"default:false" json:"generated"`
+}
+
+func (CasbinRule) TableName() string {
+	return "casbin_rule"
+}
+
+// Rule 返回策略值，去掉末尾的空值
+func (r *CasbinRule) Rule() []string {
+	rule := []string{r.V0, r.V1, r.V2, r.V3, r.V4, r.V5}
+	for len(rule) > 0 && rule[len(rule)-1] == "" {
+		rule = rule[:len(rule)-1]
+	}
+	return rule
+}
+
+func newCasbinRule(ptype string, rule []string, generated bool) *CasbinRule {
+	r := &CasbinRule{Ptype: ptype, Generated: generated}
+	fields := []*string{&r.V0, &r.V1, &r.V2, &r.V3, &r.V4, &r.V5}
+	for i, v := range rule {
+		if i >= len(fields) {
+			break
+		}
+		*fields[i] = v
+	}
+	return r
+}
+
+// Adapter 基于gorm的casbin策略存储
+type Adapter struct {
+	db *gorm.DB
+}
+
+var _ persist.Adapter = (*Adapter)(nil)
+
+// NewAdapter 创建策略存储，自动创建策略表
+func NewAdapter(db *gorm.DB) (*Adapter, error) {
+	err := db.AutoMigrate(&CasbinRule{})
+	if err != nil {
+		return nil, err
+	}
+	return &Adapter{db: db}, nil
+}
+
+// LoadPolicy 从数据库加载全部策略
+func (a *Adapter) LoadPolicy(m model.Model) error {
+	rules := make([]*CasbinRule, 0)
+	err := a.db.Order("id").Find(&rules).Error
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		err = persist.LoadPolicyArray(append([]string{r.Ptype}, r.Rule()...), m)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SavePolicy 使用内存中的策略替换数据库中的全部策略，保存后全部视为手工策略
+func (a *Adapter) SavePolicy(m model.Model) error {
+	rules := make([]*CasbinRule, 0)
+	for _, sec := range []string{"p", "g"} {
+		for ptype, ast := range m[sec] {
+			for _, rule := range ast.Policy {
+				rules = append(rules, newCasbinRule(ptype, rule, false))
+			}
+		}
+	}
+
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("1 = 1").Delete(&CasbinRule{}).Error
+		if err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+}
+
+// AddPolicy 添加一条手工策略
+func (a *Adapter) AddPolicy(sec string, ptype string, rule []string) error {
+	return a.db.Create(newCasbinRule(ptype, rule, false)).Error
+}
+
+// RemovePolicy 删除一条策略
+func (a *Adapter) RemovePolicy(sec string, ptype string, rule []string) error {
+	r := newCasbinRule(ptype, rule, false)
+	return a.db.Where("ptype = ? AND v0 = ? AND v1 = ? AND v2 = ? AND v3 = ? AND v4 = ? AND v5 = ?",
+		r.Ptype, r.V0, r.V1, r.V2, r.V3, r.V4, r.V5).Delete(&CasbinRule{}).Error
+}
+
+// RemoveFilteredPolicy 按字段删除策略
+func (a *Adapter) RemoveFilteredPolicy(sec string, ptype string, fieldIndex int, fieldValues ...string) error {
+	if fieldIndex < 0 || fieldIndex+len(fieldValues) > 6 {
+		return errors.New("invalid field index")
+	}
+	return a.filter(a.db, ptype, fieldIndex, fieldValues...).Delete(&CasbinRule{}).Error
+}
+
+// ReplaceGenerated 替换由角色配置生成的策略，rules的第一个元素为ptype
+func (a *Adapter) ReplaceGenerated(rules [][]string) error {
+	records := make([]*CasbinRule, 0, len(rules))
+	for _, rule := range rules {
+		if len(rule) < 2 {
+			continue
+		}
+		records = append(records, newCasbinRule(rule[0], rule[1:], true))
+	}
+
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("generated = ?", true).Delete(&CasbinRule{}).Error
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(records, 500).Error
+	})
+}
+
+// ListRules 查询策略，ptype为空时返回全部
+func (a *Adapter) ListRules(ptype string, fieldIndex int, fieldValues ...string) ([]*CasbinRule, error) {
+	rules := make([]*CasbinRule, 0)
+	db := a.db
+	if ptype != "" {
+		db = a.filter(db, ptype, fieldIndex, fieldValues...)
+	}
+	err := db.Order("ptype, v0, v1, id").Find(&rules).Error
+	return rules, err
+}
+
+func (a *Adapter) filter(db *gorm.DB, ptype string, fieldIndex int, fieldValues ...string) *gorm.DB {
+	db = db.Where("ptype = ?", ptype)
+	columns := []string{"v0", "v1", "v2", "v3", "v4", "v5"}
+	for i, v := range fieldValues {
+		if v == "" || fieldIndex+i >= len(columns) {
+			continue
+		}
+		db = db.Where(columns[fieldIndex+i]+" = ?", v)
+	}
+	return db
+}
@@ -8,47 +8,85 @@ import (
 	"sgin/pkg/app"
 
 	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
 )
 
+const (
+	// Any 策略的主体或域为*时对所有用户或团队生效
+	Any = "*"
+	// OwnerRole 团队拥有者角色，拥有团队内全部权限
+	OwnerRole = "team_owner"
+)
+
+// ModelText 带域的RBAC模型，主体为用户uuid，域为团队uuid，角色在团队内生效
+// 主体为*的策略对所有登录用户生效，路径支持keyMatch2通配，方法为*时匹配所有方法
+const ModelText = `
+[request_definition]
+r = sub, dom, obj, act
+
+[policy_definition]
+p = sub, dom, obj, act
+
+[role_definition]
+g = _, _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = (p.sub == "*" || g(r.sub, p.sub, r.dom)) && (p.dom == "*" || r.dom == p.dom) && keyMatch2(r.obj, p.obj) && (p.act == "*" || r.act == p.act)
+`
+
+// NewEnforcer 使用数据库中的策略创建enforcer
+func NewEnforcer(adapter *Adapter) (*casbin.SyncedEnforcer, error) {
+	m, err := model.NewModelFromString(ModelText)
+	if err != nil {
+		return nil, err
+	}
+	return casbin.NewSyncedEnforcer(m, adapter)
+}
+
 // NewAuthorizer returns the authorizer, uses a Casbin enforcer as input
-func NewAuthorizer(e *casbin.Enforcer) app.HandlerFunc {
-	a := &BasicAuthorizer{enforcer: e}
+// 主体为LoginCheck设置的user_id，域由domain返回，一般为用户当前团队
+func NewAuthorizer(e *casbin.SyncedEnforcer, domain func(c *app.Context) string) app.HandlerFunc {
+	a := &Authorizer{enforcer: e, domain: domain}
 
 	return func(c *app.Context) {
-		if !a.CheckPermission(c.Request) {
+		allowed, err := a.CheckPermission(c)
+		if err != nil {
+			c.JSONError(http.StatusInternalServerError, err.Error())
+			c.Abort()
+			return
+		}
+		if !allowed {
 			a.RequirePermission(c)
 		}
 	}
 }
 
-// BasicAuthorizer stores the casbin handler
-type BasicAuthorizer struct {
-	enforcer *casbin.Enforcer
+// Authorizer stores the casbin handler
+type Authorizer struct {
+	enforcer *casbin.SyncedEnforcer
+	domain   func(c *app.Context) string
 }
 
-// GetUserName gets the user name from the request.
-// Currently, only HTTP basic authentication is supported
-func (a *BasicAuthorizer) GetUserName(r *http.Request) string {
-	username, _, _ := r.BasicAuth()
-	return username
+// GetUserName gets the user uuid set by LoginCheck
+func (a *Authorizer) GetUserName(c *app.Context) string {
+	return c.GetString("user_id")
 }
 
-// CheckPermission checks the user/method/path combination from the request.
+// CheckPermission checks the user/domain/path/method combination from the request.
 // Returns true (permission granted) or false (permission forbidden)
-func (a *BasicAuthorizer) CheckPermission(r *http.Request) bool {
-	user := a.GetUserName(r)
-	method := r.Method
-	path := r.URL.Path
-
-	allowed, err := a.enforcer.Enforce(user, path, method)
-	if err != nil {
-		panic(err)
+func (a *Authorizer) CheckPermission(c *app.Context) (bool, error) {
+	user := a.GetUserName(c)
+	if user == "" {
+		return false, nil
 	}
-
-	return allowed
+	return a.enforcer.Enforce(user, a.domain(c), c.Request.URL.Path, c.Request.Method)
 }
 
 // RequirePermission returns the 403 Forbidden to the client
-func (a *BasicAuthorizer) RequirePermission(c *app.Context) {
-	c.AbortWithStatus(http.StatusForbidden)
+func (a *Authorizer) RequirePermission(c *app.Context) {
+	c.JSONError(http.StatusForbidden, "没有权限")
+	c.Abort()
 }
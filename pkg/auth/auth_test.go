@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	stringadapter "github.com/casbin/casbin/v2/persist/string-adapter"
+)
+
+func TestModel(t *testing.T) {
+	m, err := model.NewModelFromString(ModelText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := `
+p, editor, team1, /api/v1/product/*, POST
+p, global, *, /api/v1/order/list, POST
+p, *, *, /api/v1/user/myinfo, GET
+p, team_owner, *, /*, *
+g, alice, editor, team1
+g, bob, global, team2
+g, carol, team_owner, team1
+`
+	e, err := casbin.NewSyncedEnforcer(m, stringadapter.NewAdapter(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		sub, dom, obj, act string
+		want               bool
+	}{
+		{"alice", "team1", "/api/v1/product/delete", "POST", true},
+		{"alice", "team1", "/api/v1/product/delete", "GET", false},
+		{"alice", "team2", "/api/v1/product/delete", "POST", false},
+		{"alice", "team1", "/api/v1/order/list", "POST", false},
+		{"bob", "team2", "/api/v1/order/list", "POST", true},
+		{"bob", "team1", "/api/v1/order/list", "POST", false},
+		{"dave", "", "/api/v1/user/myinfo", "GET", true},
+		{"carol", "team1", "/api/v1/role/delete", "POST", true},
+		{"carol", "team2", "/api/v1/role/delete", "POST", false},
+	}
+	for _, c := range cases {
+		ok, err := e.Enforce(c.sub, c.dom, c.obj, c.act)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != c.want {
+			t.Errorf("%s %s %s %s: got %v, want %v", c.sub, c.dom, c.obj, c.act, ok, c.want)
+		}
+	}
+}
+
+func TestCasbinRule(t *testing.T) {
+	r := newCasbinRule("p", []string{"editor", "team1", "/api/v1/product/*", "POST"}, true)
+	if r.Ptype != "p" || r.V3 != "POST" || r.V4 != "" || !r.Generated {
+		t.Fatalf("unexpected rule %+v", r)
+	}
+	want := []string{"editor", "team1", "/api/v1/product/*", "POST"}
+	if got := r.Rule(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
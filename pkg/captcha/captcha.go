@@ -0,0 +1,158 @@
+package captcha
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"math/big"
+	mrand "math/rand"
+)
+
+const (
+	scale  = 4  // 字形放大倍数
+	glyphW = 5  // 字形宽度
+	glyphH = 7  // 字形高度
+	charW  = 28 // 每个字符占用的宽度
+	Height = 48 // 图片高度
+)
+
+// 5x7点阵数字字形，每行低5位从左到右
+var glyphs = [10][glyphH]uint8{
+	{0x0e, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0e}, // 0
+	{0x04, 0x0c, 0x04, 0x04, 0x04, 0x04, 0x0e}, // 1
+	{0x0e, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1f}, // 2
+	{0x1f, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0e}, // 3
+	{0x02, 0x06, 0x0a, 0x12, 0x1f, 0x02, 0x02}, // 4
+	{0x1f, 0x10, 0x1e, 0x01, 0x01, 0x11, 0x0e}, // 5
+	{0x06, 0x08, 0x10, 0x1e, 0x11, 0x11, 0x0e}, // 6
+	{0x1f, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08}, // 7
+	{0x0e, 0x11, 0x11, 0x0e, 0x11, 0x11, 0x0e}, // 8
+	{0x0e, 0x11, 0x11, 0x0f, 0x01, 0x02, 0x0c}, // 9
+}
+
+// Width 验证码图片宽度
+func Width(length int) int {
+	return length*charW + 16
+}
+
+// Generate 生成指定长度的数字验证码和PNG图片
+func Generate(length int) (string, []byte, error) {
+	if length <= 0 {
+		return "", nil, errors.New("invalid captcha length")
+	}
+
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", nil, err
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+
+	img, err := Image(string(code))
+	if err != nil {
+		return "", nil, err
+	}
+	return string(code), img, nil
+}
+
+// Image 绘制验证码图片，字符位置、颜色随机并添加干扰线和噪点
+func Image(code string) ([]byte, error) {
+	if code == "" {
+		return nil, errors.New("invalid captcha code")
+	}
+	for _, ch := range code {
+		if ch < '0' || ch > '9' {
+			return nil, errors.New("invalid captcha code")
+		}
+	}
+
+	width := Width(len(code))
+	img := image.NewRGBA(image.Rect(0, 0, width, Height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < Height; y++ {
+			img.Set(x, y, color.RGBA{245, 245, 245, 255})
+		}
+	}
+
+	for i, ch := range code {
+		c := randomColor()
+		offsetX := 8 + i*charW + mrand.Intn(charW-glyphW*scale+1)
+		offsetY := mrand.Intn(Height - glyphH*scale + 1)
+		glyph := glyphs[ch-'0']
+		for row := 0; row < glyphH; row++ {
+			for col := 0; col < glyphW; col++ {
+				if glyph[row]&(1<<(glyphW-1-col)) == 0 {
+					continue
+				}
+				fillRect(img, offsetX+col*scale, offsetY+row*scale, scale, scale, c)
+			}
+		}
+	}
+
+	for i := 0; i < 4; i++ {
+		drawLine(img, mrand.Intn(width), mrand.Intn(Height), mrand.Intn(width), mrand.Intn(Height), randomColor())
+	}
+	for i := 0; i < width*Height/20; i++ {
+		img.Set(mrand.Intn(width), mrand.Intn(Height), randomColor())
+	}
+
+	buf := &bytes.Buffer{}
+	err := png.Encode(buf, img)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// 深色随机颜色，与浅色背景区分
+func randomColor() color.RGBA {
+	return color.RGBA{uint8(mrand.Intn(150)), uint8(mrand.Intn(150)), uint8(mrand.Intn(150)), 255}
+}
+
+func fillRect(img *image.RGBA, x, y, w, h int, c color.RGBA) {
+	for i := x; i < x+w; i++ {
+		for j := y; j < y+h; j++ {
+			img.Set(i, j, c)
+		}
+	}
+}
+
+// Bresenham画线
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		img.Set(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,41 @@
+package captcha
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	code, data, err := Generate(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) != 4 {
+		t.Fatalf("got code %q, want 4 digits", code)
+	}
+	for _, ch := range code {
+		if ch < '0' || ch > '9' {
+			t.Fatalf("got code %q, want digits", code)
+		}
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != Width(4) || b.Dy() != Height {
+		t.Errorf("got size %dx%d, want %dx%d", b.Dx(), b.Dy(), Width(4), Height)
+	}
+}
+
+func TestImageInvalid(t *testing.T) {
+	for _, code := range []string{"", "12a4"} {
+		if _, err := Image(code); err == nil {
+			t.Errorf("expected error for %q", code)
+		}
+	}
+	if _, _, err := Generate(0); err == nil {
+		t.Error("expected error for zero length")
+	}
+}
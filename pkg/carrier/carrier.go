@@ -0,0 +1,118 @@
+package carrier
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// 面单格式
+	LabelFormatPDF = "pdf"
+	LabelFormatZPL = "zpl"
+)
+
+const (
+	// 物流事件状态，与包裹状态一致
+	TrackingStatusInTransit = "in_transit" // 运输中
+	TrackingStatusDelivered = "delivered"  // 已签收
+	TrackingStatusException = "exception"  // 异常
+)
+
+// Address 收发件地址
+type Address struct {
+	Name     string `json:"name"`
+	Phone    string `json:"phone"`
+	Email    string `json:"email"`
+	Country  string `json:"country"`
+	Province string `json:"province"`
+	City     string `json:"city"`
+	Address  string `json:"address"`
+	Zip      string `json:"zip"`
+}
+
+// Parcel 包裹尺寸和重量
+type Parcel struct {
+	Weight float64 `json:"weight"`
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// RateRequest 运费询价
+type RateRequest struct {
+	From    Address   `json:"from"`
+	To      Address   `json:"to"`
+	Parcels []*Parcel `json:"parcels"`
+}
+
+// Rate 运费报价
+type Rate struct {
+	Service       string  `json:"service"`        // 服务类型
+	Amount        float64 `json:"amount"`         // 运费
+	Currency      string  `json:"currency"`       // 货币代码
+	EstimatedDays int     `json:"estimated_days"` // 预计送达天数
+}
+
+// LabelRequest 创建面单
+type LabelRequest struct {
+	Reference string    `json:"reference"` // 业务单号，一般为订单编号
+	Service   string    `json:"service"`
+	Format    string    `json:"format"` // pdf、zpl
+	From      Address   `json:"from"`
+	To        Address   `json:"to"`
+	Parcels   []*Parcel `json:"parcels"`
+}
+
+// Label 面单
+type Label struct {
+	TrackingNo string `json:"tracking_no"`
+	Format     string `json:"format"`
+	Data       []byte `json:"data"` // 面单文件内容，JSON中为base64
+}
+
+// TrackingEvent 物流轨迹
+type TrackingEvent struct {
+	Status      string `json:"status"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+	OccurredAt  string `json:"occurred_at"` // 2006-01-02 15:04:05
+}
+
+// Carrier 承运商
+type Carrier interface {
+	// Name 承运商名称
+	Name() string
+	// Rates 运费询价
+	Rates(ctx context.Context, req *RateRequest) ([]*Rate, error)
+	// CreateLabel 创建面单
+	CreateLabel(ctx context.Context, req *LabelRequest) (*Label, error)
+	// Track 查询物流轨迹
+	Track(ctx context.Context, trackingNo string) ([]*TrackingEvent, error)
+}
+
+// Config 承运商配置
+type Config struct {
+	Name    string // 承运商名称，与Shipment.Carrier一致
+	Type    string // 适配器类型，目前支持http
+	BaseURL string // 接口地址
+	ApiKey  string // 接口密钥
+	Timeout int    // 请求超时（秒）
+}
+
+// New 根据配置创建承运商
+func New(conf Config) (Carrier, error) {
+	timeout := time.Duration(conf.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	switch conf.Type {
+	case "", "http":
+		if conf.BaseURL == "" {
+			return nil, errors.New("carrier base url is required")
+		}
+		return NewHTTPCarrier(conf.Name, conf.BaseURL, conf.ApiKey, timeout), nil
+	}
+	return nil, errors.New("unsupported carrier type: " + conf.Type)
+}
@@ -0,0 +1,117 @@
+package carrier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HTTPCarrier 基于HTTP JSON接口的承运商适配器
+//
+//	POST {BaseURL}/rates           RateRequest  -> {"rates": [Rate]}
+//	POST {BaseURL}/labels          LabelRequest -> Label
+//	GET  {BaseURL}/tracking/{no}                -> {"events": [TrackingEvent]}
+//
+// 请求头携带 Authorization: Bearer {ApiKey}，可以指向本地mock服务进行测试
+type HTTPCarrier struct {
+	name    string
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewHTTPCarrier(name, baseURL, apiKey string, timeout time.Duration) *HTTPCarrier {
+	return &HTTPCarrier{
+		name:    name,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *HTTPCarrier) Name() string {
+	return c.name
+}
+
+func (c *HTTPCarrier) Rates(ctx context.Context, req *RateRequest) ([]*Rate, error) {
+	res := struct {
+		Rates []*Rate `json:"rates"`
+	}{}
+	if err := c.do(ctx, http.MethodPost, "/rates", req, &res); err != nil {
+		return nil, err
+	}
+	return res.Rates, nil
+}
+
+func (c *HTTPCarrier) CreateLabel(ctx context.Context, req *LabelRequest) (*Label, error) {
+	if req.Format == "" {
+		req.Format = LabelFormatPDF
+	}
+	if req.Format != LabelFormatPDF && req.Format != LabelFormatZPL {
+		return nil, fmt.Errorf("unsupported label format: %s", req.Format)
+	}
+
+	label := &Label{}
+	if err := c.do(ctx, http.MethodPost, "/labels", req, label); err != nil {
+		return nil, err
+	}
+	if label.TrackingNo == "" || len(label.Data) == 0 {
+		return nil, fmt.Errorf("carrier %s returned an empty label", c.name)
+	}
+	if label.Format == "" {
+		label.Format = req.Format
+	}
+	return label, nil
+}
+
+func (c *HTTPCarrier) Track(ctx context.Context, trackingNo string) ([]*TrackingEvent, error) {
+	res := struct {
+		Events []*TrackingEvent `json:"events"`
+	}{}
+	if err := c.do(ctx, http.MethodGet, "/tracking/"+url.PathEscape(trackingNo), nil, &res); err != nil {
+		return nil, err
+	}
+	return res.Events, nil
+}
+
+func (c *HTTPCarrier) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("carrier %s %s %s: status %d: %s", c.name, method, path, resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+
+	return json.Unmarshal(b, out)
+}
@@ -0,0 +1,97 @@
+package carrier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newMockCarrier(t *testing.T) *HTTPCarrier {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rates", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		req := &RateRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"rates": []*Rate{{Service: "standard", Amount: 10 * float64(len(req.Parcels)), Currency: "CNY", EstimatedDays: 3}},
+		})
+	})
+	mux.HandleFunc("/labels", func(w http.ResponseWriter, r *http.Request) {
+		req := &LabelRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+		json.NewEncoder(w).Encode(&Label{TrackingNo: "TN-" + req.Reference, Format: req.Format, Data: []byte("%PDF-1.4")})
+	})
+	mux.HandleFunc("/tracking/TN-1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"events": []*TrackingEvent{{Status: TrackingStatusDelivered, OccurredAt: "2026-01-02 10:00:00"}},
+		})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return NewHTTPCarrier("mock", srv.URL+"/", "test-key", time.Second)
+}
+
+func TestHTTPCarrierRates(t *testing.T) {
+	c := newMockCarrier(t)
+
+	rates, err := c.Rates(context.Background(), &RateRequest{Parcels: []*Parcel{{Weight: 1}, {Weight: 2}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 1 || rates[0].Amount != 20 {
+		t.Fatalf("unexpected rates: %+v", rates)
+	}
+}
+
+func TestHTTPCarrierCreateLabel(t *testing.T) {
+	c := newMockCarrier(t)
+
+	label, err := c.CreateLabel(context.Background(), &LabelRequest{Reference: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if label.TrackingNo != "TN-1" || label.Format != LabelFormatPDF || string(label.Data) != "%PDF-1.4" {
+		t.Fatalf("unexpected label: %+v", label)
+	}
+
+	_, err = c.CreateLabel(context.Background(), &LabelRequest{Reference: "1", Format: "png"})
+	if err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
+
+func TestHTTPCarrierTrack(t *testing.T) {
+	c := newMockCarrier(t)
+
+	events, err := c.Track(context.Background(), "TN-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Status != TrackingStatusDelivered {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	_, err = c.Track(context.Background(), "TN-404")
+	if err == nil {
+		t.Fatal("expected error for unknown tracking number")
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Config{Name: "x", Type: "ftp", BaseURL: "http://localhost"}); err == nil {
+		t.Fatal("expected error for unsupported type")
+	}
+	if _, err := New(Config{Name: "x"}); err == nil {
+		t.Fatal("expected error for missing base url")
+	}
+}
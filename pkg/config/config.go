@@ -8,26 +8,157 @@ import (
 )
 
 type Config struct {
-	ServerPort      string            // 服务端口
-	LogConfig       LogConfig         // 日志配置
-	MySQL           MySQLConfig       // mysql配置
-	TencentCloud    TencenCloudConfig // 腾讯云配置
-	PkgFileDir      string            // 包文件存放目录
-	UserInfoAddress string            // 用户信息地址
-	Upload          UploadConfig      // 上传配置
-	PasswdKey       string            // 密码加密key
-	MailConfig      MailConfig        // 邮件配置
-	RedisConfig     RedisConfig       // redis配置
-	NoRouterFoward  string            // 是否转发没有路由的请求
-	ForwardPrefix   []string          // 转发前缀
-	ForwardAddress  string            // 转发地址
-	ApiPrefix       string            // api前缀
+	ServerPort       string                 // 服务端口
+	LogConfig        LogConfig              // 日志配置
+	MySQL            MySQLConfig            // mysql配置
+	TencentCloud     TencenCloudConfig      // 腾讯云配置
+	PkgFileDir       string                 // 包文件存放目录
+	UserInfoAddress  string                 // 用户信息地址
+	Upload           UploadConfig           // 上传配置
+	PasswdKey        string                 // 密码加密key
+	MailConfig       MailConfig             // 邮件配置
+	RedisConfig      RedisConfig            // redis配置
+	NoRouterFoward   string                 // 是否转发没有路由的请求
+	ForwardPrefix    []string               // 转发前缀
+	ForwardAddress   string                 // 转发地址
+	ApiPrefix        string                 // api前缀
+	Digital          DigitalConfig          // 虚拟产品配置
+	Shipping         ShippingConfig         // 物流配置
+	Invoice          InvoiceConfig          // 发票配置
+	OrderNo          OrderNoConfig          // 订单号配置
+	VerificationCode VerificationCodeConfig // 验证码配置
+	Auth             AuthConfig             // 登录认证配置
+	Tenant           TenantConfig           // 多租户配置
+	TeamInvitation   TeamInvitationConfig   // 团队邀请配置
+	Webhook          WebhookConfig          // webhook配置
+	OpenAPI          OpenAPIConfig          // 开放接口配置
+	RateLimit        RateLimitConfig        // 限流配置
+	LoginLock        LoginLockConfig        // 登录锁定配置
 }
 
 type UploadConfig struct {
 	Dir string
 }
 
+// 虚拟产品配置
+type DigitalConfig struct {
+	Dir          string // 虚拟产品文件目录，不能放在Upload.Dir下
+	SignKey      string // 下载链接签名key
+	LinkExpire   int    // 下载链接有效期（秒）
+	MaxDownloads int    // 默认最大下载次数
+	ExpireDays   int    // 默认下载有效天数
+}
+
+// 物流配置
+type ShippingConfig struct {
+	FromName     string          // 发件人
+	FromPhone    string          // 发件人电话
+	FromCountry  string          // 发件国家
+	FromProvince string          // 发件省份
+	FromCity     string          // 发件城市
+	FromAddress  string          // 发件地址
+	FromZip      string          // 发件邮编
+	LabelFormat  string          // 默认面单格式 pdf、zpl
+	PollInterval int             // 物流轨迹查询间隔（分钟）
+	Carriers     []CarrierConfig // 承运商列表
+}
+
+// 登录认证配置，为0时使用默认值
+type AuthConfig struct {
+	AccessTokenExpire  int // 访问token有效期（分钟），默认15
+	RefreshTokenExpire int // 刷新token有效期（小时），默认720
+	// 接口权限校验方式 rbac:角色菜单权限位 casbin:casbin策略，默认rbac
+	Authorizer string
+}
+
+// 多租户配置，租户为团队，开启后商城数据按团队隔离
+type TenantConfig struct {
+	Enable bool // 是否开启多租户
+	// 商城域名没有绑定团队时使用的团队uuid，为空时使用未分配团队的数据
+	DefaultTeam string
+}
+
+// 团队邀请配置
+type TeamInvitationConfig struct {
+	Expire int // 邀请有效期（小时），默认72
+	// 接受邀请的页面地址，邮件中的链接为 地址?token=xxx，为空时邮件中只包含token
+	AcceptURL string
+}
+
+// webhook配置，为0时使用默认值
+type WebhookConfig struct {
+	Timeout     int // 推送超时时间（秒），默认10
+	MaxAttempts int // 最大投递次数，默认10，第n次失败后等待2^(n-1)分钟重试
+}
+
+// 开放接口配置，为0时使用默认值
+type OpenAPIConfig struct {
+	RateLimit     float64 // 每个app每秒请求数，默认10，app单独设置时使用app的设置
+	RateBurst     int     // 每个app突发请求数，默认20
+	TimestampSkew int     // 请求时间戳与服务器时间允许的误差（秒），默认60
+}
+
+// 限流配置，使用redis在多个实例间共享，redis不可用时使用本地限流
+type RateLimitConfig struct {
+	Rules []RateLimitRule // 限流规则，login、two_factor、captcha、register、verification_code、checkout没有配置时使用默认规则
+}
+
+// 限流规则
+type RateLimitRule struct {
+	Name   string  // 规则名称，路由通过名称使用规则
+	By     string  // 限流维度 ip、user、app、route，默认ip，user和app未登录时使用ip
+	Rate   float64 // 周期内允许的请求数
+	Period int     // 周期（秒），默认60
+	Burst  int     // 突发请求数，默认与Rate相同
+}
+
+// 登录锁定配置，为0时使用默认值
+// 失败次数配置了redis时保存在redis中，锁定记录保存在数据库中
+type LoginLockConfig struct {
+	MaxFailures     int // 同一账号在统计窗口内允许的失败次数，达到后锁定账号，默认5
+	IpMaxFailures   int // 同一IP在统计窗口内允许的失败次数，达到后锁定IP，默认20
+	Window          int // 失败次数统计窗口（分钟），默认15
+	LockDuration    int // 首次锁定时长（分钟），连续锁定时每次翻倍，默认5
+	MaxLockDuration int // 最长锁定时长（分钟），超过该时间没有再次锁定时重新计算，默认1440
+	CaptchaAfter    int // 失败次数达到后登录需要图形验证码，默认3，小于0时不需要
+	CaptchaExpire   int // 图形验证码有效期（秒），默认300
+}
+
+// 验证码配置，为0时使用默认值
+type VerificationCodeConfig struct {
+	Expire         int // 有效期（分钟），默认5
+	Interval       int // 同一接收者发送间隔（秒），默认60
+	MaxAttempts    int // 最大错误次数，达到后验证码失效，默认5
+	RecipientLimit int // 每个接收者每小时最多发送次数，默认10
+	IpLimit        int // 每个IP每小时最多发送次数，默认20
+}
+
+// 订单号配置，订单号格式为 前缀-日期-序号，例如 SG-20261017-000123
+type OrderNoConfig struct {
+	Prefix     string // 前缀，为空时不包含前缀
+	DateFormat string // 日期格式，使用Go时间格式，为空时不包含日期
+	Sequence   string // 序号规则 daily 每天从1开始、global 全局递增
+	SeqLength  int    // 序号最小位数，不足补0
+}
+
+// 发票配置
+type InvoiceConfig struct {
+	Prefix       string  // 发票号前缀，例如INV，发票号为 前缀-年份-序号
+	CurrencyCode string  // 商品未设置货币时使用的货币代码
+	TaxName      string  // 税种名称
+	TaxRate      float64 // 税率（百分比），商品价格为含税价
+	AttachToMail bool    // 支付成功邮件是否附带发票
+}
+
+// 承运商配置
+type CarrierConfig struct {
+	Name    string // 承运商名称，与发货包裹的承运商一致
+	Type    string // 适配器类型，目前支持http
+	BaseURL string // 接口地址
+	ApiKey  string // 接口密钥
+	Timeout int    // 请求超时（秒）
+}
+
 type LogConfig struct {
 	Level        string // 日志级别
 	Format       string // 日志格式
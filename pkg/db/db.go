@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"sgin/pkg/config"
+	"sgin/pkg/tenant"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -17,5 +18,9 @@ func GetDB(cfg config.MySQLConfig) *gorm.DB {
 	if err != nil {
 		log.Fatalf("failed to connect to mysql: %v", err)
 	}
+	err = tenant.Register(db)
+	if err != nil {
+		log.Fatalf("failed to register tenant plugin: %v", err)
+	}
 	return db
 }
@@ -1,6 +1,7 @@
 package mail
 
 import (
+	"io"
 	"strings"
 
 	"gopkg.in/gomail.v2"
@@ -14,6 +15,14 @@ type Options struct {
 	MailTo   string // 收件人 多个用,分割
 	Subject  string // 邮件主题
 	Body     string // 邮件内容
+
+	Attachments []*Attachment // 附件
+}
+
+// 邮件附件
+type Attachment struct {
+	Name string // 文件名
+	Data []byte // 文件内容
 }
 
 func Send(o *Options) error {
@@ -33,6 +42,14 @@ func Send(o *Options) error {
 	//设置邮件正文
 	m.SetBody("text/html", o.Body)
 
+	for _, attachment := range o.Attachments {
+		data := attachment.Data
+		m.Attach(attachment.Name, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		}))
+	}
+
 	d := gomail.NewDialer(o.MailHost, o.MailPort, o.MailUser, o.MailPass)
 
 	return d.DialAndSend(m)
@@ -3,6 +3,7 @@ package paymentmethod
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sgin/pkg/app"
 
@@ -34,26 +35,8 @@ func (a *Alipay) CreateOrder(ctx *app.Context, orderId string, currencyCode stri
 	b, _ := json.Marshal(a)
 	ctx.Logger.Info("Alipay config:", string(b))
 
-	client, err := alipay.NewClient(a.AppID, a.PrivateKey, false)
+	client, err := a.newClient(ctx)
 	if err != nil {
-		ctx.Logger.Error("Failed to create alipay client:", err)
-		return
-	}
-
-	client.SetLocation(alipay.LocationShanghai). // 设置时区，不设置或出错均为默认服务器时间
-							SetCharset(alipay.UTF8).                                               // 设置字符编码，不设置默认 utf-8
-							SetSignType(alipay.RSA2).                                              // 设置签名类型，不设置默认 RSA2
-							SetReturnUrl("http://sgin-shop.biggerforum.org/api/v1/alipay/return"). // 设置返回URL
-							SetNotifyUrl("http://sgin-shop.biggerforum.org/api/v1/alipay/notify"). // 设置异步通知URL
-							SetAppAuthToken("")                                                    // 设置第三方应用授权
-
-	// 创建订单
-
-	client.AutoVerifySign([]byte(a.AlipayPublicCert))
-
-	err = client.SetCertSnByContent([]byte(a.AppPublicCert), []byte(a.AlipayRootCert), []byte(a.AlipayPublicCert))
-	if err != nil {
-		ctx.Logger.Error("Failed to set cert sn by content:", err)
 		return
 	}
 
@@ -82,3 +65,81 @@ func (a *Alipay) CreateOrder(ctx *app.Context, orderId string, currencyCode stri
 	return r, nil
 
 }
+
+// 创建支付宝客户端
+func (a *Alipay) newClient(ctx *app.Context) (*alipay.Client, error) {
+	client, err := alipay.NewClient(a.AppID, a.PrivateKey, false)
+	if err != nil {
+		ctx.Logger.Error("Failed to create alipay client:", err)
+		return nil, err
+	}
+
+	client.SetLocation(alipay.LocationShanghai). // 设置时区，不设置或出错均为默认服务器时间
+							SetCharset(alipay.UTF8).                                               // 设置字符编码，不设置默认 utf-8
+							SetSignType(alipay.RSA2).                                              // 设置签名类型，不设置默认 RSA2
+							SetReturnUrl("http://sgin-shop.biggerforum.org/api/v1/alipay/return"). // 设置返回URL
+							SetNotifyUrl("http://sgin-shop.biggerforum.org/api/v1/alipay/notify"). // 设置异步通知URL
+							SetAppAuthToken("")                                                    // 设置第三方应用授权
+
+	client.AutoVerifySign([]byte(a.AlipayPublicCert))
+
+	err = client.SetCertSnByContent([]byte(a.AppPublicCert), []byte(a.AlipayRootCert), []byte(a.AlipayPublicCert))
+	if err != nil {
+		ctx.Logger.Error("Failed to set cert sn by content:", err)
+		return nil, err
+	}
+	return client, nil
+}
+
+// Charge 使用代扣协议扣款
+func (a *Alipay) Charge(ctx *app.Context, agreementNo string, orderId string, currencyCode string, amount float64, description string) (string, error) {
+	client, err := a.newClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	bm := make(gopay.BodyMap)
+	bm.Set("subject", description).
+		Set("product_code", "GENERAL_WITHHOLDING").
+		Set("out_trade_no", orderId).
+		Set("total_amount", fmt.Sprintf("%.2f", amount)).
+		SetBodyMap("agreement_params", func(b gopay.BodyMap) {
+			b.Set("agreement_no", agreementNo)
+		})
+
+	aliRsp, err := client.TradePay(context.Background(), bm)
+	if err != nil {
+		ctx.Logger.Errorf("Failed to withholding trade pay: %+v， bm: %+v", err, bm)
+		return "", err
+	}
+
+	if aliRsp.Response == nil {
+		return "", errors.New("empty alipay response")
+	}
+	return aliRsp.Response.TradeNo, nil
+}
+
+// Refund 统一收单交易退款
+func (a *Alipay) Refund(ctx *app.Context, orderId string, refundNo string, amount float64, reason string) (string, error) {
+	client, err := a.newClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	bm := make(gopay.BodyMap)
+	bm.Set("out_trade_no", orderId).
+		Set("out_request_no", refundNo).
+		Set("refund_amount", fmt.Sprintf("%.2f", amount)).
+		Set("refund_reason", reason)
+
+	aliRsp, err := client.TradeRefund(context.Background(), bm)
+	if err != nil {
+		ctx.Logger.Errorf("Failed to trade refund: %+v， bm: %+v", err, bm)
+		return "", err
+	}
+
+	if aliRsp.Response == nil {
+		return "", errors.New("empty alipay response")
+	}
+	return aliRsp.Response.TradeNo, nil
+}
@@ -0,0 +1,9 @@
+package paymentmethod
+
+import "sgin/pkg/app"
+
+// RecurringPayment 支持周期扣款的支付方式
+type RecurringPayment interface {
+	// Charge 使用代扣协议扣款，返回渠道交易号
+	Charge(ctx *app.Context, agreementNo string, orderId string, currencyCode string, amount float64, description string) (string, error)
+}
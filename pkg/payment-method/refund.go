@@ -0,0 +1,9 @@
+package paymentmethod
+
+import "sgin/pkg/app"
+
+// RefundPayment 支持原路退款的支付方式
+type RefundPayment interface {
+	// Refund 按订单退款，refundNo用于同一订单多次部分退款，返回渠道退款交易号
+	Refund(ctx *app.Context, orderId string, refundNo string, amount float64, reason string) (string, error)
+}
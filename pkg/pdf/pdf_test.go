@@ -0,0 +1,65 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestTextWidth(t *testing.T) {
+	if w := TextWidth("ab", 10); w != 10 {
+		t.Fatalf("ascii width = %v, want 10", w)
+	}
+	if w := TextWidth("发票", 10); w != 20 {
+		t.Fatalf("cjk width = %v, want 20", w)
+	}
+}
+
+func TestEncodeText(t *testing.T) {
+	if s := encodeText("A发"); s != "004153D1" {
+		t.Fatalf("encodeText = %s", s)
+	}
+	if s := encodeText("😀"); s != "003F" {
+		t.Fatalf("non-BMP encodeText = %s", s)
+	}
+}
+
+func TestDocumentBytes(t *testing.T) {
+	doc := New()
+	doc.AddPage()
+	doc.Text(40, 40, 12, "发票 INV-2026-000001")
+	doc.Line(40, 50, 500, 50, 0.5)
+	doc.Image([]byte{0xff, 0xd8, 0xff, 0xd9}, 1, 1, 40, 60, 20, 20)
+	doc.AddPage()
+	doc.TextRight(500, 40, 12, "packing slip")
+
+	data := doc.Bytes()
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) {
+		t.Fatal("missing pdf header")
+	}
+	if !bytes.Contains(data, []byte("/Count 2")) {
+		t.Fatal("page count should be 2")
+	}
+
+	// xref中每个对象的偏移都应指向对象开始位置
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatal("startxref does not point to xref table")
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	if len(entries) != 10 {
+		t.Fatalf("xref entries = %d, want 10", len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		prefix := []byte(strconv.Itoa(i+1) + " 0 obj")
+		if !bytes.HasPrefix(data[offset:], prefix) {
+			t.Fatalf("object %d offset %d is wrong", i+1, offset)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package redisop
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// 本地限流最多保存的key数量，超过时清理已恢复满额的key
+const localLimiterMaxKeys = 10000
+
+// Limit 限流规则，每个周期允许Rate个请求，最多允许Burst个请求同时到达
+type Limit struct {
+	Rate   float64       // 周期内允许的请求数
+	Period time.Duration // 周期
+	Burst  int           // 突发请求数
+}
+
+// LimitResult 限流结果
+type LimitResult struct {
+	Allowed    bool          // 是否允许
+	Remaining  int           // 剩余可用的请求数
+	RetryAfter time.Duration // 被拒绝时需要等待的时间
+	ResetAfter time.Duration // 恢复到满额需要的时间
+}
+
+// 参数无效时使用最小值，避免除零
+func (l Limit) normalize() Limit {
+	if l.Rate <= 0 {
+		l.Rate = 1
+	}
+	if l.Period <= 0 {
+		l.Period = time.Second
+	}
+	if l.Burst <= 0 {
+		l.Burst = 1
+	}
+	return l
+}
+
+// 每个请求的间隔
+func (l Limit) emissionInterval() time.Duration {
+	return time.Duration(float64(l.Period) / l.Rate)
+}
+
+// GCRA算法，key保存理论到达时间，时间使用redis服务器时间
+var gcraScript = redis.NewScript(`
+redis.replicate_commands()
+
+local key = KEYS[1]
+local burst = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local period = tonumber(ARGV[3])
+
+local emission_interval = period / rate
+local burst_offset = emission_interval * burst
+
+-- 以2017-01-01为起点，避免浮点数精度问题
+local jan_1_2017 = 1483228800
+local now = redis.call("TIME")
+now = (now[1] - jan_1_2017) + (now[2] / 1000000)
+
+local tat = redis.call("GET", key)
+if not tat then
+  tat = now
+else
+  tat = tonumber(tat)
+end
+tat = math.max(tat, now)
+
+local new_tat = tat + emission_interval
+local allow_at = new_tat - burst_offset
+local diff = now - allow_at
+local remaining = diff / emission_interval
+
+if remaining < 0 then
+  return {0, 0, tostring(-diff), tostring(tat - now)}
+end
+
+local reset_after = new_tat - now
+redis.call("SET", key, new_tat, "EX", math.ceil(reset_after))
+return {1, remaining, "0", tostring(reset_after)}
+`)
+
+// AllowRate 使用redis限流，多个实例共享限流状态
+func (c *RedisClient) AllowRate(ctx context.Context, key string, limit Limit) (*LimitResult, error) {
+	limit = limit.normalize()
+	args := []interface{}{limit.Burst, limit.Rate, limit.Period.Seconds()}
+
+	var (
+		v   interface{}
+		err error
+	)
+	if c.isCluster {
+		v, err = gcraScript.Run(ctx, c.clusterClient, []string{key}, args...).Result()
+	} else {
+		v, err = gcraScript.Run(ctx, c.standaloneClient, []string{key}, args...).Result()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := v.([]interface{})
+	if !ok || len(values) != 4 {
+		return nil, errors.New("unexpected rate limit result")
+	}
+	allowed, _ := values[0].(int64)
+	remaining, _ := values[1].(int64)
+	retryAfter, err := parseSeconds(values[2])
+	if err != nil {
+		return nil, err
+	}
+	resetAfter, err := parseSeconds(values[3])
+	if err != nil {
+		return nil, err
+	}
+
+	return &LimitResult{
+		Allowed:    allowed == 1,
+		Remaining:  int(remaining),
+		RetryAfter: retryAfter,
+		ResetAfter: resetAfter,
+	}, nil
+}
+
+func parseSeconds(v interface{}) (time.Duration, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, errors.New("unexpected rate limit result")
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(f * float64(time.Second)), nil
+}
+
+// LocalLimiter 本地限流，算法与redis限流一致，只对当前实例有效
+type LocalLimiter struct {
+	mu   sync.Mutex
+	tats map[string]time.Time
+	now  func() time.Time
+}
+
+func NewLocalLimiter() *LocalLimiter {
+	return &LocalLimiter{
+		tats: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+// Allow 本地限流
+func (l *LocalLimiter) Allow(key string, limit Limit) *LimitResult {
+	limit = limit.normalize()
+	emission := limit.emissionInterval()
+	burstOffset := emission * time.Duration(limit.Burst)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	tat, ok := l.tats[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+
+	newTat := tat.Add(emission)
+	diff := now.Sub(newTat.Add(-burstOffset))
+	if diff < 0 {
+		return &LimitResult{
+			Allowed:    false,
+			Remaining:  0,
+			RetryAfter: -diff,
+			ResetAfter: tat.Sub(now),
+		}
+	}
+
+	// 已恢复满额的key与不存在时相同，key过多时清理
+	if !ok && len(l.tats) >= localLimiterMaxKeys {
+		for k, t := range l.tats {
+			if !t.After(now) {
+				delete(l.tats, k)
+			}
+		}
+	}
+	l.tats[key] = newTat
+
+	return &LimitResult{
+		Allowed:    true,
+		Remaining:  int(diff / emission),
+		ResetAfter: newTat.Sub(now),
+	}
+}
+
+// Limiter 限流器，使用redis在多个实例间共享限流，redis未配置或不可用时使用本地限流
+type Limiter struct {
+	client *RedisClient
+	local  *LocalLimiter
+}
+
+// NewLimiter client为nil时只使用本地限流
+func NewLimiter(client *RedisClient) *Limiter {
+	return &Limiter{
+		client: client,
+		local:  NewLocalLimiter(),
+	}
+}
+
+// Allow 检查key是否允许请求，redis出错时返回本地限流的结果和redis的错误
+func (l *Limiter) Allow(ctx context.Context, key string, limit Limit) (*LimitResult, error) {
+	if l.client == nil {
+		return l.local.Allow(key, limit), nil
+	}
+
+	res, err := l.client.AllowRate(ctx, key, limit)
+	if err != nil {
+		return l.local.Allow(key, limit), err
+	}
+	return res, nil
+}
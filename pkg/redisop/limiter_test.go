@@ -0,0 +1,118 @@
+package redisop
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func newTestLimiter(now *time.Time) *LocalLimiter {
+	l := NewLocalLimiter()
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestLocalLimiterBurst(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newTestLimiter(&now)
+	limit := Limit{Rate: 1, Period: time.Second, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		res := l.Allow("k", limit)
+		if !res.Allowed || res.Remaining != i {
+			t.Fatalf("got %+v, want allowed with %d remaining", res, i)
+		}
+	}
+
+	res := l.Allow("k", limit)
+	if res.Allowed {
+		t.Fatal("expected limited")
+	}
+	if res.RetryAfter != time.Second {
+		t.Errorf("got retry after %s, want 1s", res.RetryAfter)
+	}
+	if res.ResetAfter != 3*time.Second {
+		t.Errorf("got reset after %s, want 3s", res.ResetAfter)
+	}
+
+	// 其他key不受影响
+	if !l.Allow("other", limit).Allowed {
+		t.Error("expected other key allowed")
+	}
+
+	now = now.Add(time.Second)
+	res = l.Allow("k", limit)
+	if !res.Allowed || res.Remaining != 0 {
+		t.Errorf("got %+v, want allowed after retry", res)
+	}
+}
+
+func TestLocalLimiterPeriod(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newTestLimiter(&now)
+	limit := Limit{Rate: 5, Period: time.Minute, Burst: 5}
+
+	for i := 0; i < 5; i++ {
+		if !l.Allow("k", limit).Allowed {
+			t.Fatalf("request %d limited", i)
+		}
+	}
+	res := l.Allow("k", limit)
+	if res.Allowed || res.RetryAfter != 12*time.Second {
+		t.Errorf("got %+v, want limited for 12s", res)
+	}
+
+	now = now.Add(time.Minute)
+	res = l.Allow("k", limit)
+	if !res.Allowed || res.Remaining != 4 {
+		t.Errorf("got %+v, want full burst after a period", res)
+	}
+}
+
+func TestLocalLimiterCleanup(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newTestLimiter(&now)
+	limit := Limit{Rate: 1, Period: time.Second, Burst: 1}
+
+	for i := 0; i < localLimiterMaxKeys; i++ {
+		l.Allow(time.Duration(i).String(), limit)
+	}
+	now = now.Add(2 * time.Second)
+	l.Allow("new", limit)
+	if len(l.tats) != 1 {
+		t.Errorf("got %d keys, want expired keys removed", len(l.tats))
+	}
+}
+
+func TestLimiterWithoutRedis(t *testing.T) {
+	l := NewLimiter(nil)
+	limit := Limit{Rate: 1, Period: time.Minute, Burst: 1}
+
+	res, err := l.Allow(context.Background(), "k", limit)
+	if err != nil || !res.Allowed {
+		t.Fatalf("got %+v %v, want allowed", res, err)
+	}
+	res, err = l.Allow(context.Background(), "k", limit)
+	if err != nil || res.Allowed {
+		t.Errorf("got %+v %v, want limited", res, err)
+	}
+}
+
+func TestLimiterRedisDown(t *testing.T) {
+	client := NewRedisClient("127.0.0.1:1", "", 0)
+	defer client.Close()
+	l := NewLimiter(client)
+	limit := Limit{Rate: 1, Period: time.Minute, Burst: 1}
+
+	res, err := l.Allow(context.Background(), "k", limit)
+	if err == nil {
+		t.Skip("redis is reachable")
+	}
+	if !res.Allowed {
+		t.Errorf("got %+v, want local fallback allowed", res)
+	}
+	res, _ = l.Allow(context.Background(), "k", limit)
+	if res.Allowed {
+		t.Errorf("got %+v, want local fallback limited", res)
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
+// Nil key不存在时Get返回的错误
+const Nil = redis.Nil
+
 type RedisClient struct {
 	standaloneClient *redis.Client
 	clusterClient    *redis.ClusterClient
@@ -73,6 +76,44 @@ func (c *RedisClient) Get(ctx context.Context, key string) (string, error) {
 	return c.standaloneClient.Get(ctx, key).Result()
 }
 
+// SetNX 仅在key不存在时设置，用于分布式锁
+func (c *RedisClient) SetNX(ctx context.Context, key, value string, expiration time.Duration) (bool, error) {
+	if c.isCluster {
+		return c.clusterClient.SetNX(ctx, key, value, expiration).Result()
+	}
+	return c.standaloneClient.SetNX(ctx, key, value, expiration).Result()
+}
+
+// Incr 自增并返回自增后的值
+func (c *RedisClient) Incr(ctx context.Context, key string) (int64, error) {
+	if c.isCluster {
+		return c.clusterClient.Incr(ctx, key).Result()
+	}
+	return c.standaloneClient.Incr(ctx, key).Result()
+}
+
+// Expire 设置key的过期时间
+func (c *RedisClient) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	if c.isCluster {
+		return c.clusterClient.Expire(ctx, key, expiration).Err()
+	}
+	return c.standaloneClient.Expire(ctx, key, expiration).Err()
+}
+
+// Exists 判断key是否存在
+func (c *RedisClient) Exists(ctx context.Context, key string) (bool, error) {
+	var (
+		n   int64
+		err error
+	)
+	if c.isCluster {
+		n, err = c.clusterClient.Exists(ctx, key).Result()
+	} else {
+		n, err = c.standaloneClient.Exists(ctx, key).Result()
+	}
+	return n > 0, err
+}
+
 // Enqueue adds a value to the end of the queue with the given key
 func (c *RedisClient) Enqueue(ctx context.Context, key, value string) error {
 	if c.isCluster {
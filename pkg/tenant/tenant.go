@@ -0,0 +1,144 @@
+package tenant
+
+// 多租户数据隔离，租户为团队，数据表通过tenant_uuid字段区分租户
+// 请求的context中带有租户时，gorm插件自动为查询、更新和删除添加租户条件，创建时写入租户
+// context中没有租户时不做限制，用于后台任务和未开启多租户的部署
+
+import (
+	"context"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Column 租户字段
+const Column = "tenant_uuid"
+
+type contextKey struct{}
+
+// WithTenant 返回带有租户的context，tenantUuid为空表示未分配团队的数据
+func WithTenant(ctx context.Context, tenantUuid string) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantUuid)
+}
+
+// FromContext 获取context中的租户，没有设置租户时ok为false
+func FromContext(ctx context.Context) (tenantUuid string, ok bool) {
+	if ctx == nil {
+		return "", false
+	}
+	tenantUuid, ok = ctx.Value(contextKey{}).(string)
+	return
+}
+
+// Scope 按指定租户过滤，用于没有请求上下文时查询单个租户的数据，只对有租户字段的表生效
+func Scope(tenantUuid string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db.Statement.Context = WithTenant(db.Statement.Context, tenantUuid)
+		return db
+	}
+}
+
+// Global 不按租户过滤，用于跨租户全局唯一的数据，例如订单编号序列
+func Global(db *gorm.DB) *gorm.DB {
+	db.Statement.Context = context.WithValue(db.Statement.Context, contextKey{}, nil)
+	return db
+}
+
+// Register 注册租户插件
+func Register(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("tenant:create", beforeCreate); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant:query", addCondition); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("tenant:row", addCondition); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:update", beforeUpdate); err != nil {
+		return err
+	}
+	return cb.Delete().Before("gorm:delete").Register("tenant:delete", addCondition)
+}
+
+// 获取当前语句的租户，表没有租户字段或context中没有租户时ok为false
+func tenantField(db *gorm.DB) (string, bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return "", false
+	}
+	if db.Statement.Schema.LookUpField(Column) == nil {
+		return "", false
+	}
+	return FromContext(db.Statement.Context)
+}
+
+// 添加租户条件，原有条件用括号包起来，避免OR条件越过租户限制
+// 同一个语句多次执行时先去掉上次添加的租户条件再重新添加
+func addCondition(db *gorm.DB) {
+	tenantUuid, ok := tenantField(db)
+	if !ok {
+		return
+	}
+
+	cond := clause.Eq{Column: clause.Column{Table: db.Statement.Table, Name: Column}, Value: tenantUuid}
+	exprs := make([]clause.Expression, 0)
+	if c, ok := db.Statement.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			for _, expr := range where.Exprs {
+				if eq, ok := expr.(clause.Eq); ok && eq.Column == cond.Column {
+					continue
+				}
+				exprs = append(exprs, expr)
+			}
+		}
+	}
+	if len(exprs) > 0 {
+		exprs = []clause.Expression{clause.And(exprs...), cond}
+	} else {
+		exprs = []clause.Expression{cond}
+	}
+
+	c := db.Statement.Clauses["WHERE"]
+	c.Name = "WHERE"
+	c.Expression = clause.Where{Exprs: exprs}
+	db.Statement.Clauses["WHERE"] = c
+}
+
+// 更新时不允许修改租户
+func beforeUpdate(db *gorm.DB) {
+	if _, ok := tenantField(db); !ok {
+		return
+	}
+	db.Statement.Omits = append(db.Statement.Omits, Column)
+	addCondition(db)
+}
+
+// 创建时写入当前租户，忽略请求中传入的租户
+func beforeCreate(db *gorm.DB) {
+	tenantUuid, ok := tenantField(db)
+	if !ok {
+		return
+	}
+	field := db.Statement.Schema.LookUpField(Column)
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			elem := reflect.Indirect(rv.Index(i))
+			if elem.Kind() == reflect.Struct {
+				db.AddError(field.Set(db.Statement.Context, elem, tenantUuid))
+			}
+		}
+	case reflect.Struct:
+		db.AddError(field.Set(db.Statement.Context, rv, tenantUuid))
+	}
+
+	// Save更新不到记录时会改为冲突时更新全部字段的插入，主键属于其他租户时会覆盖其他租户的数据，改为冲突时不处理
+	if c, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
+		if onConflict, ok := c.Expression.(clause.OnConflict); ok && onConflict.UpdateAll {
+			db.Statement.AddClause(clause.OnConflict{DoNothing: true})
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package tenant_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/tenant"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 只生成SQL不连接数据库
+func openDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "root:root@tcp(127.0.0.1:3306)/sgin",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tenant.Register(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func tenantDB(t *testing.T, tenantUuid string) *gorm.DB {
+	return openDB(t).WithContext(tenant.WithTenant(context.Background(), tenantUuid))
+}
+
+// 商城数据表
+var commerceModels = []interface{}{
+	&model.Product{},
+	&model.ProductCategory{},
+	&model.ProductVariants{},
+	&model.ProductVariantsOption{},
+	&model.ProductVariantsOptionValue{},
+	&model.ProductItem{},
+	&model.ProductGroupItem{},
+	&model.Resource{},
+	&model.Payment{},
+	&model.Cart{},
+	&model.Order{},
+	&model.OrderItem{},
+	&model.OrderNote{},
+	&model.OrderAudit{},
+	&model.PaymentMethod{},
+	&model.Configuration{},
+	&model.UserAddress{},
+	&model.Currency{},
+	&model.Page{},
+	&model.InventoryLog{},
+	&model.ProductDigitalFile{},
+	&model.DigitalDownload{},
+	&model.LicenseKey{},
+	&model.SubscriptionPlan{},
+	&model.Subscription{},
+	&model.CustomerGroup{},
+	&model.CustomerGroupUser{},
+	&model.PriceList{},
+	&model.PriceListItem{},
+	&model.Shipment{},
+	&model.ShipmentItem{},
+	&model.ShipmentEvent{},
+	&model.ReturnRequest{},
+	&model.ReturnItem{},
+	&model.Refund{},
+	&model.Invoice{},
+	&model.Customer{},
+	&model.Webhook{},
+	&model.WebhookDelivery{},
+	&model.CustomerTag{},
+	&model.CustomerSegment{},
+}
+
+func TestQueryScoped(t *testing.T) {
+	db := tenantDB(t, "team-a")
+	for _, m := range commerceModels {
+		sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Model(m).Where("id = ?", 1).Find(m)
+		})
+		if !strings.Contains(sql, ".`tenant_uuid` = 'team-a'") {
+			t.Errorf("%T query not scoped: %s", m, sql)
+		}
+
+		sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			var count int64
+			return tx.Model(m).Count(&count)
+		})
+		if !strings.Contains(sql, ".`tenant_uuid` = 'team-a'") {
+			t.Errorf("%T count not scoped: %s", m, sql)
+		}
+	}
+}
+
+func TestUpdateDeleteScoped(t *testing.T) {
+	db := tenantDB(t, "team-a")
+	for _, m := range commerceModels {
+		sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Model(m).Where("id = ?", 1).Update("tenant_uuid", "team-b")
+		})
+		if strings.Contains(sql, "team-b") {
+			t.Errorf("%T update changed tenant: %s", m, sql)
+		}
+
+		sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Where("id = ?", 1).Delete(m)
+		})
+		if !strings.Contains(sql, ".`tenant_uuid` = 'team-a'") {
+			t.Errorf("%T delete not scoped: %s", m, sql)
+		}
+	}
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&model.Product{}).Where("uuid = ?", "p1").Updates(map[string]interface{}{"name": "x", "tenant_uuid": "team-b"})
+	})
+	if !strings.Contains(sql, "SET `name`='x'") || strings.Contains(sql, "team-b") || !strings.Contains(sql, "`products`.`tenant_uuid` = 'team-a'") {
+		t.Errorf("update not scoped: %s", sql)
+	}
+}
+
+// OR条件不能越过租户条件
+func TestOrCondition(t *testing.T) {
+	db := tenantDB(t, "team-a")
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("name = ?", "a").Or("name = ?", "b").Find(&[]*model.Product{})
+	})
+	want := "WHERE (name = 'a' OR name = 'b') AND `products`.`tenant_uuid` = 'team-a'"
+	if !strings.Contains(sql, want) {
+		t.Errorf("got %s, want %s", sql, want)
+	}
+
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("name = ? OR sku = ?", "a", "b").Find(&[]*model.ProductItem{})
+	})
+	want = "WHERE (name = 'a' OR sku = 'b') AND `product_items`.`tenant_uuid` = 'team-a'"
+	if !strings.Contains(sql, want) {
+		t.Errorf("got %s, want %s", sql, want)
+	}
+}
+
+// 同一个查询先统计再查询时只添加一次租户条件
+func TestRepeatedExecution(t *testing.T) {
+	db := tenantDB(t, "team-a")
+	query := db.Model(&model.Order{}).Where("status = ?", "paid")
+
+	var count int64
+	query.Count(&count)
+	stmt := query.Find(&[]*model.Order{}).Statement
+	sql := stmt.SQL.String()
+	if strings.Count(sql, "tenant_uuid") != 1 {
+		t.Errorf("tenant condition repeated: %s", sql)
+	}
+}
+
+func TestJoinScoped(t *testing.T) {
+	db := tenantDB(t, "team-a")
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Model(&model.ReturnItem{}).
+			Joins("JOIN return_requests ON return_requests.uuid = return_items.return_uuid").
+			Select("return_items.order_item_id").Scan(&[]*model.ReturnItem{})
+	})
+	if !strings.Contains(sql, "`return_items`.`tenant_uuid` = 'team-a'") {
+		t.Errorf("join not scoped: %s", sql)
+	}
+}
+
+// 关联和条件中的子查询也要添加租户条件
+func TestSubqueryScoped(t *testing.T) {
+	db := tenantDB(t, "team-a")
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		orders := db.Model(&model.Order{}).Select("user_id, COUNT(*) AS order_count").Group("user_id")
+		tags := db.Model(&model.CustomerTag{}).Select("customer_uuid").Where("tag = ?", "vip")
+		return tx.Model(&model.Customer{}).
+			Joins("LEFT JOIN (?) AS order_stats ON order_stats.user_id = customers.uuid", orders).
+			Where("customers.uuid IN (?)", tags).
+			Select("customers.*, order_stats.order_count").Scan(&[]*model.CustomerSummary{})
+	})
+	for _, want := range []string{"`orders`.`tenant_uuid` = 'team-a'", "`customer_tags`.`tenant_uuid` = 'team-a'", "`customers`.`tenant_uuid` = 'team-a'"} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("subquery not scoped, want %s: %s", want, sql)
+		}
+	}
+}
+
+func TestCreate(t *testing.T) {
+	db := tenantDB(t, "team-a")
+
+	product := &model.Product{Uuid: "p1", TenantUuid: "team-b"}
+	db.Create(product)
+	if product.TenantUuid != "team-a" {
+		t.Errorf("got tenant %s, want team-a", product.TenantUuid)
+	}
+
+	items := []*model.ProductItem{{Uuid: "i1"}, {Uuid: "i2", TenantUuid: "team-b"}}
+	db.Create(&items)
+	for _, item := range items {
+		if item.TenantUuid != "team-a" {
+			t.Errorf("got tenant %s, want team-a", item.TenantUuid)
+		}
+	}
+
+	// Save找不到记录时的插入不能覆盖其他租户的数据
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&model.Product{ID: 1, Name: "x"})
+	})
+	if strings.Contains(sql, "`name`=VALUES(`name`)") {
+		t.Errorf("upsert overwrites other tenant: %s", sql)
+	}
+}
+
+func TestScope(t *testing.T) {
+	db := openDB(t)
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Scopes(tenant.Scope("team-c")).Find(&[]*model.Order{})
+	})
+	if !strings.Contains(sql, "`orders`.`tenant_uuid` = 'team-c'") {
+		t.Errorf("scope not applied: %s", sql)
+	}
+}
+
+// 没有租户的context和没有租户字段的表不做限制
+func TestUnscoped(t *testing.T) {
+	sql := openDB(t).ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Find(&[]*model.Order{})
+	})
+	if strings.Contains(sql, "tenant_uuid") {
+		t.Errorf("unexpected tenant condition: %s", sql)
+	}
+
+	db := tenantDB(t, "team-a")
+	for _, m := range []interface{}{&model.User{}, &model.Team{}, &model.Role{}, &model.OrderNoSequence{}} {
+		sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			return tx.Find(m)
+		})
+		if strings.Contains(sql, "tenant_uuid") {
+			t.Errorf("%T unexpected tenant condition: %s", m, sql)
+		}
+	}
+
+	product := &model.Product{TenantUuid: "team-b"}
+	openDB(t).Create(product)
+	if product.TenantUuid != "team-b" {
+		t.Errorf("got tenant %s, want team-b", product.TenantUuid)
+	}
+}
+
+func TestContext(t *testing.T) {
+	if _, ok := tenant.FromContext(context.Background()); ok {
+		t.Error("expected no tenant")
+	}
+	ctx := tenant.WithTenant(context.Background(), "")
+	tenantUuid, ok := tenant.FromContext(ctx)
+	if !ok || tenantUuid != "" {
+		t.Errorf("got %q %v, want empty tenant", tenantUuid, ok)
+	}
+}
+
+// 全局查询不添加租户条件
+func TestGlobal(t *testing.T) {
+	db := tenantDB(t, "team-a")
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Scopes(tenant.Global).Unscoped().Model(&model.Order{}).Where("order_no LIKE ?", "SG-%").Pluck("order_no", &[]string{})
+	})
+	if strings.Contains(sql, "tenant_uuid") {
+		t.Errorf("global query scoped: %s", sql)
+	}
+}
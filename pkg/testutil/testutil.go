@@ -0,0 +1,66 @@
+// Package testutil 测试使用的应用和上下文，数据库为临时目录中的sqlite，表结构与线上一致
+package testutil
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/config"
+	"sgin/pkg/logger"
+	"sgin/pkg/tenant"
+
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	glogger "gorm.io/gorm/logger"
+)
+
+// NewApp 创建使用新数据库的应用，已执行表迁移和默认数据初始化
+func NewApp(t testing.TB) *app.App {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "sgin.db") + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: glogger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tenant.Register(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model.MigrateDbTable(db)
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		if err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	gin.SetMode(gin.TestMode)
+	return &app.App{
+		DB:     db,
+		Logger: &logger.Logger{SugaredLogger: zap.NewNop().Sugar()},
+		Config: &config.Config{ApiPrefix: "/api"},
+		Router: gin.New(),
+	}
+}
+
+// NewContext 创建使用新数据库的请求上下文
+func NewContext(t testing.TB) *app.Context {
+	t.Helper()
+	return Context(NewApp(t))
+}
+
+// Context 创建应用的请求上下文
+func Context(a *app.App) *app.Context {
+	ctx := a.NewContext()
+	gc, _ := gin.CreateTestContext(httptest.NewRecorder())
+	gc.Request = httptest.NewRequest("POST", "/", nil)
+	ctx.Context = gc
+	ctx.Ctx = gc.Request.Context()
+	return ctx
+}
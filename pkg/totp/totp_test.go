@@ -0,0 +1,69 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 附录B的SHA1测试向量，取后6位
+func TestCode(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	cases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, c := range cases {
+		code, err := Code(secret, time.Unix(c.unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != c.code {
+			t.Errorf("time %d: got %s, want %s", c.unix, code, c.code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	code, _ := Code(secret, now)
+	if !Validate(secret, code, now) {
+		t.Error("current code should be valid")
+	}
+	if !Validate(secret, code, now.Add(Period*time.Second)) {
+		t.Error("code of previous step should be valid")
+	}
+	if Validate(secret, code, now.Add(3*Period*time.Second)) {
+		t.Error("code out of skew should be invalid")
+	}
+	if Validate(secret, "12345", now) {
+		t.Error("short code should be invalid")
+	}
+
+	step, ok := ValidateStep(secret, code, now.Add(Period*time.Second))
+	if !ok || step != now.Unix()/Period {
+		t.Errorf("step = %d, %v, want %d", step, ok, now.Unix()/Period)
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("JBSWY3DPEHPK3PXP", "sgin shop", "admin")
+	if !strings.HasPrefix(uri, "otpauth://totp/sgin%20shop:admin?") {
+		t.Errorf("unexpected uri %s", uri)
+	}
+	if !strings.Contains(uri, "secret=JBSWY3DPEHPK3PXP") {
+		t.Errorf("secret missing in %s", uri)
+	}
+}
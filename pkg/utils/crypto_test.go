@@ -0,0 +1,22 @@
+package utils
+
+import "testing"
+
+func TestEncryptString(t *testing.T) {
+	encrypted, err := EncryptString("JBSWY3DPEHPK3PXP", "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plain, err := DecryptString(encrypted, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("got %s", plain)
+	}
+
+	if _, err := DecryptString(encrypted, "other"); err == nil {
+		t.Error("decrypt with wrong key should fail")
+	}
+}
@@ -3,14 +3,17 @@ package utils
 import (
 	"crypto/hmac"
 	"crypto/md5"
+	crand "crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"math/rand"
 	"mime/multipart"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -18,18 +21,36 @@ import (
 
 const (
 	secretKey = "your-secret-key"
+
+	// token受众，区分后台用户和商城客户
+	TokenAudienceAdmin    = "admin"
+	TokenAudienceCustomer = "customer"
 )
 
 // GenerateToken 生成 JWT token
 func GenerateToken(userID string) (string, error) {
+	return GenerateTokenWithAudience(userID, TokenAudienceAdmin)
+}
+
+// GenerateTokenWithAudience 生成指定受众的 JWT token
+func GenerateTokenWithAudience(userID string, audience string) (string, error) {
+	return GenerateAccessToken(userID, audience, "", 7*24*time.Hour)
+}
+
+// GenerateAccessToken 生成绑定登录会话的访问token，会话撤销后token立即失效
+func GenerateAccessToken(userID string, audience string, sessionID string, expire time.Duration) (string, error) {
 	// 定义 JWT 的有效期限
-	expirationTime := time.Now().Add(7 * 24 * time.Hour) // 设置为 24 小时有效期，可根据需求调整
+	expirationTime := time.Now().Add(expire)
 
 	// 创建 token 的声明部分
 	claims := jwt.MapClaims{
 		"user_id": userID,
+		"aud":     audience,
 		"exp":     expirationTime.Unix(),
 	}
+	if sessionID != "" {
+		claims["sid"] = sessionID
+	}
 
 	// 使用 HS256 算法进行签名
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -64,24 +85,59 @@ func ParseToken(tokenString string) (jwt.MapClaims, error) {
 
 // 解析token返回user_id
 func ParseTokenGetUserID(tokenString string) (string, error) {
+	userID, _, err := ParseTokenGetUserIDAndAudience(tokenString)
+	return userID, err
+}
+
+// 解析token返回user_id和受众，没有受众的旧token视为后台用户
+func ParseTokenGetUserIDAndAudience(tokenString string) (string, string, error) {
+	claims, err := ParseTokenClaims(tokenString)
+	if err != nil {
+		return "", "", err
+	}
+	return claims.UserID, claims.Audience, nil
+}
+
+// token中的用户信息
+type TokenClaims struct {
+	UserID    string
+	Audience  string
+	SessionID string // 登录会话ID
+}
+
+// ParseTokenClaims 解析token返回用户信息
+func ParseTokenClaims(tokenString string) (*TokenClaims, error) {
 	claims, err := ParseToken(tokenString)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	userID, ok := claims["user_id"].(string)
 	if !ok {
-		return "", err
+		return nil, errors.New("invalid token")
+	}
+
+	audience, _ := claims["aud"].(string)
+	if audience == "" {
+		audience = TokenAudienceAdmin
 	}
+	sessionID, _ := claims["sid"].(string)
 
-	return userID, nil
+	return &TokenClaims{
+		UserID:    userID,
+		Audience:  audience,
+		SessionID: sessionID,
+	}, nil
 }
 
-// 生成验证码
+// 生成6位数字验证码，使用加密安全的随机数
 func GenerateVerificationCode() string {
-	rand.Seed(time.Now().UnixNano())
-	code := rand.Intn(999999)
-	return strconv.Itoa(code)
+	n, err := crand.Int(crand.Reader, big.NewInt(1000000))
+	if err != nil {
+		rand.Seed(time.Now().UnixNano())
+		return fmt.Sprintf("%06d", rand.Intn(1000000))
+	}
+	return fmt.Sprintf("%06d", n.Int64())
 }
 
 // SignBody 签名
@@ -91,6 +147,14 @@ func SignBody(body, secretKey []byte) string {
 	return hex.EncodeToString(mac.Sum(nil))
 }
 
+// SignRequest 开放接口请求签名，签名内容为 请求方法\n请求路径和参数\n时间戳\nnonce\nbody，时间戳和nonce参与签名防止被替换
+func SignRequest(method, uri, timestamp, nonce string, body, secretKey []byte) string {
+	content := make([]byte, 0, len(method)+len(uri)+len(timestamp)+len(nonce)+len(body)+4)
+	content = append(content, strings.ToUpper(method)+"\n"+uri+"\n"+timestamp+"\n"+nonce+"\n"...)
+	content = append(content, body...)
+	return SignBody(content, secretKey)
+}
+
 // 数组转换成json字符串
 func ArrayToJsonString(arr []string) string {
 	if len(arr) == 0 {
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"testing"
+	"time"
 )
 
 func TestGenerateToken(t *testing.T) {
@@ -11,3 +12,75 @@ func TestGenerateToken(t *testing.T) {
 	}
 	t.Log(token)
 }
+
+func TestTokenAudience(t *testing.T) {
+	token, err := GenerateTokenWithAudience("123", TokenAudienceCustomer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userID, audience, err := ParseTokenGetUserIDAndAudience(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userID != "123" || audience != TokenAudienceCustomer {
+		t.Errorf("got %s %s", userID, audience)
+	}
+
+	token, err = GenerateToken("456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, audience, err = ParseTokenGetUserIDAndAudience(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if audience != TokenAudienceAdmin {
+		t.Errorf("got %s", audience)
+	}
+}
+
+func TestGenerateVerificationCode(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		code := GenerateVerificationCode()
+		if len(code) != 6 {
+			t.Fatalf("invalid code %s", code)
+		}
+	}
+}
+
+func TestAccessTokenSession(t *testing.T) {
+	token, err := GenerateAccessToken("123", TokenAudienceAdmin, "sid-1", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := ParseTokenClaims(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserID != "123" || claims.SessionID != "sid-1" {
+		t.Errorf("got %+v", claims)
+	}
+
+	token, err = GenerateAccessToken("123", TokenAudienceAdmin, "sid-1", -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ParseTokenClaims(token); err == nil {
+		t.Error("expired token should be rejected")
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	key := []byte("sec")
+	body := []byte(`{"uuid":"1"}`)
+	sign := SignRequest("post", "/api/open/v1/order/info", "1700000000", "n1", body, key)
+	if sign != SignBody([]byte("POST\n/api/open/v1/order/info\n1700000000\nn1\n"+string(body)), key) {
+		t.Errorf("unexpected sign %s", sign)
+	}
+	if sign == SignRequest("POST", "/api/open/v1/order/info", "1700000000", "n2", body, key) {
+		t.Error("nonce not signed")
+	}
+	if sign == SignRequest("POST", "/api/open/v1/order/info", "1700000001", "n1", body, key) {
+		t.Error("timestamp not signed")
+	}
+}
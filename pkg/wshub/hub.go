@@ -0,0 +1,69 @@
+package wshub
+
+import (
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// Hub 管理一组websocket连接，用于服务端推送
+// 连接按key分组，推送只发给同一分组的连接，例如按租户分组
+type Hub struct {
+	mu    sync.Mutex
+	conns map[string]map[*websocket.Conn]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		conns: make(map[string]map[*websocket.Conn]struct{}),
+	}
+}
+
+// Add 注册连接到分组
+func (h *Hub) Add(key string, conn *websocket.Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.conns[key] == nil {
+		h.conns[key] = make(map[*websocket.Conn]struct{})
+	}
+	h.conns[key][conn] = struct{}{}
+}
+
+// Remove 移除并关闭连接
+func (h *Hub) Remove(key string, conn *websocket.Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.conns[key][conn]; ok {
+		h.remove(key, conn)
+	}
+}
+
+// Len 当前连接数
+func (h *Hub) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := 0
+	for _, conns := range h.conns {
+		n += len(conns)
+	}
+	return n
+}
+
+// Broadcast 向分组的所有连接推送json消息，写失败的连接会被移除
+func (h *Hub) Broadcast(key string, v interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for conn := range h.conns[key] {
+		if err := conn.WriteJSON(v); err != nil {
+			h.remove(key, conn)
+		}
+	}
+}
+
+func (h *Hub) remove(key string, conn *websocket.Conn) {
+	delete(h.conns[key], conn)
+	if len(h.conns[key]) == 0 {
+		delete(h.conns, key)
+	}
+	conn.Close()
+}
@@ -0,0 +1,52 @@
+package wshub
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestHubBroadcast(t *testing.T) {
+	hub := NewHub()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Upgrade(w, r, nil, 1024, 1024)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		hub.Add("tenant1", conn)
+	}))
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+	client, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 50 && hub.Len() == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if hub.Len() != 1 {
+		t.Fatalf("expected 1 connection, got %d", hub.Len())
+	}
+
+	// 其他分组的消息不会推送到连接
+	hub.Broadcast("tenant2", map[string]string{"type": "other"})
+	hub.Broadcast("tenant1", map[string]string{"type": "low_stock"})
+
+	msg := map[string]string{}
+	client.SetReadDeadline(time.Now().Add(time.Second))
+	if err := client.ReadJSON(&msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg["type"] != "low_stock" {
+		t.Errorf("unexpected message %v", msg)
+	}
+}
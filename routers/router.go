@@ -13,6 +13,10 @@ import (
 )
 
 func InitRouter(ctx *app.App) {
+	if err := service.InitCasbin(ctx); err != nil {
+		ctx.Logger.Error("Failed to init casbin", err)
+	}
+
 	InitSwaggerRouter(ctx)
 	InitUserRouter(ctx)
 	InitMenuRouter(ctx)
@@ -45,11 +49,25 @@ func InitRouter(ctx *app.App) {
 	InitPageRouter(ctx)
 	InitAlipayRouter(ctx)
 	InitWechatPayRouter(ctx)
+	InitInventoryRouter(ctx)
+	InitDigitalRouter(ctx)
+	InitSubscriptionRouter(ctx)
+	InitPriceRouter(ctx)
+	InitShipmentRouter(ctx)
+	InitReturnRouter(ctx)
+	InitInvoiceRouter(ctx)
+	InitCustomerRouter(ctx)
+	InitSessionRouter(ctx)
+	InitTwoFactorRouter(ctx)
+	InitCasbinRouter(ctx)
+	InitWebhookRouter(ctx)
+	InitOpenRouter(ctx)
 }
 
 func InitUserRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		userController := &controller.UserController{
@@ -97,6 +115,7 @@ func InitUserRouter(ctx *app.App) {
 func InitMenuRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		menuController := &controller.MenuController{
@@ -113,6 +132,7 @@ func InitMenuRouter(ctx *app.App) {
 func InitAppRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		appController := &controller.AppController{
@@ -123,11 +143,18 @@ func InitAppRouter(ctx *app.App) {
 		v1.POST("/app/update", appController.UpdateApp)
 		v1.POST("/app/delete", appController.DeleteApp)
 
+		apiPermissionController := &controller.ApiPermissionController{
+			APIPermissionService: &service.AppPermissionService{},
+		}
+		v1.POST("/app/api/permissions/list", apiPermissionController.List)
+		v1.POST("/app/api/permissions/set", apiPermissionController.Set)
+
 	}
 }
 
 func InitVerificationCodeRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.RateLimit("verification_code"))
 	{
 		verificationCodeController := &controller.VerificationCodeController{
 			VerificationCodeService: &service.VerificationCodeService{},
@@ -139,6 +166,7 @@ func InitVerificationCodeRouter(ctx *app.App) {
 // 注册的路由
 func InitRegisterRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.RateLimit("register"))
 	{
 		registerController := &controller.RegisterController{
 			UserService:             &service.UserService{},
@@ -149,19 +177,43 @@ func InitRegisterRouter(ctx *app.App) {
 }
 
 func InitLoginRouter(ctx *app.App) {
+	loginController := &controller.LoginController{
+		UserService:        &service.UserService{},
+		SysLoginLogService: &service.SysLoginLogService{},
+		SessionService:     &service.SessionService{},
+		TwoFactorService:   &service.TwoFactorService{},
+		LoginLockService:   &service.LoginLockService{},
+	}
+
+	// 登录、两步验证和图形验证码分别限流，获取验证码不占用登录次数
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.RateLimit("login"))
 	{
-		loginController := &controller.LoginController{
-			UserService: &service.UserService{},
-		}
 		v1.POST("/login", loginController.Login)
 	}
+
+	twoFactor := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	twoFactor.Use(middleware.RateLimit("two_factor"))
+	{
+		twoFactor.POST("/login/2fa/verify", loginController.VerifyTwoFactor)
+		twoFactor.POST("/login/2fa/setup", loginController.SetupTwoFactor)
+	}
+
+	captcha := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	captcha.Use(middleware.RateLimit("captcha"))
+	{
+		captchaController := &controller.CaptchaController{
+			CaptchaService: &service.CaptchaService{},
+		}
+		captcha.POST("/captcha/create", captchaController.CreateCaptcha)
+	}
 }
 
 // 服务的路由
 func InitServerRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		serverController := &controller.ServerController{
@@ -179,6 +231,7 @@ func InitServerRouter(ctx *app.App) {
 func InitTeamRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		teamController := &controller.TeamController{
@@ -189,12 +242,14 @@ func InitTeamRouter(ctx *app.App) {
 		v1.POST("/team/delete", teamController.DeleteTeam)
 		v1.POST("/team/info", teamController.GetTeamInfo)
 		v1.POST("/team/list", teamController.GetTeamList)
+		v1.POST("/team/owner/transfer", teamController.TransferOwner)
 	}
 }
 
 func InitTeamMemberRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		teamMemberController := &controller.TeamMemberController{
@@ -203,12 +258,31 @@ func InitTeamMemberRouter(ctx *app.App) {
 		v1.POST("/team_member/create", teamMemberController.CreateTeamMember)
 		v1.POST("/team_member/delete", teamMemberController.DeleteTeamMember)
 		v1.POST("/team_member/list", teamMemberController.GetTeamMemberList)
+
+		teamInvitationController := &controller.TeamInvitationController{
+			TeamInvitationService: &service.TeamInvitationService{},
+		}
+		v1.POST("/team_invitation/create", teamInvitationController.CreateInvitation)
+		v1.POST("/team_invitation/list", teamInvitationController.GetInvitationList)
+		v1.POST("/team_invitation/revoke", teamInvitationController.RevokeInvitation)
+	}
+
+	// 被邀请人通过邮件中的链接处理邀请，不需要登录
+	front := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	{
+		teamInvitationController := &controller.TeamInvitationController{
+			TeamInvitationService: &service.TeamInvitationService{},
+		}
+		front.POST("/team_invitation/info", teamInvitationController.GetInvitationInfo)
+		front.POST("/team_invitation/accept", teamInvitationController.AcceptInvitation)
+		front.POST("/team_invitation/decline", teamInvitationController.DeclineInvitation)
 	}
 }
 
 func InitSysApiRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		apiController := &controller.APIController{
@@ -219,6 +293,8 @@ func InitSysApiRouter(ctx *app.App) {
 		v1.POST("/sys_api/delete", apiController.DeleteAPI)
 		v1.POST("/sys_api/list", apiController.GetAPIList)
 		v1.POST("/sys_api/info", apiController.GetAPIInfo)
+		v1.POST("/sys_api/sync", apiController.SyncAPI)
+		v1.POST("/sys_api/diff", apiController.GetAPIDiff)
 
 	}
 }
@@ -226,6 +302,7 @@ func InitSysApiRouter(ctx *app.App) {
 func InitSysOpLogRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		sysOpLogController := &controller.SysOpLogController{
@@ -241,6 +318,7 @@ func InitSysOpLogRouter(ctx *app.App) {
 func InitSysLoginLogRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		sysLoginLogController := &controller.SysLoginLogController{
@@ -249,12 +327,19 @@ func InitSysLoginLogRouter(ctx *app.App) {
 
 		v1.POST("/sys_login_log/info", sysLoginLogController.GetLoginLog)
 		v1.POST("/sys_login_log/list", sysLoginLogController.GetLoginLogList)
+
+		loginLockController := &controller.LoginLockController{
+			LoginLockService: &service.LoginLockService{},
+		}
+		v1.POST("/login_lock/list", loginLockController.GetLoginLockList)
+		v1.POST("/login_lock/unlock", loginLockController.Unlock)
 	}
 }
 
 func InitPermissionRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		permissionController := &controller.PermissionController{
@@ -271,6 +356,7 @@ func InitPermissionRouter(ctx *app.App) {
 func InitPermissionMenuRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		permissionMenuController := &controller.PermissionMenuController{
@@ -288,6 +374,7 @@ func InitPermissionMenuRouter(ctx *app.App) {
 func InitPermissionUserRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		permissionUserController := &controller.UserPermissionController{
@@ -304,6 +391,7 @@ func InitPermissionUserRouter(ctx *app.App) {
 func InitMenuAPIRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
 	{
 		menuAPIController := &controller.MenuAPIController{
@@ -322,6 +410,7 @@ func InitMenuAPIRouter(ctx *app.App) {
 func InitProductCategoryRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	{
 		productCategoryController := &controller.ProductCategoryController{
 			CategoryService: &service.ProductCategoryService{},
@@ -337,6 +426,7 @@ func InitProductCategoryRouter(ctx *app.App) {
 func InitResourceRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	{
 		resourceController := &controller.ResourceController{
 			ResourceService: &service.ResourceService{},
@@ -354,9 +444,11 @@ func InitResourceRouter(ctx *app.App) {
 func InitProductRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	{
 		productController := &controller.ProductController{
-			ProductService: &service.ProductService{},
+			ProductService:      &service.ProductService{},
+			ProductGroupService: &service.ProductGroupService{},
 		}
 		v1.POST("/product/create", productController.ProductCreate)
 		v1.POST("/product/list", productController.GetProductList)
@@ -375,11 +467,17 @@ func InitProductRouter(ctx *app.App) {
 
 		// 获取产品变体信息
 		v1.POST("/product/variant/info", productController.GetProductItemVariantInfo)
+
+		// 组合产品
+		v1.POST("/product/group/info", productController.GetProductGroupInfo)
+		v1.POST("/product/group/update", productController.UpdateProductGroup)
 	}
 }
 
 func InitProductFrontRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	// 登录用户按客户分组展示价格
+	v1.Use(middleware.OptionalLoginCheck())
 	{
 		productController := &controller.ProductController{
 			ProductService: &service.ProductService{},
@@ -410,6 +508,7 @@ func InitProductFrontRouter(ctx *app.App) {
 func InitPaymentMethodRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	{
 		paymentMethodController := &controller.PaymentMethodController{
 			PaymentMethodService: &service.PaymentMethodService{},
@@ -425,23 +524,34 @@ func InitPaymentMethodRouter(ctx *app.App) {
 		// 获取详细信息
 		v1.POST("/payment_method/info", paymentMethodController.GetPaymentMethodInfo)
 
-		// 创建paypal 支付订单
-		v1.POST("/payment_method/paypal/create", paymentMethodController.CreatePaypalPayment)
-
 		// 设置支付宝支付配置
 		v1.POST("/payment_method/alipay/config", paymentMethodController.SetAlipayConfig)
 
 		// 设置微信支付配置
 		v1.POST("/payment_method/wechat/config", paymentMethodController.SetWechatConfig)
 
+		// 创建paypal 沙盒支付订单
+		//v1.POST("/payment_method/paypal/sandbox/create_test", paymentMethodController.CreatePaypalPaymentSandboxTest)
+	}
+
+	// 支付限流
+	checkout := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	checkout.Use(middleware.LoginCheck())
+	checkout.Use(middleware.UserPermission())
+	checkout.Use(middleware.RateLimit("checkout"))
+	{
+		paymentMethodController := &controller.PaymentMethodController{
+			PaymentMethodService: &service.PaymentMethodService{},
+		}
+
+		// 创建paypal 支付订单
+		checkout.POST("/payment_method/paypal/create", paymentMethodController.CreatePaypalPayment)
+
 		// 创建支付宝支付订单
-		v1.POST("/payment_method/alipay/create", paymentMethodController.CreateAlipayPayment)
+		checkout.POST("/payment_method/alipay/create", paymentMethodController.CreateAlipayPayment)
 
 		// 创建微信支付订单
-		v1.POST("/payment_method/wechat/create", paymentMethodController.CreateWechatPayment)
-
-		// 创建paypal 沙盒支付订单
-		//v1.POST("/payment_method/paypal/sandbox/create_test", paymentMethodController.CreatePaypalPaymentSandboxTest)
+		checkout.POST("/payment_method/wechat/create", paymentMethodController.CreateWechatPayment)
 	}
 }
 
@@ -470,6 +580,7 @@ func InitAlipayRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	alipayController := &controller.AlipayController{
 		PaymentMethodService: &service.PaymentMethodService{},
+		OrderService:         &service.OrderService{},
 	}
 
 	{
@@ -484,6 +595,7 @@ func InitWechatPayRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	wechatPayController := &controller.WechatPayController{
 		PaymentMethodService: &service.PaymentMethodService{},
+		OrderService:         &service.OrderService{},
 	}
 
 	{
@@ -494,6 +606,7 @@ func InitWechatPayRouter(ctx *app.App) {
 func InitCartRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	{
 		cartController := &controller.CartController{
 			CartService: &service.CartService{},
@@ -509,22 +622,44 @@ func InitCartRouter(ctx *app.App) {
 func InitOrderRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	{
 		orderController := &controller.OrderController{
 			OrderService: &service.OrderService{},
 		}
-		v1.POST("/order/create", orderController.CreateOrder)
 		v1.POST("/order/list", orderController.GetOrderList)
 		v1.POST("/order/delete", orderController.DeleteOrder)
 		v1.POST("/order/info", orderController.GetOrderInfo)
 		// 获取订单详情
 		v1.POST("/order/item/list", orderController.GetOrderItemList)
+		v1.POST("/order/receiver/update", orderController.UpdateOrderReceiver)
+		v1.POST("/order/item/update", orderController.UpdateOrderItems)
+		v1.POST("/order/split", orderController.SplitOrder)
+		v1.POST("/order/merge", orderController.MergeOrders)
+		v1.POST("/order/note/create", orderController.AddOrderNote)
+		v1.POST("/order/note/list", orderController.GetOrderNotes)
+		v1.POST("/order/audit/list", orderController.GetOrderAuditList)
+
+		v1.POST("/f/order/notes", orderController.GetMyOrderNotes)
+	}
+
+	// 下单限流
+	checkout := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	checkout.Use(middleware.LoginCheck())
+	checkout.Use(middleware.UserPermission())
+	checkout.Use(middleware.RateLimit("checkout"))
+	{
+		orderController := &controller.OrderController{
+			OrderService: &service.OrderService{},
+		}
+		checkout.POST("/order/create", orderController.CreateOrder)
 	}
 }
 
 func InitConfigurationRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	{
 		configurationController := &controller.ConfigurationController{
 			ConfigurationService: &service.ConfigurationService{},
@@ -544,6 +679,7 @@ func InitConfigurationRouter(ctx *app.App) {
 func InitUserAddressRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	{
 		userAddressController := &controller.UserAddressController{
 			UserAddressService: &service.UserAddressService{},
@@ -559,6 +695,7 @@ func InitUserAddressRouter(ctx *app.App) {
 func InitCurrencyRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	{
 		currencyController := &controller.CurrencyController{
 			CurrencyService: &service.CurrencyService{},
@@ -574,6 +711,7 @@ func InitCurrencyRouter(ctx *app.App) {
 func InitPageRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	{
 		pageController := &controller.PageController{
 			PageService: &service.PageService{},
@@ -619,6 +757,7 @@ func InitSwaggerRouter(ctx *app.App) {
 func InitPaymentRouter(ctx *app.App) {
 	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
 	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
 	{
 		paymentController := &controller.PaymentController{
 			PaymentService: &service.PaymentService{},
@@ -629,3 +768,366 @@ func InitPaymentRouter(ctx *app.App) {
 		v1.POST("/payments/list", paymentController.GetPaymentList)
 	}
 }
+
+// InitInventoryRouter 库存相关的路由
+func InitInventoryRouter(ctx *app.App) {
+	inventoryController := &controller.InventoryController{
+		InventoryService: &service.InventoryService{},
+	}
+
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
+	{
+		v1.POST("/inventory/log/list", inventoryController.GetInventoryLogList)
+		v1.POST("/inventory/adjust", inventoryController.AdjustStock)
+		v1.POST("/inventory/low_stock", inventoryController.GetLowStockList)
+	}
+
+	// websocket 无法设置header，token通过query参数传递
+	ctx.Router.GET(ctx.Config.ApiPrefix+"/v1/ws/inventory", gin.WrapF(ctx.WrapWS(inventoryController.LowStockWs)))
+}
+
+// InitDigitalRouter 虚拟产品相关的路由
+func InitDigitalRouter(ctx *app.App) {
+	digitalController := &controller.DigitalController{
+		DigitalService: &service.DigitalService{},
+	}
+
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
+	{
+		v1.POST("/product/digital/upload", digitalController.CreateDigitalFile)
+		v1.POST("/product/digital/list", digitalController.GetDigitalFileList)
+		v1.POST("/product/digital/delete", digitalController.DeleteDigitalFile)
+		v1.POST("/product/license/import", digitalController.ImportLicenseKeys)
+		v1.POST("/product/license/list", digitalController.GetLicenseKeyList)
+
+		v1.POST("/f/digital/order", digitalController.GetDigitalOrder)
+	}
+
+	// 下载链接通过签名鉴权
+	front := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	{
+		front.GET("/f/digital/download", digitalController.Download)
+	}
+}
+
+// InitSubscriptionRouter 订阅相关的路由
+func InitSubscriptionRouter(ctx *app.App) {
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
+	{
+		subscriptionController := &controller.SubscriptionController{
+			SubscriptionService: &service.SubscriptionService{},
+		}
+
+		v1.POST("/subscription/plan/create", subscriptionController.CreateSubscriptionPlan)
+		v1.POST("/subscription/plan/update", subscriptionController.UpdateSubscriptionPlan)
+		v1.POST("/subscription/plan/list", subscriptionController.GetSubscriptionPlanList)
+		v1.POST("/subscription/list", subscriptionController.GetSubscriptionList)
+
+		v1.POST("/f/subscription/plans", subscriptionController.GetAvailablePlanList)
+		v1.POST("/f/subscription/create", subscriptionController.Subscribe)
+		v1.POST("/f/subscription/list", subscriptionController.GetMySubscriptionList)
+		v1.POST("/f/subscription/pause", subscriptionController.PauseSubscription)
+		v1.POST("/f/subscription/resume", subscriptionController.ResumeSubscription)
+		v1.POST("/f/subscription/cancel", subscriptionController.CancelSubscription)
+	}
+}
+
+// InitPriceRouter 价格表和客户分组相关的路由
+func InitPriceRouter(ctx *app.App) {
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
+	{
+		priceController := &controller.PriceController{
+			PriceService: &service.PriceService{},
+		}
+
+		v1.POST("/price/list/create", priceController.CreatePriceList)
+		v1.POST("/price/list/update", priceController.UpdatePriceList)
+		v1.POST("/price/list/delete", priceController.DeletePriceList)
+		v1.POST("/price/list/info", priceController.GetPriceListInfo)
+		v1.POST("/price/list/list", priceController.GetPriceListList)
+	}
+
+	{
+		customerGroupController := &controller.CustomerGroupController{
+			CustomerGroupService: &service.CustomerGroupService{},
+		}
+
+		v1.POST("/customer/group/create", customerGroupController.CreateCustomerGroup)
+		v1.POST("/customer/group/update", customerGroupController.UpdateCustomerGroup)
+		v1.POST("/customer/group/delete", customerGroupController.DeleteCustomerGroup)
+		v1.POST("/customer/group/list", customerGroupController.GetCustomerGroupList)
+		v1.POST("/customer/group/user/add", customerGroupController.AddGroupUsers)
+		v1.POST("/customer/group/user/remove", customerGroupController.RemoveGroupUsers)
+	}
+}
+
+// InitShipmentRouter 发货相关的路由
+func InitShipmentRouter(ctx *app.App) {
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
+	{
+		shipmentController := &controller.ShipmentController{
+			ShipmentService: &service.ShipmentService{},
+			CarrierService:  &service.CarrierService{},
+		}
+
+		v1.POST("/shipment/create", shipmentController.CreateShipment)
+		v1.POST("/shipment/list", shipmentController.GetShipmentList)
+		v1.POST("/shipment/order", shipmentController.GetOrderShipments)
+		v1.GET("/shipment/carrier/list", shipmentController.GetCarrierList)
+		v1.POST("/shipment/rates", shipmentController.GetShipmentRates)
+		v1.POST("/shipment/label/create", shipmentController.CreateLabelShipment)
+		v1.GET("/shipment/label/download", shipmentController.DownloadLabel)
+		v1.POST("/shipment/track", shipmentController.TrackShipment)
+
+		v1.POST("/f/order/tracking", shipmentController.GetOrderTracking)
+	}
+}
+
+// InitReturnRouter 退货相关的路由
+func InitReturnRouter(ctx *app.App) {
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
+	{
+		returnController := &controller.ReturnController{
+			ReturnService: &service.ReturnService{},
+		}
+
+		v1.POST("/return/list", returnController.GetReturnList)
+		v1.POST("/return/info", returnController.GetReturnInfo)
+		v1.POST("/return/approve", returnController.ApproveReturn)
+		v1.POST("/return/reject", returnController.RejectReturn)
+		v1.POST("/return/receive", returnController.ReceiveReturn)
+		v1.POST("/return/refund", returnController.RefundReturn)
+		v1.POST("/return/exchange", returnController.ExchangeReturn)
+		v1.POST("/return/report/reason", returnController.GetReturnReasonReport)
+
+		v1.POST("/f/return/create", returnController.CreateReturn)
+		v1.POST("/f/return/list", returnController.GetMyReturnList)
+		v1.POST("/f/return/info", returnController.GetMyReturnInfo)
+		v1.POST("/f/return/cancel", returnController.CancelReturn)
+	}
+}
+
+// InitInvoiceRouter 发票和装箱单相关的路由
+func InitInvoiceRouter(ctx *app.App) {
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
+	{
+		invoiceController := &controller.InvoiceController{
+			InvoiceService: &service.InvoiceService{},
+		}
+
+		v1.POST("/invoice/list", invoiceController.GetInvoiceList)
+		v1.GET("/invoice/download", invoiceController.DownloadInvoice)
+		v1.GET("/invoice/packing_slip", invoiceController.DownloadPackingSlip)
+		v1.POST("/invoice/bulk", invoiceController.DownloadBulk)
+
+		v1.GET("/f/invoice/download", invoiceController.DownloadMyInvoice)
+	}
+}
+
+// InitCustomerRouter 商城客户账号相关的路由
+func InitCustomerRouter(ctx *app.App) {
+	customerController := &controller.CustomerController{
+		CustomerService: &service.CustomerService{},
+	}
+
+	front := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	front.Use(middleware.RateLimit("login"))
+	{
+		front.POST("/f/customer/login", customerController.Login)
+		front.POST("/f/customer/password/reset", customerController.ResetPassword)
+	}
+
+	register := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	register.Use(middleware.RateLimit("register"))
+	{
+		register.POST("/f/customer/register", customerController.Register)
+	}
+
+	captcha := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	captcha.Use(middleware.RateLimit("captcha"))
+	{
+		captchaController := &controller.CaptchaController{
+			CaptchaService: &service.CaptchaService{},
+		}
+		captcha.POST("/f/captcha/create", captchaController.CreateCaptcha)
+	}
+
+	code := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	code.Use(middleware.RateLimit("verification_code"))
+	{
+		code.POST("/f/customer/password/forgot", customerController.SendPasswordResetCode)
+	}
+
+	sessionController := &controller.SessionController{
+		SessionService: &service.SessionService{},
+	}
+
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.CustomerLoginCheck())
+	{
+		v1.POST("/f/customer/logout", sessionController.Logout)
+		v1.POST("/f/customer/session/list", sessionController.GetSessionList)
+		v1.POST("/f/customer/session/revoke", sessionController.RevokeSession)
+		v1.POST("/f/customer/session/revoke_all", sessionController.RevokeAllSessions)
+
+		v1.GET("/f/customer/profile", customerController.GetProfile)
+		v1.POST("/f/customer/profile/update", customerController.UpdateProfile)
+		v1.POST("/f/customer/password/update", customerController.UpdatePassword)
+		v1.POST("/f/customer/email/change", customerController.RequestEmailChange)
+		v1.POST("/f/customer/email/confirm", customerController.ConfirmEmailChange)
+		v1.GET("/f/customer/export", customerController.ExportData)
+		v1.POST("/f/customer/delete", customerController.DeleteAccount)
+	}
+
+	admin := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	admin.Use(middleware.LoginCheck())
+	admin.Use(middleware.UserPermission())
+	admin.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
+	{
+		admin.POST("/customer/list", customerController.GetCustomerList)
+		admin.POST("/customer/info", customerController.GetCustomerInfo)
+		admin.POST("/customer/export", customerController.ExportCustomers)
+		admin.POST("/customer/tag/add", customerController.AddCustomerTags)
+		admin.POST("/customer/tag/remove", customerController.RemoveCustomerTags)
+		admin.POST("/customer/tag/list", customerController.GetCustomerTagList)
+
+		customerSegmentController := &controller.CustomerSegmentController{
+			CustomerSegmentService: &service.CustomerSegmentService{},
+		}
+		admin.POST("/customer/segment/create", customerSegmentController.CreateCustomerSegment)
+		admin.POST("/customer/segment/update", customerSegmentController.UpdateCustomerSegment)
+		admin.POST("/customer/segment/delete", customerSegmentController.DeleteCustomerSegment)
+		admin.POST("/customer/segment/list", customerSegmentController.GetCustomerSegmentList)
+	}
+}
+
+// InitSessionRouter 登录会话相关的路由
+func InitSessionRouter(ctx *app.App) {
+	sessionController := &controller.SessionController{
+		SessionService: &service.SessionService{},
+	}
+
+	front := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	{
+		front.POST("/token/refresh", sessionController.RefreshToken)
+	}
+
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.LoginCheck())
+	{
+		v1.POST("/logout", sessionController.Logout)
+		v1.POST("/user/session/list", sessionController.GetSessionList)
+		v1.POST("/user/session/revoke", sessionController.RevokeSession)
+		v1.POST("/user/session/revoke_all", sessionController.RevokeAllSessions)
+	}
+}
+
+// InitTwoFactorRouter 两步验证相关的路由
+func InitTwoFactorRouter(ctx *app.App) {
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
+	{
+		twoFactorController := &controller.TwoFactorController{
+			UserService:        &service.UserService{},
+			TwoFactorService:   &service.TwoFactorService{},
+			SysLoginLogService: &service.SysLoginLogService{},
+		}
+		v1.POST("/user/2fa/status", twoFactorController.GetStatus)
+		v1.POST("/user/2fa/setup", twoFactorController.Setup)
+		v1.POST("/user/2fa/enable", twoFactorController.Enable)
+		v1.POST("/user/2fa/disable", twoFactorController.Disable)
+		v1.POST("/user/2fa/recovery_codes", twoFactorController.RegenerateRecoveryCodes)
+		v1.POST("/user/2fa/email/send", twoFactorController.SendEmailCode)
+	}
+}
+
+// InitCasbinRouter casbin策略管理的路由
+func InitCasbinRouter(ctx *app.App) {
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
+	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
+	{
+		casbinController := &controller.CasbinController{
+			CasbinService: &service.CasbinService{},
+		}
+		v1.POST("/casbin/policy/list", casbinController.GetPolicyList)
+		v1.POST("/casbin/policy/create", casbinController.AddPolicy)
+		v1.POST("/casbin/policy/delete", casbinController.RemovePolicy)
+		v1.POST("/casbin/policy/sync", casbinController.SyncPolicies)
+		v1.POST("/casbin/reload", casbinController.Reload)
+		v1.POST("/casbin/explain", casbinController.Explain)
+	}
+}
+
+// InitWebhookRouter webhook相关的路由
+func InitWebhookRouter(ctx *app.App) {
+	v1 := ctx.Group(ctx.Config.ApiPrefix + "/v1")
+	v1.Use(middleware.LoginCheck())
+	v1.Use(middleware.UserPermission())
+	v1.Use(middleware.SysOpLogMiddleware(&service.SysOpLogService{}))
+	{
+		webhookController := &controller.WebhookController{
+			WebhookService: &service.WebhookService{},
+		}
+		v1.GET("/webhook/events", webhookController.GetWebhookEvents)
+		v1.POST("/webhook/create", webhookController.CreateWebhook)
+		v1.POST("/webhook/update", webhookController.UpdateWebhook)
+		v1.POST("/webhook/delete", webhookController.DeleteWebhook)
+		v1.POST("/webhook/list", webhookController.GetWebhookList)
+		v1.POST("/webhook/delivery/list", webhookController.GetDeliveryList)
+		v1.POST("/webhook/delivery/info", webhookController.GetDeliveryInfo)
+		v1.POST("/webhook/delivery/redeliver", webhookController.Redeliver)
+	}
+}
+
+// InitOpenRouter 开放接口的路由，外部系统使用app的ApiKey调用，请求需要签名，只能调用授权的接口
+func InitOpenRouter(ctx *app.App) {
+	rateLimit := ctx.Config.OpenAPI.RateLimit
+	if rateLimit <= 0 {
+		rateLimit = 10
+	}
+	rateBurst := ctx.Config.OpenAPI.RateBurst
+	if rateBurst <= 0 {
+		rateBurst = 20
+	}
+
+	open := ctx.Group(ctx.Config.ApiPrefix + "/open/v1")
+	open.Use(middleware.AppKeyCheck())
+	open.Use(middleware.NewAppRateLimit(rateLimit, rateBurst).HandleRateLimit())
+	open.Use(middleware.Signature())
+	open.Use(middleware.NonceHandler())
+	open.Use(middleware.ApiPermission())
+	{
+		openController := &controller.OpenController{
+			ProductService:   &service.ProductService{},
+			InventoryService: &service.InventoryService{},
+			OrderService:     &service.OrderService{},
+			ShipmentService:  &service.ShipmentService{},
+		}
+		open.POST("/product/list", openController.GetProductList)
+		open.POST("/product/info", openController.GetProductInfo)
+		open.POST("/product/item/list", openController.GetProductItemList)
+		open.POST("/inventory/adjust", openController.AdjustStock)
+		open.POST("/inventory/log/list", openController.GetInventoryLogList)
+		open.POST("/order/list", openController.GetOrderList)
+		open.POST("/order/info", openController.GetOrderInfo)
+		open.POST("/shipment/list", openController.GetShipmentList)
+		open.POST("/shipment/create", openController.CreateShipment)
+	}
+}
@@ -0,0 +1,224 @@
+package routers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/testutil"
+	"sgin/pkg/utils"
+	"sgin/service"
+
+	"github.com/gorilla/websocket"
+)
+
+// 新安装的应用，路由已注册并同步到接口表
+func newTestApp(t *testing.T, authorizer string) *app.App {
+	a := testutil.NewApp(t)
+	a.Config.Auth.Authorizer = authorizer
+	InitRouter(a)
+	service.SyncAPIRoutes(a)
+	return a
+}
+
+func login(t *testing.T, a *app.App, userUuid, audience string) string {
+	res, err := service.NewSessionService().CreateSession(testutil.Context(a), userUuid, audience)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.Token
+}
+
+func post(t *testing.T, a *app.App, path, token string, body interface{}) *app.Response {
+	data, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Token", token)
+	w := httptest.NewRecorder()
+	a.Router.ServeHTTP(w, req)
+
+	res := &app.Response{}
+	err := json.Unmarshal(w.Body.Bytes(), res)
+	if err != nil {
+		t.Fatalf("%s: %s", path, w.Body.String())
+	}
+	return res
+}
+
+// 新安装后默认用户可以创建团队，没有团队的注册用户不能调用后台接口
+func TestFreshInstallAdminCreatesTeam(t *testing.T) {
+	for _, authorizer := range []string{service.AuthorizerRbac, service.AuthorizerCasbin} {
+		t.Run(authorizer, func(t *testing.T) {
+			testFreshInstallAdminCreatesTeam(t, authorizer)
+		})
+	}
+}
+
+func testFreshInstallAdminCreatesTeam(t *testing.T, authorizer string) {
+	a := newTestApp(t, authorizer)
+
+	admin := &model.User{}
+	err := a.DB.Where("username = ?", "admin").First(admin).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := post(t, a, "/api/v1/team/create", login(t, a, admin.Uuid, utils.TokenAudienceAdmin), map[string]string{"name": "shop"})
+	if res.Code != http.StatusOK {
+		t.Fatalf("admin create team: %d %s", res.Code, res.Message)
+	}
+
+	user := &model.User{Uuid: "user-1", Username: "user1", Email: "user1@example.com", Phone: "13800000001", CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}
+	err = a.DB.Create(user).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	res = post(t, a, "/api/v1/team/create", login(t, a, user.Uuid, utils.TokenAudienceAdmin), map[string]string{"name": "other"})
+	if res.Code != http.StatusForbidden {
+		t.Fatalf("user without team create team: %d %s", res.Code, res.Message)
+	}
+}
+
+// 库存预警推送只允许有权限的后台用户连接，连接按用户当前团队分组
+func TestInventoryWsAuth(t *testing.T) {
+	a := newTestApp(t, service.AuthorizerRbac)
+	a.Config.Tenant.Enable = true
+	srv := httptest.NewServer(a.Router)
+	defer srv.Close()
+
+	admin := &model.User{}
+	err := a.DB.Where("username = ?", "admin").First(admin).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	team := &model.TeamMember{}
+	err = a.DB.Where("user_uuid = ?", admin.Uuid).First(team).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/v1/ws/inventory?token="
+	tests := []struct {
+		name    string
+		token   string
+		allowed bool
+	}{
+		{"invalid token", "invalid", false},
+		{"customer token", login(t, a, admin.Uuid, utils.TokenAudienceCustomer), false},
+		{"team owner", login(t, a, admin.Uuid, utils.TokenAudienceAdmin), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, _, err := websocket.DefaultDialer.Dial(url+tt.token, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			if !tt.allowed {
+				conn.SetReadDeadline(time.Now().Add(time.Second))
+				_, _, err = conn.ReadMessage()
+				if !websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+					t.Errorf("err = %v, want policy violation close", err)
+				}
+				return
+			}
+
+			for i := 0; i < 50 && service.InventoryWsHub.Len() == 0; i++ {
+				time.Sleep(10 * time.Millisecond)
+			}
+			service.InventoryWsHub.Broadcast("other-team", map[string]string{"type": "other"})
+			service.InventoryWsHub.Broadcast(team.TeamUUID, map[string]string{"type": "low_stock"})
+			msg := map[string]string{}
+			conn.SetReadDeadline(time.Now().Add(time.Second))
+			err = conn.ReadJSON(&msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if msg["type"] != "low_stock" {
+				t.Errorf("message = %v, want low_stock", msg)
+			}
+		})
+	}
+}
+
+// 用户不存在与密码错误返回相同的结果，账号被锁定后不能继续提交两步验证
+func TestLoginFailures(t *testing.T) {
+	a := newTestApp(t, service.AuthorizerRbac)
+
+	res := post(t, a, "/api/v1/login", "", map[string]string{"username": "nobody", "password": "secret"})
+	if res.Code != http.StatusBadRequest || res.Message != "用户名或密码错误" {
+		t.Errorf("unknown user: %d %s", res.Code, res.Message)
+	}
+	failures := &model.LoginLock{}
+	err := a.DB.Where("type = ? AND subject = ?", model.LoginLockTypeAccount, "nobody").First(failures).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failures.Failures != 1 {
+		t.Errorf("failures = %d, want 1", failures.Failures)
+	}
+
+	admin := &model.User{}
+	err = a.DB.Where("username = ?", "admin").First(admin).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = a.DB.Create(&model.UserTwoFactor{UserUuid: admin.Uuid, Method: model.TwoFactorMethodEmail, Enabled: true,
+		CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = a.DB.Create(&model.LoginChallenge{Uuid: "challenge1", UserUuid: admin.Uuid, Method: model.TwoFactorMethodEmail,
+		ExpiresAt: time.Now().Add(time.Minute).Format(time.DateTime), CreatedAt: "2026-01-01 00:00:00"}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = a.DB.Create(&model.LoginLock{Audience: utils.TokenAudienceAdmin, Type: model.LoginLockTypeAccount, Subject: "admin",
+		LockCount: 1, LockedUntil: time.Now().Add(time.Hour).Format(time.DateTime), CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res = post(t, a, "/api/v1/login/2fa/verify", "", map[string]string{"challenge_uuid": "challenge1", "code": "123456"})
+	if res.Code != http.StatusLocked {
+		t.Errorf("locked account 2fa verify: %d %s", res.Code, res.Message)
+	}
+	challenge := &model.LoginChallenge{}
+	err = a.DB.Where("uuid = ?", "challenge1").First(challenge).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if challenge.Attempts != 0 {
+		t.Errorf("attempts = %d, want 0", challenge.Attempts)
+	}
+}
+
+// 注册、图形验证码和登录使用各自的限流规则，一个接口超出限制不影响其他接口
+func TestAuthRateLimitRules(t *testing.T) {
+	a := newTestApp(t, service.AuthorizerRbac)
+
+	for i := 0; i < 5; i++ {
+		res := post(t, a, "/api/v1/register", "", map[string]string{})
+		if res.Code == http.StatusTooManyRequests {
+			t.Fatalf("register request %d limited", i)
+		}
+	}
+	res := post(t, a, "/api/v1/register", "", map[string]string{})
+	if res.Code != http.StatusTooManyRequests {
+		t.Errorf("register over limit: %d %s", res.Code, res.Message)
+	}
+
+	for _, path := range []string{"/api/v1/f/customer/register", "/api/v1/login", "/api/v1/login/2fa/verify"} {
+		res := post(t, a, path, "", map[string]string{})
+		wantLimited := path == "/api/v1/f/customer/register"
+		if (res.Code == http.StatusTooManyRequests) != wantLimited {
+			t.Errorf("%s: %d %s, want limited %v", path, res.Code, res.Message, wantLimited)
+		}
+	}
+}
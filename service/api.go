@@ -29,6 +29,7 @@ func (s *APIService) CreateAPI(ctx *app.Context, api *model.API) error {
 		ctx.Logger.Error("Failed to create API", err)
 		return errors.New("failed to create API")
 	}
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -54,6 +55,7 @@ func (s *APIService) UpdateAPI(ctx *app.Context, api *model.API) error {
 		return errors.New("failed to update API")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -64,6 +66,7 @@ func (s *APIService) DeleteAPI(ctx *app.Context, uuid string) error {
 		return errors.New("failed to delete API")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"sgin/model"
 	"sgin/pkg/app"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -75,10 +76,13 @@ func (s *AppPermissionService) GetAppAPIPermissions(ctx *app.Context, appUUID st
 	return apis, nil
 }
 
-// 根据name ，path，method获取api 权限信息
+// 根据path，method获取app的api权限信息，path为路由路径
 func (s *AppPermissionService) GetAPIPermissionByNamePathMethod(ctx *app.Context, appUUID, path, method string) (*model.AppPermission, error) {
 	appPermission := &model.AppPermission{}
-	err := ctx.DB.Where("app_uuid = ? and path = ? and method = ?", appUUID, path, method).First(appPermission).Error
+	err := ctx.DB.Model(&model.AppPermission{}).Select("app_permissions.*").
+		Joins("JOIN apis ON apis.uuid = app_permissions.api_uuid").
+		Where("app_permissions.app_uuid = ? AND apis.path = ? AND apis.method = ? AND apis.status <> ?", appUUID, path, method, APIStatusDeleted).
+		First(appPermission).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.New("api permissions  not found")
@@ -88,3 +92,52 @@ func (s *AppPermissionService) GetAPIPermissionByNamePathMethod(ctx *app.Context
 	}
 	return appPermission, nil
 }
+
+// 设置app的api权限，替换原有的权限
+func (s *AppPermissionService) SetAppAPIPermissions(ctx *app.Context, params *model.ReqAppPermissionSet) error {
+	_, err := NewAppService().GetAppByUUID(ctx, params.AppUuid)
+	if err != nil {
+		return err
+	}
+
+	var count int64
+	err = ctx.DB.Model(&model.API{}).Where("uuid IN (?)", params.ApiUuids).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get apis", err)
+		return errors.New("failed to set app api permissions")
+	}
+	if int(count) != len(params.ApiUuids) {
+		return errors.New("api not found")
+	}
+
+	now := time.Now()
+	permissions := make([]*model.AppPermission, 0)
+	for _, apiUuid := range params.ApiUuids {
+		permissions = append(permissions, &model.AppPermission{
+			UUID:      uuid.New().String(),
+			AppUUID:   params.AppUuid,
+			APIUUID:   apiUuid,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+
+	return ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("app_uuid = ?", params.AppUuid).Delete(&model.AppPermission{}).Error
+		if err != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to delete app permissions", err)
+			return errors.New("failed to set app api permissions")
+		}
+		if len(permissions) == 0 {
+			return nil
+		}
+		err = tx.Create(&permissions).Error
+		if err != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to create app permissions", err)
+			return errors.New("failed to set app api permissions")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// 接口状态
+	APIStatusPending = 0 // 未启用，同步时新增的接口，确认权限后启用
+	APIStatusEnabled = 1 // 启用
+	APIStatusDeleted = 2 // 删除，路由中已不存在
+
+	apiSyncLockKey = "sgin:api_sync:lock"
+	swaggerDocPath = "./docs/swagger.json"
+)
+
+// 启动时从gin路由生成的接口列表
+var (
+	routeAPIsMu sync.RWMutex
+	routeAPIs   []*model.API
+)
+
+// SyncAPIRoutes 注册路由后调用，根据gin路由同步接口表
+func SyncAPIRoutes(a *app.App) {
+	ctx := a.NewContext()
+	apis := NewAPIService().routesToAPIs(ctx, a.Router.Routes())
+
+	routeAPIsMu.Lock()
+	routeAPIs = apis
+	routeAPIsMu.Unlock()
+
+	res, err := NewAPIService().SyncRoutes(ctx)
+	if err != nil {
+		ctx.Logger.Error("Failed to sync API routes", err)
+		return
+	}
+	ctx.Logger.Infof("API routes synced, added %d, restored %d, removed %d", res.Added, res.Restored, res.Removed)
+}
+
+// SyncRoutes 使用路由更新接口表
+// 新路由以未启用状态添加，已删除的接口重新出现时恢复为未启用，确认权限后再启用，路由中不存在的接口标记为删除
+// 已有接口只补充空的名称和模块，不覆盖手工修改的内容
+func (s *APIService) SyncRoutes(ctx *app.Context) (*model.ResAPISync, error) {
+	routes := s.getRouteAPIs()
+	if len(routes) == 0 {
+		return nil, errors.New("routes are not loaded")
+	}
+
+	// 多实例同时启动时只有一个实例同步
+	if ctx.Redis != nil {
+		ok, err := ctx.Redis.SetNX(ctx.Ctx, apiSyncLockKey, ctx.TraceID, time.Minute)
+		if err != nil {
+			ctx.Logger.Error("Failed to acquire API sync lock", err)
+			return nil, errors.New("failed to sync API")
+		}
+		if !ok {
+			return nil, errors.New("API sync is running")
+		}
+		defer ctx.Redis.Del(ctx.Ctx, apiSyncLockKey)
+	}
+
+	apis := make([]*model.API, 0)
+	err := ctx.DB.Find(&apis).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get API list", err)
+		return nil, errors.New("failed to sync API")
+	}
+	existing := make(map[string]*model.API)
+	for _, api := range apis {
+		key := api.Method + " " + api.Path
+		if _, ok := existing[key]; !ok {
+			existing[key] = api
+		}
+	}
+
+	res := &model.ResAPISync{}
+	now := time.Now()
+	routeKeys := make(map[string]bool)
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		routeKeys[key] = true
+
+		api, ok := existing[key]
+		if !ok {
+			api = &model.API{
+				UUID:            uuid.New().String(),
+				Module:          route.Module,
+				Name:            route.Name,
+				Path:            route.Path,
+				Method:          route.Method,
+				PermissionLevel: APILevelAdmin,
+				Status:          APIStatusPending,
+				CreatedAt:       now,
+				UpdatedAt:       now,
+			}
+			err = ctx.DB.Create(api).Error
+			if err != nil {
+				ctx.Logger.Error("Failed to create API", err)
+				return nil, errors.New("failed to sync API")
+			}
+			res.Added++
+			continue
+		}
+
+		updates := map[string]interface{}{}
+		if api.Name == "" {
+			updates["name"] = route.Name
+		}
+		if api.Module == "" {
+			updates["module"] = route.Module
+		}
+		// 删除期间接口的处理逻辑可能已经变化，不沿用原来的启用状态
+		if api.Status == APIStatusDeleted {
+			updates["status"] = APIStatusPending
+			res.Restored++
+		}
+		if len(updates) == 0 {
+			continue
+		}
+		updates["updated_at"] = now
+		err = ctx.DB.Model(&model.API{}).Where("uuid = ?", api.UUID).Updates(updates).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update API", err)
+			return nil, errors.New("failed to sync API")
+		}
+	}
+
+	removed := make([]string, 0)
+	for _, api := range apis {
+		if api.Status != APIStatusDeleted && !routeKeys[api.Method+" "+api.Path] {
+			removed = append(removed, api.UUID)
+		}
+	}
+	if len(removed) > 0 {
+		err = ctx.DB.Model(&model.API{}).Where("uuid IN (?)", removed).Updates(map[string]interface{}{
+			"status":     APIStatusDeleted,
+			"updated_at": now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to mark removed API", err)
+			return nil, errors.New("failed to sync API")
+		}
+		res.Removed = len(removed)
+	}
+
+	if res.Added > 0 || res.Restored > 0 || res.Removed > 0 {
+		NewRbacService().InvalidateCache(ctx)
+	}
+	return res, nil
+}
+
+// GetAPIDiff 对比路由和接口表
+// 新增为路由中存在但接口表中没有或未启用的接口，需要分配权限后启用
+// 删除为接口表中已标记删除或路由中已不存在的接口
+func (s *APIService) GetAPIDiff(ctx *app.Context) (*model.ResAPIDiff, error) {
+	routes := s.getRouteAPIs()
+
+	apis := make([]*model.API, 0)
+	err := ctx.DB.Order("module, path").Find(&apis).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get API list", err)
+		return nil, errors.New("failed to get API diff")
+	}
+	existing := make(map[string]*model.API)
+	for _, api := range apis {
+		key := api.Method + " " + api.Path
+		if old, ok := existing[key]; !ok || old.Status == APIStatusDeleted {
+			existing[key] = api
+		}
+	}
+
+	res := &model.ResAPIDiff{
+		Added:   make([]*model.API, 0),
+		Removed: make([]*model.API, 0),
+	}
+	routeKeys := make(map[string]bool)
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		routeKeys[key] = true
+		api, ok := existing[key]
+		if !ok {
+			res.Added = append(res.Added, route)
+		} else if api.Status == APIStatusPending {
+			res.Added = append(res.Added, api)
+		}
+	}
+	for _, api := range apis {
+		if api.Status == APIStatusDeleted || !routeKeys[api.Method+" "+api.Path] {
+			res.Removed = append(res.Removed, api)
+		}
+	}
+	return res, nil
+}
+
+func (s *APIService) getRouteAPIs() []*model.API {
+	routeAPIsMu.RLock()
+	defer routeAPIsMu.RUnlock()
+	return routeAPIs
+}
+
+// 将gin路由转换为接口，只包括api前缀下的路由
+// 名称取swagger文档中的summary，模块取路由分组，即版本号后的第一段路径，商城接口为f/第二段，开放接口为open/第一段
+func (s *APIService) routesToAPIs(ctx *app.Context, routes gin.RoutesInfo) []*model.API {
+	summaries := s.loadSwaggerSummaries(ctx)
+	prefix := ctx.Config.ApiPrefix + "/"
+
+	apis := make([]*model.API, 0)
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, prefix) {
+			continue
+		}
+
+		// 去掉前缀和版本号，开放接口的模块为open/版本号后的第一段
+		segments := strings.Split(strings.TrimPrefix(route.Path, prefix), "/")
+		open := segments[0] == "open" && len(segments) > 2
+		if open {
+			segments = segments[1:]
+		}
+		if len(segments) > 1 {
+			segments = segments[1:]
+		}
+		module := segments[0]
+		if module == "f" && len(segments) > 2 {
+			module = "f/" + segments[1]
+		}
+		if open {
+			module = "open/" + module
+		}
+
+		name := summaries[strings.ToLower(route.Method)+" "+route.Path]
+		if name == "" {
+			name = strings.Join(segments, "/")
+		}
+
+		apis = append(apis, &model.API{
+			Module: module,
+			Name:   name,
+			Path:   route.Path,
+			Method: route.Method,
+			Status: APIStatusPending,
+		})
+	}
+
+	sort.Slice(apis, func(i, j int) bool {
+		if apis[i].Path == apis[j].Path {
+			return apis[i].Method < apis[j].Method
+		}
+		return apis[i].Path < apis[j].Path
+	})
+	return apis
+}
+
+// 读取swagger文档中接口的summary，文档不存在时返回空
+func (s *APIService) loadSwaggerSummaries(ctx *app.Context) map[string]string {
+	summaries := make(map[string]string)
+	data, err := os.ReadFile(swaggerDocPath)
+	if err != nil {
+		return summaries
+	}
+
+	doc := struct {
+		Paths map[string]map[string]struct {
+			Summary string `json:"summary"`
+		} `json:"paths"`
+	}{}
+	err = json.Unmarshal(data, &doc)
+	if err != nil {
+		ctx.Logger.Error("Failed to parse swagger doc", err)
+		return summaries
+	}
+	for path, methods := range doc.Paths {
+		for method, op := range methods {
+			summaries[method+" "+path] = op.Summary
+		}
+	}
+	return summaries
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/testutil"
+)
+
+func TestSyncRoutesStatus(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	routeAPIsMu.Lock()
+	routeAPIs = []*model.API{
+		{Method: "POST", Path: "/api/v1/product/create"},
+		{Method: "POST", Path: "/api/v1/product/delete"},
+		{Method: "POST", Path: "/api/v1/product/list"},
+	}
+	routeAPIsMu.Unlock()
+
+	for _, api := range []*model.API{
+		{UUID: "api1", Method: "POST", Path: "/api/v1/product/delete", Status: APIStatusDeleted},
+		{UUID: "api2", Method: "POST", Path: "/api/v1/product/list", Status: APIStatusEnabled},
+		{UUID: "api3", Method: "POST", Path: "/api/v1/product/old", Status: APIStatusEnabled},
+	} {
+		err := ctx.DB.Create(api).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := NewAPIService().SyncRoutes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Added != 1 || res.Restored != 1 || res.Removed != 1 {
+		t.Errorf("res = %+v, want 1 added, 1 restored, 1 removed", res)
+	}
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/api/v1/product/create", APIStatusPending},
+		{"/api/v1/product/delete", APIStatusPending},
+		{"/api/v1/product/list", APIStatusEnabled},
+		{"/api/v1/product/old", APIStatusDeleted},
+	}
+	for _, tt := range tests {
+		api := &model.API{}
+		err := ctx.DB.Where("path = ?", tt.path).First(api).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+		if api.Status != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.path, api.Status, tt.status)
+		}
+	}
+}
@@ -21,6 +21,11 @@ func (s *AppService) CreateApp(ctx *app.Context, app *model.App) error {
 	app.CreatedAt = time.Now()
 	app.UpdatedAt = app.CreatedAt
 
+	// 开放接口使用创建时所在团队的数据
+	if tenantUuid, ok := ctx.Tenant(); ok {
+		app.TeamUuid = tenantUuid
+	}
+
 	err := ctx.DB.Create(app).Error
 	if err != nil {
 		ctx.Logger.Error("Failed to create app", err)
@@ -29,9 +34,18 @@ func (s *AppService) CreateApp(ctx *app.Context, app *model.App) error {
 	return nil
 }
 
+// 后台接口只能查看和修改当前团队的app，开放接口校验时还没有租户，不做限制
+func (s *AppService) teamScope(ctx *app.Context) *gorm.DB {
+	db := ctx.DB
+	if tenantUuid, ok := ctx.Tenant(); ok {
+		db = db.Where("team_uuid = ?", tenantUuid)
+	}
+	return db
+}
+
 func (s *AppService) GetAppByUUID(ctx *app.Context, uuid string) (*model.App, error) {
 	app := &model.App{}
-	err := ctx.DB.Where("uuid = ?", uuid).First(app).Error
+	err := s.teamScope(ctx).Where("uuid = ?", uuid).First(app).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errors.New("app not found")
@@ -55,19 +69,28 @@ func (s *AppService) GetAppByApiKey(ctx *app.Context, apikey string) (*model.App
 	return app, nil
 }
 
-func (s *AppService) UpdateApp(ctx *app.Context, app *model.App) error {
+func (s *AppService) UpdateApp(ctx *app.Context, params *model.ReqAppUpdate) (*model.App, error) {
+	app, err := s.GetAppByUUID(ctx, params.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	app.Name = params.Name
+	app.Status = params.Status
+	app.RateLimit = params.RateLimit
+	app.RateBurst = params.RateBurst
 	app.UpdatedAt = time.Now()
-	err := ctx.DB.Save(app).Error
+	err = ctx.DB.Model(app).Select("name", "status", "rate_limit", "rate_burst", "updated_at").Updates(app).Error
 	if err != nil {
 		ctx.Logger.Error("Failed to update app", err)
-		return errors.New("failed to update app")
+		return nil, errors.New("failed to update app")
 	}
 
-	return nil
+	return app, nil
 }
 
 func (s *AppService) DeleteApp(ctx *app.Context, uuid string) error {
-	err := ctx.DB.Where("uuid = ?", uuid).Delete(&model.App{}).Error
+	err := s.teamScope(ctx).Where("uuid = ?", uuid).Delete(&model.App{}).Error
 	if err != nil {
 		ctx.Logger.Error("Failed to delete app", err)
 		return errors.New("failed to delete app")
@@ -84,7 +107,7 @@ func (s *AppService) GetAppList(ctx *app.Context, params *model.ReqAppQueryParam
 		total int64
 	)
 
-	query := ctx.DB.Model(&model.App{})
+	query := s.teamScope(ctx).Model(&model.App{})
 
 	if params.Name != "" {
 		query = query.Where("name LIKE ?", "%"+params.Name+"%")
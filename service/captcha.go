@@ -0,0 +1,130 @@
+package service
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"errors"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/captcha"
+	"sgin/pkg/redisop"
+	"sgin/pkg/utils"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	captchaKeyPrefix = "sgin:captcha:"
+	captchaLength    = 4
+)
+
+// 没有配置redis时在本地保存图形验证码，只对单实例有效
+var (
+	captchaMu     sync.Mutex
+	localCaptchas = make(map[string]localCaptcha)
+)
+
+type localCaptcha struct {
+	code     string
+	expireAt time.Time
+}
+
+type CaptchaService struct {
+}
+
+func NewCaptchaService() *CaptchaService {
+	return &CaptchaService{}
+}
+
+// CreateCaptcha 创建图形验证码，只保存验证码摘要
+func (s *CaptchaService) CreateCaptcha(ctx *app.Context) (*model.CaptchaRes, error) {
+	code, img, err := captcha.Generate(captchaLength)
+	if err != nil {
+		ctx.Logger.Error("Failed to generate captcha", err)
+		return nil, errors.New("failed to create captcha")
+	}
+
+	id := uuid.New().String()
+	expire := NewLoginLockService().getConfig(ctx).CaptchaExpire
+	hash := s.hashCode(ctx, id, code)
+
+	if ctx.Redis != nil {
+		err = ctx.Redis.Set(ctx.Ctx, captchaKeyPrefix+id, hash, time.Duration(expire)*time.Second)
+		if err != nil {
+			ctx.Logger.Error("Failed to save captcha", err)
+			return nil, errors.New("failed to create captcha")
+		}
+	} else {
+		setLocalCaptcha(id, hash, time.Duration(expire)*time.Second)
+	}
+
+	return &model.CaptchaRes{
+		CaptchaId: id,
+		Image:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(img),
+		Expire:    expire,
+	}, nil
+}
+
+// VerifyCaptcha 校验图形验证码，无论是否正确验证码都会失效，同一验证码只能使用一次
+func (s *CaptchaService) VerifyCaptcha(ctx *app.Context, id, code string) bool {
+	if id == "" || code == "" {
+		return false
+	}
+
+	var hash string
+	if ctx.Redis != nil {
+		v, err := ctx.Redis.Get(ctx.Ctx, captchaKeyPrefix+id)
+		if err != nil {
+			if !errors.Is(err, redisop.Nil) {
+				ctx.Logger.Error("Failed to get captcha", err)
+			}
+			return false
+		}
+		err = ctx.Redis.Del(ctx.Ctx, captchaKeyPrefix+id)
+		if err != nil {
+			ctx.Logger.Error("Failed to delete captcha", err)
+		}
+		hash = v
+	} else {
+		hash = takeLocalCaptcha(id)
+	}
+
+	return hash != "" && hmac.Equal([]byte(hash), []byte(s.hashCode(ctx, id, code)))
+}
+
+func (s *CaptchaService) hashCode(ctx *app.Context, id, code string) string {
+	return utils.SignBody([]byte(id+":"+code), []byte(ctx.Config.PasswdKey))
+}
+
+func setLocalCaptcha(id, hash string, expire time.Duration) {
+	captchaMu.Lock()
+	defer captchaMu.Unlock()
+
+	now := time.Now()
+	// 数量较多时清理过期的验证码
+	if len(localCaptchas) >= 10000 {
+		for k, c := range localCaptchas {
+			if now.After(c.expireAt) {
+				delete(localCaptchas, k)
+			}
+		}
+	}
+	localCaptchas[id] = localCaptcha{code: hash, expireAt: now.Add(expire)}
+}
+
+func takeLocalCaptcha(id string) string {
+	captchaMu.Lock()
+	defer captchaMu.Unlock()
+
+	c, ok := localCaptchas[id]
+	if !ok {
+		return ""
+	}
+	delete(localCaptchas, id)
+	if time.Now().After(c.expireAt) {
+		return ""
+	}
+	return c.code
+}
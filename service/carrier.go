@@ -0,0 +1,430 @@
+package service
+
+import (
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/carrier"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CarrierService struct {
+}
+
+func NewCarrierService() *CarrierService {
+	return &CarrierService{}
+}
+
+// GetCarrierNames 获取已配置的承运商
+func (s *CarrierService) GetCarrierNames(ctx *app.Context) []string {
+	names := make([]string, 0)
+	for _, conf := range ctx.Config.Shipping.Carriers {
+		names = append(names, conf.Name)
+	}
+	return names
+}
+
+// GetCarrier 根据名称获取承运商
+func (s *CarrierService) GetCarrier(ctx *app.Context, name string) (carrier.Carrier, error) {
+	for _, conf := range ctx.Config.Shipping.Carriers {
+		if conf.Name != name {
+			continue
+		}
+		c, err := carrier.New(carrier.Config{
+			Name:    conf.Name,
+			Type:    conf.Type,
+			BaseURL: conf.BaseURL,
+			ApiKey:  conf.ApiKey,
+			Timeout: conf.Timeout,
+		})
+		if err != nil {
+			ctx.Logger.Error("Failed to create carrier "+name, err)
+			return nil, err
+		}
+		return c, nil
+	}
+	return nil, errors.New("carrier not found")
+}
+
+// GetRates 订单运费询价
+func (s *CarrierService) GetRates(ctx *app.Context, params *model.ReqShipmentRateParam) ([]*carrier.Rate, error) {
+	c, err := s.GetCarrier(ctx, params.Carrier)
+	if err != nil {
+		return nil, err
+	}
+
+	order, parcel, err := s.orderParcel(ctx, params.OrderNo, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := c.Rates(ctx.Ctx, &carrier.RateRequest{
+		From:    s.fromAddress(ctx),
+		To:      s.toAddress(order),
+		Parcels: []*carrier.Parcel{parcel},
+	})
+	if err != nil {
+		ctx.Logger.Error("Failed to get carrier rates", err)
+		return nil, errors.New("failed to get carrier rates")
+	}
+
+	return rates, nil
+}
+
+// CreateLabelShipment 通过承运商创建面单并发货，面单保存为私有资源
+func (s *CarrierService) CreateLabelShipment(ctx *app.Context, params *model.ReqShipmentLabelCreate, operator string) (*model.ShipmentRes, error) {
+	c, err := s.GetCarrier(ctx, params.Carrier)
+	if err != nil {
+		return nil, err
+	}
+
+	order, parcel, err := s.orderParcel(ctx, params.OrderNo, params.Items)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != model.OrderStatusPaid {
+		return nil, errors.New("order is not waiting for shipment")
+	}
+
+	format := params.Format
+	if format == "" {
+		format = ctx.Config.Shipping.LabelFormat
+	}
+
+	label, err := c.CreateLabel(ctx.Ctx, &carrier.LabelRequest{
+		Reference: order.OrderNo,
+		Service:   params.Service,
+		Format:    format,
+		From:      s.fromAddress(ctx),
+		To:        s.toAddress(order),
+		Parcels:   []*carrier.Parcel{parcel},
+	})
+	if err != nil {
+		ctx.Logger.Error("Failed to create carrier label", err)
+		return nil, errors.New("failed to create carrier label")
+	}
+
+	resource, err := s.saveLabel(ctx, order.OrderNo, label)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewShipmentService().createShipment(ctx, &model.ReqShipmentCreate{
+		OrderNo:    order.OrderNo,
+		Carrier:    c.Name(),
+		TrackingNo: label.TrackingNo,
+		Remark:     params.Remark,
+		Items:      params.Items,
+	}, operator, &model.Shipment{
+		Service:           params.Service,
+		LabelResourceUuid: resource.Uuid,
+		LabelFormat:       label.Format,
+	})
+}
+
+// GetLabelFile 获取包裹面单文件路径和文件名
+func (s *CarrierService) GetLabelFile(ctx *app.Context, shipmentUuid string) (string, string, error) {
+	shipment := &model.Shipment{}
+	err := ctx.DB.Where("uuid = ?", shipmentUuid).First(shipment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", "", errors.New("shipment not found")
+		}
+		ctx.Logger.Error("Failed to get shipment by UUID", err)
+		return "", "", errors.New("failed to get shipment by UUID")
+	}
+	if shipment.LabelResourceUuid == "" {
+		return "", "", errors.New("shipment has no label")
+	}
+
+	resource, err := NewResourceService().GetResourceByUUID(ctx, shipment.LabelResourceUuid)
+	if err != nil {
+		return "", "", err
+	}
+
+	return filepath.Join(ctx.Config.Digital.Dir, resource.Path), resource.Name, nil
+}
+
+// PollTracking 查询未签收包裹的物流轨迹，更新包裹和订单状态
+func (s *CarrierService) PollTracking(ctx *app.Context) {
+	names := s.GetCarrierNames(ctx)
+	if len(names) == 0 {
+		return
+	}
+
+	shipments := make([]*model.Shipment, 0)
+	err := ctx.DB.Where("carrier IN (?) AND status IN (?)", names, []string{
+		model.ShipmentStatusShipped,
+		model.ShipmentStatusInTransit,
+		model.ShipmentStatusException,
+	}).Order("tracked_at ASC").Limit(100).Find(&shipments).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get shipments for tracking", err)
+		return
+	}
+
+	for _, shipment := range shipments {
+		err := s.TrackShipment(ctx.WithTenant(shipment.TenantUuid), shipment)
+		if err != nil {
+			ctx.Logger.Error("Failed to track shipment "+shipment.Uuid, err)
+		}
+	}
+}
+
+// TrackShipmentByUUID 立即同步包裹物流轨迹，返回最新的包裹信息
+func (s *CarrierService) TrackShipmentByUUID(ctx *app.Context, shipmentUuid string) (*model.ShipmentRes, error) {
+	shipment := &model.Shipment{}
+	err := ctx.DB.Where("uuid = ?", shipmentUuid).First(shipment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("shipment not found")
+		}
+		ctx.Logger.Error("Failed to get shipment by UUID", err)
+		return nil, errors.New("failed to get shipment by UUID")
+	}
+
+	err = s.TrackShipment(ctx, shipment)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ctx.DB.Where("uuid = ?", shipmentUuid).First(shipment).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get shipment by UUID", err)
+		return nil, errors.New("failed to get shipment by UUID")
+	}
+	res, err := NewShipmentService().fillShipmentRes(ctx, []*model.Shipment{shipment})
+	if err != nil {
+		return nil, err
+	}
+	return res[0], nil
+}
+
+// TrackShipment 查询包裹物流轨迹并保存新的事件
+func (s *CarrierService) TrackShipment(ctx *app.Context, shipment *model.Shipment) error {
+	c, err := s.GetCarrier(ctx, shipment.Carrier)
+	if err != nil {
+		return err
+	}
+
+	events, err := c.Track(ctx.Ctx, shipment.TrackingNo)
+	if err != nil {
+		return err
+	}
+
+	existing := make([]*model.ShipmentEvent, 0)
+	err = ctx.DB.Where("shipment_uuid = ?", shipment.Uuid).Find(&existing).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get shipment events", err)
+		return errors.New("failed to get shipment events")
+	}
+	existMap := make(map[string]bool)
+	for _, event := range existing {
+		existMap[event.Status+"|"+event.OccurredAt] = true
+	}
+
+	now := time.Now().Format(time.DateTime)
+	newEvents := make([]*model.ShipmentEvent, 0)
+	for _, event := range events {
+		if existMap[event.Status+"|"+event.OccurredAt] {
+			continue
+		}
+		existMap[event.Status+"|"+event.OccurredAt] = true
+		newEvents = append(newEvents, &model.ShipmentEvent{
+			ShipmentUuid: shipment.Uuid,
+			OrderNo:      shipment.OrderNo,
+			Status:       event.Status,
+			Description:  event.Description,
+			Location:     event.Location,
+			OccurredAt:   event.OccurredAt,
+			CreatedAt:    now,
+		})
+	}
+
+	updates := map[string]interface{}{
+		"tracked_at": now,
+		"updated_at": now,
+	}
+
+	// 以最新的事件作为包裹状态
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].OccurredAt < events[j].OccurredAt
+	})
+	if len(events) > 0 {
+		latest := events[len(events)-1]
+		switch latest.Status {
+		case carrier.TrackingStatusInTransit, carrier.TrackingStatusException:
+			updates["status"] = latest.Status
+		case carrier.TrackingStatusDelivered:
+			updates["status"] = model.ShipmentStatusDelivered
+			updates["delivered_at"] = latest.OccurredAt
+		}
+	}
+
+	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
+		if len(newEvents) > 0 {
+			err := tx.Create(&newEvents).Error
+			if err != nil {
+				ctx.Logger.Error("Failed to create shipment events", err)
+				tx.Rollback()
+				return errors.New("failed to create shipment events")
+			}
+		}
+
+		err := tx.Model(&model.Shipment{}).Where("uuid = ?", shipment.Uuid).Updates(updates).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update shipment", err)
+			tx.Rollback()
+			return errors.New("failed to update shipment")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if updates["status"] == model.ShipmentStatusDelivered {
+		return s.completeOrder(ctx, shipment.OrderNo)
+	}
+	return nil
+}
+
+// 订单已全部发货且所有包裹都已签收时，订单变为已完成
+func (s *CarrierService) completeOrder(ctx *app.Context, orderNo string) error {
+	var pending int64
+	err := ctx.DB.Model(&model.Shipment{}).Where("order_no = ? AND status <> ?", orderNo, model.ShipmentStatusDelivered).Count(&pending).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to count undelivered shipments", err)
+		return errors.New("failed to count undelivered shipments")
+	}
+	if pending > 0 {
+		return nil
+	}
+
+	now := time.Now().Format(time.DateTime)
+	err = ctx.DB.Model(&model.Order{}).Where("order_no = ? AND status = ?", orderNo, model.OrderStatusDelivered).Updates(map[string]interface{}{
+		"status":       model.OrderStatusCompleted,
+		"completed_at": now,
+		"updated_at":   now,
+	}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to complete order", err)
+		return errors.New("failed to complete order")
+	}
+	return nil
+}
+
+// 获取订单和包裹信息，items为空时按所有订单商品计算
+func (s *CarrierService) orderParcel(ctx *app.Context, orderNo string, items []*model.ReqShipmentItem) (*model.Order, *carrier.Parcel, error) {
+	order, err := NewOrderService().GetOrderByID(ctx, orderNo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if order.IsVirtual {
+		return nil, nil, errors.New("virtual order does not need shipping")
+	}
+
+	orderItems, err := NewOrderService().GetOrderItemsByOrderNo(ctx, order.OrderNo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	quantities := make(map[int64]int)
+	for _, item := range items {
+		quantities[item.OrderItemID] = item.Quantity
+	}
+
+	parcel := &carrier.Parcel{}
+	for _, orderItem := range orderItems {
+		quantity := orderItem.Quantity
+		if len(items) > 0 {
+			quantity = quantities[orderItem.ID]
+		}
+		if quantity <= 0 || orderItem.ProductItem == nil {
+			continue
+		}
+
+		productItem := orderItem.ProductItem
+		parcel.Weight += productItem.Weight * float64(quantity)
+		parcel.Length = max(parcel.Length, productItem.Length)
+		parcel.Width = max(parcel.Width, productItem.Width)
+		parcel.Height += productItem.Height * float64(quantity)
+	}
+
+	return order, parcel, nil
+}
+
+func (s *CarrierService) fromAddress(ctx *app.Context) carrier.Address {
+	conf := ctx.Config.Shipping
+	return carrier.Address{
+		Name:     conf.FromName,
+		Phone:    conf.FromPhone,
+		Country:  conf.FromCountry,
+		Province: conf.FromProvince,
+		City:     conf.FromCity,
+		Address:  conf.FromAddress,
+		Zip:      conf.FromZip,
+	}
+}
+
+func (s *CarrierService) toAddress(order *model.Order) carrier.Address {
+	return carrier.Address{
+		Name:     order.ReceiverName,
+		Phone:    order.ReceiverPhone,
+		Email:    order.ReceiverEmail,
+		Country:  order.ReceiverCountry,
+		Province: order.ReceiverProvince,
+		City:     order.ReceiverCity,
+		Address:  order.ReceiverAddress,
+		Zip:      order.ReceiverZip,
+	}
+}
+
+// 保存面单文件，面单包含收件人信息，保存在私有目录
+func (s *CarrierService) saveLabel(ctx *app.Context, orderNo string, label *carrier.Label) (*model.Resource, error) {
+	if ctx.Config.Digital.Dir == "" {
+		return nil, errors.New("private file dir is not configured")
+	}
+
+	mimeType := "application/pdf"
+	if label.Format == carrier.LabelFormatZPL {
+		mimeType = "application/zpl"
+	}
+
+	filename := filepath.Join("labels", uuid.New().String()+"."+label.Format)
+	path := filepath.Join(ctx.Config.Digital.Dir, filename)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err == nil {
+		err = os.WriteFile(path, label.Data, 0600)
+	}
+	if err != nil {
+		ctx.Logger.Error("Failed to save shipment label", err)
+		return nil, errors.New("failed to save shipment label")
+	}
+
+	resource := &model.Resource{
+		Name:      orderNo + "-" + label.TrackingNo + "." + label.Format,
+		Type:      model.ResourceTypeFile,
+		MimeType:  mimeType,
+		Size:      int64(len(label.Data)),
+		Md5:       fmt.Sprintf("%x", md5.Sum(label.Data)),
+		Path:      filename,
+		IsPrivate: true,
+	}
+	err = NewResourceService().CreateResource(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
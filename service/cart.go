@@ -195,6 +195,16 @@ func (s *CartService) GetCartList(ctx *app.Context, params *model.ReqCartQueryPa
 		ctx.Logger.Error("Failed to get product item by UUID list", err)
 		return nil, errors.New("failed to get product item by UUID list")
 	}
+
+	productItems := make([]*model.ProductItemRes, 0)
+	for _, productItem := range productItemMap {
+		productItems = append(productItems, productItem)
+	}
+	err = NewPriceService().ResolveItemResPrices(ctx, params.UserID, productItems)
+	if err != nil {
+		return nil, err
+	}
+
 	res := make([]*model.CartProductItemRes, 0)
 	for _, cart := range carts {
 		item := &model.CartProductItemRes{
@@ -0,0 +1,598 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/auth"
+	"sgin/pkg/redisop"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/util"
+)
+
+const (
+	// 接口权限校验方式
+	AuthorizerRbac   = "rbac"
+	AuthorizerCasbin = "casbin"
+
+	casbinVersionKey = "sgin:casbin:version"
+)
+
+var (
+	casbinMu       sync.Mutex
+	casbinEnforcer *casbin.SyncedEnforcer
+	casbinAdapter  *auth.Adapter
+	// 本实例已加载的策略版本，配置了redis时与redis中的版本不同则重新加载
+	casbinVersion string
+)
+
+// InitCasbin 创建casbin enforcer，启动时在注册路由前调用
+func InitCasbin(a *app.App) error {
+	adapter, err := auth.NewAdapter(a.DB)
+	if err != nil {
+		return err
+	}
+	enforcer, err := auth.NewEnforcer(adapter)
+	if err != nil {
+		return err
+	}
+
+	casbinMu.Lock()
+	casbinAdapter = adapter
+	casbinEnforcer = enforcer
+	if a.Redis != nil {
+		casbinVersion, _ = a.Redis.Get(context.Background(), casbinVersionKey)
+	}
+	casbinMu.Unlock()
+
+	// 使用casbin校验时启动后按当前角色配置生成策略
+	if a.Config.Auth.Authorizer == AuthorizerCasbin {
+		_, err = NewCasbinService().SyncPolicies(a.NewContext())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CasbinEnforcer 返回全局的enforcer，未初始化时为nil
+func CasbinEnforcer() *casbin.SyncedEnforcer {
+	casbinMu.Lock()
+	defer casbinMu.Unlock()
+	return casbinEnforcer
+}
+
+type CasbinService struct {
+}
+
+func NewCasbinService() *CasbinService {
+	return &CasbinService{}
+}
+
+// GetDomain 请求的域，为用户当前团队uuid，没有团队时为空
+func (s *CasbinService) GetDomain(ctx *app.Context) string {
+	teamMember, err := NewTeamMemberService().GetUserCurrentTeam(ctx, ctx.GetString("user_id"))
+	if err != nil {
+		return ""
+	}
+	return teamMember.TeamUUID
+}
+
+// ReloadIfChanged 其他实例修改策略后重新加载，没有配置redis时不需要检查
+func (s *CasbinService) ReloadIfChanged(ctx *app.Context) error {
+	enforcer := CasbinEnforcer()
+	if enforcer == nil {
+		return errors.New("authorizer is not ready")
+	}
+	if ctx.Redis == nil {
+		return nil
+	}
+
+	version, err := ctx.Redis.Get(ctx.Ctx, casbinVersionKey)
+	if err != nil && !errors.Is(err, redisop.Nil) {
+		ctx.Logger.Error("Failed to get casbin policy version", err)
+		return errors.New("failed to check permission")
+	}
+
+	casbinMu.Lock()
+	defer casbinMu.Unlock()
+	if version == casbinVersion {
+		return nil
+	}
+	err = enforcer.LoadPolicy()
+	if err != nil {
+		ctx.Logger.Error("Failed to load casbin policy", err)
+		return errors.New("failed to load policy")
+	}
+	casbinVersion = version
+	return nil
+}
+
+// Reload 从数据库重新加载策略，并通知其他实例重新加载
+func (s *CasbinService) Reload(ctx *app.Context) error {
+	enforcer := CasbinEnforcer()
+	if enforcer == nil {
+		return errors.New("authorizer is not ready")
+	}
+
+	casbinMu.Lock()
+	defer casbinMu.Unlock()
+	err := enforcer.LoadPolicy()
+	if err != nil {
+		ctx.Logger.Error("Failed to load casbin policy", err)
+		return errors.New("failed to load policy")
+	}
+	if version := s.notify(ctx); version != "" {
+		casbinVersion = version
+	}
+	return nil
+}
+
+// SyncPolicies 根据角色、菜单和接口的关联重新生成策略，手工添加的策略保留，生成的策略与RbacService的判断一致
+// 角色在菜单下拥有接口需要的权限位时生成 p, 角色uuid, 团队uuid, 接口路径, 方法，直接授予用户的权限生成 p, 用户uuid, 团队uuid, 接口路径, 方法
+// 团队成员的角色和用户角色生成 g, 用户uuid, 角色uuid, 团队uuid，团队拥有者可以调用团队内所有登记的接口
+func (s *CasbinService) SyncPolicies(ctx *app.Context) (int, error) {
+	casbinMu.Lock()
+	adapter := casbinAdapter
+	casbinMu.Unlock()
+	if adapter == nil {
+		return 0, errors.New("authorizer is not ready")
+	}
+
+	rules, err := s.generatePolicies(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	err = adapter.ReplaceGenerated(rules)
+	if err != nil {
+		ctx.Logger.Error("Failed to save casbin policy", err)
+		return 0, errors.New("failed to save policy")
+	}
+
+	err = s.Reload(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(rules), nil
+}
+
+// GetPolicyList 查询策略
+func (s *CasbinService) GetPolicyList(ctx *app.Context, params *model.ReqCasbinPolicyQuery) ([]*auth.CasbinRule, error) {
+	casbinMu.Lock()
+	adapter := casbinAdapter
+	casbinMu.Unlock()
+	if adapter == nil {
+		return nil, errors.New("authorizer is not ready")
+	}
+
+	// 只能查看当前团队的策略
+	teamUuid, err := s.currentTeam(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// p策略的团队为v1，g策略的团队为v2
+	rules := make([]*auth.CasbinRule, 0)
+	if params.Ptype == "" || params.Ptype == "p" {
+		policies, err := adapter.ListRules("p", 0, params.Sub, teamUuid)
+		if err != nil {
+			ctx.Logger.Error("Failed to get casbin policy list", err)
+			return nil, errors.New("failed to get policy list")
+		}
+		rules = append(rules, policies...)
+	}
+	if params.Ptype == "" || params.Ptype == "g" {
+		groupings, err := adapter.ListRules("g", 0, params.Sub, "", teamUuid)
+		if err != nil {
+			ctx.Logger.Error("Failed to get casbin policy list", err)
+			return nil, errors.New("failed to get policy list")
+		}
+		rules = append(rules, groupings...)
+	}
+	return rules, nil
+}
+
+// AddPolicy 手工添加当前团队的策略，立即生效
+func (s *CasbinService) AddPolicy(ctx *app.Context, params *model.ReqCasbinPolicy) error {
+	enforcer, rule, err := s.checkPolicy(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if params.Ptype == "p" {
+		_, err = enforcer.AddNamedPolicy("p", rule)
+	} else {
+		_, err = enforcer.AddNamedGroupingPolicy("g", rule)
+	}
+	if err != nil {
+		ctx.Logger.Error("Failed to add casbin policy", err)
+		return errors.New("failed to add policy")
+	}
+	s.notify(ctx)
+	return nil
+}
+
+// RemovePolicy 删除当前团队的策略，立即生效，生成的策略在下次同步时会重新生成
+func (s *CasbinService) RemovePolicy(ctx *app.Context, params *model.ReqCasbinPolicy) error {
+	enforcer, rule, err := s.checkPolicy(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if params.Ptype == "p" {
+		_, err = enforcer.RemoveNamedPolicy("p", rule)
+	} else {
+		_, err = enforcer.RemoveNamedGroupingPolicy("g", rule)
+	}
+	if err != nil {
+		ctx.Logger.Error("Failed to remove casbin policy", err)
+		return errors.New("failed to remove policy")
+	}
+	s.notify(ctx)
+	return nil
+}
+
+// Explain 检查当前团队的成员能否调用接口，并说明原因
+func (s *CasbinService) Explain(ctx *app.Context, params *model.ReqCasbinExplain) (*model.ResCasbinExplain, error) {
+	enforcer := CasbinEnforcer()
+	if enforcer == nil {
+		return nil, errors.New("authorizer is not ready")
+	}
+
+	teamUuid, err := s.currentTeam(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if params.TeamUuid != "" && params.TeamUuid != teamUuid {
+		return nil, errors.New("只能查询当前团队")
+	}
+
+	res := &model.ResCasbinExplain{
+		UserUuid: params.UserUuid,
+		TeamUuid: teamUuid,
+	}
+	if res.UserUuid == "" {
+		res.UserUuid = ctx.GetString("user_id")
+	}
+	err = s.checkMember(ctx, teamUuid, res.UserUuid)
+	if err != nil {
+		return nil, err
+	}
+	method := strings.ToUpper(params.Method)
+
+	allowed, explain, err := enforcer.EnforceEx(res.UserUuid, res.TeamUuid, params.Path, method)
+	if err != nil {
+		ctx.Logger.Error("Failed to enforce casbin policy", err)
+		return nil, errors.New("failed to check permission")
+	}
+	res.Allowed = allowed
+	res.MatchedPolicy = explain
+	res.Roles = enforcer.GetRolesForUserInDomain(res.UserUuid, res.TeamUuid)
+
+	// 用户、角色和所有用户在团队中的策略
+	res.Policies = make([][]string, 0)
+	for _, sub := range append([]string{res.UserUuid, auth.Any}, res.Roles...) {
+		for _, policy := range enforcer.GetFilteredPolicy(0, sub) {
+			if policy[1] == auth.Any || policy[1] == res.TeamUuid {
+				res.Policies = append(res.Policies, policy)
+			}
+		}
+	}
+
+	switch {
+	case allowed:
+		res.Reason = "允许，匹配策略: " + strings.Join(explain, ", ")
+	case res.TeamUuid == "":
+		res.Reason = "用户没有加入团队"
+	case len(res.Roles) == 0:
+		res.Reason = "用户在团队中没有角色"
+	default:
+		pathMatched := false
+		for _, policy := range res.Policies {
+			if util.KeyMatch2(params.Path, policy[2]) {
+				pathMatched = true
+				break
+			}
+		}
+		if pathMatched {
+			res.Reason = fmt.Sprintf("匹配该路径的策略不允许%s方法", method)
+		} else {
+			res.Reason = "用户和角色在团队中没有该接口的策略"
+		}
+	}
+	return res, nil
+}
+
+// 检查手工策略，团队固定为当前团队，主体和角色需要属于当前团队
+func (s *CasbinService) checkPolicy(ctx *app.Context, params *model.ReqCasbinPolicy) (*casbin.SyncedEnforcer, []string, error) {
+	enforcer := CasbinEnforcer()
+	if enforcer == nil {
+		return nil, nil, errors.New("authorizer is not ready")
+	}
+
+	teamUuid, err := s.currentTeam(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule := make([]string, 0, len(params.Rule))
+	for _, v := range params.Rule {
+		rule = append(rule, strings.TrimSpace(v))
+	}
+	switch params.Ptype {
+	case "p":
+		if len(rule) != 4 {
+			return nil, nil, errors.New("p策略格式为 主体, 团队, 路径, 方法")
+		}
+		rule[1] = teamUuid
+		rule[3] = strings.ToUpper(rule[3])
+	case "g":
+		if len(rule) != 3 {
+			return nil, nil, errors.New("g策略格式为 用户, 角色, 团队")
+		}
+		rule[2] = teamUuid
+	default:
+		return nil, nil, errors.New("策略类型只能为p或g")
+	}
+	for _, v := range rule {
+		if v == "" {
+			return nil, nil, errors.New("策略不能包含空值")
+		}
+	}
+	if rule[0] == auth.Any || (params.Ptype == "g" && rule[1] == auth.Any) {
+		return nil, nil, errors.New("策略的主体和角色不能为*")
+	}
+
+	if params.Ptype == "p" {
+		// 主体为团队成员或团队的角色
+		if !s.isTeamRole(ctx, teamUuid, rule[0]) {
+			err = s.checkMember(ctx, teamUuid, rule[0])
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	} else {
+		err = s.checkMember(ctx, teamUuid, rule[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		if !s.isTeamRole(ctx, teamUuid, rule[1]) {
+			return nil, nil, errors.New("角色不属于当前团队")
+		}
+	}
+	return enforcer, rule, nil
+}
+
+// 当前用户的当前团队
+func (s *CasbinService) currentTeam(ctx *app.Context) (string, error) {
+	teamMember, err := NewTeamMemberService().GetUserCurrentTeam(ctx, ctx.GetString("user_id"))
+	if err != nil {
+		return "", errors.New("用户没有加入团队")
+	}
+	return teamMember.TeamUUID, nil
+}
+
+func (s *CasbinService) checkMember(ctx *app.Context, teamUuid, userUuid string) error {
+	var count int64
+	err := ctx.DB.Model(&model.TeamMember{}).Where("team_uuid = ? AND user_uuid = ?", teamUuid, userUuid).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get team member", err)
+		return errors.New("failed to get team member")
+	}
+	if count == 0 {
+		return errors.New("用户不是当前团队的成员")
+	}
+	return nil
+}
+
+// 角色是否属于团队，团队拥有者角色不能手工分配
+func (s *CasbinService) isTeamRole(ctx *app.Context, teamUuid, roleUuid string) bool {
+	var count int64
+	err := ctx.DB.Model(&model.Role{}).Where("uuid = ? AND team_uuid = ?", roleUuid, teamUuid).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get role", err)
+		return false
+	}
+	return count > 0
+}
+
+// 生成角色配置对应的策略
+func (s *CasbinService) generatePolicies(ctx *app.Context) ([][]string, error) {
+	rules := make([][]string, 0)
+	seen := make(map[string]bool)
+	add := func(rule ...string) {
+		key := strings.Join(rule, ",")
+		if !seen[key] {
+			seen[key] = true
+			rules = append(rules, rule)
+		}
+	}
+
+	// 接口等级与RbacService相同，同步新增还未确认的接口只有团队拥有者可以调用
+	apis := make([]*model.API, 0)
+	err := ctx.DB.Where("status <> ?", APIStatusDeleted).Find(&apis).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get API list", err)
+		return nil, errors.New("failed to generate policy")
+	}
+	apiMap := make(map[string]*model.API)
+	for _, api := range apis {
+		level := apiLevel(api)
+		if level == APILevelDisabled {
+			continue
+		}
+		apiMap[api.UUID] = api
+		// 公开和登录用户等级的接口所有用户都可以调用
+		if level == APILevelPublic || level == APILevelLogin {
+			add("p", auth.Any, auth.Any, api.Path, api.Method)
+		}
+	}
+
+	menuAPIs := make([]*model.MenuAPI, 0)
+	err = ctx.DB.Find(&menuAPIs).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get menu API list", err)
+		return nil, errors.New("failed to generate policy")
+	}
+	menuAPIMap := make(map[string][]*model.API)
+	for _, menuAPI := range menuAPIs {
+		api, ok := apiMap[menuAPI.APIUUID]
+		if !ok || apiLevel(api) == APILevelSuper {
+			continue
+		}
+		menuAPIMap[menuAPI.MenuUUID] = append(menuAPIMap[menuAPI.MenuUUID], api)
+	}
+
+	roles := make([]*model.Role, 0)
+	err = ctx.DB.Where("is_active = ?", true).Find(&roles).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get role list", err)
+		return nil, errors.New("failed to generate policy")
+	}
+	roleMap := make(map[string]*model.Role)
+	for _, role := range roles {
+		roleMap[role.Uuid] = role
+	}
+
+	roleMenus := make([]*model.RoleMenuPermission, 0)
+	err = ctx.DB.Find(&roleMenus).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get role menu list", err)
+		return nil, errors.New("failed to generate policy")
+	}
+	for _, roleMenu := range roleMenus {
+		role, ok := roleMap[roleMenu.RoleUUID]
+		if !ok {
+			continue
+		}
+		dom := role.TeamUuid
+		if dom == "" {
+			dom = auth.Any
+		}
+		for _, api := range menuAPIMap[roleMenu.MenuUUID] {
+			if roleMenu.Permission&model.ActionPermissionBit(api.Method, api.Path) != 0 {
+				add("p", role.Uuid, dom, api.Path, api.Method)
+			}
+		}
+	}
+
+	// 团队成员角色
+	members := make([]*model.TeamMember, 0)
+	err = ctx.DB.Find(&members).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get team member list", err)
+		return nil, errors.New("failed to generate policy")
+	}
+	userTeams := make(map[string][]string)
+	for _, member := range members {
+		userTeams[member.UserUUID] = append(userTeams[member.UserUUID], member.TeamUUID)
+		if _, ok := roleMap[member.Role]; ok {
+			add("g", member.UserUUID, member.Role, member.TeamUUID)
+		}
+	}
+
+	// 用户角色，不属于团队的角色在用户加入的所有团队生效
+	userRoles := make([]*model.UserRole, 0)
+	err = ctx.DB.Find(&userRoles).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get user role list", err)
+		return nil, errors.New("failed to generate policy")
+	}
+	for _, userRole := range userRoles {
+		role, ok := roleMap[userRole.RoleUUID]
+		if !ok {
+			continue
+		}
+		for _, team := range userTeams[userRole.UserUUID] {
+			if role.TeamUuid == "" || role.TeamUuid == team {
+				add("g", userRole.UserUUID, role.Uuid, team)
+			}
+		}
+	}
+
+	// 直接授予用户的权限，按权限关联的菜单在用户加入的所有团队生效
+	userPermissions := make([]*model.UserPermission, 0)
+	err = ctx.DB.Find(&userPermissions).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get user permission list", err)
+		return nil, errors.New("failed to generate policy")
+	}
+	if len(userPermissions) > 0 {
+		permissions := make([]*model.Permission, 0)
+		err = ctx.DB.Find(&permissions).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get permission list", err)
+			return nil, errors.New("failed to generate policy")
+		}
+		bits := make(map[string]uint)
+		for _, permission := range permissions {
+			bits[permission.Uuid] = permission.Bit
+		}
+
+		permissionMenus := make([]*model.PermissionMenu, 0)
+		err = ctx.DB.Find(&permissionMenus).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get permission menu list", err)
+			return nil, errors.New("failed to generate policy")
+		}
+		permissionMenuMap := make(map[string][]string)
+		for _, permissionMenu := range permissionMenus {
+			permissionMenuMap[permissionMenu.PermissionUuid] = append(permissionMenuMap[permissionMenu.PermissionUuid], permissionMenu.MenuUuid)
+		}
+
+		for _, userPermission := range userPermissions {
+			bit := bits[userPermission.PermissionUuid]
+			for _, menuUuid := range permissionMenuMap[userPermission.PermissionUuid] {
+				for _, api := range menuAPIMap[menuUuid] {
+					if bit&model.ActionPermissionBit(api.Method, api.Path) == 0 {
+						continue
+					}
+					for _, team := range userTeams[userPermission.UserUuid] {
+						add("p", userPermission.UserUuid, team, api.Path, api.Method)
+					}
+				}
+			}
+		}
+	}
+
+	// 团队拥有者可以调用所有登记的接口
+	teams := make([]*model.Team, 0)
+	err = ctx.DB.Where("owner_uuid != ?", "").Find(&teams).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get team list", err)
+		return nil, errors.New("failed to generate policy")
+	}
+	if len(teams) > 0 {
+		for _, api := range apis {
+			if _, ok := apiMap[api.UUID]; ok {
+				add("p", auth.OwnerRole, auth.Any, api.Path, api.Method)
+			}
+		}
+	}
+	for _, team := range teams {
+		add("g", team.OwnerUuid, auth.OwnerRole, team.UUID)
+	}
+
+	return rules, nil
+}
+
+// 策略变更后增加版本号，其他实例检查到版本变化后重新加载，返回新的版本号
+func (s *CasbinService) notify(ctx *app.Context) string {
+	if ctx.Redis == nil {
+		return ""
+	}
+	version, err := ctx.Redis.Incr(ctx.Ctx, casbinVersionKey)
+	if err != nil {
+		ctx.Logger.Error("Failed to update casbin policy version", err)
+		return ""
+	}
+	return strconv.FormatInt(version, 10)
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/auth"
+	"sgin/pkg/testutil"
+	"sgin/pkg/utils"
+)
+
+// 权限测试数据：团队team1，拥有者owner，editor的角色在菜单menu1有查询权限
+// direct没有角色，直接授予菜单menu2的查询权限，outsider没有加入团队
+func setupPermissionFixture(t *testing.T) (*app.App, *app.Context) {
+	a := testutil.NewApp(t)
+	ctx := testutil.Context(a)
+
+	rows := []interface{}{
+		&model.API{UUID: "api-list", Path: "/api/v1/product/list", Method: "POST", PermissionLevel: APILevelAdmin, Status: APIStatusEnabled},
+		&model.API{UUID: "api-delete", Path: "/api/v1/product/delete", Method: "POST", PermissionLevel: APILevelAdmin, Status: APIStatusEnabled},
+		&model.API{UUID: "api-create", Path: "/api/v1/product/create", Method: "POST", PermissionLevel: APILevelAdmin, Status: APIStatusPending},
+		&model.API{UUID: "api-update", Path: "/api/v1/product/update", Method: "POST", PermissionLevel: APILevelDisabled, Status: APIStatusEnabled},
+		&model.API{UUID: "api-info", Path: "/api/v1/product/info", Method: "POST", PermissionLevel: APILevelPublic, Status: APIStatusEnabled},
+		&model.API{UUID: "api-team-delete", Path: "/api/v1/team/delete", Method: "POST", PermissionLevel: APILevelSuper, Status: APIStatusEnabled},
+		&model.API{UUID: "api-order-list", Path: "/api/v1/order/list", Method: "POST", PermissionLevel: APILevelAdmin, Status: APIStatusEnabled},
+		&model.API{UUID: "api-removed", Path: "/api/v1/order/removed", Method: "POST", PermissionLevel: APILevelLogin, Status: APIStatusDeleted},
+		&model.MenuAPI{Uuid: "ma1", MenuUUID: "menu1", APIUUID: "api-list"},
+		&model.MenuAPI{Uuid: "ma2", MenuUUID: "menu1", APIUUID: "api-delete"},
+		&model.MenuAPI{Uuid: "ma3", MenuUUID: "menu1", APIUUID: "api-create"},
+		&model.MenuAPI{Uuid: "ma4", MenuUUID: "menu2", APIUUID: "api-order-list"},
+		&model.Team{UUID: "team1", Name: "team1", OwnerUuid: "owner"},
+		&model.Role{Uuid: "role1", Name: "role1", TeamUuid: "team1", IsActive: true},
+		&model.RoleMenuPermission{UUID: "rm1", RoleUUID: "role1", MenuUUID: "menu1", Permission: model.Read | model.Create},
+		&model.TeamMember{UUID: "tm1", TeamUUID: "team1", UserUUID: "owner", IsCurrentTeam: true},
+		&model.TeamMember{UUID: "tm2", TeamUUID: "team1", UserUUID: "editor", Role: "role1", IsCurrentTeam: true},
+		&model.TeamMember{UUID: "tm3", TeamUUID: "team1", UserUUID: "direct", IsCurrentTeam: true},
+		&model.Permission{Uuid: "perm1", Name: "order read", Bit: model.Read, CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.PermissionMenu{Uuid: "pm1", PermissionUuid: "perm1", MenuUuid: "menu2", CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.UserPermission{Uuid: "up1", UserUuid: "direct", PermissionUuid: "perm1", CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+	}
+	for _, row := range rows {
+		err := ctx.DB.Create(row).Error
+		if err != nil {
+			t.Fatalf("create %T: %v", row, err)
+		}
+	}
+
+	err := InitCasbin(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewCasbinService().SyncPolicies(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	NewRbacService().InvalidateCache(ctx)
+	return a, ctx
+}
+
+// 同一份数据在rbac和casbin两种校验方式下结果相同
+func TestAuthorizersAgree(t *testing.T) {
+	_, ctx := setupPermissionFixture(t)
+
+	tests := []struct {
+		user string
+		path string
+		want bool
+	}{
+		{"owner", "/api/v1/product/list", true},
+		{"owner", "/api/v1/product/create", true},
+		{"owner", "/api/v1/product/update", false},
+		{"owner", "/api/v1/team/delete", true},
+		{"owner", "/api/v1/unknown", false},
+		{"owner", "/api/v1/order/removed", false},
+		{"editor", "/api/v1/product/list", true},
+		{"editor", "/api/v1/product/delete", false},
+		{"editor", "/api/v1/product/create", false},
+		{"editor", "/api/v1/product/info", true},
+		{"editor", "/api/v1/team/delete", false},
+		{"editor", "/api/v1/order/list", false},
+		{"direct", "/api/v1/order/list", true},
+		{"direct", "/api/v1/product/list", false},
+		{"outsider", "/api/v1/product/info", true},
+		{"outsider", "/api/v1/order/list", false},
+		{"outsider", "/api/v1/unknown", false},
+	}
+	enforcer := CasbinEnforcer()
+	for _, tt := range tests {
+		rbac, err := NewRbacService().CheckPermission(ctx, tt.user, utils.TokenAudienceAdmin, "POST", tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dom := ""
+		if member, err := NewTeamMemberService().GetUserCurrentTeam(ctx, tt.user); err == nil {
+			dom = member.TeamUUID
+		}
+		casbin, err := enforcer.Enforce(tt.user, dom, tt.path, "POST")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if rbac != tt.want || casbin != tt.want {
+			t.Errorf("%s %s: rbac = %v, casbin = %v, want %v", tt.user, tt.path, rbac, casbin, tt.want)
+		}
+	}
+}
+
+func TestCasbinPolicyScopedToCurrentTeam(t *testing.T) {
+	_, ctx := setupPermissionFixture(t)
+	err := ctx.DB.Create(&model.Team{UUID: "team2", Name: "team2", OwnerUuid: "other"}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.Set("user_id", "owner")
+
+	tests := []struct {
+		name string
+		rule model.ReqCasbinPolicy
+		ok   bool
+	}{
+		{"any subject", model.ReqCasbinPolicy{Ptype: "p", Rule: []string{auth.Any, "team1", "/*", "*"}}, false},
+		{"non member subject", model.ReqCasbinPolicy{Ptype: "p", Rule: []string{"outsider", "team1", "/api/v1/order/list", "POST"}}, false},
+		{"member subject", model.ReqCasbinPolicy{Ptype: "p", Rule: []string{"editor", "team1", "/api/v1/order/list", "POST"}}, true},
+		{"team role subject", model.ReqCasbinPolicy{Ptype: "p", Rule: []string{"role1", "team1", "/api/v1/order/info", "POST"}}, true},
+		{"owner role", model.ReqCasbinPolicy{Ptype: "g", Rule: []string{"editor", auth.OwnerRole, "team1"}}, false},
+		{"non member grouping", model.ReqCasbinPolicy{Ptype: "g", Rule: []string{"outsider", "role1", "team1"}}, false},
+		{"member grouping", model.ReqCasbinPolicy{Ptype: "g", Rule: []string{"direct", "role1", "team1"}}, true},
+	}
+	for _, tt := range tests {
+		err := NewCasbinService().AddPolicy(ctx, &tt.rule)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+
+	// 其他团队的域被改为当前团队
+	err = NewCasbinService().AddPolicy(ctx, &model.ReqCasbinPolicy{Ptype: "p", Rule: []string{"editor", "team2", "/api/v1/order/export", "POST"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	enforcer := CasbinEnforcer()
+	if ok, _ := enforcer.Enforce("editor", "team2", "/api/v1/order/export", "POST"); ok {
+		t.Error("policy was added to another team")
+	}
+	if ok, _ := enforcer.Enforce("editor", "team1", "/api/v1/order/export", "POST"); !ok {
+		t.Error("policy was not added to the current team")
+	}
+
+	rules, err := NewCasbinService().GetPolicyList(ctx, &model.ReqCasbinPolicyQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rule := range rules {
+		dom := rule.V1
+		if rule.Ptype == "g" {
+			dom = rule.V2
+		}
+		if dom != "team1" {
+			t.Errorf("listed policy of another team: %+v", rule)
+		}
+	}
+
+	_, err = NewCasbinService().Explain(ctx, &model.ReqCasbinExplain{UserUuid: "other", Path: "/api/v1/product/list", Method: "POST"})
+	if err == nil {
+		t.Error("explain allowed a user outside the current team")
+	}
+	_, err = NewCasbinService().Explain(ctx, &model.ReqCasbinExplain{TeamUuid: "team2", Path: "/api/v1/product/list", Method: "POST"})
+	if err == nil {
+		t.Error("explain allowed another team")
+	}
+	res, err := NewCasbinService().Explain(ctx, &model.ReqCasbinExplain{UserUuid: "editor", Path: "/api/v1/product/list", Method: "POST"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Allowed {
+		t.Errorf("explain editor: %+v", res)
+	}
+}
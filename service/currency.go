@@ -42,6 +42,20 @@ func (s *CurrencyService) GetCurrencyByUUID(ctx *app.Context, uuid string) (*mod
 	return currency, nil
 }
 
+// 根据货币代码获取币种
+func (s *CurrencyService) GetCurrencyByCode(ctx *app.Context, code string) (*model.Currency, error) {
+	currency := &model.Currency{}
+	err := ctx.DB.Where("code = ?", code).First(currency).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("currency not found")
+		}
+		ctx.Logger.Error("Failed to get currency by code", err)
+		return nil, errors.New("failed to get currency by code")
+	}
+	return currency, nil
+}
+
 func (s *CurrencyService) UpdateCurrency(ctx *app.Context, currency *model.Currency) error {
 
 	err := ctx.DB.Where("uuid = ?", currency.Uuid).Updates(currency).Error
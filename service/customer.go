@@ -0,0 +1,455 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/mail"
+	"sgin/pkg/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var customerCodeMailContent = `
+<html>
+<body>
+    <h2>%s</h2>
+    <p>尊敬的用户，您的验证码为：<strong>%s</strong></p>
+    <p>验证码%d分钟内有效，请勿将验证码透露给他人。</p>
+    <p>如果不是您本人操作，请忽略本邮件。</p>
+</body>
+</html>
+`
+
+type CustomerService struct {
+}
+
+func NewCustomerService() *CustomerService {
+	return &CustomerService{}
+}
+
+// Register 客户注册，邮箱需先通过验证码验证
+func (s *CustomerService) Register(ctx *app.Context, params *model.ReqCustomerRegister) (*model.Customer, error) {
+	email := strings.ToLower(strings.TrimSpace(params.Email))
+
+	exists, err := s.emailExists(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("email already registered")
+	}
+
+	err = s.verifyCode(ctx, email, model.VerificationPurposeRegister, params.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Format(time.DateTime)
+	customer := &model.Customer{
+		Uuid:            uuid.New().String(),
+		Email:           email,
+		Password:        utils.HashPasswordWithSalt(params.Password, ctx.Config.PasswdKey),
+		Nickname:        params.Nickname,
+		Phone:           params.Phone,
+		Status:          model.CustomerStatusEnabled,
+		EmailVerifiedAt: now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	err = ctx.DB.Create(customer).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create customer", err)
+		return nil, errors.New("failed to create customer")
+	}
+	NewWebhookService().Publish(ctx, customer.TenantUuid, model.WebhookEventCustomerCreated, customer)
+
+	return customer, nil
+}
+
+// Login 客户登录，创建客户受众的登录会话
+func (s *CustomerService) Login(ctx *app.Context, params *model.ReqCustomerLogin) (*model.ResUserLogin, error) {
+	lockService := NewLoginLockService()
+	err := lockService.CheckLogin(ctx, utils.TokenAudienceCustomer, params.Email, params.CaptchaId, params.CaptchaCode)
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := s.getCustomerByEmail(ctx, params.Email)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil || !utils.CheckPasswordHashWithSalt(params.Password, customer.Password, ctx.Config.PasswdKey) {
+		lockService.RecordFailure(ctx, utils.TokenAudienceCustomer, params.Email)
+		return nil, errors.New("邮箱或密码错误")
+	}
+	if customer.Status == model.CustomerStatusDisabled {
+		return nil, errors.New("account is disabled")
+	}
+
+	res, err := NewSessionService().CreateSession(ctx, customer.Uuid, utils.TokenAudienceCustomer)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Format(time.DateTime)
+	err = s.updateCustomer(ctx, customer.Uuid, map[string]interface{}{
+		"last_login_at": now,
+		"last_login_ip": ctx.ClientIP(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	lockService.RecordSuccess(ctx, utils.TokenAudienceCustomer, params.Email)
+
+	return res, nil
+}
+
+// GetCustomerByUUID 获取客户信息，已注销的客户视为不存在
+func (s *CustomerService) GetCustomerByUUID(ctx *app.Context, customerUuid string) (*model.Customer, error) {
+	customer := &model.Customer{}
+	err := ctx.DB.Where("uuid = ? AND status <> ?", customerUuid, model.CustomerStatusDeleted).First(customer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("customer not found")
+		}
+		ctx.Logger.Error("Failed to get customer by UUID", err)
+		return nil, errors.New("failed to get customer by UUID")
+	}
+	return customer, nil
+}
+
+// UpdateProfile 更新客户资料
+func (s *CustomerService) UpdateProfile(ctx *app.Context, customerUuid string, params *model.ReqCustomerProfileUpdate) (*model.Customer, error) {
+	_, err := s.GetCustomerByUUID(ctx, customerUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.updateCustomer(ctx, customerUuid, map[string]interface{}{
+		"nickname": params.Nickname,
+		"phone":    params.Phone,
+		"avatar":   params.Avatar,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCustomerByUUID(ctx, customerUuid)
+}
+
+// UpdatePassword 修改密码，需要验证原密码，修改后所有会话需要重新登录
+func (s *CustomerService) UpdatePassword(ctx *app.Context, customerUuid string, params *model.ReqCustomerPasswordUpdate) error {
+	customer, err := s.GetCustomerByUUID(ctx, customerUuid)
+	if err != nil {
+		return err
+	}
+	if !utils.CheckPasswordHashWithSalt(params.OldPassword, customer.Password, ctx.Config.PasswdKey) {
+		return errors.New("原密码错误")
+	}
+
+	err = s.updateCustomer(ctx, customerUuid, map[string]interface{}{
+		"password": utils.HashPasswordWithSalt(params.NewPassword, ctx.Config.PasswdKey),
+	})
+	if err != nil {
+		return err
+	}
+
+	return NewSessionService().RevokeAllSessions(ctx, customerUuid)
+}
+
+// SendPasswordResetCode 发送重置密码验证码，邮箱未注册时不返回错误，避免泄露注册信息
+func (s *CustomerService) SendPasswordResetCode(ctx *app.Context, email string) error {
+	customer, err := s.getCustomerByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if customer == nil {
+		return nil
+	}
+
+	return s.sendCode(ctx, customer.Email, model.VerificationPurposeReset, "重置密码验证码")
+}
+
+// ResetPassword 通过邮箱验证码重置密码
+func (s *CustomerService) ResetPassword(ctx *app.Context, params *model.ReqCustomerPasswordReset) error {
+	customer, err := s.getCustomerByEmail(ctx, params.Email)
+	if err != nil {
+		return err
+	}
+	if customer == nil {
+		return errors.New("验证码错误")
+	}
+
+	err = s.verifyCode(ctx, customer.Email, model.VerificationPurposeReset, params.Code)
+	if err != nil {
+		return err
+	}
+
+	err = s.updateCustomer(ctx, customer.Uuid, map[string]interface{}{
+		"password": utils.HashPasswordWithSalt(params.Password, ctx.Config.PasswdKey),
+	})
+	if err != nil {
+		return err
+	}
+
+	return NewSessionService().RevokeAllSessions(ctx, customer.Uuid)
+}
+
+// RequestEmailChange 申请修改邮箱，验证码发送到新邮箱，确认后才会修改
+func (s *CustomerService) RequestEmailChange(ctx *app.Context, customerUuid string, params *model.ReqCustomerEmailChange) error {
+	customer, err := s.GetCustomerByUUID(ctx, customerUuid)
+	if err != nil {
+		return err
+	}
+	if !utils.CheckPasswordHashWithSalt(params.Password, customer.Password, ctx.Config.PasswdKey) {
+		return errors.New("密码错误")
+	}
+
+	email := strings.ToLower(strings.TrimSpace(params.Email))
+	if email == customer.Email {
+		return errors.New("email is not changed")
+	}
+	exists, err := s.emailExists(ctx, email)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("email already registered")
+	}
+
+	return s.sendCode(ctx, email, model.VerificationPurposeChangeEmail, "邮箱变更验证码")
+}
+
+// ConfirmEmailChange 验证新邮箱后修改邮箱
+func (s *CustomerService) ConfirmEmailChange(ctx *app.Context, customerUuid string, params *model.ReqCustomerEmailConfirm) (*model.Customer, error) {
+	_, err := s.GetCustomerByUUID(ctx, customerUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(params.Email))
+	err = s.verifyCode(ctx, email, model.VerificationPurposeChangeEmail, params.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	// 发送验证码后邮箱可能已被注册
+	exists, err := s.emailExists(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("email already registered")
+	}
+
+	err = s.updateCustomer(ctx, customerUuid, map[string]interface{}{
+		"email":             email,
+		"email_verified_at": time.Now().Format(time.DateTime),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCustomerByUUID(ctx, customerUuid)
+}
+
+// ExportData 导出客户的个人数据，JSON格式
+func (s *CustomerService) ExportData(ctx *app.Context, customerUuid string) ([]byte, error) {
+	customer, err := s.GetCustomerByUUID(ctx, customerUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &model.CustomerExport{
+		Customer:   customer,
+		ExportedAt: time.Now().Format(time.DateTime),
+	}
+
+	queries := []struct {
+		name string
+		dest interface{}
+	}{
+		{"addresses", &export.Addresses},
+		{"carts", &export.Carts},
+		{"orders", &export.Orders},
+		{"payments", &export.Payments},
+		{"subscriptions", &export.Subscriptions},
+		{"returns", &export.Returns},
+	}
+	for _, q := range queries {
+		err = ctx.DB.Where("user_id = ?", customerUuid).Find(q.dest).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to export customer "+q.name, err)
+			return nil, errors.New("failed to export customer data")
+		}
+	}
+
+	orderNos := make([]string, 0)
+	for _, order := range export.Orders {
+		orderNos = append(orderNos, order.OrderNo)
+	}
+	export.OrderItems = make([]*model.OrderItem, 0)
+	if len(orderNos) > 0 {
+		err = ctx.DB.Where("order_id IN (?)", orderNos).Find(&export.OrderItems).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to export customer order items", err)
+			return nil, errors.New("failed to export customer data")
+		}
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		ctx.Logger.Error("Failed to marshal customer export", err)
+		return nil, errors.New("failed to export customer data")
+	}
+	return data, nil
+}
+
+// DeleteAccount 注销账号
+// 删除地址、购物车和分组等个人数据，取消订阅，客户信息匿名化
+// 订单、付款和发票属于交易记录，按法律要求保留
+func (s *CustomerService) DeleteAccount(ctx *app.Context, customerUuid string, params *model.ReqCustomerDelete) error {
+	customer, err := s.GetCustomerByUUID(ctx, customerUuid)
+	if err != nil {
+		return err
+	}
+	if !utils.CheckPasswordHashWithSalt(params.Password, customer.Password, ctx.Config.PasswdKey) {
+		return errors.New("密码错误")
+	}
+
+	subscriptions := make([]*model.Subscription, 0)
+	err = ctx.DB.Where("user_id = ? AND status <> ?", customerUuid, model.SubscriptionStatusCanceled).Find(&subscriptions).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer subscriptions", err)
+		return errors.New("failed to get customer subscriptions")
+	}
+	for _, subscription := range subscriptions {
+		err = NewSubscriptionService().cancel(ctx, subscription)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().Format(time.DateTime)
+	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
+		for _, m := range []interface{}{&model.UserAddress{}, &model.Cart{}, &model.CustomerGroupUser{}} {
+			err := tx.Where("user_id = ?", customerUuid).Delete(m).Error
+			if err != nil {
+				ctx.Logger.Error("Failed to delete customer data", err)
+				tx.Rollback()
+				return errors.New("failed to delete customer data")
+			}
+		}
+
+		err := tx.Model(&model.Customer{}).Where("uuid = ?", customerUuid).Updates(map[string]interface{}{
+			"email":             fmt.Sprintf("deleted-%s@deleted.invalid", customerUuid),
+			"password":          "",
+			"nickname":          "",
+			"phone":             "",
+			"avatar":            "",
+			"last_login_ip":     "",
+			"email_verified_at": "",
+			"status":            model.CustomerStatusDeleted,
+			"deleted_at":        now,
+			"updated_at":        now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to anonymize customer", err)
+			tx.Rollback()
+			return errors.New("failed to delete customer")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return NewSessionService().RevokeAllSessions(ctx, customerUuid)
+}
+
+// GetContactEmail 获取用户的联系邮箱，先查商城客户再查后台用户，用于订单邮件
+func (s *CustomerService) GetContactEmail(ctx *app.Context, userId string) string {
+	customer, err := s.GetCustomerByUUID(ctx, userId)
+	if err == nil {
+		return customer.Email
+	}
+
+	user, err := NewUserService().GetUserByUUID(ctx, userId)
+	if err != nil {
+		return ""
+	}
+	return user.Email
+}
+
+func (s *CustomerService) getCustomerByEmail(ctx *app.Context, email string) (*model.Customer, error) {
+	customer := &model.Customer{}
+	err := ctx.DB.Where("email = ? AND status <> ?", strings.ToLower(strings.TrimSpace(email)), model.CustomerStatusDeleted).First(customer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		ctx.Logger.Error("Failed to get customer by email", err)
+		return nil, errors.New("failed to get customer by email")
+	}
+	return customer, nil
+}
+
+func (s *CustomerService) emailExists(ctx *app.Context, email string) (bool, error) {
+	var count int64
+	err := ctx.DB.Model(&model.Customer{}).Where("email = ?", email).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to check customer email", err)
+		return false, errors.New("failed to check customer email")
+	}
+	return count > 0, nil
+}
+
+func (s *CustomerService) updateCustomer(ctx *app.Context, customerUuid string, updates map[string]interface{}) error {
+	updates["updated_at"] = time.Now().Format(time.DateTime)
+	err := ctx.DB.Model(&model.Customer{}).Where("uuid = ?", customerUuid).Updates(updates).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to update customer", err)
+		return errors.New("failed to update customer")
+	}
+	return nil
+}
+
+// 校验邮箱验证码，校验通过后标记为已使用
+func (s *CustomerService) verifyCode(ctx *app.Context, email, purpose, code string) error {
+	return (&VerificationCodeService{}).CheckVerificationCode(ctx, purpose, code, email, "", true)
+}
+
+// 生成验证码并发送邮件
+func (s *CustomerService) sendCode(ctx *app.Context, email, purpose, subject string) error {
+	if ctx.Config.MailConfig.Host == "" {
+		return errors.New("mail is not configured")
+	}
+
+	codeService := &VerificationCodeService{}
+	code, err := codeService.CreateVerificationCode(ctx, purpose, email, "")
+	if err != nil {
+		return err
+	}
+
+	err = mail.Send(&mail.Options{
+		MailHost: ctx.Config.MailConfig.Host,
+		MailPort: ctx.Config.MailConfig.Port,
+		MailUser: ctx.Config.MailConfig.Username,
+		MailPass: ctx.Config.MailConfig.Password,
+		MailTo:   email,
+		Subject:  subject,
+		Body:     fmt.Sprintf(customerCodeMailContent, subject, code, codeService.getConfig(ctx).Expire),
+	})
+	if err != nil {
+		ctx.Logger.Error("Failed to send verification code mail", err)
+		return errors.New("failed to send verification code mail")
+	}
+	return nil
+}
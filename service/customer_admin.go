@@ -0,0 +1,396 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sgin/model"
+	"sgin/pkg/app"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// 导出客户时每批查询的数量
+const customerExportBatchSize = 500
+
+// 计入消费统计的订单状态
+var customerPaidOrderStatuses = []string{model.OrderStatusPaid, model.OrderStatusDelivered, model.OrderStatusCompleted}
+
+// 客户列表可排序的字段
+var customerSortColumns = map[string]string{
+	"order_count":   "order_count",
+	"total_spent":   "total_spent",
+	"last_order_at": "last_order_at",
+	"created_at":    "customers.created_at",
+}
+
+// GetCustomerList 后台获取客户列表，包含订单数、消费金额、平均订单金额、首次和最近下单时间、默认地址和标签
+// 指定客户分群时使用分群保存的筛选条件
+func (s *CustomerService) GetCustomerList(ctx *app.Context, params *model.ReqCustomerQueryParam) (*model.PagedResponse, error) {
+	filter, err := s.resolveFilter(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	db := s.customerQuery(ctx, filter)
+
+	var total int64
+	err = db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer count", err)
+		return nil, errors.New("failed to get customer count")
+	}
+
+	customers, err := s.findCustomerSummaries(ctx, db, params, params.GetOffset(), params.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     customers,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// GetCustomerDetail 后台获取客户详情，包含消费统计、收货地址和最近10个订单
+func (s *CustomerService) GetCustomerDetail(ctx *app.Context, customerUuid string) (*model.CustomerDetail, error) {
+	db := s.customerQuery(ctx, &model.CustomerFilter{}).Where("customers.uuid = ?", customerUuid)
+	customers, err := s.findCustomerSummaries(ctx, db, &model.ReqCustomerQueryParam{}, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(customers) == 0 {
+		return nil, errors.New("customer not found")
+	}
+
+	addresses := make([]*model.UserAddress, 0)
+	err = ctx.DB.Where("user_id = ?", customerUuid).Order("is_default DESC, id DESC").Find(&addresses).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer addresses", err)
+		return nil, errors.New("failed to get customer addresses")
+	}
+
+	orders, err := NewOrderService().GetOrderList(ctx, &model.ReqOrderQueryParam{
+		UserID:     customerUuid,
+		Pagination: model.Pagination{Current: 1, PageSize: 10},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CustomerDetail{
+		CustomerSummary: *customers[0],
+		Addresses:       addresses,
+		Orders:          orders.Data.([]*model.OrderRes),
+	}, nil
+}
+
+// ExportCustomers 按筛选条件导出客户为CSV，忽略分页参数
+func (s *CustomerService) ExportCustomers(ctx *app.Context, params *model.ReqCustomerQueryParam) ([]byte, error) {
+	filter, err := s.resolveFilter(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	// 带BOM，Excel打开时能正确识别UTF-8
+	buf.WriteString("\xEF\xBB\xBF")
+	w := csv.NewWriter(buf)
+	w.Write([]string{"uuid", "email", "nickname", "phone", "status", "order_count", "total_spent", "average_order_value",
+		"first_order_at", "last_order_at", "country", "province", "city", "address", "zip", "tags", "created_at"})
+
+	for offset := 0; ; offset += customerExportBatchSize {
+		customers, err := s.findCustomerSummaries(ctx, s.customerQuery(ctx, filter), params, offset, customerExportBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range customers {
+			address := c.DefaultAddress
+			if address == nil {
+				address = &model.UserAddress{}
+			}
+			w.Write([]string{
+				c.Uuid, c.Email, c.Nickname, c.Phone, fmt.Sprint(c.Status),
+				fmt.Sprint(c.OrderCount), fmt.Sprintf("%.2f", c.TotalSpent), fmt.Sprintf("%.2f", c.AverageOrderValue),
+				c.FirstOrderAt, c.LastOrderAt,
+				address.ReceiverCountry, address.ReceiverProvince, address.ReceiverCity, address.ReceiverAddress, address.ReceiverZip,
+				strings.Join(c.Tags, ","), c.CreatedAt,
+			})
+		}
+
+		if len(customers) < customerExportBatchSize {
+			break
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		ctx.Logger.Error("Failed to write customer csv", err)
+		return nil, errors.New("failed to export customers")
+	}
+	return buf.Bytes(), nil
+}
+
+// AddCustomerTags 给客户添加标签，已有的标签忽略
+func (s *CustomerService) AddCustomerTags(ctx *app.Context, params *model.ReqCustomerTagParam) error {
+	tags := s.normalizeTags(params.Tags)
+	if len(tags) == 0 {
+		return errors.New("标签不能为空")
+	}
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > 50 {
+			return errors.New("标签不能超过50个字符")
+		}
+	}
+
+	customerUuids := make([]string, 0)
+	err := ctx.DB.Model(&model.Customer{}).Where("uuid IN (?)", params.CustomerUuids).Pluck("uuid", &customerUuids).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customers", err)
+		return errors.New("failed to get customers")
+	}
+	if len(customerUuids) == 0 {
+		return errors.New("customer not found")
+	}
+
+	existing := make([]*model.CustomerTag, 0)
+	err = ctx.DB.Where("customer_uuid IN (?) AND tag IN (?)", customerUuids, tags).Find(&existing).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer tags", err)
+		return errors.New("failed to get customer tags")
+	}
+	existMap := make(map[string]bool)
+	for _, t := range existing {
+		existMap[t.CustomerUuid+":"+t.Tag] = true
+	}
+
+	now := time.Now().Format(time.DateTime)
+	customerTags := make([]*model.CustomerTag, 0)
+	for _, customerUuid := range customerUuids {
+		for _, tag := range tags {
+			if existMap[customerUuid+":"+tag] {
+				continue
+			}
+			customerTags = append(customerTags, &model.CustomerTag{
+				CustomerUuid: customerUuid,
+				Tag:          tag,
+				CreatedAt:    now,
+			})
+		}
+	}
+	if len(customerTags) == 0 {
+		return nil
+	}
+
+	err = ctx.DB.Create(&customerTags).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create customer tags", err)
+		return errors.New("failed to create customer tags")
+	}
+	return nil
+}
+
+// RemoveCustomerTags 删除客户的标签
+func (s *CustomerService) RemoveCustomerTags(ctx *app.Context, params *model.ReqCustomerTagParam) error {
+	err := ctx.DB.Where("customer_uuid IN (?) AND tag IN (?)", params.CustomerUuids, s.normalizeTags(params.Tags)).
+		Delete(&model.CustomerTag{}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to delete customer tags", err)
+		return errors.New("failed to delete customer tags")
+	}
+	return nil
+}
+
+// GetCustomerTagList 获取所有标签及使用的客户数
+func (s *CustomerService) GetCustomerTagList(ctx *app.Context) ([]*model.CustomerTagCount, error) {
+	tags := make([]*model.CustomerTagCount, 0)
+	err := ctx.DB.Model(&model.CustomerTag{}).Select("tag, COUNT(*) AS count").
+		Group("tag").Order("count DESC, tag").Scan(&tags).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer tag list", err)
+		return nil, errors.New("failed to get customer tag list")
+	}
+	return tags, nil
+}
+
+// 指定客户分群时使用分群的筛选条件
+func (s *CustomerService) resolveFilter(ctx *app.Context, params *model.ReqCustomerQueryParam) (*model.CustomerFilter, error) {
+	if params.SegmentUuid == "" {
+		return &params.CustomerFilter, nil
+	}
+
+	segment, err := NewCustomerSegmentService().GetCustomerSegment(ctx, params.SegmentUuid)
+	if err != nil {
+		return nil, err
+	}
+	filter := &model.CustomerFilter{}
+	err = json.Unmarshal([]byte(segment.Filter), filter)
+	if err != nil {
+		ctx.Logger.Error("Failed to parse customer segment filter", err)
+		return nil, errors.New("failed to parse customer segment filter")
+	}
+	return filter, nil
+}
+
+// 客户查询，关联订单和付款的统计结果，已支付订单计入订单数，已支付金额减去退款金额为消费金额
+func (s *CustomerService) customerQuery(ctx *app.Context, filter *model.CustomerFilter) *gorm.DB {
+	since := ""
+	if filter.Days > 0 {
+		since = time.Now().AddDate(0, 0, -filter.Days).Format(time.DateTime)
+	}
+
+	orders := ctx.DB.Model(&model.Order{}).
+		Select("user_id, COUNT(*) AS order_count, MIN(created_at) AS first_order_at, MAX(created_at) AS last_order_at").
+		Where("status IN (?)", customerPaidOrderStatuses).Group("user_id")
+	payments := ctx.DB.Model(&model.Payment{}).Select("user_id, SUM(amount) AS amount").
+		Where("status = ?", model.PaymentStatusPaid).Group("user_id")
+	refunds := ctx.DB.Model(&model.Refund{}).Select("user_id, SUM(amount) AS amount").
+		Where("status IN (?)", []string{model.RefundStatusSucceeded, model.RefundStatusManual}).Group("user_id")
+	if since != "" {
+		orders = orders.Where("created_at >= ?", since)
+		payments = payments.Where("paid_at >= ?", since)
+		refunds = refunds.Where("created_at >= ?", since)
+	}
+
+	db := ctx.DB.Model(&model.Customer{}).
+		Joins("LEFT JOIN (?) AS order_stats ON order_stats.user_id = customers.uuid", orders).
+		Joins("LEFT JOIN (?) AS payment_stats ON payment_stats.user_id = customers.uuid", payments).
+		Joins("LEFT JOIN (?) AS refund_stats ON refund_stats.user_id = customers.uuid", refunds)
+
+	if filter.Keyword != "" {
+		keyword := "%" + filter.Keyword + "%"
+		db = db.Where("customers.email LIKE ? OR customers.nickname LIKE ? OR customers.phone LIKE ?", keyword, keyword, keyword)
+	}
+	if filter.Status != nil {
+		db = db.Where("customers.status = ?", *filter.Status)
+	} else {
+		db = db.Where("customers.status <> ?", model.CustomerStatusDeleted)
+	}
+	if tags := s.normalizeTags(filter.Tags); len(tags) > 0 {
+		db = db.Where("customers.uuid IN (?)", ctx.DB.Model(&model.CustomerTag{}).Select("customer_uuid").Where("tag IN (?)", tags))
+	}
+	if filter.GroupUuid != "" {
+		db = db.Where("customers.uuid IN (?)", ctx.DB.Model(&model.CustomerGroupUser{}).Select("user_id").Where("group_uuid = ?", filter.GroupUuid))
+	}
+	if filter.Country != "" {
+		db = db.Where("customers.uuid IN (?)", ctx.DB.Model(&model.UserAddress{}).Select("user_id").
+			Where("is_default = ? AND receiver_country = ?", true, filter.Country))
+	}
+	if filter.MinOrders != nil {
+		db = db.Where("COALESCE(order_stats.order_count, 0) >= ?", *filter.MinOrders)
+	}
+	if filter.MaxOrders != nil {
+		db = db.Where("COALESCE(order_stats.order_count, 0) <= ?", *filter.MaxOrders)
+	}
+	if filter.MinSpent != nil {
+		db = db.Where(customerSpentColumn+" >= ?", *filter.MinSpent)
+	}
+	if filter.MaxSpent != nil {
+		db = db.Where(customerSpentColumn+" <= ?", *filter.MaxSpent)
+	}
+	if filter.LastOrderAfter != "" {
+		db = db.Where("order_stats.last_order_at >= ?", filter.LastOrderAfter)
+	}
+	if filter.LastOrderBefore != "" {
+		db = db.Where("order_stats.last_order_at <= ?", filter.LastOrderBefore)
+	}
+	if filter.CreatedAfter != "" {
+		db = db.Where("customers.created_at >= ?", filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != "" {
+		db = db.Where("customers.created_at <= ?", filter.CreatedBefore)
+	}
+
+	return db
+}
+
+const customerSpentColumn = "(COALESCE(payment_stats.amount, 0) - COALESCE(refund_stats.amount, 0))"
+
+// 查询一页客户统计，并加载默认地址和标签
+func (s *CustomerService) findCustomerSummaries(ctx *app.Context, db *gorm.DB, params *model.ReqCustomerQueryParam, offset, limit int) ([]*model.CustomerSummary, error) {
+	order := "customers.id DESC"
+	if column, ok := customerSortColumns[params.SortBy]; ok {
+		direction := "DESC"
+		if strings.ToLower(params.SortOrder) == "asc" {
+			direction = "ASC"
+		}
+		order = column + " " + direction + ", customers.id DESC"
+	}
+
+	customers := make([]*model.CustomerSummary, 0)
+	err := db.Select("customers.*, COALESCE(order_stats.order_count, 0) AS order_count, " +
+		customerSpentColumn + " AS total_spent, " +
+		"COALESCE(order_stats.first_order_at, '') AS first_order_at, COALESCE(order_stats.last_order_at, '') AS last_order_at").
+		Order(order).Offset(offset).Limit(limit).Scan(&customers).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer list", err)
+		return nil, errors.New("failed to get customer list")
+	}
+	if len(customers) == 0 {
+		return customers, nil
+	}
+
+	customerUuids := make([]string, 0)
+	for _, c := range customers {
+		customerUuids = append(customerUuids, c.Uuid)
+	}
+
+	addresses := make([]*model.UserAddress, 0)
+	err = ctx.DB.Where("user_id IN (?) AND is_default = ?", customerUuids, true).Find(&addresses).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer addresses", err)
+		return nil, errors.New("failed to get customer addresses")
+	}
+	addressMap := make(map[string]*model.UserAddress)
+	for _, address := range addresses {
+		addressMap[address.UserID] = address
+	}
+
+	tags := make([]*model.CustomerTag, 0)
+	err = ctx.DB.Where("customer_uuid IN (?)", customerUuids).Order("tag").Find(&tags).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer tags", err)
+		return nil, errors.New("failed to get customer tags")
+	}
+	tagMap := make(map[string][]string)
+	for _, t := range tags {
+		tagMap[t.CustomerUuid] = append(tagMap[t.CustomerUuid], t.Tag)
+	}
+
+	for _, c := range customers {
+		c.TotalSpent = math.Round(c.TotalSpent*100) / 100
+		if c.OrderCount > 0 {
+			c.AverageOrderValue = math.Round(c.TotalSpent/float64(c.OrderCount)*100) / 100
+		}
+		c.DefaultAddress = addressMap[c.Uuid]
+		c.Tags = tagMap[c.Uuid]
+		if c.Tags == nil {
+			c.Tags = []string{}
+		}
+	}
+	return customers, nil
+}
+
+// 去掉空标签和重复标签
+func (s *CustomerService) normalizeTags(tags []string) []string {
+	res := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		res = append(res, tag)
+	}
+	return res
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CustomerGroupService struct {
+}
+
+func NewCustomerGroupService() *CustomerGroupService {
+	return &CustomerGroupService{}
+}
+
+// CreateCustomerGroup 创建客户分组
+func (s *CustomerGroupService) CreateCustomerGroup(ctx *app.Context, params *model.ReqCustomerGroupCreate) (*model.CustomerGroup, error) {
+	now := time.Now().Format(time.DateTime)
+	group := &model.CustomerGroup{
+		Uuid:        uuid.New().String(),
+		Name:        params.Name,
+		Description: params.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err := ctx.DB.Create(group).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create customer group", err)
+		return nil, errors.New("failed to create customer group")
+	}
+
+	return group, nil
+}
+
+// UpdateCustomerGroup 更新客户分组
+func (s *CustomerGroupService) UpdateCustomerGroup(ctx *app.Context, params *model.ReqCustomerGroupUpdate) error {
+	updates := map[string]interface{}{
+		"description": params.Description,
+		"updated_at":  time.Now().Format(time.DateTime),
+	}
+	if params.Name != "" {
+		updates["name"] = params.Name
+	}
+
+	result := ctx.DB.Model(&model.CustomerGroup{}).Where("uuid = ?", params.Uuid).Updates(updates)
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to update customer group", result.Error)
+		return errors.New("failed to update customer group")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("customer group not found")
+	}
+
+	return nil
+}
+
+// DeleteCustomerGroup 删除客户分组，分组的价格表改为禁用
+func (s *CustomerGroupService) DeleteCustomerGroup(ctx *app.Context, groupUuid string) error {
+	return ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("group_uuid = ?", groupUuid).Delete(&model.CustomerGroupUser{}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to delete customer group users", err)
+			tx.Rollback()
+			return errors.New("failed to delete customer group users")
+		}
+
+		err = tx.Model(&model.PriceList{}).Where("customer_group_uuid = ?", groupUuid).Updates(map[string]interface{}{
+			"status":     model.PriceListStatusDisabled,
+			"updated_at": time.Now().Format(time.DateTime),
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to disable price lists", err)
+			tx.Rollback()
+			return errors.New("failed to disable price lists")
+		}
+
+		err = tx.Where("uuid = ?", groupUuid).Delete(&model.CustomerGroup{}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to delete customer group", err)
+			tx.Rollback()
+			return errors.New("failed to delete customer group")
+		}
+
+		return nil
+	})
+}
+
+// GetCustomerGroupList 获取客户分组列表
+func (s *CustomerGroupService) GetCustomerGroupList(ctx *app.Context, params *model.ReqCustomerGroupQueryParam) (*model.PagedResponse, error) {
+	var (
+		groups []*model.CustomerGroup
+		total  int64
+	)
+
+	db := ctx.DB.Model(&model.CustomerGroup{})
+	if params.Name != "" {
+		db = db.Where("name LIKE ?", "%"+params.Name+"%")
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer group count", err)
+		return nil, errors.New("failed to get customer group count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&groups).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer group list", err)
+		return nil, errors.New("failed to get customer group list")
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     groups,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// AddGroupUsers 添加客户到分组，已在分组中的忽略
+func (s *CustomerGroupService) AddGroupUsers(ctx *app.Context, params *model.ReqCustomerGroupUserParam) error {
+	var count int64
+	err := ctx.DB.Model(&model.CustomerGroup{}).Where("uuid = ?", params.GroupUuid).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer group", err)
+		return errors.New("failed to get customer group")
+	}
+	if count == 0 {
+		return errors.New("customer group not found")
+	}
+
+	existing := make([]string, 0)
+	err = ctx.DB.Model(&model.CustomerGroupUser{}).Where("group_uuid = ? AND user_id IN (?)", params.GroupUuid, params.UserIds).Pluck("user_id", &existing).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer group users", err)
+		return errors.New("failed to get customer group users")
+	}
+	existMap := make(map[string]bool)
+	for _, userId := range existing {
+		existMap[userId] = true
+	}
+
+	now := time.Now().Format(time.DateTime)
+	users := make([]*model.CustomerGroupUser, 0)
+	for _, userId := range params.UserIds {
+		if existMap[userId] {
+			continue
+		}
+		existMap[userId] = true
+		users = append(users, &model.CustomerGroupUser{
+			GroupUuid: params.GroupUuid,
+			UserID:    userId,
+			CreatedAt: now,
+		})
+	}
+	if len(users) == 0 {
+		return nil
+	}
+
+	err = ctx.DB.Create(&users).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create customer group users", err)
+		return errors.New("failed to create customer group users")
+	}
+	return nil
+}
+
+// RemoveGroupUsers 从分组中移除客户
+func (s *CustomerGroupService) RemoveGroupUsers(ctx *app.Context, params *model.ReqCustomerGroupUserParam) error {
+	err := ctx.DB.Where("group_uuid = ? AND user_id IN (?)", params.GroupUuid, params.UserIds).Delete(&model.CustomerGroupUser{}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to delete customer group users", err)
+		return errors.New("failed to delete customer group users")
+	}
+	return nil
+}
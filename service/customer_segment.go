@@ -0,0 +1,130 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"sgin/model"
+	"sgin/pkg/app"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CustomerSegmentService struct {
+}
+
+func NewCustomerSegmentService() *CustomerSegmentService {
+	return &CustomerSegmentService{}
+}
+
+// CreateCustomerSegment 创建客户分群
+func (s *CustomerSegmentService) CreateCustomerSegment(ctx *app.Context, params *model.ReqCustomerSegmentCreate) (*model.CustomerSegment, error) {
+	filter, err := json.Marshal(params.Filter)
+	if err != nil {
+		return nil, errors.New("invalid customer segment filter")
+	}
+
+	now := time.Now().Format(time.DateTime)
+	segment := &model.CustomerSegment{
+		Uuid:        uuid.New().String(),
+		Name:        params.Name,
+		Description: params.Description,
+		Filter:      string(filter),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err = ctx.DB.Create(segment).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create customer segment", err)
+		return nil, errors.New("failed to create customer segment")
+	}
+
+	return segment, nil
+}
+
+// UpdateCustomerSegment 更新客户分群
+func (s *CustomerSegmentService) UpdateCustomerSegment(ctx *app.Context, params *model.ReqCustomerSegmentUpdate) error {
+	updates := map[string]interface{}{
+		"description": params.Description,
+		"updated_at":  time.Now().Format(time.DateTime),
+	}
+	if params.Name != "" {
+		updates["name"] = params.Name
+	}
+	if params.Filter != nil {
+		filter, err := json.Marshal(params.Filter)
+		if err != nil {
+			return errors.New("invalid customer segment filter")
+		}
+		updates["filter"] = string(filter)
+	}
+
+	result := ctx.DB.Model(&model.CustomerSegment{}).Where("uuid = ?", params.Uuid).Updates(updates)
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to update customer segment", result.Error)
+		return errors.New("failed to update customer segment")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("customer segment not found")
+	}
+
+	return nil
+}
+
+// DeleteCustomerSegment 删除客户分群
+func (s *CustomerSegmentService) DeleteCustomerSegment(ctx *app.Context, segmentUuid string) error {
+	err := ctx.DB.Where("uuid = ?", segmentUuid).Delete(&model.CustomerSegment{}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to delete customer segment", err)
+		return errors.New("failed to delete customer segment")
+	}
+	return nil
+}
+
+// GetCustomerSegment 获取客户分群
+func (s *CustomerSegmentService) GetCustomerSegment(ctx *app.Context, segmentUuid string) (*model.CustomerSegment, error) {
+	segment := &model.CustomerSegment{}
+	err := ctx.DB.Where("uuid = ?", segmentUuid).First(segment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("customer segment not found")
+		}
+		ctx.Logger.Error("Failed to get customer segment", err)
+		return nil, errors.New("failed to get customer segment")
+	}
+	return segment, nil
+}
+
+// GetCustomerSegmentList 获取客户分群列表
+func (s *CustomerSegmentService) GetCustomerSegmentList(ctx *app.Context, params *model.ReqCustomerSegmentQueryParam) (*model.PagedResponse, error) {
+	var (
+		segments []*model.CustomerSegment
+		total    int64
+	)
+
+	db := ctx.DB.Model(&model.CustomerSegment{})
+	if params.Name != "" {
+		db = db.Where("name LIKE ?", "%"+params.Name+"%")
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer segment count", err)
+		return nil, errors.New("failed to get customer segment count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&segments).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get customer segment list", err)
+		return nil, errors.New("failed to get customer segment list")
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     segments,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
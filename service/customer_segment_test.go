@@ -0,0 +1,132 @@
+package service
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/testutil"
+)
+
+// 客户：c1最近下了两单，消费300退款50，c2一年前下了一单，c3已禁用没有订单，c4已注销
+func setupCustomerSegments(t *testing.T, ctx *app.Context) {
+	t.Helper()
+	recent := time.Now().AddDate(0, 0, -1).Format(time.DateTime)
+	old := time.Now().AddDate(-1, 0, 0).Format(time.DateTime)
+	rows := []interface{}{
+		&model.Customer{Uuid: "c1", Email: "alice@example.com", Nickname: "alice", Status: model.CustomerStatusEnabled,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.Customer{Uuid: "c2", Email: "bob@example.com", Nickname: "bob", Status: model.CustomerStatusEnabled,
+			CreatedAt: "2025-01-01 00:00:00", UpdatedAt: "2025-01-01 00:00:00"},
+		&model.Customer{Uuid: "c3", Email: "carol@example.com", Status: model.CustomerStatusDisabled,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.Customer{Uuid: "c4", Email: "dave@example.com", Status: model.CustomerStatusDeleted,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+
+		&model.Order{OrderNo: "SG1", UserID: "c1", Status: model.OrderStatusPaid, CreatedAt: recent, UpdatedAt: recent},
+		&model.Order{OrderNo: "SG2", UserID: "c1", Status: model.OrderStatusCompleted, CreatedAt: recent, UpdatedAt: recent},
+		&model.Order{OrderNo: "SG3", UserID: "c1", Status: model.OrderStatusPending, CreatedAt: recent, UpdatedAt: recent},
+		&model.Order{OrderNo: "SG4", UserID: "c2", Status: model.OrderStatusDelivered, CreatedAt: old, UpdatedAt: old},
+		&model.Payment{Uuid: "p1", UserID: "c1", OrderID: "SG1", Amount: 100, Status: model.PaymentStatusPaid, PaidAt: recent, CreatedAt: recent, UpdatedAt: recent},
+		&model.Payment{Uuid: "p2", UserID: "c1", OrderID: "SG2", Amount: 200, Status: model.PaymentStatusPaid, PaidAt: recent, CreatedAt: recent, UpdatedAt: recent},
+		&model.Payment{Uuid: "p4", UserID: "c2", OrderID: "SG4", Amount: 150, Status: model.PaymentStatusPaid, PaidAt: old, CreatedAt: old, UpdatedAt: old},
+		&model.Refund{Uuid: "r1", UserID: "c1", OrderID: "SG2", Amount: 50, Status: model.RefundStatusSucceeded, CreatedAt: recent, UpdatedAt: recent},
+
+		&model.CustomerTag{CustomerUuid: "c1", Tag: "vip", CreatedAt: "2026-01-01 00:00:00"},
+		&model.CustomerTag{CustomerUuid: "c2", Tag: "wholesale", CreatedAt: "2026-01-01 00:00:00"},
+		&model.CustomerGroupUser{GroupUuid: "group1", UserID: "c2", CreatedAt: "2026-01-01 00:00:00"},
+		&model.UserAddress{Uuid: "a1", UserID: "c1", ReceiverCountry: "CN", IsDefault: true, CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.UserAddress{Uuid: "a2", UserID: "c2", ReceiverCountry: "US", IsDefault: true, CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.UserAddress{Uuid: "a3", UserID: "c3", ReceiverCountry: "US", CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+	}
+	for _, row := range rows {
+		err := ctx.DB.Create(row).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func customerUuids(t *testing.T, res *model.PagedResponse) []string {
+	t.Helper()
+	uuids := make([]string, 0)
+	for _, c := range res.Data.([]*model.CustomerSummary) {
+		uuids = append(uuids, c.Uuid)
+	}
+	sort.Strings(uuids)
+	return uuids
+}
+
+func TestCustomerFilter(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	floatPtr := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name   string
+		filter model.CustomerFilter
+		want   []string
+	}{
+		{"no filter excludes deleted", model.CustomerFilter{}, []string{"c1", "c2", "c3"}},
+		{"status", model.CustomerFilter{Status: intPtr(model.CustomerStatusDeleted)}, []string{"c4"}},
+		{"keyword", model.CustomerFilter{Keyword: "bob"}, []string{"c2"}},
+		{"tags", model.CustomerFilter{Tags: []string{"vip", " wholesale "}}, []string{"c1", "c2"}},
+		{"group", model.CustomerFilter{GroupUuid: "group1"}, []string{"c2"}},
+		{"default address country", model.CustomerFilter{Country: "US"}, []string{"c2"}},
+		{"min orders counts paid orders", model.CustomerFilter{MinOrders: intPtr(2)}, []string{"c1"}},
+		{"max orders", model.CustomerFilter{MaxOrders: intPtr(0)}, []string{"c3"}},
+		{"min spent after refunds", model.CustomerFilter{MinSpent: floatPtr(250)}, []string{"c1"}},
+		{"max spent", model.CustomerFilter{MaxSpent: floatPtr(200)}, []string{"c2", "c3"}},
+		{"recent days", model.CustomerFilter{Days: 30, MinOrders: intPtr(1)}, []string{"c1"}},
+		{"last order before", model.CustomerFilter{LastOrderBefore: time.Now().AddDate(0, -1, 0).Format(time.DateTime)}, []string{"c2"}},
+		{"created after", model.CustomerFilter{CreatedAfter: "2025-06-01 00:00:00"}, []string{"c1", "c3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			setupCustomerSegments(t, ctx)
+
+			res, err := NewCustomerService().GetCustomerList(ctx, &model.ReqCustomerQueryParam{CustomerFilter: tt.filter,
+				Pagination: model.Pagination{Current: 1, PageSize: 10}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := customerUuids(t, res); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("customers = %v, want %v", got, tt.want)
+			}
+			if res.Total != int64(len(tt.want)) {
+				t.Errorf("total = %d, want %d", res.Total, len(tt.want))
+			}
+		})
+	}
+}
+
+// 按分群查询时使用分群保存的筛选条件，忽略请求中的条件
+func TestCustomerSegmentFilter(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	setupCustomerSegments(t, ctx)
+
+	minSpent := 100.0
+	segment, err := NewCustomerSegmentService().CreateCustomerSegment(ctx, &model.ReqCustomerSegmentCreate{Name: "big spenders",
+		Filter: model.CustomerFilter{MinSpent: &minSpent}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := NewCustomerService().GetCustomerList(ctx, &model.ReqCustomerQueryParam{SegmentUuid: segment.Uuid,
+		CustomerFilter: model.CustomerFilter{Keyword: "carol"}, Pagination: model.Pagination{Current: 1, PageSize: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := customerUuids(t, res); !reflect.DeepEqual(got, []string{"c1", "c2"}) {
+		t.Errorf("customers = %v, want [c1 c2]", got)
+	}
+
+	summary := res.Data.([]*model.CustomerSummary)
+	for _, c := range summary {
+		if c.Uuid == "c1" && (c.OrderCount != 2 || c.TotalSpent != 250 || c.AverageOrderValue != 125) {
+			t.Errorf("c1 summary = %d orders, spent %v, average %v", c.OrderCount, c.TotalSpent, c.AverageOrderValue)
+		}
+	}
+}
@@ -0,0 +1,483 @@
+package service
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/mail"
+	"sgin/pkg/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var digitalMailContent = `
+<html>
+<body>
+    <h2>您购买的虚拟商品</h2>
+    <p>订单号：%s</p>
+    <ul>%s</ul>
+    <p>下载链接有时效，过期后请登录后在订单中重新获取。</p>
+</body>
+</html>
+`
+
+type DigitalService struct {
+}
+
+func NewDigitalService() *DigitalService {
+	return &DigitalService{}
+}
+
+// CreateDigitalFile 上传虚拟产品文件，文件保存在私有目录
+func (s *DigitalService) CreateDigitalFile(ctx *app.Context, param *model.ReqDigitalFileCreate, file *multipart.FileHeader) (*model.ProductDigitalFile, error) {
+	if ctx.Config.Digital.Dir == "" {
+		return nil, errors.New("digital file dir is not configured")
+	}
+
+	product := &model.Product{}
+	err := ctx.DB.Where("uuid = ?", param.ProductUuid).First(product).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("product not found")
+		}
+		ctx.Logger.Error("Failed to get product by UUID", err)
+		return nil, errors.New("failed to get product by UUID")
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		ctx.Logger.Error("Failed to open upload file", err)
+		return nil, errors.New("failed to open upload file")
+	}
+	md5, err := utils.GetFileMd5(f)
+	f.Close()
+	if err != nil {
+		ctx.Logger.Error("Failed to get file md5", err)
+		return nil, errors.New("failed to get file md5")
+	}
+
+	filename := uuid.New().String() + filepath.Ext(file.Filename)
+	err = ctx.SaveUploadedFile(file, filepath.Join(ctx.Config.Digital.Dir, filename))
+	if err != nil {
+		ctx.Logger.Error("Failed to save digital file", err)
+		return nil, errors.New("failed to save digital file")
+	}
+
+	resource := &model.Resource{
+		Name:      file.Filename,
+		Type:      model.ResourceTypeFile,
+		MimeType:  file.Header.Get("Content-Type"),
+		Size:      file.Size,
+		Md5:       md5,
+		Path:      filename,
+		IsPrivate: true,
+	}
+	err = NewResourceService().CreateResource(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	digitalFile := &model.ProductDigitalFile{
+		Uuid:            uuid.New().String(),
+		ProductUuid:     param.ProductUuid,
+		ProductItemUuid: param.ProductItemUuid,
+		ResourceUuid:    resource.Uuid,
+		Name:            file.Filename,
+		MaxDownloads:    param.MaxDownloads,
+		ExpireDays:      param.ExpireDays,
+		CreatedAt:       time.Now().Format(time.DateTime),
+		UpdatedAt:       time.Now().Format(time.DateTime),
+	}
+	if digitalFile.MaxDownloads <= 0 {
+		digitalFile.MaxDownloads = ctx.Config.Digital.MaxDownloads
+	}
+	if digitalFile.ExpireDays <= 0 {
+		digitalFile.ExpireDays = ctx.Config.Digital.ExpireDays
+	}
+
+	err = ctx.DB.Create(digitalFile).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create digital file", err)
+		return nil, errors.New("failed to create digital file")
+	}
+
+	return digitalFile, nil
+}
+
+// GetDigitalFileList 获取产品的虚拟文件列表
+func (s *DigitalService) GetDigitalFileList(ctx *app.Context, productUuid string) ([]*model.ProductDigitalFile, error) {
+	files := make([]*model.ProductDigitalFile, 0)
+	err := ctx.DB.Where("product_uuid = ?", productUuid).Find(&files).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get digital file list", err)
+		return nil, errors.New("failed to get digital file list")
+	}
+	return files, nil
+}
+
+// DeleteDigitalFile 删除虚拟文件，已发放的下载授权不受影响
+func (s *DigitalService) DeleteDigitalFile(ctx *app.Context, uuid string) error {
+	err := ctx.DB.Where("uuid = ?", uuid).Delete(&model.ProductDigitalFile{}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to delete digital file", err)
+		return errors.New("failed to delete digital file")
+	}
+	return nil
+}
+
+// ImportLicenseKeys 导入许可证到SKU的许可证池
+func (s *DigitalService) ImportLicenseKeys(ctx *app.Context, param *model.ReqLicenseKeyImport) (int, error) {
+	now := time.Now().Format(time.DateTime)
+	keys := make([]*model.LicenseKey, 0)
+	for _, key := range param.Keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		keys = append(keys, &model.LicenseKey{
+			Uuid:            uuid.New().String(),
+			ProductItemUuid: param.ProductItemUuid,
+			Key:             key,
+			Status:          model.LicenseKeyStatusAvailable,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		})
+	}
+	if len(keys) == 0 {
+		return 0, errors.New("no license key to import")
+	}
+
+	err := ctx.DB.Create(&keys).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to import license keys", err)
+		return 0, errors.New("failed to import license keys")
+	}
+	return len(keys), nil
+}
+
+// GetLicenseKeyList 获取许可证列表
+func (s *DigitalService) GetLicenseKeyList(ctx *app.Context, params *model.ReqLicenseKeyQueryParam) (*model.PagedResponse, error) {
+	var (
+		keys  []*model.LicenseKey
+		total int64
+	)
+
+	db := ctx.DB.Model(&model.LicenseKey{})
+	if params.ProductItemUuid != "" {
+		db = db.Where("product_item_uuid = ?", params.ProductItemUuid)
+	}
+	if params.Status != "" {
+		db = db.Where("status = ?", params.Status)
+	}
+	if params.OrderNo != "" {
+		db = db.Where("order_no = ?", params.OrderNo)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get license key count", err)
+		return nil, errors.New("failed to get license key count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&keys).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get license key list", err)
+		return nil, errors.New("failed to get license key list")
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     keys,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// FulfillOrder 在事务中为已支付订单发放下载授权和许可证
+func (s *DigitalService) FulfillOrder(ctx *app.Context, tx *gorm.DB, order *model.Order) error {
+	orderItems := make([]*model.OrderItem, 0)
+	err := tx.Where("order_id = ?", order.OrderNo).Find(&orderItems).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get order items by order no", err)
+		return errors.New("failed to get order items by order no")
+	}
+
+	itemUuids := make([]string, 0)
+	for _, item := range orderItems {
+		itemUuids = append(itemUuids, item.ProductItemID)
+	}
+
+	productItems := make([]*model.ProductItem, 0)
+	err = tx.Where("uuid IN (?)", itemUuids).Find(&productItems).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get product item list by UUID list", err)
+		return errors.New("failed to get product item list by UUID list")
+	}
+	productUuidMap := make(map[string]string)
+	productUuids := make([]string, 0)
+	for _, productItem := range productItems {
+		productUuidMap[productItem.Uuid] = productItem.ProductUuid
+		productUuids = append(productUuids, productItem.ProductUuid)
+	}
+
+	files := make([]*model.ProductDigitalFile, 0)
+	err = tx.Where("product_uuid IN (?)", productUuids).Find(&files).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get digital file list", err)
+		return errors.New("failed to get digital file list")
+	}
+
+	now := time.Now()
+	downloads := make([]*model.DigitalDownload, 0)
+	for _, item := range orderItems {
+		productUuid := productUuidMap[item.ProductItemID]
+		for _, file := range files {
+			if file.ProductUuid != productUuid {
+				continue
+			}
+			if file.ProductItemUuid != "" && file.ProductItemUuid != item.ProductItemID {
+				continue
+			}
+			downloads = append(downloads, &model.DigitalDownload{
+				Uuid:            uuid.New().String(),
+				OrderNo:         order.OrderNo,
+				OrderItemID:     item.ID,
+				UserID:          order.UserID,
+				ProductItemUuid: item.ProductItemID,
+				ResourceUuid:    file.ResourceUuid,
+				Name:            file.Name,
+				MaxDownloads:    file.MaxDownloads * item.Quantity,
+				ExpiredAt:       now.AddDate(0, 0, file.ExpireDays).Format(time.DateTime),
+				CreatedAt:       now.Format(time.DateTime),
+				UpdatedAt:       now.Format(time.DateTime),
+			})
+		}
+
+		err = s.assignLicenseKeys(ctx, tx, order, item)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(downloads) > 0 {
+		err = tx.Create(&downloads).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create digital downloads", err)
+			return errors.New("failed to create digital downloads")
+		}
+	}
+
+	return nil
+}
+
+// 按订单商品数量从许可证池分配许可证
+func (s *DigitalService) assignLicenseKeys(ctx *app.Context, tx *gorm.DB, order *model.Order, item *model.OrderItem) error {
+	keys := make([]*model.LicenseKey, 0)
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_item_uuid = ? AND status = ?", item.ProductItemID, model.LicenseKeyStatusAvailable).
+		Order("id ASC").Limit(item.Quantity).Find(&keys).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get available license keys", err)
+		return errors.New("failed to get available license keys")
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	if len(keys) < item.Quantity {
+		// 已经支付的订单不能回滚，先分配已有的许可证，缺少的由管理员补发
+		ctx.Logger.Error("License key pool exhausted", item.ProductItemID, order.OrderNo)
+	}
+
+	keyUuids := make([]string, 0)
+	for _, key := range keys {
+		keyUuids = append(keyUuids, key.Uuid)
+	}
+
+	now := time.Now().Format(time.DateTime)
+	err = tx.Model(&model.LicenseKey{}).Where("uuid IN (?)", keyUuids).Updates(map[string]interface{}{
+		"status":        model.LicenseKeyStatusAssigned,
+		"order_no":      order.OrderNo,
+		"order_item_id": item.ID,
+		"user_id":       order.UserID,
+		"assigned_at":   now,
+		"updated_at":    now,
+	}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to assign license keys", err)
+		return errors.New("failed to assign license keys")
+	}
+	return nil
+}
+
+// GetDigitalOrder 获取订单的下载链接和许可证，userId为空时不校验用户
+func (s *DigitalService) GetDigitalOrder(ctx *app.Context, orderNo, userId string) (*model.DigitalOrderRes, error) {
+	order, err := NewOrderService().GetOrderByID(ctx, orderNo)
+	if err != nil {
+		return nil, err
+	}
+	if userId != "" && order.UserID != userId {
+		return nil, errors.New("order not found")
+	}
+	if order.Status == model.OrderStatusPending || order.Status == model.OrderStatusClosed {
+		return nil, errors.New("order is not paid")
+	}
+
+	downloads := make([]*model.DigitalDownload, 0)
+	err = ctx.DB.Where("order_no = ?", orderNo).Find(&downloads).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get digital downloads", err)
+		return nil, errors.New("failed to get digital downloads")
+	}
+
+	keys := make([]*model.LicenseKey, 0)
+	err = ctx.DB.Where("order_no = ? AND status = ?", orderNo, model.LicenseKeyStatusAssigned).Find(&keys).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get license keys", err)
+		return nil, errors.New("failed to get license keys")
+	}
+
+	res := &model.DigitalOrderRes{
+		OrderNo:     orderNo,
+		Downloads:   make([]*model.DigitalDownloadRes, 0),
+		LicenseKeys: keys,
+	}
+	for _, download := range downloads {
+		res.Downloads = append(res.Downloads, &model.DigitalDownloadRes{
+			DigitalDownload: *download,
+			Url:             s.SignDownloadURL(ctx, download.Uuid),
+		})
+	}
+
+	return res, nil
+}
+
+// SignDownloadURL 生成带签名和过期时间的下载链接
+func (s *DigitalService) SignDownloadURL(ctx *app.Context, id string) string {
+	expire := ctx.Config.Digital.LinkExpire
+	if expire <= 0 {
+		expire = 3600
+	}
+	expires := time.Now().Unix() + int64(expire)
+
+	query := url.Values{}
+	query.Set("id", id)
+	query.Set("expires", fmt.Sprintf("%d", expires))
+	query.Set("sign", s.sign(ctx, id, expires))
+
+	return ctx.Config.ApiPrefix + "/v1/f/digital/download?" + query.Encode()
+}
+
+func (s *DigitalService) sign(ctx *app.Context, id string, expires int64) string {
+	key := ctx.Config.Digital.SignKey
+	if key == "" {
+		key = ctx.Config.PasswdKey
+	}
+	return utils.SignBody([]byte(fmt.Sprintf("%s:%d", id, expires)), []byte(key))
+}
+
+// Download 校验下载链接并计数，返回文件路径和文件名
+func (s *DigitalService) Download(ctx *app.Context, param *model.ReqDigitalDownloadParam) (string, string, error) {
+	if !hmac.Equal([]byte(s.sign(ctx, param.Id, param.Expires)), []byte(param.Sign)) {
+		return "", "", errors.New("invalid download sign")
+	}
+	if time.Now().Unix() > param.Expires {
+		return "", "", errors.New("download link expired")
+	}
+
+	download := &model.DigitalDownload{}
+	err := ctx.DB.Where("uuid = ?", param.Id).First(download).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", "", errors.New("download not found")
+		}
+		ctx.Logger.Error("Failed to get digital download", err)
+		return "", "", errors.New("failed to get digital download")
+	}
+
+	if download.ExpiredAt != "" && download.ExpiredAt < time.Now().Format(time.DateTime) {
+		return "", "", errors.New("download expired")
+	}
+
+	resource, err := NewResourceService().GetResourceByUUID(ctx, download.ResourceUuid)
+	if err != nil {
+		return "", "", err
+	}
+
+	// 下载次数计数，超过限制时不更新
+	result := ctx.DB.Model(&model.DigitalDownload{}).
+		Where("uuid = ? AND downloads < max_downloads", param.Id).
+		Updates(map[string]interface{}{
+			"downloads":  gorm.Expr("downloads + 1"),
+			"updated_at": time.Now().Format(time.DateTime),
+		})
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to update download count", result.Error)
+		return "", "", errors.New("failed to update download count")
+	}
+	if result.RowsAffected == 0 {
+		return "", "", errors.New("download limit reached")
+	}
+
+	return filepath.Join(ctx.Config.Digital.Dir, resource.Path), resource.Name, nil
+}
+
+// SendDigitalMail 发送虚拟商品下载邮件
+func (s *DigitalService) SendDigitalMail(ctx *app.Context, order *model.Order) {
+	if ctx.Config.MailConfig.Host == "" {
+		return
+	}
+
+	res, err := s.GetDigitalOrder(ctx, order.OrderNo, "")
+	if err != nil || (len(res.Downloads) == 0 && len(res.LicenseKeys) == 0) {
+		return
+	}
+
+	mailTo := order.ReceiverEmail
+	if mailTo == "" {
+		mailTo = NewCustomerService().GetContactEmail(ctx, order.UserID)
+		if mailTo == "" {
+			return
+		}
+	}
+
+	siteUrl := ""
+	conf, err := NewConfigurationService().GetConfigurationByCategoryAndName(ctx, model.ConfigCategorySite, model.ConfigNameSiteUrl)
+	if err == nil {
+		siteUrl = strings.TrimRight(conf.Value, "/")
+	}
+
+	lines := make([]string, 0)
+	for _, download := range res.Downloads {
+		lines = append(lines, fmt.Sprintf(`<li><a href="%s%s">%s</a></li>`, siteUrl, download.Url, download.Name))
+	}
+	for _, key := range res.LicenseKeys {
+		lines = append(lines, fmt.Sprintf("<li>许可证：<strong>%s</strong></li>", key.Key))
+	}
+
+	mailConfig := ctx.Config.MailConfig
+	logger := ctx.Logger
+	go func() {
+		err := mail.Send(&mail.Options{
+			MailHost: mailConfig.Host,
+			MailPort: mailConfig.Port,
+			MailUser: mailConfig.Username,
+			MailPass: mailConfig.Password,
+			MailTo:   mailTo,
+			Subject:  "虚拟商品发货通知",
+			Body:     fmt.Sprintf(digitalMailContent, order.OrderNo, strings.Join(lines, "")),
+		})
+		if err != nil {
+			logger.Error("Failed to send digital mail", err)
+		}
+	}()
+}
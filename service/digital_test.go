@@ -0,0 +1,108 @@
+package service
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/testutil"
+)
+
+func setupDigitalDownload(t *testing.T, ctx *app.Context, download *model.DigitalDownload) {
+	t.Helper()
+	err := ctx.DB.Create(&model.Resource{Uuid: "res1", Name: "manual.pdf", Path: "files/manual.pdf",
+		CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	download.Uuid = "dl1"
+	download.ResourceUuid = "res1"
+	download.CreatedAt = "2026-01-01 00:00:00"
+	download.UpdatedAt = "2026-01-01 00:00:00"
+	err = ctx.DB.Create(download).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 从签名链接中取出下载参数
+func downloadParam(t *testing.T, link string) *model.ReqDigitalDownloadParam {
+	t.Helper()
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	expires, _ := strconv.ParseInt(q.Get("expires"), 10, 64)
+	return &model.ReqDigitalDownloadParam{Id: q.Get("id"), Expires: expires, Sign: q.Get("sign")}
+}
+
+func TestDigitalDownload(t *testing.T) {
+	future := time.Now().Add(time.Hour).Format(time.DateTime)
+	past := time.Now().Add(-time.Hour).Format(time.DateTime)
+	tests := []struct {
+		name     string
+		download model.DigitalDownload
+		modify   func(ctx *app.Context, p *model.ReqDigitalDownloadParam)
+		wantErr  string
+	}{
+		{"valid", model.DigitalDownload{MaxDownloads: 3, ExpiredAt: future}, nil, ""},
+		{"no expiry", model.DigitalDownload{MaxDownloads: 3}, nil, ""},
+		{"bad sign", model.DigitalDownload{MaxDownloads: 3}, func(ctx *app.Context, p *model.ReqDigitalDownloadParam) { p.Sign = "bad" }, "invalid download sign"},
+		{"extended expires", model.DigitalDownload{MaxDownloads: 3}, func(ctx *app.Context, p *model.ReqDigitalDownloadParam) { p.Expires += 3600 }, "invalid download sign"},
+		{"link expired", model.DigitalDownload{MaxDownloads: 3}, func(ctx *app.Context, p *model.ReqDigitalDownloadParam) {
+			p.Expires = time.Now().Add(-time.Minute).Unix()
+			p.Sign = NewDigitalService().sign(ctx, p.Id, p.Expires)
+		}, "download link expired"},
+		{"download expired", model.DigitalDownload{MaxDownloads: 3, ExpiredAt: past}, nil, "download expired"},
+		{"limit reached", model.DigitalDownload{MaxDownloads: 3, Downloads: 3}, nil, "download limit reached"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			download := tt.download
+			setupDigitalDownload(t, ctx, &download)
+
+			param := downloadParam(t, NewDigitalService().SignDownloadURL(ctx, "dl1"))
+			if tt.modify != nil {
+				tt.modify(ctx, param)
+			}
+			_, name, err := NewDigitalService().Download(ctx, param)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Fatalf("err = %q, want %q", got, tt.wantErr)
+			}
+			if err == nil && name != "manual.pdf" {
+				t.Errorf("name = %q, want manual.pdf", name)
+			}
+		})
+	}
+}
+
+func TestDigitalDownloadCount(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	setupDigitalDownload(t, ctx, &model.DigitalDownload{MaxDownloads: 2})
+	param := downloadParam(t, NewDigitalService().SignDownloadURL(ctx, "dl1"))
+
+	for i, wantOK := range []bool{true, true, false} {
+		_, _, err := NewDigitalService().Download(ctx, param)
+		if (err == nil) != wantOK {
+			t.Errorf("download %d: err = %v, want ok %v", i, err, wantOK)
+		}
+	}
+
+	download := &model.DigitalDownload{}
+	err := ctx.DB.Where("uuid = ?", "dl1").First(download).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if download.Downloads != 2 {
+		t.Errorf("downloads = %d, want 2", download.Downloads)
+	}
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/mail"
+	"sgin/pkg/wshub"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InventoryWsHub 库存预警的websocket推送，连接按租户分组
+var InventoryWsHub = wshub.NewHub()
+
+var lowStockMailContent = `
+<html>
+<body>
+    <h2>库存预警</h2>
+    <p>以下商品库存已低于警戒值：</p>
+    <ul>%s</ul>
+</body>
+</html>
+`
+
+type InventoryService struct {
+}
+
+func NewInventoryService() *InventoryService {
+	return &InventoryService{}
+}
+
+// 库存预警推送消息
+type LowStockMessage struct {
+	Type string              `json:"type"`
+	Data *model.LowStockItem `json:"data"`
+}
+
+// AdjustStock 在事务中按变动数量调整库存并记录流水
+func (s *InventoryService) AdjustStock(ctx *app.Context, tx *gorm.DB, itemUuid string, change int64, source, sourceNo, actor, remark string) (*model.InventoryLog, error) {
+	productItem, err := s.lockProductItem(ctx, tx, itemUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.writeStock(ctx, tx, productItem, productItem.Stock+change, source, sourceNo, actor, remark)
+}
+
+// SetStock 在事务中将库存设置为指定值并记录流水，库存未变化时返回nil
+func (s *InventoryService) SetStock(ctx *app.Context, tx *gorm.DB, itemUuid string, stock int64, source, sourceNo, actor, remark string) (*model.InventoryLog, error) {
+	productItem, err := s.lockProductItem(ctx, tx, itemUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if productItem.Stock == stock {
+		return nil, nil
+	}
+
+	return s.writeStock(ctx, tx, productItem, stock, source, sourceNo, actor, remark)
+}
+
+func (s *InventoryService) lockProductItem(ctx *app.Context, tx *gorm.DB, itemUuid string) (*model.ProductItem, error) {
+	productItem := &model.ProductItem{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", itemUuid).First(productItem).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("product item not found")
+		}
+		ctx.Logger.Error("Failed to get product item by UUID", err)
+		return nil, errors.New("failed to get product item by UUID")
+	}
+	return productItem, nil
+}
+
+func (s *InventoryService) writeStock(ctx *app.Context, tx *gorm.DB, productItem *model.ProductItem, after int64, source, sourceNo, actor, remark string) (*model.InventoryLog, error) {
+	if after < 0 {
+		return nil, fmt.Errorf("insufficient stock: %s", productItem.Uuid)
+	}
+
+	now := time.Now().Format(time.DateTime)
+
+	err := tx.Model(&model.ProductItem{}).Where("uuid = ?", productItem.Uuid).Updates(map[string]interface{}{
+		"stock":      after,
+		"updated_at": now,
+	}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to update product item stock", err)
+		return nil, errors.New("failed to update product item stock")
+	}
+
+	log := &model.InventoryLog{
+		Uuid:            uuid.New().String(),
+		ProductUuid:     productItem.ProductUuid,
+		ProductItemUuid: productItem.Uuid,
+		Source:          source,
+		SourceNo:        sourceNo,
+		Before:          productItem.Stock,
+		Change:          after - productItem.Stock,
+		After:           after,
+		Actor:           actor,
+		Remark:          remark,
+		CreatedAt:       now,
+	}
+
+	err = tx.Create(log).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create inventory log", err)
+		return nil, errors.New("failed to create inventory log")
+	}
+
+	productItem.Stock = after
+	return log, nil
+}
+
+// AdjustStockByParam 手动或导入调整库存
+func (s *InventoryService) AdjustStockByParam(ctx *app.Context, param *model.ReqInventoryAdjustParam, actor string) (*model.InventoryLog, error) {
+	source := param.Source
+	if source == "" {
+		source = model.InventorySourceManual
+	}
+	if source != model.InventorySourceManual && source != model.InventorySourceImport {
+		return nil, errors.New("invalid inventory source")
+	}
+
+	var log *model.InventoryLog
+	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		log, err = s.AdjustStock(ctx, tx, param.ProductItemUuid, param.Change, source, "", actor, param.Remark)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.NotifyLowStock(ctx, []*model.InventoryLog{log})
+	return log, nil
+}
+
+// NotifyLowStock 检查库存流水，库存从警戒值之上跌破警戒值时发送邮件并推送
+// 需要在事务提交之后调用
+func (s *InventoryService) NotifyLowStock(ctx *app.Context, logs []*model.InventoryLog) {
+	productUuids := make([]string, 0)
+	for _, log := range logs {
+		if log == nil || log.Change >= 0 {
+			continue
+		}
+		productUuids = append(productUuids, log.ProductUuid)
+	}
+	if len(productUuids) == 0 {
+		return
+	}
+
+	products := make([]*model.Product, 0)
+	err := ctx.DB.Where("uuid IN (?)", productUuids).Find(&products).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get product list by UUID list", err)
+		return
+	}
+	productMap := make(map[string]*model.Product)
+	for _, product := range products {
+		productMap[product.Uuid] = product
+	}
+
+	items := make([]*model.LowStockItem, 0)
+	for _, log := range logs {
+		if log == nil || log.Change >= 0 {
+			continue
+		}
+		product, ok := productMap[log.ProductUuid]
+		if !ok || product.StockWarning <= 0 {
+			continue
+		}
+		if log.Before > product.StockWarning && log.After <= product.StockWarning {
+			items = append(items, &model.LowStockItem{
+				ProductUuid:     product.Uuid,
+				ProductName:     product.Name,
+				ProductItemUuid: log.ProductItemUuid,
+				Stock:           log.After,
+				StockWarning:    product.StockWarning,
+			})
+		}
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	// 只推送给同一租户的连接
+	tenantUuid, _ := ctx.Tenant()
+	for _, item := range items {
+		InventoryWsHub.Broadcast(tenantUuid, &LowStockMessage{
+			Type: "low_stock",
+			Data: item,
+		})
+	}
+
+	s.sendLowStockMail(ctx, items)
+}
+
+func (s *InventoryService) sendLowStockMail(ctx *app.Context, items []*model.LowStockItem) {
+	conf, err := NewConfigurationService().GetConfigurationByCategoryAndName(ctx, model.ConfigCategoryEmail, model.ConfigNameEmailStockWarning)
+	if err != nil || conf.Value == "" || ctx.Config.MailConfig.Host == "" {
+		return
+	}
+
+	lines := make([]string, 0)
+	for _, item := range items {
+		lines = append(lines, fmt.Sprintf("<li>%s (%s)：库存 %d，警戒值 %d</li>", item.ProductName, item.ProductItemUuid, item.Stock, item.StockWarning))
+	}
+
+	mailConfig := ctx.Config.MailConfig
+	logger := ctx.Logger
+	go func() {
+		err := mail.Send(&mail.Options{
+			MailHost: mailConfig.Host,
+			MailPort: mailConfig.Port,
+			MailUser: mailConfig.Username,
+			MailPass: mailConfig.Password,
+			MailTo:   conf.Value,
+			Subject:  "库存预警",
+			Body:     fmt.Sprintf(lowStockMailContent, strings.Join(lines, "")),
+		})
+		if err != nil {
+			logger.Error("Failed to send low stock mail", err)
+		}
+	}()
+}
+
+// GetInventoryLogList 获取库存流水列表
+func (s *InventoryService) GetInventoryLogList(ctx *app.Context, params *model.ReqInventoryLogQueryParam) (*model.PagedResponse, error) {
+	var (
+		logs  []*model.InventoryLog
+		total int64
+	)
+
+	db := ctx.DB.Model(&model.InventoryLog{})
+
+	if params.ProductUuid != "" {
+		db = db.Where("product_uuid = ?", params.ProductUuid)
+	}
+	if params.ProductItemUuid != "" {
+		db = db.Where("product_item_uuid = ?", params.ProductItemUuid)
+	}
+	if params.Source != "" {
+		db = db.Where("source = ?", params.Source)
+	}
+	if params.SourceNo != "" {
+		db = db.Where("source_no = ?", params.SourceNo)
+	}
+	if params.StartTime != "" {
+		db = db.Where("created_at >= ?", params.StartTime)
+	}
+	if params.EndTime != "" {
+		db = db.Where("created_at <= ?", params.EndTime)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get inventory log count", err)
+		return nil, errors.New("failed to get inventory log count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&logs).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get inventory log list", err)
+		return nil, errors.New("failed to get inventory log list")
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     logs,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// GetLowStockList 获取低于警戒库存的SKU列表
+func (s *InventoryService) GetLowStockList(ctx *app.Context, params *model.ReqLowStockQueryParam) (*model.PagedResponse, error) {
+	var (
+		items []*model.LowStockItem
+		total int64
+	)
+
+	db := ctx.DB.Model(&model.ProductItem{}).
+		Joins("JOIN products ON products.uuid = product_items.product_uuid").
+		Where("products.stock_warning > 0 AND product_items.stock <= products.stock_warning")
+
+	if params.Name != "" {
+		db = db.Where("products.name LIKE ?", "%"+params.Name+"%")
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get low stock count", err)
+		return nil, errors.New("failed to get low stock count")
+	}
+
+	err = db.Select("products.uuid AS product_uuid, products.name AS product_name, product_items.uuid AS product_item_uuid, product_items.variants, product_items.stock, products.stock_warning").
+		Order("product_items.stock ASC").Offset(params.GetOffset()).Limit(params.PageSize).Scan(&items).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get low stock list", err)
+		return nil, errors.New("failed to get low stock list")
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     items,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
@@ -0,0 +1,575 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/pdf"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 单据页面边距和表格位置，单位pt
+const (
+	invoiceMargin     = 40.0
+	invoiceColPrice   = 360.0
+	invoiceColQty     = 450.0
+	invoiceFooterLine = pdf.PageHeight - 50
+)
+
+type InvoiceService struct {
+}
+
+func NewInvoiceService() *InvoiceService {
+	return &InvoiceService{}
+}
+
+// 店铺信息，来自site配置
+type shopIdentity struct {
+	Title     string
+	CopyRight string
+	Logo      []byte
+	LogoW     int
+	LogoH     int
+}
+
+// IssueInvoice 为已支付的订单开具发票，已开具时直接返回
+// 发票号在事务中锁定年度序列后递增，事务失败时序号一起回滚，保证连续无空号
+func (s *InvoiceService) IssueInvoice(ctx *app.Context, orderNo string) (*model.Invoice, error) {
+	invoice, err := s.getInvoiceByOrderNo(ctx, ctx.DB, orderNo)
+	if err != nil || invoice != nil {
+		return invoice, err
+	}
+
+	order, err := NewOrderService().GetOrderByID(ctx, orderNo)
+	if err != nil {
+		return nil, err
+	}
+	if order.PaidAt == "" || order.Status == model.OrderStatusPending || order.Status == model.OrderStatusClosed {
+		return nil, errors.New("order is not paid")
+	}
+
+	items, err := NewOrderService().GetOrderItemsByOrderNo(ctx, orderNo)
+	if err != nil {
+		return nil, err
+	}
+
+	currencyCode := ctx.Config.Invoice.CurrencyCode
+	var discount float64
+	for _, item := range items {
+		discount += item.DiscountAmount
+		if item.ProductItem != nil && item.ProductItem.CurrencyCode != "" {
+			currencyCode = item.ProductItem.CurrencyCode
+		}
+	}
+	symbol := currencyCode
+	if currency, err := NewCurrencyService().GetCurrencyByCode(ctx, currencyCode); err == nil && currency.Symbol != "" {
+		symbol = currency.Symbol
+	}
+
+	// 商品价格为含税价，税额从订单金额中拆分
+	taxRate := ctx.Config.Invoice.TaxRate
+	taxAmount := math.Round(order.TotalAmount*taxRate/(100+taxRate)*100) / 100
+
+	now := time.Now()
+	invoice = &model.Invoice{
+		Uuid:           uuid.New().String(),
+		Year:           now.Year(),
+		OrderNo:        order.OrderNo,
+		UserID:         order.UserID,
+		CurrencyCode:   currencyCode,
+		CurrencySymbol: symbol,
+		Subtotal:       math.Round((order.TotalAmount-taxAmount)*100) / 100,
+		DiscountAmount: math.Round(discount*100) / 100,
+		TaxName:        ctx.Config.Invoice.TaxName,
+		TaxRate:        taxRate,
+		TaxAmount:      taxAmount,
+		TotalAmount:    order.TotalAmount,
+		IssuedAt:       now.Format(time.DateTime),
+		CreatedAt:      now.Format(time.DateTime),
+	}
+
+	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.InvoiceSequence{Year: invoice.Year, UpdatedAt: now.Format(time.DateTime)}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create invoice sequence", err)
+			tx.Rollback()
+			return errors.New("failed to create invoice sequence")
+		}
+
+		seq := &model.InvoiceSequence{}
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("year = ?", invoice.Year).First(seq).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to lock invoice sequence", err)
+			tx.Rollback()
+			return errors.New("failed to lock invoice sequence")
+		}
+
+		// 并发开票时，拿到锁后再确认一次
+		existing, err := s.getInvoiceByOrderNo(ctx, tx, orderNo)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if existing != nil {
+			invoice = existing
+			return nil
+		}
+
+		invoice.Seq = seq.LastSeq + 1
+		invoice.InvoiceNo = s.invoiceNo(ctx, invoice.Year, invoice.Seq)
+
+		err = tx.Model(&model.InvoiceSequence{}).Where("year = ?", invoice.Year).Updates(map[string]interface{}{
+			"last_seq":   invoice.Seq,
+			"updated_at": invoice.CreatedAt,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update invoice sequence", err)
+			tx.Rollback()
+			return errors.New("failed to update invoice sequence")
+		}
+
+		err = tx.Create(invoice).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create invoice", err)
+			tx.Rollback()
+			return errors.New("failed to create invoice")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// GetInvoiceList 获取发票列表
+func (s *InvoiceService) GetInvoiceList(ctx *app.Context, params *model.ReqInvoiceQueryParam) (*model.PagedResponse, error) {
+	var (
+		invoices []*model.Invoice
+		total    int64
+	)
+
+	db := ctx.DB.Model(&model.Invoice{})
+	if params.InvoiceNo != "" {
+		db = db.Where("invoice_no = ?", params.InvoiceNo)
+	}
+	if params.OrderNo != "" {
+		db = db.Where("order_no = ?", params.OrderNo)
+	}
+	if params.Year > 0 {
+		db = db.Where("year = ?", params.Year)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get invoice count", err)
+		return nil, errors.New("failed to get invoice count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&invoices).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get invoice list", err)
+		return nil, errors.New("failed to get invoice list")
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     invoices,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// RenderInvoice 生成订单发票PDF，userId不为空时只能下载自己订单的发票
+func (s *InvoiceService) RenderInvoice(ctx *app.Context, orderNo, userId string) ([]byte, string, error) {
+	order, err := s.getOrderRes(ctx, orderNo)
+	if err != nil {
+		return nil, "", err
+	}
+	if userId != "" && order.UserID != userId {
+		return nil, "", errors.New("order not found")
+	}
+
+	invoice, err := s.IssueInvoice(ctx, orderNo)
+	if err != nil {
+		return nil, "", err
+	}
+
+	doc := s.renderInvoice(s.getShopIdentity(ctx), order, invoice)
+	return doc.Bytes(), invoice.InvoiceNo + ".pdf", nil
+}
+
+// RenderPackingSlip 生成订单装箱单PDF，不包含价格
+func (s *InvoiceService) RenderPackingSlip(ctx *app.Context, orderNo string) ([]byte, string, error) {
+	order, err := s.getOrderRes(ctx, orderNo)
+	if err != nil {
+		return nil, "", err
+	}
+	if order.IsVirtual {
+		return nil, "", errors.New("virtual order does not need packing slip")
+	}
+
+	doc := s.renderPackingSlip(s.getShopIdentity(ctx), order)
+	return doc.Bytes(), "packing-slip-" + order.OrderNo + ".pdf", nil
+}
+
+// RenderBulkZip 批量生成单据并打包为ZIP，生成失败的订单会跳过并记录在errors.txt中
+func (s *InvoiceService) RenderBulkZip(ctx *app.Context, params *model.ReqInvoiceBulkParam) ([]byte, error) {
+	if len(params.OrderNos) == 0 {
+		return nil, errors.New("order list is empty")
+	}
+
+	docType := params.Type
+	if docType == "" {
+		docType = model.InvoiceDocInvoice
+	}
+	if docType != model.InvoiceDocInvoice && docType != model.InvoiceDocPackingSlip {
+		return nil, errors.New("invalid document type")
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	failed := make([]string, 0)
+	for _, orderNo := range params.OrderNos {
+		var (
+			data []byte
+			name string
+			err  error
+		)
+		if docType == model.InvoiceDocInvoice {
+			data, name, err = s.RenderInvoice(ctx, orderNo, "")
+		} else {
+			data, name, err = s.RenderPackingSlip(ctx, orderNo)
+		}
+		if err != nil {
+			failed = append(failed, orderNo+": "+err.Error())
+			continue
+		}
+
+		w, err := zw.Create(name)
+		if err == nil {
+			_, err = w.Write(data)
+		}
+		if err != nil {
+			ctx.Logger.Error("Failed to write zip file", err)
+			return nil, errors.New("failed to write zip file")
+		}
+	}
+
+	if len(failed) > 0 {
+		w, err := zw.Create("errors.txt")
+		if err == nil {
+			_, err = w.Write([]byte(strings.Join(failed, "\n")))
+		}
+		if err != nil {
+			ctx.Logger.Error("Failed to write zip file", err)
+			return nil, errors.New("failed to write zip file")
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		ctx.Logger.Error("Failed to close zip file", err)
+		return nil, errors.New("failed to close zip file")
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *InvoiceService) renderInvoice(shop *shopIdentity, order *model.OrderRes, invoice *model.Invoice) *pdf.Document {
+	money := func(amount float64) string {
+		return fmt.Sprintf("%s%.2f", invoice.CurrencySymbol, amount)
+	}
+
+	doc := pdf.New()
+	y := s.renderHeader(doc, shop, "发票 INVOICE")
+
+	doc.Text(invoiceMargin, y, 10, "发票号："+invoice.InvoiceNo)
+	doc.TextRight(pdf.PageWidth-invoiceMargin, y, 10, "开票日期："+invoice.IssuedAt)
+	y += 16
+	doc.Text(invoiceMargin, y, 10, "订单号："+order.OrderNo)
+	doc.TextRight(pdf.PageWidth-invoiceMargin, y, 10, "支付时间："+order.PaidAt)
+	y += 24
+
+	y = s.renderReceiver(doc, "购买方", &order.Order, y)
+
+	header := func(y float64) float64 {
+		doc.Text(invoiceMargin, y, 10, "商品")
+		doc.TextRight(invoiceColPrice, y, 10, "单价")
+		doc.TextRight(invoiceColQty, y, 10, "数量")
+		doc.TextRight(pdf.PageWidth-invoiceMargin, y, 10, "金额")
+		doc.Line(invoiceMargin, y+6, pdf.PageWidth-invoiceMargin, y+6, 0.5)
+		return y + 20
+	}
+	y = header(y)
+
+	for _, item := range order.Items {
+		if y > invoiceFooterLine-40 {
+			s.renderFooter(doc, shop)
+			doc.AddPage()
+			y = header(invoiceMargin + 20)
+		}
+
+		unitPrice := item.Price
+		if item.Quantity > 0 {
+			unitPrice = item.TotalAmount / float64(item.Quantity)
+		}
+		doc.Text(invoiceMargin, y, 10, s.truncate(s.itemName(item), invoiceColPrice-invoiceMargin-80, 10))
+		doc.TextRight(invoiceColPrice, y, 10, money(unitPrice))
+		doc.TextRight(invoiceColQty, y, 10, fmt.Sprintf("%d", item.Quantity))
+		doc.TextRight(pdf.PageWidth-invoiceMargin, y, 10, money(item.TotalAmount))
+		y += 18
+	}
+
+	if y > invoiceFooterLine-100 {
+		s.renderFooter(doc, shop)
+		doc.AddPage()
+		y = invoiceMargin + 20
+	}
+	doc.Line(invoiceMargin, y-8, pdf.PageWidth-invoiceMargin, y-8, 0.5)
+	y += 8
+
+	lines := [][2]string{}
+	if invoice.DiscountAmount > 0 {
+		lines = append(lines, [2]string{"已优惠", "-" + money(invoice.DiscountAmount)})
+	}
+	if invoice.TaxRate > 0 {
+		lines = append(lines,
+			[2]string{"不含税金额", money(invoice.Subtotal)},
+			[2]string{fmt.Sprintf("%s (%g%%)", invoice.TaxName, invoice.TaxRate), money(invoice.TaxAmount)},
+		)
+	}
+	lines = append(lines, [2]string{"合计 (" + invoice.CurrencyCode + ")", money(invoice.TotalAmount)})
+	for _, line := range lines {
+		doc.TextRight(invoiceColQty, y, 10, line[0])
+		doc.TextRight(pdf.PageWidth-invoiceMargin, y, 10, line[1])
+		y += 16
+	}
+
+	s.renderFooter(doc, shop)
+	return doc
+}
+
+func (s *InvoiceService) renderPackingSlip(shop *shopIdentity, order *model.OrderRes) *pdf.Document {
+	doc := pdf.New()
+	y := s.renderHeader(doc, shop, "装箱单 PACKING SLIP")
+
+	doc.Text(invoiceMargin, y, 10, "订单号："+order.OrderNo)
+	doc.TextRight(pdf.PageWidth-invoiceMargin, y, 10, "下单时间："+order.CreatedAt)
+	y += 24
+
+	y = s.renderReceiver(doc, "收货人", &order.Order, y)
+
+	header := func(y float64) float64 {
+		doc.Text(invoiceMargin, y, 10, "商品")
+		doc.Text(invoiceColPrice-60, y, 10, "SKU")
+		doc.TextRight(pdf.PageWidth-invoiceMargin, y, 10, "数量")
+		doc.Line(invoiceMargin, y+6, pdf.PageWidth-invoiceMargin, y+6, 0.5)
+		return y + 20
+	}
+	y = header(y)
+
+	total := 0
+	for _, item := range order.Items {
+		if y > invoiceFooterLine-40 {
+			s.renderFooter(doc, shop)
+			doc.AddPage()
+			y = header(invoiceMargin + 20)
+		}
+
+		doc.Text(invoiceMargin, y, 10, s.truncate(s.itemName(item), invoiceColPrice-invoiceMargin-70, 10))
+		doc.Text(invoiceColPrice-60, y, 10, item.ProductItemID)
+		doc.TextRight(pdf.PageWidth-invoiceMargin, y, 10, fmt.Sprintf("%d", item.Quantity))
+		total += item.Quantity
+		y += 18
+	}
+
+	doc.Line(invoiceMargin, y-8, pdf.PageWidth-invoiceMargin, y-8, 0.5)
+	doc.TextRight(pdf.PageWidth-invoiceMargin, y+8, 10, fmt.Sprintf("共 %d 件", total))
+	if order.ReceiverRemark != "" {
+		doc.Text(invoiceMargin, y+8, 10, s.truncate("备注："+order.ReceiverRemark, 400, 10))
+	}
+
+	s.renderFooter(doc, shop)
+	return doc
+}
+
+// 页眉：logo、店铺名称和单据标题，返回下一行的位置
+func (s *InvoiceService) renderHeader(doc *pdf.Document, shop *shopIdentity, title string) float64 {
+	doc.AddPage()
+	y := invoiceMargin
+
+	if shop.Logo != nil {
+		// logo最大 120x40，保持比例
+		scale := math.Min(120/float64(shop.LogoW), 40/float64(shop.LogoH))
+		doc.Image(shop.Logo, shop.LogoW, shop.LogoH, invoiceMargin, y, float64(shop.LogoW)*scale, float64(shop.LogoH)*scale)
+	} else {
+		doc.Text(invoiceMargin, y+24, 18, shop.Title)
+	}
+	if shop.Logo != nil {
+		doc.TextRight(pdf.PageWidth-invoiceMargin, y+14, 12, shop.Title)
+	}
+	doc.TextRight(pdf.PageWidth-invoiceMargin, y+34, 14, title)
+
+	y += 50
+	doc.Line(invoiceMargin, y, pdf.PageWidth-invoiceMargin, y, 1)
+	return y + 24
+}
+
+func (s *InvoiceService) renderReceiver(doc *pdf.Document, label string, order *model.Order, y float64) float64 {
+	doc.Text(invoiceMargin, y, 10, label+"：")
+	y += 16
+	lines := []string{
+		strings.TrimSpace(order.ReceiverName + " " + order.ReceiverPhone),
+		order.ReceiverEmail,
+		strings.TrimSpace(strings.Join([]string{order.ReceiverCountry, order.ReceiverProvince, order.ReceiverCity}, " ")),
+		strings.TrimSpace(order.ReceiverAddress + " " + order.ReceiverZip),
+	}
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		doc.Text(invoiceMargin+10, y, 10, s.truncate(line, pdf.PageWidth-2*invoiceMargin-10, 10))
+		y += 14
+	}
+	return y + 16
+}
+
+func (s *InvoiceService) renderFooter(doc *pdf.Document, shop *shopIdentity) {
+	doc.Line(invoiceMargin, invoiceFooterLine, pdf.PageWidth-invoiceMargin, invoiceFooterLine, 0.5)
+	doc.Text(invoiceMargin, invoiceFooterLine+16, 8, shop.CopyRight)
+	doc.TextRight(pdf.PageWidth-invoiceMargin, invoiceFooterLine+16, 8, fmt.Sprintf("%d", doc.PageCount()))
+}
+
+// 商品名称加上变体信息
+func (s *InvoiceService) itemName(item *model.OrderItemRes) string {
+	if item.ProductItem == nil {
+		return item.ProductItemID
+	}
+	name := item.ProductItem.Name
+	if name == "" && item.ProductItem.ProductInfo != nil {
+		name = item.ProductItem.ProductInfo.Name
+	}
+	options := make([]string, 0)
+	for _, variant := range item.ProductItem.VariantsInfo {
+		options = append(options, variant.Option)
+	}
+	if len(options) > 0 {
+		name += " (" + strings.Join(options, "/") + ")"
+	}
+	return name
+}
+
+// 超出宽度的文本截断
+func (s *InvoiceService) truncate(text string, width, size float64) string {
+	if pdf.TextWidth(text, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.TextWidth(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+func (s *InvoiceService) invoiceNo(ctx *app.Context, year int, seq int64) string {
+	prefix := ctx.Config.Invoice.Prefix
+	if prefix == "" {
+		prefix = "INV"
+	}
+	return fmt.Sprintf("%s-%d-%06d", prefix, year, seq)
+}
+
+func (s *InvoiceService) getInvoiceByOrderNo(ctx *app.Context, db *gorm.DB, orderNo string) (*model.Invoice, error) {
+	invoice := &model.Invoice{}
+	err := db.Where("order_no = ?", orderNo).First(invoice).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		ctx.Logger.Error("Failed to get invoice by order no", err)
+		return nil, errors.New("failed to get invoice by order no")
+	}
+	return invoice, nil
+}
+
+func (s *InvoiceService) getOrderRes(ctx *app.Context, orderNo string) (*model.OrderRes, error) {
+	order, err := NewOrderService().GetOrderByID(ctx, orderNo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := NewOrderService().GetOrderItemsByOrderNo(ctx, orderNo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.OrderRes{
+		Order: *order,
+		Items: items,
+	}, nil
+}
+
+// 读取site配置中的店铺名称、版权信息和logo，logo转换为JPEG后嵌入PDF
+func (s *InvoiceService) getShopIdentity(ctx *app.Context) *shopIdentity {
+	shop := &shopIdentity{}
+	site, err := NewConfigurationService().GetConfigurationMapByCategory(ctx, model.ConfigCategorySite)
+	if err != nil {
+		return shop
+	}
+	shop.Title = site[model.ConfigNameSiteTitle]
+	shop.CopyRight = site[model.ConfigNameSiteCopyRight]
+
+	// 只支持上传目录中的logo，不请求外部地址
+	logo := site[model.ConfigNameSiteLogo]
+	if logo == "" || strings.Contains(logo, "://") {
+		return shop
+	}
+	file, err := os.Open(filepath.Join(ctx.Config.Upload.Dir, filepath.Clean("/"+logo)))
+	if err != nil {
+		return shop
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		ctx.Logger.Error("Failed to decode site logo", err)
+		return shop
+	}
+
+	// 透明背景填充为白色
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(bounds)
+	draw.Draw(rgba, bounds, &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(rgba, bounds, img, bounds.Min, draw.Over)
+
+	buf := &bytes.Buffer{}
+	err = jpeg.Encode(buf, rgba, &jpeg.Options{Quality: 90})
+	if err != nil {
+		ctx.Logger.Error("Failed to encode site logo", err)
+		return shop
+	}
+
+	shop.Logo = buf.Bytes()
+	shop.LogoW = bounds.Dx()
+	shop.LogoH = bounds.Dy()
+	return shop
+}
@@ -0,0 +1,499 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/config"
+	"sgin/pkg/mail"
+	"sgin/pkg/redisop"
+	"sgin/pkg/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const loginLockKeyPrefix = "sgin:loginlock:"
+
+var loginLockMailContent = `
+<html>
+<body>
+    <h2>账号已被临时锁定</h2>
+    <p>尊敬的用户，您的账号 <strong>%s</strong> 登录失败次数过多，已被锁定至 %s。</p>
+    <p>最近一次失败的登录来自IP：%s</p>
+    <p>如果不是您本人操作，请在解除锁定后及时修改密码。</p>
+</body>
+</html>
+`
+
+var (
+	ErrCaptchaRequired = errors.New("请输入图形验证码")
+	ErrCaptchaInvalid  = errors.New("图形验证码错误")
+)
+
+// LoginLockedError 账号或IP已被锁定
+type LoginLockedError struct {
+	RetryAfter time.Duration // 剩余锁定时长
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("登录失败次数过多，请%d分钟后再试", int(math.Ceil(e.RetryAfter.Minutes())))
+}
+
+// 锁定对象
+type loginLockKey struct {
+	audience string
+	teamUuid string
+	typ      string
+	subject  string
+}
+
+func (k loginLockKey) redisKey() string {
+	return loginLockKeyPrefix + "fail:" + k.audience + ":" + k.teamUuid + ":" + k.typ + ":" + k.subject
+}
+
+func (k loginLockKey) query(db *gorm.DB) *gorm.DB {
+	return db.Where("audience = ? AND team_uuid = ? AND type = ? AND subject = ?", k.audience, k.teamUuid, k.typ, k.subject)
+}
+
+type LoginLockService struct {
+}
+
+func NewLoginLockService() *LoginLockService {
+	return &LoginLockService{}
+}
+
+// CheckLogin 登录前检查账号和IP是否被锁定，失败次数达到配置值后需要提交图形验证码
+func (s *LoginLockService) CheckLogin(ctx *app.Context, audience, account, captchaId, captchaCode string) error {
+	conf := s.getConfig(ctx)
+	keys := s.keys(ctx, audience, account)
+	locks, err := s.getLocks(ctx, keys)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, lock := range locks {
+		until, ok := parseDateTime(lock.LockedUntil)
+		if ok && until.After(now) {
+			return &LoginLockedError{RetryAfter: until.Sub(now)}
+		}
+	}
+
+	if conf.CaptchaAfter < 0 {
+		return nil
+	}
+
+	// 曾经被锁定过的账号或IP在登录成功前都需要图形验证码
+	required := false
+	for _, key := range keys {
+		lock := locks[key.typ]
+		if lock != nil && lock.LockCount > 0 {
+			required = true
+			break
+		}
+		if s.getFailures(ctx, key, lock, conf) >= conf.CaptchaAfter {
+			required = true
+			break
+		}
+	}
+	if !required {
+		return nil
+	}
+
+	if captchaId == "" || captchaCode == "" {
+		return ErrCaptchaRequired
+	}
+	if !NewCaptchaService().VerifyCaptcha(ctx, captchaId, captchaCode) {
+		return ErrCaptchaInvalid
+	}
+	return nil
+}
+
+// RecordFailure 记录登录失败，账号或IP失败次数达到配置值时锁定，锁定时长按连续锁定次数翻倍
+// 账号被锁定时发送邮件通知，出错时只记录日志不影响登录结果
+func (s *LoginLockService) RecordFailure(ctx *app.Context, audience, account string) {
+	conf := s.getConfig(ctx)
+	keys := s.keys(ctx, audience, account)
+	locks, err := s.getLocks(ctx, keys)
+	if err != nil {
+		return
+	}
+
+	for _, key := range keys {
+		if key.subject == "" {
+			continue
+		}
+		limit := conf.MaxFailures
+		if key.typ == model.LoginLockTypeIp {
+			limit = conf.IpMaxFailures
+		}
+
+		n, err := s.incrFailures(ctx, key, locks, conf)
+		if err != nil || n < limit {
+			continue
+		}
+
+		until, err := s.lock(ctx, key, locks[key.typ], conf)
+		if err != nil {
+			continue
+		}
+		ctx.Logger.Warn(fmt.Sprintf("Login locked, audience %s, %s %s until %s", key.audience, key.typ, key.subject, until))
+		if key.typ == model.LoginLockTypeAccount {
+			s.sendLockMail(ctx, key, until)
+		}
+	}
+}
+
+// RecordSuccess 登录成功后清除账号的失败次数和连续锁定次数，IP的失败次数不清除
+func (s *LoginLockService) RecordSuccess(ctx *app.Context, audience, account string) {
+	key := s.keys(ctx, audience, account)[0]
+	s.reset(ctx, key, ctx.DB.Model(&model.LoginLock{}))
+}
+
+// GetLoginLockList 获取登录锁定列表，默认只返回锁定中的记录
+func (s *LoginLockService) GetLoginLockList(ctx *app.Context, params *model.ReqLoginLockQueryParam) (*model.PagedResponse, error) {
+	var (
+		locks []*model.LoginLock
+		total int64
+	)
+
+	db := ctx.DB.Model(&model.LoginLock{})
+	if params.Audience != "" {
+		db = db.Where("audience = ?", params.Audience)
+	}
+	if params.Type != "" {
+		db = db.Where("type = ?", params.Type)
+	}
+	if params.Subject != "" {
+		db = db.Where("subject LIKE ?", "%"+params.Subject+"%")
+	}
+	if !params.All {
+		db = db.Where("locked_until > ?", time.Now().Format(time.DateTime))
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get login lock count", err)
+		return nil, errors.New("failed to get login lock count")
+	}
+
+	err = db.Offset(params.GetOffset()).Limit(params.PageSize).Order("updated_at DESC").Find(&locks).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get login lock list", err)
+		return nil, errors.New("failed to get login lock list")
+	}
+
+	return &model.PagedResponse{
+		Total: total,
+		Data:  locks,
+	}, nil
+}
+
+// Unlock 解除锁定，同时清除失败次数和连续锁定次数
+func (s *LoginLockService) Unlock(ctx *app.Context, id int64) error {
+	lock := &model.LoginLock{}
+	err := ctx.DB.Where("id = ?", id).First(lock).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("锁定记录不存在")
+		}
+		ctx.Logger.Error("Failed to get login lock", err)
+		return errors.New("failed to get login lock")
+	}
+
+	key := loginLockKey{audience: lock.Audience, teamUuid: lock.TeamUuid, typ: lock.Type, subject: lock.Subject}
+	return s.reset(ctx, key, ctx.DB.Model(&model.LoginLock{}).Where("id = ?", lock.Id))
+}
+
+// 登录的账号和IP，商城客户按团队区分
+func (s *LoginLockService) keys(ctx *app.Context, audience, account string) []loginLockKey {
+	teamUuid := ""
+	if audience == utils.TokenAudienceCustomer {
+		teamUuid, _ = ctx.Tenant()
+	}
+	// 超出字段长度的账号不可能存在，截断后统计
+	account = strings.ToLower(strings.TrimSpace(account))
+	if len(account) > 100 {
+		account = account[:100]
+	}
+	return []loginLockKey{
+		{audience: audience, teamUuid: teamUuid, typ: model.LoginLockTypeAccount, subject: account},
+		{audience: audience, teamUuid: teamUuid, typ: model.LoginLockTypeIp, subject: ctx.ClientIP()},
+	}
+}
+
+// 获取账号和IP的锁定记录，按锁定类型返回
+func (s *LoginLockService) getLocks(ctx *app.Context, keys []loginLockKey) (map[string]*model.LoginLock, error) {
+	locks := make([]*model.LoginLock, 0)
+	db := ctx.DB.Where("1 = 0")
+	for _, key := range keys {
+		db = db.Or(key.query(ctx.DB))
+	}
+	err := db.Find(&locks).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get login locks", err)
+		return nil, errors.New("failed to get login locks")
+	}
+
+	res := make(map[string]*model.LoginLock)
+	for _, lock := range locks {
+		res[lock.Type] = lock
+	}
+	return res, nil
+}
+
+// 统计窗口内的失败次数，配置了redis时使用redis计数
+func (s *LoginLockService) getFailures(ctx *app.Context, key loginLockKey, lock *model.LoginLock, conf config.LoginLockConfig) int {
+	if ctx.Redis != nil {
+		v, err := ctx.Redis.Get(ctx.Ctx, key.redisKey())
+		if err != nil {
+			if !errors.Is(err, redisop.Nil) {
+				ctx.Logger.Error("Failed to get login failures", err)
+			}
+			return 0
+		}
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+
+	if lock == nil || !s.inWindow(lock, conf) {
+		return 0
+	}
+	return lock.Failures
+}
+
+// 失败次数加1，返回统计窗口内的失败次数
+func (s *LoginLockService) incrFailures(ctx *app.Context, key loginLockKey, locks map[string]*model.LoginLock, conf config.LoginLockConfig) (int, error) {
+	if ctx.Redis != nil {
+		n, err := ctx.Redis.Incr(ctx.Ctx, key.redisKey())
+		if err != nil {
+			ctx.Logger.Error("Failed to count login failures", err)
+			return 0, err
+		}
+		if n == 1 {
+			err = ctx.Redis.Expire(ctx.Ctx, key.redisKey(), time.Duration(conf.Window)*time.Minute)
+			if err != nil {
+				ctx.Logger.Error("Failed to expire login failures counter", err)
+			}
+		}
+		return int(n), nil
+	}
+
+	now := time.Now().Format(time.DateTime)
+	lock := locks[key.typ]
+	if lock == nil {
+		lock = &model.LoginLock{
+			Audience:     key.audience,
+			TeamUuid:     key.teamUuid,
+			Type:         key.typ,
+			Subject:      key.subject,
+			Failures:     1,
+			WindowStart:  now,
+			LastFailedAt: now,
+			LastIp:       ctx.ClientIP(),
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		err := ctx.DB.Create(lock).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create login lock", err)
+			return 0, err
+		}
+		locks[key.typ] = lock
+		return 1, nil
+	}
+
+	n := lock.Failures + 1
+	updates := map[string]interface{}{
+		"failures":       gorm.Expr("failures + 1"),
+		"last_failed_at": now,
+		"last_ip":        ctx.ClientIP(),
+	}
+	if !s.inWindow(lock, conf) {
+		n = 1
+		updates["failures"] = 1
+		updates["window_start"] = now
+	}
+	err := ctx.DB.Model(&model.LoginLock{}).Where("id = ?", lock.Id).Updates(updates).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to update login failures", err)
+		return 0, err
+	}
+	return n, nil
+}
+
+// 锁定账号或IP并清除失败次数，返回锁定截止时间
+func (s *LoginLockService) lock(ctx *app.Context, key loginLockKey, lock *model.LoginLock, conf config.LoginLockConfig) (time.Time, error) {
+	now := time.Now()
+	lockCount := 1
+	if lock != nil {
+		lockCount = lock.LockCount + 1
+		// 上次锁定结束后超过最长锁定时长没有再次锁定时重新计算
+		until, ok := parseDateTime(lock.LockedUntil)
+		if ok && now.Sub(until) > time.Duration(conf.MaxLockDuration)*time.Minute {
+			lockCount = 1
+		}
+	}
+	until := now.Add(s.lockDuration(conf, lockCount))
+
+	values := map[string]interface{}{
+		"failures":       0,
+		"window_start":   now.Format(time.DateTime),
+		"lock_count":     lockCount,
+		"locked_until":   until.Format(time.DateTime),
+		"last_failed_at": now.Format(time.DateTime),
+		"last_ip":        ctx.ClientIP(),
+	}
+
+	var err error
+	if lock == nil {
+		err = ctx.DB.Create(&model.LoginLock{
+			Audience:     key.audience,
+			TeamUuid:     key.teamUuid,
+			Type:         key.typ,
+			Subject:      key.subject,
+			WindowStart:  now.Format(time.DateTime),
+			LockCount:    lockCount,
+			LockedUntil:  until.Format(time.DateTime),
+			LastFailedAt: now.Format(time.DateTime),
+			LastIp:       ctx.ClientIP(),
+			CreatedAt:    now.Format(time.DateTime),
+			UpdatedAt:    now.Format(time.DateTime),
+		}).Error
+	} else {
+		err = ctx.DB.Model(&model.LoginLock{}).Where("id = ?", lock.Id).Updates(values).Error
+	}
+	if err != nil {
+		ctx.Logger.Error("Failed to lock login", err)
+		return until, errors.New("failed to lock login")
+	}
+
+	if ctx.Redis != nil {
+		err = ctx.Redis.Del(ctx.Ctx, key.redisKey())
+		if err != nil {
+			ctx.Logger.Error("Failed to delete login failures counter", err)
+		}
+	}
+	return until, nil
+}
+
+// 锁定时长，首次为LockDuration，之后每次翻倍，不超过MaxLockDuration
+func (s *LoginLockService) lockDuration(conf config.LoginLockConfig, lockCount int) time.Duration {
+	minutes := conf.LockDuration
+	for i := 1; i < lockCount && minutes < conf.MaxLockDuration; i++ {
+		minutes *= 2
+	}
+	if minutes > conf.MaxLockDuration {
+		minutes = conf.MaxLockDuration
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// 清除失败次数和锁定状态，db为要更新的锁定记录
+func (s *LoginLockService) reset(ctx *app.Context, key loginLockKey, db *gorm.DB) error {
+	if ctx.Redis != nil {
+		err := ctx.Redis.Del(ctx.Ctx, key.redisKey())
+		if err != nil {
+			ctx.Logger.Error("Failed to delete login failures counter", err)
+		}
+	}
+
+	err := key.query(db).Updates(map[string]interface{}{
+		"failures":     0,
+		"window_start": "",
+		"lock_count":   0,
+		"locked_until": "",
+	}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to reset login lock", err)
+		return errors.New("failed to reset login lock")
+	}
+	return nil
+}
+
+func (s *LoginLockService) inWindow(lock *model.LoginLock, conf config.LoginLockConfig) bool {
+	start, ok := parseDateTime(lock.WindowStart)
+	return ok && time.Since(start) < time.Duration(conf.Window)*time.Minute
+}
+
+// 账号锁定时通知账号邮箱，账号不存在或没有配置邮件时不发送
+func (s *LoginLockService) sendLockMail(ctx *app.Context, key loginLockKey, until time.Time) {
+	if ctx.Config.MailConfig.Host == "" {
+		return
+	}
+
+	email := ""
+	if key.audience == utils.TokenAudienceCustomer {
+		customer, err := NewCustomerService().getCustomerByEmail(ctx, key.subject)
+		if err != nil || customer == nil {
+			return
+		}
+		email = customer.Email
+	} else {
+		user, err := NewUserService().GetUserByUsernameOrEmail(ctx, key.subject)
+		if err != nil {
+			return
+		}
+		email = user.Email
+	}
+	if email == "" {
+		return
+	}
+
+	mailConfig := ctx.Config.MailConfig
+	logger := ctx.Logger
+	ip := ctx.ClientIP()
+	go func() {
+		err := mail.Send(&mail.Options{
+			MailHost: mailConfig.Host,
+			MailPort: mailConfig.Port,
+			MailUser: mailConfig.Username,
+			MailPass: mailConfig.Password,
+			MailTo:   email,
+			Subject:  "账号已被临时锁定",
+			Body:     fmt.Sprintf(loginLockMailContent, key.subject, until.Format(time.DateTime), ip),
+		})
+		if err != nil {
+			logger.Error("Failed to send login lock mail", err)
+		}
+	}()
+}
+
+func (s *LoginLockService) getConfig(ctx *app.Context) config.LoginLockConfig {
+	conf := ctx.Config.LoginLock
+	if conf.MaxFailures <= 0 {
+		conf.MaxFailures = 5
+	}
+	if conf.IpMaxFailures <= 0 {
+		conf.IpMaxFailures = 20
+	}
+	if conf.Window <= 0 {
+		conf.Window = 15
+	}
+	if conf.LockDuration <= 0 {
+		conf.LockDuration = 5
+	}
+	if conf.MaxLockDuration <= 0 {
+		conf.MaxLockDuration = 1440
+	}
+	if conf.CaptchaAfter == 0 {
+		conf.CaptchaAfter = 3
+	}
+	if conf.CaptchaExpire <= 0 {
+		conf.CaptchaExpire = 300
+	}
+	return conf
+}
+
+func parseDateTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(time.DateTime, value, time.Local)
+	return t, err == nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"sgin/pkg/testutil"
+	"sgin/pkg/utils"
+)
+
+// 没有配置redis时失败次数和锁定记录保存在数据库中
+func TestLoginLockByDB(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	svc := NewLoginLockService()
+
+	tests := []struct {
+		failures int
+		want     error
+	}{
+		{2, nil},
+		{3, ErrCaptchaRequired},
+		{5, &LoginLockedError{}},
+	}
+	recorded := 0
+	for _, tt := range tests {
+		for ; recorded < tt.failures; recorded++ {
+			svc.RecordFailure(ctx, utils.TokenAudienceAdmin, "user1")
+		}
+		err := svc.CheckLogin(ctx, utils.TokenAudienceAdmin, "user1", "", "")
+		switch want := tt.want.(type) {
+		case nil:
+			if err != nil {
+				t.Errorf("%d failures: err = %v, want nil", tt.failures, err)
+			}
+		case *LoginLockedError:
+			if !errors.As(err, &want) {
+				t.Errorf("%d failures: err = %v, want locked", tt.failures, err)
+			}
+		default:
+			if !errors.Is(err, want) {
+				t.Errorf("%d failures: err = %v, want %v", tt.failures, err, want)
+			}
+		}
+	}
+}
@@ -58,6 +58,7 @@ func (s *MenuAPIService) CreateMenuAPI(ctx *app.Context, menuAPI *model.ReqMenuA
 		return err
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -85,6 +86,7 @@ func (s *MenuAPIService) UpdateMenuAPI(ctx *app.Context, menuAPI *model.MenuAPI)
 		return errors.New("failed to update menu API")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -96,6 +98,7 @@ func (s *MenuAPIService) DeleteMenuAPI(ctx *app.Context, uuid string) error {
 		return errors.New("failed to delete menu API")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
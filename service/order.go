@@ -2,15 +2,29 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"sgin/model"
 	"sgin/pkg/app"
+	"sgin/pkg/mail"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+var orderPaidMailContent = `
+<html>
+<body>
+    <h2>订单支付成功</h2>
+    <p>订单号：%s</p>
+    <p>支付金额：%.2f</p>
+    <p>支付时间：%s</p>
+</body>
+</html>
+`
+
 type OrderService struct {
 }
 
@@ -20,9 +34,22 @@ func NewOrderService() *OrderService {
 
 // CreateOrder creates a new order along with its items and receiver details
 func (s *OrderService) CreateOrder(ctx *app.Context, req *model.ReqOrderCreate) (*model.Order, error) {
+	for _, item := range req.Items {
+		err := checkItemQuantity(item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	orderNo, err := s.NewOrderNo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the order
 	order := &model.Order{
-		OrderNo:          uuid.New().String(),
+		Uuid:             uuid.New().String(),
+		OrderNo:          orderNo,
 		UserID:           req.UserId,
 		Status:           model.OrderStatusPending,
 		ReceiverName:     req.Receiver.ReceiverName,
@@ -48,6 +75,22 @@ func (s *OrderService) CreateOrder(ctx *app.Context, req *model.ReqOrderCreate)
 		return nil, err
 	}
 
+	productItems := make([]*model.ProductItemRes, 0)
+	for _, productItem := range productItemMap {
+		productItems = append(productItems, productItem)
+	}
+	order.IsVirtual = s.isVirtualOrder(productItems)
+
+	// 按价格表计算实际售价，与前台展示一致
+	err = NewPriceService().ResolveItemResPrices(ctx, req.UserId, productItems)
+	if err != nil {
+		return nil, err
+	}
+	if !order.IsVirtual && order.ReceiverAddress == "" {
+		return nil, errors.New("receiver address is required")
+	}
+
+	inventoryLogs := make([]*model.InventoryLog, 0)
 	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
 
 		orderItems := make([]*model.OrderItem, 0)
@@ -59,16 +102,7 @@ func (s *OrderService) CreateOrder(ctx *app.Context, req *model.ReqOrderCreate)
 				return errors.New("product item not found")
 			}
 
-			orderItem := &model.OrderItem{
-				OrderID:       order.OrderNo,
-				ProductItemID: item.ProductItemID,
-				Quantity:      item.Quantity,
-				Price:         productItem.Price,
-				TotalAmount:   productItem.Price * float64(item.Quantity),
-				// Additional calculations for price, discount, etc., can be added here
-				CreatedAt: time.Now().Format(time.DateTime),
-				UpdatedAt: time.Now().Format(time.DateTime),
-			}
+			orderItem := s.newOrderItem(order.OrderNo, &productItem.ProductItem, item.Quantity)
 
 			order.TotalAmount += orderItem.TotalAmount
 			orderItems = append(orderItems, orderItem)
@@ -91,6 +125,13 @@ func (s *OrderService) CreateOrder(ctx *app.Context, req *model.ReqOrderCreate)
 			return errors.New("failed to create order items")
 		}
 
+		// 扣减库存
+		inventoryLogs, err = s.deductStock(ctx, tx, order, orderItems)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
 		return nil
 
 	})
@@ -99,14 +140,23 @@ func (s *OrderService) CreateOrder(ctx *app.Context, req *model.ReqOrderCreate)
 		return nil, err
 	}
 
+	NewInventoryService().NotifyLowStock(ctx, inventoryLogs)
+	NewWebhookService().PublishOrder(ctx, model.WebhookEventOrderCreated, order)
+
 	return order, nil
 }
 
 // 创建订单，根据购物车
 func (s *OrderService) CreateOrderByCart(ctx *app.Context, req *model.ReqOrderCreate) (*model.Order, error) {
+	orderNo, err := s.NewOrderNo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the order
 	order := &model.Order{
-		OrderNo:          uuid.New().String(),
+		Uuid:             uuid.New().String(),
+		OrderNo:          orderNo,
 		UserID:           req.UserId,
 		Status:           model.OrderStatusPending,
 		ReceiverName:     req.Receiver.ReceiverName,
@@ -129,6 +179,29 @@ func (s *OrderService) CreateOrderByCart(ctx *app.Context, req *model.ReqOrderCr
 		return nil, err
 	}
 
+	productItems := make([]*model.ProductItemRes, 0)
+	for _, cartItem := range cartProductMap {
+		if cartItem.ProductItem == nil {
+			return nil, errors.New("product item not found")
+		}
+		err = checkItemQuantity(cartItem.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		productItems = append(productItems, cartItem.ProductItem)
+	}
+	order.IsVirtual = s.isVirtualOrder(productItems)
+	if !order.IsVirtual && order.ReceiverAddress == "" {
+		return nil, errors.New("receiver address is required")
+	}
+
+	// 按价格表计算实际售价，与购物车展示一致
+	err = NewPriceService().ResolveItemResPrices(ctx, req.UserId, productItems)
+	if err != nil {
+		return nil, err
+	}
+
+	inventoryLogs := make([]*model.InventoryLog, 0)
 	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
 
 		orderItems := make([]*model.OrderItem, 0)
@@ -136,16 +209,7 @@ func (s *OrderService) CreateOrderByCart(ctx *app.Context, req *model.ReqOrderCr
 		for _, cartUuid := range req.CartUuids {
 			if cartItem, ok := cartProductMap[cartUuid]; ok {
 
-				orderItem := &model.OrderItem{
-					OrderID:       order.OrderNo,
-					ProductItemID: cartItem.ProductItemUuid,
-					Quantity:      cartItem.Quantity,
-					Price:         cartItem.ProductItem.Price,
-					TotalAmount:   cartItem.ProductItem.Price * float64(cartItem.Quantity),
-					// Additional calculations for price, discount, etc., can be added here
-					CreatedAt: time.Now().Format(time.DateTime),
-					UpdatedAt: time.Now().Format(time.DateTime),
-				}
+				orderItem := s.newOrderItem(order.OrderNo, &cartItem.ProductItem.ProductItem, cartItem.Quantity)
 
 				order.TotalAmount += orderItem.TotalAmount
 				orderItems = append(orderItems, orderItem)
@@ -168,6 +232,13 @@ func (s *OrderService) CreateOrderByCart(ctx *app.Context, req *model.ReqOrderCr
 			return errors.New("failed to create order items")
 		}
 
+		// 扣减库存
+		inventoryLogs, err = s.deductStock(ctx, tx, order, orderItems)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
 		// 删除购物车
 		err = tx.Where("uuid in (?)", req.CartUuids).Delete(&model.Cart{}).Error
 		if err != nil {
@@ -184,9 +255,284 @@ func (s *OrderService) CreateOrderByCart(ctx *app.Context, req *model.ReqOrderCr
 		return nil, err
 	}
 
+	NewInventoryService().NotifyLowStock(ctx, inventoryLogs)
+	NewWebhookService().PublishOrder(ctx, model.WebhookEventOrderCreated, order)
+
 	return order, nil
 }
 
+// 按SKU实际售价创建订单商品
+func (s *OrderService) newOrderItem(orderNo string, productItem *model.ProductItem, quantity int) *model.OrderItem {
+	salePrice := productItem.SalePrice()
+	orderItem := &model.OrderItem{
+		OrderID:        orderNo,
+		ProductItemID:  productItem.Uuid,
+		Quantity:       quantity,
+		Price:          productItem.Price,
+		TotalAmount:    salePrice * float64(quantity),
+		DiscountAmount: (productItem.Price - salePrice) * float64(quantity),
+		CreatedAt:      time.Now().Format(time.DateTime),
+		UpdatedAt:      time.Now().Format(time.DateTime),
+	}
+	if salePrice < productItem.Price {
+		orderItem.Discount = productItem.Discount
+		orderItem.DiscountPrice = salePrice
+	}
+	return orderItem
+}
+
+// 订单商品全部为虚拟产品时无需发货
+func (s *OrderService) isVirtualOrder(productItems []*model.ProductItemRes) bool {
+	if len(productItems) == 0 {
+		return false
+	}
+	for _, productItem := range productItems {
+		if productItem.ProductInfo == nil || productItem.ProductInfo.Type != model.ProductKindVirtual {
+			return false
+		}
+	}
+	return true
+}
+
+// MarkOrderPaid 支付成功后更新订单和付款状态，并发放虚拟商品
+// 重复的支付通知不会重复处理
+func (s *OrderService) MarkOrderPaid(ctx *app.Context, orderNo, method, transactionNo string) error {
+	var paidOrder *model.Order
+
+	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+		order := &model.Order{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_no = ?", orderNo).First(order).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.New("order not found")
+			}
+			ctx.Logger.Error("Failed to get order by order no", err)
+			return errors.New("failed to get order by order no")
+		}
+
+		if order.Status != model.OrderStatusPending {
+			return nil
+		}
+
+		now := time.Now().Format(time.DateTime)
+		order.Status = model.OrderStatusPaid
+		order.PaidAt = now
+		if order.IsVirtual {
+			// 虚拟订单支付后直接完成
+			order.Status = model.OrderStatusCompleted
+			order.DeliveredAt = now
+			order.CompletedAt = now
+		}
+		order.UpdatedAt = now
+
+		err = tx.Model(&model.Order{}).Where("order_no = ?", orderNo).Updates(map[string]interface{}{
+			"status":       order.Status,
+			"paid_at":      order.PaidAt,
+			"delivered_at": order.DeliveredAt,
+			"completed_at": order.CompletedAt,
+			"updated_at":   now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update order status", err)
+			tx.Rollback()
+			return errors.New("failed to update order status")
+		}
+
+		query := tx.Model(&model.Payment{}).Where("order_id = ? AND status = ?", orderNo, model.PaymentStatusPending)
+		if method != "" {
+			query = query.Where("method = ?", method)
+		}
+		err = query.Updates(map[string]interface{}{
+			"status":                 model.PaymentStatusPaid,
+			"paid_at":                now,
+			"channel_transaction_no": transactionNo,
+			"updated_at":             now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update payment status", err)
+			tx.Rollback()
+			return errors.New("failed to update payment status")
+		}
+
+		err = NewDigitalService().FulfillOrder(ctx, tx, order)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		paidOrder = order
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if paidOrder != nil {
+		s.SendPaidMail(ctx, paidOrder)
+		NewDigitalService().SendDigitalMail(ctx, paidOrder)
+		NewWebhookService().PublishOrder(ctx, model.WebhookEventOrderPaid, paidOrder)
+	}
+
+	return nil
+}
+
+// SendPaidMail 发送支付成功邮件，配置了Invoice.AttachToMail时附带发票
+func (s *OrderService) SendPaidMail(ctx *app.Context, order *model.Order) {
+	if ctx.Config.MailConfig.Host == "" {
+		return
+	}
+
+	mailTo := order.ReceiverEmail
+	if mailTo == "" {
+		mailTo = NewCustomerService().GetContactEmail(ctx, order.UserID)
+		if mailTo == "" {
+			return
+		}
+	}
+
+	attachments := make([]*mail.Attachment, 0)
+	if ctx.Config.Invoice.AttachToMail {
+		data, name, err := NewInvoiceService().RenderInvoice(ctx, order.OrderNo, "")
+		if err != nil {
+			ctx.Logger.Error("Failed to render invoice for paid mail", err)
+		} else {
+			attachments = append(attachments, &mail.Attachment{Name: name, Data: data})
+		}
+	}
+
+	mailConfig := ctx.Config.MailConfig
+	logger := ctx.Logger
+	go func() {
+		err := mail.Send(&mail.Options{
+			MailHost:    mailConfig.Host,
+			MailPort:    mailConfig.Port,
+			MailUser:    mailConfig.Username,
+			MailPass:    mailConfig.Password,
+			MailTo:      mailTo,
+			Subject:     "订单支付成功",
+			Body:        fmt.Sprintf(orderPaidMailContent, order.OrderNo, order.TotalAmount, order.PaidAt),
+			Attachments: attachments,
+		})
+		if err != nil {
+			logger.Error("Failed to send paid mail", err)
+		}
+	}()
+}
+
+// CloseOrder 关闭待支付订单，取消付款并按库存流水退回库存
+func (s *OrderService) CloseOrder(ctx *app.Context, orderNo string) error {
+	return ctx.DB.Transaction(func(tx *gorm.DB) error {
+		order := &model.Order{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_no = ?", orderNo).First(order).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.New("order not found")
+			}
+			ctx.Logger.Error("Failed to get order by order no", err)
+			return errors.New("failed to get order by order no")
+		}
+
+		if order.Status != model.OrderStatusPending {
+			return nil
+		}
+
+		now := time.Now().Format(time.DateTime)
+		err = tx.Model(&model.Order{}).Where("order_no = ?", orderNo).Updates(map[string]interface{}{
+			"status":     model.OrderStatusClosed,
+			"closed_at":  now,
+			"updated_at": now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to close order", err)
+			tx.Rollback()
+			return errors.New("failed to close order")
+		}
+
+		err = tx.Model(&model.Payment{}).Where("order_id = ? AND status = ?", orderNo, model.PaymentStatusPending).Updates(map[string]interface{}{
+			"status":     model.PaymentStatusCanceled,
+			"updated_at": now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to cancel payment", err)
+			tx.Rollback()
+			return errors.New("failed to cancel payment")
+		}
+
+		logs := make([]*model.InventoryLog, 0)
+		err = tx.Where("source = ? AND source_no = ?", model.InventorySourceOrder, orderNo).Find(&logs).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get inventory log list", err)
+			tx.Rollback()
+			return errors.New("failed to get inventory log list")
+		}
+
+		// 订单修改过数量时有多条流水，按SKU汇总后退回
+		itemUuids := make([]string, 0)
+		changes := make(map[string]int64)
+		for _, log := range logs {
+			if _, ok := changes[log.ProductItemUuid]; !ok {
+				itemUuids = append(itemUuids, log.ProductItemUuid)
+			}
+			changes[log.ProductItemUuid] += log.Change
+		}
+		for _, itemUuid := range itemUuids {
+			if changes[itemUuid] >= 0 {
+				continue
+			}
+			_, err := NewInventoryService().AdjustStock(ctx, tx, itemUuid, -changes[itemUuid], model.InventorySourceOrder, orderNo, order.UserID, "close")
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// 下单数量必须大于0，负数会增加库存并产生负的订单金额
+func checkItemQuantity(quantity int) error {
+	if quantity <= 0 {
+		return errors.New("quantity must be greater than 0")
+	}
+	return nil
+}
+
+// 根据订单商品扣减库存
+func (s *OrderService) deductStock(ctx *app.Context, tx *gorm.DB, order *model.Order, orderItems []*model.OrderItem) ([]*model.InventoryLog, error) {
+	itemUuids := make([]string, 0)
+	for _, item := range orderItems {
+		itemUuids = append(itemUuids, item.ProductItemID)
+	}
+
+	groupMap, err := NewProductGroupService().GetGroupItemMap(ctx, itemUuids)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]*model.InventoryLog, 0)
+	for _, item := range orderItems {
+		// 组合产品扣减各组件库存
+		if groupItems, ok := groupMap[item.ProductItemID]; ok {
+			for _, groupItem := range groupItems {
+				log, err := NewInventoryService().AdjustStock(ctx, tx, groupItem.ComponentItemUuid, -int64(item.Quantity)*groupItem.Quantity, model.InventorySourceOrder, order.OrderNo, order.UserID, "group:"+item.ProductItemID)
+				if err != nil {
+					return nil, err
+				}
+				logs = append(logs, log)
+			}
+			continue
+		}
+
+		log, err := NewInventoryService().AdjustStock(ctx, tx, item.ProductItemID, -int64(item.Quantity), model.InventorySourceOrder, order.OrderNo, order.UserID, "")
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
 // GetOrderByID retrieves an order by its ID
 func (s *OrderService) GetOrderByID(ctx *app.Context, uuidStr string) (*model.Order, error) {
 	order := &model.Order{}
@@ -202,6 +548,24 @@ func (s *OrderService) GetOrderByID(ctx *app.Context, uuidStr string) (*model.Or
 	return order, nil
 }
 
+// GetOrderRes 根据订单号获取订单和订单商品
+func (s *OrderService) GetOrderRes(ctx *app.Context, orderNo string) (*model.OrderRes, error) {
+	order, err := s.GetOrderByID(ctx, orderNo)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.GetOrderItemsByOrderNo(ctx, orderNo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.OrderRes{
+		Order: *order,
+		Items: items,
+	}, nil
+}
+
 // 根据订单号获取订单商品列表信息
 func (s *OrderService) GetOrderItemsByOrderNo(ctx *app.Context, orderNo string) ([]*model.OrderItemRes, error) {
 	orderItems := make([]*model.OrderItem, 0)
@@ -238,7 +602,7 @@ func (s *OrderService) GetOrderItemsByOrderNo(ctx *app.Context, orderNo string)
 
 // UpdateOrderStatus updates the status of an existing order
 func (s *OrderService) UpdateOrderStatus(ctx *app.Context, id string, status string) error {
-	err := ctx.DB.Model(&model.Order{}).Where("uuid = ?", id).Updates(map[string]interface{}{
+	err := ctx.DB.Model(&model.Order{}).Where("order_no = ?", id).Updates(map[string]interface{}{
 		"status":     status,
 		"updated_at": time.Now().Format(time.DateTime),
 	}).Error
@@ -249,34 +613,39 @@ func (s *OrderService) UpdateOrderStatus(ctx *app.Context, id string, status str
 	return nil
 }
 
-// DeleteOrder deletes an order by its ID
-func (s *OrderService) DeleteOrder(ctx *app.Context, uuid string) error {
+// DeleteOrder 软删除订单，待支付订单先关闭并退回库存，履约中的订单不能删除
+func (s *OrderService) DeleteOrder(ctx *app.Context, orderNo, operator string) error {
+	order, err := s.GetOrderByID(ctx, orderNo)
+	if err != nil {
+		return err
+	}
 
-	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
-		// Delete the order items
-		err := tx.Where("order_id = ?", uuid).Delete(&model.OrderItem{}).Error
+	switch order.Status {
+	case model.OrderStatusPaid, model.OrderStatusDelivered:
+		return errors.New("order in fulfilment can not be deleted")
+	case model.OrderStatusPending:
+		err = s.CloseOrder(ctx, orderNo)
 		if err != nil {
-			ctx.Logger.Error("Failed to delete order items", err)
-			tx.Rollback()
-			return errors.New("failed to delete order items")
+			return err
 		}
+	}
 
-		// Delete the order
-		err = tx.Where("uuid = ?", uuid).Delete(&model.Order{}).Error
+	return ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("order_no = ?", orderNo).Delete(&model.Order{}).Error
 		if err != nil {
 			ctx.Logger.Error("Failed to delete order", err)
 			tx.Rollback()
 			return errors.New("failed to delete order")
 		}
 
+		err = s.audit(ctx, tx, orderNo, model.OrderAuditDelete, operator, order, nil)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
 		return nil
 	})
-
-	if err != nil {
-		return err
-	}
-
-	return nil
 }
 
 // 根据订单号列表获取订单物品列表
@@ -0,0 +1,695 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 订单商品数量快照，用于修改记录
+type orderItemsSnapshot struct {
+	TotalAmount float64       `json:"total_amount"`
+	Items       map[int64]int `json:"items"`
+}
+
+// UpdateOrderReceiver 发货前修改收货信息
+func (s *OrderService) UpdateOrderReceiver(ctx *app.Context, params *model.ReqOrderReceiverUpdate, operator string) error {
+	return ctx.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := s.lockEditableOrder(ctx, tx, params.OrderNo, model.OrderStatusPending, model.OrderStatusPaid)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		before := s.orderReceiver(order)
+		receiver := params.Receiver
+		if !order.IsVirtual && receiver.ReceiverAddress == "" {
+			tx.Rollback()
+			return errors.New("receiver address is required")
+		}
+
+		err = tx.Model(&model.Order{}).Where("order_no = ?", order.OrderNo).Updates(map[string]interface{}{
+			"receiver_name":     receiver.ReceiverName,
+			"receiver_phone":    receiver.ReceiverPhone,
+			"receiver_email":    receiver.ReceiverEmail,
+			"receiver_country":  receiver.ReceiverCountry,
+			"receiver_province": receiver.ReceiverProvince,
+			"receiver_city":     receiver.ReceiverCity,
+			"receiver_address":  receiver.ReceiverAddress,
+			"receiver_zip":      receiver.ReceiverZip,
+			"receiver_remark":   receiver.ReceiverRemark,
+			"updated_at":        time.Now().Format(time.DateTime),
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update order receiver", err)
+			tx.Rollback()
+			return errors.New("failed to update order receiver")
+		}
+
+		err = s.audit(ctx, tx, order.OrderNo, model.OrderAuditUpdateReceiver, operator, before, receiver)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		return nil
+	})
+}
+
+// UpdateOrderItems 支付前修改商品数量，按下单时的实付单价重新计算金额，并调整库存
+// 待支付的付款会被取消，买家需要按新金额重新支付，已支付订单的金额已经扣款，不能修改商品
+func (s *OrderService) UpdateOrderItems(ctx *app.Context, params *model.ReqOrderItemsUpdate, operator string) error {
+	inventoryLogs := make([]*model.InventoryLog, 0)
+	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := s.lockEditableOrder(ctx, tx, params.OrderNo, model.OrderStatusPending)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		orderItems, err := s.getOrderItems(ctx, tx, order.OrderNo)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		before := s.itemsSnapshot(order.TotalAmount, orderItems)
+
+		itemMap := make(map[int64]*model.OrderItem)
+		for _, item := range orderItems {
+			itemMap[item.ID] = item
+		}
+
+		// 数量变化，正数为增加，用于扣减库存
+		changes := make([]*model.OrderItem, 0)
+		now := time.Now().Format(time.DateTime)
+		for _, param := range params.Items {
+			item, ok := itemMap[param.OrderItemID]
+			if !ok {
+				tx.Rollback()
+				return errors.New("order item not found")
+			}
+			if param.Quantity < 0 {
+				tx.Rollback()
+				return errors.New("quantity can not be negative")
+			}
+			if param.Quantity == item.Quantity {
+				continue
+			}
+
+			changes = append(changes, &model.OrderItem{
+				ProductItemID: item.ProductItemID,
+				Quantity:      param.Quantity - item.Quantity,
+			})
+
+			if param.Quantity == 0 {
+				err = tx.Where("id = ?", item.ID).Delete(&model.OrderItem{}).Error
+				delete(itemMap, item.ID)
+			} else {
+				s.resizeOrderItem(item, param.Quantity)
+				err = tx.Model(&model.OrderItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+					"quantity":        item.Quantity,
+					"total_amount":    item.TotalAmount,
+					"discount_amount": item.DiscountAmount,
+					"updated_at":      now,
+				}).Error
+			}
+			if err != nil {
+				ctx.Logger.Error("Failed to update order item", err)
+				tx.Rollback()
+				return errors.New("failed to update order item")
+			}
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		if len(itemMap) == 0 {
+			tx.Rollback()
+			return errors.New("order must keep at least one item")
+		}
+
+		remaining := make([]*model.OrderItem, 0)
+		for _, item := range orderItems {
+			if _, ok := itemMap[item.ID]; ok {
+				remaining = append(remaining, item)
+			}
+		}
+		total := s.sumOrderItems(remaining)
+
+		inventoryLogs, err = s.deductStock(ctx, tx, order, changes)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		err = tx.Model(&model.Order{}).Where("order_no = ?", order.OrderNo).Updates(map[string]interface{}{
+			"total_amount": total,
+			"updated_at":   now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update order amount", err)
+			tx.Rollback()
+			return errors.New("failed to update order amount")
+		}
+
+		err = tx.Model(&model.Payment{}).Where("order_id = ? AND status = ?", order.OrderNo, model.PaymentStatusPending).Updates(map[string]interface{}{
+			"status":     model.PaymentStatusCanceled,
+			"updated_at": now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to cancel payment", err)
+			tx.Rollback()
+			return errors.New("failed to cancel payment")
+		}
+
+		err = s.audit(ctx, tx, order.OrderNo, model.OrderAuditUpdateItems, operator, before, s.itemsSnapshot(total, remaining))
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	NewInventoryService().NotifyLowStock(ctx, inventoryLogs)
+	return nil
+}
+
+// SplitOrder 拆分已支付未发货的订单，指定的商品数量移到新订单，付款金额一起拆分
+func (s *OrderService) SplitOrder(ctx *app.Context, params *model.ReqOrderSplit, operator string) (*model.Order, error) {
+	// 在事务外生成订单号，避免持有订单锁时等待序列
+	orderNo, err := s.NewOrderNo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var newOrder *model.Order
+	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
+		order, err := s.lockEditableOrder(ctx, tx, params.OrderNo, model.OrderStatusPaid)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		orderItems, err := s.getOrderItems(ctx, tx, order.OrderNo)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		before := s.itemsSnapshot(order.TotalAmount, orderItems)
+
+		itemMap := make(map[int64]*model.OrderItem)
+		for _, item := range orderItems {
+			itemMap[item.ID] = item
+		}
+
+		now := time.Now().Format(time.DateTime)
+		newOrder = &model.Order{}
+		*newOrder = *order
+		newOrder.ID = 0
+		newOrder.Uuid = uuid.New().String()
+		newOrder.OrderNo = orderNo
+		newOrder.SplitFrom = order.OrderNo
+		newOrder.TotalAmount = 0
+		newOrder.CreatedAt = now
+		newOrder.UpdatedAt = now
+
+		movedItems := make([]*model.OrderItem, 0)
+		for _, param := range params.Items {
+			item, ok := itemMap[param.OrderItemID]
+			if !ok {
+				tx.Rollback()
+				return errors.New("order item not found")
+			}
+			if param.Quantity <= 0 || param.Quantity > item.Quantity {
+				tx.Rollback()
+				return errors.New("invalid split quantity")
+			}
+
+			moved := &model.OrderItem{}
+			*moved = *item
+			moved.ID = 0
+			moved.OrderID = newOrder.OrderNo
+			moved.CreatedAt = now
+			moved.UpdatedAt = now
+			s.resizeOrderItem(moved, param.Quantity)
+			movedItems = append(movedItems, moved)
+
+			if param.Quantity == item.Quantity {
+				err = tx.Where("id = ?", item.ID).Delete(&model.OrderItem{}).Error
+				delete(itemMap, item.ID)
+			} else {
+				s.resizeOrderItem(item, item.Quantity-param.Quantity)
+				err = tx.Model(&model.OrderItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+					"quantity":        item.Quantity,
+					"total_amount":    item.TotalAmount,
+					"discount_amount": item.DiscountAmount,
+					"updated_at":      now,
+				}).Error
+			}
+			if err != nil {
+				ctx.Logger.Error("Failed to update order item", err)
+				tx.Rollback()
+				return errors.New("failed to update order item")
+			}
+		}
+		if len(movedItems) == 0 {
+			tx.Rollback()
+			return errors.New("split items are required")
+		}
+		if len(itemMap) == 0 {
+			tx.Rollback()
+			return errors.New("order must keep at least one item")
+		}
+
+		remaining := make([]*model.OrderItem, 0)
+		for _, item := range orderItems {
+			if _, ok := itemMap[item.ID]; ok {
+				remaining = append(remaining, item)
+			}
+		}
+		total := s.sumOrderItems(remaining)
+		newOrder.TotalAmount = s.sumOrderItems(movedItems)
+
+		err = tx.Create(newOrder).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create split order", err)
+			tx.Rollback()
+			return errors.New("failed to create split order")
+		}
+
+		err = tx.Create(&movedItems).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create split order items", err)
+			tx.Rollback()
+			return errors.New("failed to create split order items")
+		}
+
+		err = tx.Model(&model.Order{}).Where("order_no = ?", order.OrderNo).Updates(map[string]interface{}{
+			"total_amount": total,
+			"updated_at":   now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update order amount", err)
+			tx.Rollback()
+			return errors.New("failed to update order amount")
+		}
+
+		err = s.splitPayment(ctx, tx, order.OrderNo, newOrder.OrderNo, newOrder.TotalAmount)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		err = s.audit(ctx, tx, order.OrderNo, model.OrderAuditSplit, operator, before, s.itemsSnapshot(total, remaining))
+		if err == nil {
+			err = s.audit(ctx, tx, newOrder.OrderNo, model.OrderAuditSplit, operator, order.OrderNo, s.itemsSnapshot(newOrder.TotalAmount, movedItems))
+		}
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrder, nil
+}
+
+// MergeOrders 合并同一买家已支付未发货的订单，商品和付款移到目标订单，被合并的订单关闭
+func (s *OrderService) MergeOrders(ctx *app.Context, params *model.ReqOrderMerge, operator string) (*model.Order, error) {
+	var target *model.Order
+	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		target, err = s.lockEditableOrder(ctx, tx, params.TargetOrderNo, model.OrderStatusPaid)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		now := time.Now().Format(time.DateTime)
+		merged := make([]string, 0)
+		total := target.TotalAmount
+		for _, orderNo := range params.OrderNos {
+			if orderNo == target.OrderNo {
+				continue
+			}
+
+			order, err := s.lockEditableOrder(ctx, tx, orderNo, model.OrderStatusPaid)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+			if order.UserID != target.UserID {
+				tx.Rollback()
+				return errors.New("orders belong to different buyers")
+			}
+			if order.IsVirtual != target.IsVirtual {
+				tx.Rollback()
+				return errors.New("virtual and physical orders can not be merged")
+			}
+
+			err = tx.Model(&model.OrderItem{}).Where("order_id = ?", order.OrderNo).Updates(map[string]interface{}{
+				"order_id":   target.OrderNo,
+				"updated_at": now,
+			}).Error
+			if err != nil {
+				ctx.Logger.Error("Failed to move order items", err)
+				tx.Rollback()
+				return errors.New("failed to move order items")
+			}
+
+			err = tx.Model(&model.Payment{}).Where("order_id = ?", order.OrderNo).Updates(map[string]interface{}{
+				"order_id":   target.OrderNo,
+				"updated_at": now,
+			}).Error
+			if err != nil {
+				ctx.Logger.Error("Failed to move payments", err)
+				tx.Rollback()
+				return errors.New("failed to move payments")
+			}
+
+			err = tx.Model(&model.Order{}).Where("order_no = ?", order.OrderNo).Updates(map[string]interface{}{
+				"status":       model.OrderStatusClosed,
+				"merged_into":  target.OrderNo,
+				"total_amount": 0,
+				"closed_at":    now,
+				"updated_at":   now,
+			}).Error
+			if err != nil {
+				ctx.Logger.Error("Failed to close merged order", err)
+				tx.Rollback()
+				return errors.New("failed to close merged order")
+			}
+
+			err = s.audit(ctx, tx, order.OrderNo, model.OrderAuditMerge, operator, order.TotalAmount, target.OrderNo)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+
+			total += order.TotalAmount
+			merged = append(merged, order.OrderNo)
+		}
+		if len(merged) == 0 {
+			tx.Rollback()
+			return errors.New("no orders to merge")
+		}
+
+		total = math.Round(total*100) / 100
+		err = tx.Model(&model.Order{}).Where("order_no = ?", target.OrderNo).Updates(map[string]interface{}{
+			"total_amount": total,
+			"updated_at":   now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update order amount", err)
+			tx.Rollback()
+			return errors.New("failed to update order amount")
+		}
+
+		err = s.audit(ctx, tx, target.OrderNo, model.OrderAuditMerge, operator, target.TotalAmount, map[string]interface{}{
+			"total_amount": total,
+			"merged":       merged,
+		})
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		target.TotalAmount = total
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// AddOrderNote 添加订单备注，买家可见的备注会在前台订单中展示
+func (s *OrderService) AddOrderNote(ctx *app.Context, params *model.ReqOrderNoteCreate, operator string) (*model.OrderNote, error) {
+	if _, err := s.GetOrderByID(ctx, params.OrderNo); err != nil {
+		return nil, err
+	}
+
+	note := &model.OrderNote{
+		Uuid:      uuid.New().String(),
+		OrderNo:   params.OrderNo,
+		Content:   params.Content,
+		IsPublic:  params.IsPublic,
+		Operator:  operator,
+		CreatedAt: time.Now().Format(time.DateTime),
+	}
+
+	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(note).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create order note", err)
+			tx.Rollback()
+			return errors.New("failed to create order note")
+		}
+
+		err = s.audit(ctx, tx, note.OrderNo, model.OrderAuditNote, operator, nil, note)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+// GetOrderNotes 获取订单备注，userId不为空时只返回该买家订单的公开备注
+func (s *OrderService) GetOrderNotes(ctx *app.Context, orderNo, userId string) ([]*model.OrderNote, error) {
+	db := ctx.DB.Where("order_no = ?", orderNo)
+	if userId != "" {
+		order, err := s.GetOrderByID(ctx, orderNo)
+		if err != nil {
+			return nil, err
+		}
+		if order.UserID != userId {
+			return nil, errors.New("order not found")
+		}
+		db = db.Where("is_public = ?", true)
+	}
+
+	notes := make([]*model.OrderNote, 0)
+	err := db.Order("id ASC").Find(&notes).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get order notes", err)
+		return nil, errors.New("failed to get order notes")
+	}
+	return notes, nil
+}
+
+// GetOrderAuditList 获取订单修改记录
+func (s *OrderService) GetOrderAuditList(ctx *app.Context, params *model.ReqOrderAuditQueryParam) (*model.PagedResponse, error) {
+	var (
+		audits []*model.OrderAudit
+		total  int64
+	)
+
+	db := ctx.DB.Model(&model.OrderAudit{})
+	if params.OrderNo != "" {
+		db = db.Where("order_no = ?", params.OrderNo)
+	}
+	if params.Action != "" {
+		db = db.Where("action = ?", params.Action)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get order audit count", err)
+		return nil, errors.New("failed to get order audit count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&audits).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get order audit list", err)
+		return nil, errors.New("failed to get order audit list")
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     audits,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// 锁定可修改的订单：状态符合、未发货且未开票
+func (s *OrderService) lockEditableOrder(ctx *app.Context, tx *gorm.DB, orderNo string, statuses ...string) (*model.Order, error) {
+	order := &model.Order{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_no = ?", orderNo).First(order).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("order not found")
+		}
+		ctx.Logger.Error("Failed to get order by order no", err)
+		return nil, errors.New("failed to get order by order no")
+	}
+
+	allowed := false
+	for _, status := range statuses {
+		if order.Status == status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, errors.New("order status " + order.Status + " can not be edited")
+	}
+
+	var count int64
+	err = tx.Model(&model.Shipment{}).Where("order_no = ?", orderNo).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to count shipments", err)
+		return nil, errors.New("failed to count shipments")
+	}
+	if count > 0 {
+		return nil, errors.New("order has been shipped")
+	}
+
+	err = tx.Model(&model.Invoice{}).Where("order_no = ?", orderNo).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to count invoices", err)
+		return nil, errors.New("failed to count invoices")
+	}
+	if count > 0 {
+		return nil, errors.New("order has been invoiced")
+	}
+
+	return order, nil
+}
+
+func (s *OrderService) getOrderItems(ctx *app.Context, tx *gorm.DB, orderNo string) ([]*model.OrderItem, error) {
+	items := make([]*model.OrderItem, 0)
+	err := tx.Where("order_id = ?", orderNo).Order("id ASC").Find(&items).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get order items", err)
+		return nil, errors.New("failed to get order items")
+	}
+	return items, nil
+}
+
+// 按原实付单价调整订单商品数量
+func (s *OrderService) resizeOrderItem(item *model.OrderItem, quantity int) {
+	unitPrice := item.Price
+	if item.Quantity > 0 {
+		unitPrice = item.TotalAmount / float64(item.Quantity)
+	}
+	item.Quantity = quantity
+	item.TotalAmount = math.Round(unitPrice*float64(quantity)*100) / 100
+	item.DiscountAmount = math.Round((item.Price-unitPrice)*float64(quantity)*100) / 100
+}
+
+func (s *OrderService) sumOrderItems(items []*model.OrderItem) float64 {
+	var total float64
+	for _, item := range items {
+		total += item.TotalAmount
+	}
+	return math.Round(total*100) / 100
+}
+
+// 拆分已支付的付款记录，新订单的付款沿用原渠道交易号
+func (s *OrderService) splitPayment(ctx *app.Context, tx *gorm.DB, orderNo, newOrderNo string, amount float64) error {
+	payment := &model.Payment{}
+	err := tx.Where("order_id = ? AND status = ?", orderNo, model.PaymentStatusPaid).Order("amount DESC").First(payment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		ctx.Logger.Error("Failed to get payment by order", err)
+		return errors.New("failed to get payment by order")
+	}
+
+	now := time.Now().Format(time.DateTime)
+	err = tx.Model(&model.Payment{}).Where("id = ?", payment.ID).Updates(map[string]interface{}{
+		"amount":     math.Round((payment.Amount-amount)*100) / 100,
+		"updated_at": now,
+	}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to update payment amount", err)
+		return errors.New("failed to update payment amount")
+	}
+
+	payment.ID = 0
+	payment.Uuid = uuid.New().String()
+	payment.OrderID = newOrderNo
+	payment.Amount = amount
+	payment.CreatedAt = now
+	payment.UpdatedAt = now
+	err = tx.Create(payment).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create split payment", err)
+		return errors.New("failed to create split payment")
+	}
+	return nil
+}
+
+func (s *OrderService) itemsSnapshot(total float64, items []*model.OrderItem) *orderItemsSnapshot {
+	snapshot := &orderItemsSnapshot{
+		TotalAmount: total,
+		Items:       make(map[int64]int),
+	}
+	for _, item := range items {
+		snapshot.Items[item.ID] = item.Quantity
+	}
+	return snapshot
+}
+
+func (s *OrderService) orderReceiver(order *model.Order) model.OrderReceiver {
+	return model.OrderReceiver{
+		ReceiverName:     order.ReceiverName,
+		ReceiverPhone:    order.ReceiverPhone,
+		ReceiverEmail:    order.ReceiverEmail,
+		ReceiverCountry:  order.ReceiverCountry,
+		ReceiverProvince: order.ReceiverProvince,
+		ReceiverCity:     order.ReceiverCity,
+		ReceiverAddress:  order.ReceiverAddress,
+		ReceiverZip:      order.ReceiverZip,
+		ReceiverRemark:   order.ReceiverRemark,
+	}
+}
+
+// 记录订单修改
+func (s *OrderService) audit(ctx *app.Context, tx *gorm.DB, orderNo, action, operator string, before, after interface{}) error {
+	record := &model.OrderAudit{
+		OrderNo:   orderNo,
+		Action:    action,
+		Operator:  operator,
+		CreatedAt: time.Now().Format(time.DateTime),
+	}
+	if before != nil {
+		b, _ := json.Marshal(before)
+		record.Before = string(b)
+	}
+	if after != nil {
+		b, _ := json.Marshal(after)
+		record.After = string(b)
+	}
+
+	err := tx.Create(record).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create order audit", err)
+		return errors.New("failed to create order audit")
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/testutil"
+)
+
+// 只有未发货、未开票的待支付订单可以修改商品数量，修改后重新计算金额并调整库存
+func TestUpdateOrderItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		shipped  bool
+		invoiced bool
+		wantErr  string
+	}{
+		{"pending", model.OrderStatusPending, false, false, ""},
+		{"paid", model.OrderStatusPaid, false, false, "order status paid can not be edited"},
+		{"delivered", model.OrderStatusDelivered, false, false, "order status delivered can not be edited"},
+		{"closed", model.OrderStatusClosed, false, false, "order status closed can not be edited"},
+		{"pending shipped", model.OrderStatusPending, true, false, "order has been shipped"},
+		{"pending invoiced", model.OrderStatusPending, false, true, "order has been invoiced"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			item := &model.OrderItem{OrderID: "SG1", ProductItemID: "item1", Quantity: 2, Price: 30, TotalAmount: 50,
+				CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}
+			rows := []interface{}{
+				&model.ProductItem{Uuid: "item1", Stock: 10, Price: 30, CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+				&model.Order{OrderNo: "SG1", UserID: "customer1", Status: tt.status, TotalAmount: 50,
+					CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+				item,
+			}
+			if tt.shipped {
+				rows = append(rows, &model.Shipment{Uuid: "ship1", OrderNo: "SG1", CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"})
+			}
+			if tt.invoiced {
+				rows = append(rows, &model.Invoice{Uuid: "inv1", OrderNo: "SG1", CreatedAt: "2026-01-01 00:00:00"})
+			}
+			for _, row := range rows {
+				err := ctx.DB.Create(row).Error
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := NewOrderService().UpdateOrderItems(ctx, &model.ReqOrderItemsUpdate{OrderNo: "SG1",
+				Items: []*model.ReqOrderItemQuantity{{OrderItemID: item.ID, Quantity: 3}}}, "admin")
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Fatalf("err = %q, want %q", got, tt.wantErr)
+			}
+
+			// 失败时订单和库存保持不变
+			wantQuantity, wantTotal, wantStock := 2, 50.0, int64(10)
+			if err == nil {
+				wantQuantity, wantTotal, wantStock = 3, 75, 9
+			}
+			saved := &model.OrderItem{}
+			err = ctx.DB.Where("id = ?", item.ID).First(saved).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+			order := &model.Order{}
+			err = ctx.DB.Where("order_no = ?", "SG1").First(order).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+			productItem := &model.ProductItem{}
+			err = ctx.DB.Where("uuid = ?", "item1").First(productItem).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.Quantity != wantQuantity || order.TotalAmount != wantTotal || productItem.Stock != wantStock {
+				t.Errorf("quantity = %d, total = %v, stock = %d, want %d, %v, %d",
+					saved.Quantity, order.TotalAmount, productItem.Stock, wantQuantity, wantTotal, wantStock)
+			}
+		})
+	}
+}
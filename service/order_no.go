@@ -0,0 +1,194 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/tenant"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// 订单号序号规则
+	OrderNoSequenceDaily  = "daily"
+	OrderNoSequenceGlobal = "global"
+
+	orderNoSeqKeyPrefix = "sgin:order_no:seq:"
+)
+
+// NewOrderNo 生成订单编号，格式为 前缀-日期-序号，例如 SG-20261017-000123
+// 配置了redis时使用INCR生成序号，否则使用数据库序列表加行锁，多实例部署时不会重复
+func (s *OrderService) NewOrderNo(ctx *app.Context) (string, error) {
+	conf := ctx.Config.OrderNo
+	now := time.Now()
+
+	dateFormat := conf.DateFormat
+	if conf.Sequence != OrderNoSequenceGlobal && dateFormat == "" {
+		// 按天重置序号时订单号必须包含日期
+		dateFormat = "20060102"
+	}
+
+	parts := make([]string, 0)
+	if conf.Prefix != "" {
+		parts = append(parts, conf.Prefix)
+	}
+	if dateFormat != "" {
+		parts = append(parts, now.Format(dateFormat))
+	}
+	base := strings.Join(parts, "-")
+
+	// 序列名称，按天重置时为日期，否则为global
+	name := OrderNoSequenceGlobal
+	like := base + "-%"
+	if conf.Sequence != OrderNoSequenceGlobal {
+		name = now.Format(dateFormat)
+	} else if conf.Prefix != "" {
+		like = conf.Prefix + "-%"
+	} else {
+		like = "%"
+	}
+
+	var (
+		seq int64
+		err error
+	)
+	if ctx.Redis != nil {
+		seq, err = s.nextOrderSeqByRedis(ctx, name, like, conf.Sequence != OrderNoSequenceGlobal)
+	} else {
+		seq, err = s.nextOrderSeqByDB(ctx, name, like)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	length := conf.SeqLength
+	if length <= 0 {
+		length = 6
+	}
+	seqStr := fmt.Sprintf("%0*d", length, seq)
+	if base == "" {
+		return seqStr, nil
+	}
+	return base + "-" + seqStr, nil
+}
+
+func (s *OrderService) nextOrderSeqByRedis(ctx *app.Context, name, like string, daily bool) (int64, error) {
+	key := orderNoSeqKeyPrefix + name
+
+	exists, err := ctx.Redis.Exists(ctx.Ctx, key)
+	if err != nil {
+		ctx.Logger.Error("Failed to check order no sequence", err)
+		return 0, errors.New("failed to generate order no")
+	}
+	if !exists {
+		// redis数据丢失或首次使用时，从已有订单的最大序号继续，避免重复
+		last, err := s.lastOrderSeq(ctx, ctx.DB, like)
+		if err != nil {
+			return 0, err
+		}
+		expiration := time.Duration(0)
+		if daily {
+			expiration = 48 * time.Hour
+		}
+		// 多个实例同时初始化时只有一个会成功，其余直接自增
+		_, err = ctx.Redis.SetNX(ctx.Ctx, key, strconv.FormatInt(last, 10), expiration)
+		if err != nil {
+			ctx.Logger.Error("Failed to init order no sequence", err)
+			return 0, errors.New("failed to generate order no")
+		}
+	}
+
+	seq, err := ctx.Redis.Incr(ctx.Ctx, key)
+	if err != nil {
+		ctx.Logger.Error("Failed to incr order no sequence", err)
+		return 0, errors.New("failed to generate order no")
+	}
+	return seq, nil
+}
+
+func (s *OrderService) nextOrderSeqByDB(ctx *app.Context, name, like string) (int64, error) {
+	var seq int64
+	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+		sequence := &model.OrderNoSequence{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(sequence).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.Logger.Error("Failed to lock order no sequence", err)
+			tx.Rollback()
+			return errors.New("failed to lock order no sequence")
+		}
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 首次使用时从已有订单的最大序号继续
+			last, err := s.lastOrderSeq(ctx, tx, like)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+			err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.OrderNoSequence{
+				Name:      name,
+				LastSeq:   last,
+				UpdatedAt: time.Now().Format(time.DateTime),
+			}).Error
+			if err != nil {
+				ctx.Logger.Error("Failed to create order no sequence", err)
+				tx.Rollback()
+				return errors.New("failed to create order no sequence")
+			}
+
+			err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(sequence).Error
+			if err != nil {
+				ctx.Logger.Error("Failed to lock order no sequence", err)
+				tx.Rollback()
+				return errors.New("failed to lock order no sequence")
+			}
+		}
+
+		seq = sequence.LastSeq + 1
+		err = tx.Model(&model.OrderNoSequence{}).Where("name = ?", name).Updates(map[string]interface{}{
+			"last_seq":   seq,
+			"updated_at": time.Now().Format(time.DateTime),
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update order no sequence", err)
+			tx.Rollback()
+			return errors.New("failed to update order no sequence")
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// lastOrderSeq 已有订单中匹配规则的最大序号，包括已删除的订单和其他租户的订单，订单编号全局唯一
+func (s *OrderService) lastOrderSeq(ctx *app.Context, db *gorm.DB, like string) (int64, error) {
+	orderNos := make([]string, 0)
+	err := db.Scopes(tenant.Global).Unscoped().Model(&model.Order{}).Where("order_no LIKE ?", like).
+		Order("LENGTH(order_no) DESC, order_no DESC").Limit(1).Pluck("order_no", &orderNos).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get last order no", err)
+		return 0, errors.New("failed to get last order no")
+	}
+	if len(orderNos) == 0 {
+		return 0, nil
+	}
+
+	orderNo := orderNos[0]
+	if i := strings.LastIndex(orderNo, "-"); i >= 0 {
+		orderNo = orderNo[i+1:]
+	}
+	// 旧订单编号为uuid，无法解析时从0开始
+	seq, err := strconv.ParseInt(orderNo, 10, 64)
+	if err != nil {
+		return 0, nil
+	}
+	return seq, nil
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"testing"
+
+	"sgin/pkg/testutil"
+)
+
+// 没有配置redis时使用数据库序列，首次生成时创建序列
+func TestNewOrderNoByDB(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	ctx.Config.OrderNo.Prefix = "SG"
+	ctx.Config.OrderNo.Sequence = OrderNoSequenceGlobal
+
+	for _, want := range []string{"SG-000001", "SG-000002"} {
+		orderNo, err := NewOrderService().NewOrderNo(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if orderNo != want {
+			t.Errorf("order no = %s, want %s", orderNo, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/app"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+func TestCheckItemQuantity(t *testing.T) {
+	for _, quantity := range []int{-1, 0} {
+		if err := checkItemQuantity(quantity); err == nil {
+			t.Errorf("checkItemQuantity(%d) should fail", quantity)
+		}
+	}
+	if err := checkItemQuantity(1); err != nil {
+		t.Errorf("checkItemQuantity(1) = %v", err)
+	}
+}
+
+func TestCreateOrderRejectsNonPositiveQuantity(t *testing.T) {
+	req := &model.ReqOrderCreate{
+		Items: []model.ReqOrderItemCreate{
+			{ProductItemID: "item1", Quantity: 1},
+			{ProductItemID: "item2", Quantity: -3},
+		},
+	}
+
+	// 校验在生成订单号和查询数据库之前
+	_, err := NewOrderService().CreateOrder(&app.Context{}, req)
+	if err == nil {
+		t.Fatal("negative quantity should be rejected")
+	}
+
+	err = binding.Validator.ValidateStruct(req)
+	if err == nil {
+		t.Fatal("negative quantity should fail binding validation")
+	}
+}
@@ -69,6 +69,7 @@ func (s *PermissionService) UpdatePermission(ctx *app.Context, permission *model
 		return errors.New("failed to update permission")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -80,6 +81,7 @@ func (s *PermissionService) DeletePermission(ctx *app.Context, uuid string) erro
 		return errors.New("failed to delete permission")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
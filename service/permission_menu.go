@@ -55,6 +55,7 @@ func (s *PermissionMenuService) CreatePermissionMenu(ctx *app.Context, permissio
 		return err
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -82,6 +83,7 @@ func (s *PermissionMenuService) UpdatePermissionMenu(ctx *app.Context, permissio
 		return errors.New("failed to update permission menu")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -93,6 +95,7 @@ func (s *PermissionMenuService) DeletePermissionMenu(ctx *app.Context, uuid stri
 		return errors.New("failed to delete permission menu")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
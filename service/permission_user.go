@@ -54,6 +54,7 @@ func (s *UserPermissionService) CreateUserPermission(ctx *app.Context, userPermi
 	if err != nil {
 		return err
 	}
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -81,6 +82,7 @@ func (s *UserPermissionService) UpdateUserPermission(ctx *app.Context, userPermi
 		return errors.New("failed to update user permission")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -92,6 +94,7 @@ func (s *UserPermissionService) DeleteUserPermission(ctx *app.Context, uuid stri
 		return errors.New("failed to delete user permission")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PriceService struct {
+}
+
+func NewPriceService() *PriceService {
+	return &PriceService{}
+}
+
+// 生效中的价格表商品价格
+type activePrice struct {
+	ProductItemUuid string
+	Price           float64
+	EndAt           string
+}
+
+// ResolvePrices 计算SKU当前的折扣价，前台展示和下单共用
+// 候选价格为SKU自身的折扣价、所有客户可用的促销价和用户所在分组的价格，取最低价
+func (s *PriceService) ResolvePrices(ctx *app.Context, userId string, items []*model.ProductItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	itemUuids := make([]string, 0)
+	for _, item := range items {
+		itemUuids = append(itemUuids, item.Uuid)
+	}
+
+	groupUuids := make([]string, 0)
+	if userId != "" {
+		err := ctx.DB.Model(&model.CustomerGroupUser{}).Where("user_id = ?", userId).Pluck("group_uuid", &groupUuids).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get customer groups by user", err)
+			return errors.New("failed to get customer groups by user")
+		}
+	}
+
+	now := time.Now().Format(time.DateTime)
+	query := ctx.DB.Model(&model.PriceListItem{}).
+		Select("price_list_items.product_item_uuid, price_list_items.price, price_lists.end_at").
+		Joins("JOIN price_lists ON price_lists.uuid = price_list_items.price_list_uuid").
+		Where("price_list_items.product_item_uuid IN (?)", itemUuids).
+		Where("price_lists.status = ?", model.PriceListStatusEnabled).
+		Where("price_lists.start_at = '' OR price_lists.start_at <= ?", now).
+		Where("price_lists.end_at = '' OR price_lists.end_at >= ?", now)
+	if len(groupUuids) > 0 {
+		query = query.Where("price_lists.customer_group_uuid = '' OR price_lists.customer_group_uuid IN (?)", groupUuids)
+	} else {
+		query = query.Where("price_lists.customer_group_uuid = ''")
+	}
+
+	prices := make([]*activePrice, 0)
+	err := query.Scan(&prices).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get active prices", err)
+		return errors.New("failed to get active prices")
+	}
+	if len(prices) == 0 {
+		return nil
+	}
+
+	priceMap := make(map[string][]*activePrice)
+	for _, price := range prices {
+		priceMap[price.ProductItemUuid] = append(priceMap[price.ProductItemUuid], price)
+	}
+
+	for _, item := range items {
+		best := item.SalePrice()
+		var winner *activePrice
+		for _, price := range priceMap[item.Uuid] {
+			if price.Price > 0 && price.Price < best {
+				best = price.Price
+				winner = price
+			}
+		}
+		if winner == nil {
+			continue
+		}
+
+		item.DiscountPrice = best
+		item.Discount = math.Round(best/item.Price*100) / 100
+		item.DiscountEndAt = winner.EndAt
+	}
+
+	return nil
+}
+
+// ResolveItemResPrices 计算SKU详情列表的折扣价
+func (s *PriceService) ResolveItemResPrices(ctx *app.Context, userId string, items []*model.ProductItemRes) error {
+	productItems := make([]*model.ProductItem, 0)
+	for _, item := range items {
+		if item != nil {
+			productItems = append(productItems, &item.ProductItem)
+		}
+	}
+	return s.ResolvePrices(ctx, userId, productItems)
+}
+
+// CreatePriceList 创建价格表
+func (s *PriceService) CreatePriceList(ctx *app.Context, params *model.ReqPriceListCreate) (*model.PriceList, error) {
+	if err := s.validPeriod(params.StartAt, params.EndAt); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Format(time.DateTime)
+	priceList := &model.PriceList{
+		Uuid:              uuid.New().String(),
+		Name:              params.Name,
+		CustomerGroupUuid: params.CustomerGroupUuid,
+		StartAt:           params.StartAt,
+		EndAt:             params.EndAt,
+		Status:            model.PriceListStatusEnabled,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(priceList).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create price list", err)
+			tx.Rollback()
+			return errors.New("failed to create price list")
+		}
+
+		err = s.createPriceListItems(ctx, tx, priceList.Uuid, params.Items)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return priceList, nil
+}
+
+// UpdatePriceList 更新价格表，商品价格不为空时整体替换
+func (s *PriceService) UpdatePriceList(ctx *app.Context, params *model.ReqPriceListUpdate) error {
+	priceList, err := s.getPriceList(ctx, params.Uuid)
+	if err != nil {
+		return err
+	}
+
+	if params.StartAt != "" {
+		priceList.StartAt = params.StartAt
+	}
+	if params.EndAt != "" {
+		priceList.EndAt = params.EndAt
+	}
+	if err := s.validPeriod(priceList.StartAt, priceList.EndAt); err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"start_at":            priceList.StartAt,
+		"end_at":              priceList.EndAt,
+		"customer_group_uuid": params.CustomerGroupUuid,
+		"updated_at":          time.Now().Format(time.DateTime),
+	}
+	if params.Name != "" {
+		updates["name"] = params.Name
+	}
+	if params.Status == model.PriceListStatusEnabled || params.Status == model.PriceListStatusDisabled {
+		updates["status"] = params.Status
+	}
+
+	return ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.PriceList{}).Where("uuid = ?", priceList.Uuid).Updates(updates).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update price list", err)
+			tx.Rollback()
+			return errors.New("failed to update price list")
+		}
+
+		if len(params.Items) == 0 {
+			return nil
+		}
+
+		err = tx.Where("price_list_uuid = ?", priceList.Uuid).Delete(&model.PriceListItem{}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to delete price list items", err)
+			tx.Rollback()
+			return errors.New("failed to delete price list items")
+		}
+
+		err = s.createPriceListItems(ctx, tx, priceList.Uuid, params.Items)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		return nil
+	})
+}
+
+// DeletePriceList 删除价格表
+func (s *PriceService) DeletePriceList(ctx *app.Context, priceListUuid string) error {
+	return ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("price_list_uuid = ?", priceListUuid).Delete(&model.PriceListItem{}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to delete price list items", err)
+			tx.Rollback()
+			return errors.New("failed to delete price list items")
+		}
+
+		err = tx.Where("uuid = ?", priceListUuid).Delete(&model.PriceList{}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to delete price list", err)
+			tx.Rollback()
+			return errors.New("failed to delete price list")
+		}
+
+		return nil
+	})
+}
+
+// GetPriceListInfo 获取价格表及商品价格
+func (s *PriceService) GetPriceListInfo(ctx *app.Context, priceListUuid string) (*model.PriceListRes, error) {
+	priceList, err := s.getPriceList(ctx, priceListUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*model.PriceListItem, 0)
+	err = ctx.DB.Where("price_list_uuid = ?", priceList.Uuid).Find(&items).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get price list items", err)
+		return nil, errors.New("failed to get price list items")
+	}
+
+	return &model.PriceListRes{
+		PriceList: *priceList,
+		Items:     items,
+	}, nil
+}
+
+// GetPriceListList 获取价格表列表
+func (s *PriceService) GetPriceListList(ctx *app.Context, params *model.ReqPriceListQueryParam) (*model.PagedResponse, error) {
+	var (
+		priceLists []*model.PriceList
+		total      int64
+	)
+
+	db := ctx.DB.Model(&model.PriceList{})
+	if params.Name != "" {
+		db = db.Where("name LIKE ?", "%"+params.Name+"%")
+	}
+	if params.CustomerGroupUuid != "" {
+		db = db.Where("customer_group_uuid = ?", params.CustomerGroupUuid)
+	}
+	if params.Status > 0 {
+		db = db.Where("status = ?", params.Status)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get price list count", err)
+		return nil, errors.New("failed to get price list count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&priceLists).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get price list list", err)
+		return nil, errors.New("failed to get price list list")
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     priceLists,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+func (s *PriceService) getPriceList(ctx *app.Context, priceListUuid string) (*model.PriceList, error) {
+	priceList := &model.PriceList{}
+	err := ctx.DB.Where("uuid = ?", priceListUuid).First(priceList).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("price list not found")
+		}
+		ctx.Logger.Error("Failed to get price list by UUID", err)
+		return nil, errors.New("failed to get price list by UUID")
+	}
+	return priceList, nil
+}
+
+func (s *PriceService) createPriceListItems(ctx *app.Context, tx *gorm.DB, priceListUuid string, params []*model.ReqPriceListItem) error {
+	if len(params) == 0 {
+		return nil
+	}
+
+	now := time.Now().Format(time.DateTime)
+	items := make([]*model.PriceListItem, 0)
+	for _, param := range params {
+		if param.Price <= 0 {
+			return errors.New("price must be greater than 0")
+		}
+		items = append(items, &model.PriceListItem{
+			PriceListUuid:   priceListUuid,
+			ProductItemUuid: param.ProductItemUuid,
+			Price:           param.Price,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		})
+	}
+
+	err := tx.Create(&items).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create price list items", err)
+		return errors.New("failed to create price list items")
+	}
+	return nil
+}
+
+// 校验生效时间
+func (s *PriceService) validPeriod(startAt, endAt string) error {
+	for _, t := range []string{startAt, endAt} {
+		if t == "" {
+			continue
+		}
+		if _, err := time.ParseInLocation(time.DateTime, t, time.Local); err != nil {
+			return errors.New("invalid time format, expected " + time.DateTime)
+		}
+	}
+	if startAt != "" && endAt != "" && endAt <= startAt {
+		return errors.New("end time must be after start time")
+	}
+	return nil
+}
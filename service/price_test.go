@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/testutil"
+)
+
+// 价格表：所有客户的促销价、分组价、已禁用、已过期和未开始的价格表各一个
+func setupPriceLists(t *testing.T, ctx *app.Context, future string) {
+	t.Helper()
+	past := time.Now().Add(-time.Hour).Format(time.DateTime)
+	lists := []struct {
+		list  model.PriceList
+		price float64
+	}{
+		{model.PriceList{Uuid: "promo", EndAt: future, Status: model.PriceListStatusEnabled}, 85},
+		{model.PriceList{Uuid: "vip", CustomerGroupUuid: "group-vip", Status: model.PriceListStatusEnabled}, 80},
+		{model.PriceList{Uuid: "disabled", Status: model.PriceListStatusDisabled}, 50},
+		{model.PriceList{Uuid: "expired", EndAt: past, Status: model.PriceListStatusEnabled}, 60},
+		{model.PriceList{Uuid: "upcoming", StartAt: future, Status: model.PriceListStatusEnabled}, 70},
+	}
+	for _, l := range lists {
+		list := l.list
+		list.CreatedAt = "2026-01-01 00:00:00"
+		list.UpdatedAt = "2026-01-01 00:00:00"
+		err := ctx.DB.Create(&list).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ctx.DB.Create(&model.PriceListItem{PriceListUuid: list.Uuid, ProductItemUuid: "sku1", Price: l.price,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := ctx.DB.Create(&model.CustomerGroupUser{GroupUuid: "group-vip", UserID: "vip-user", CreatedAt: "2026-01-01 00:00:00"}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 取SKU折扣价、促销价和分组价中的最低价，未生效的价格表不参与
+func TestResolvePrices(t *testing.T) {
+	future := time.Now().Add(time.Hour).Format(time.DateTime)
+	tests := []struct {
+		name          string
+		userId        string
+		discountPrice float64
+		wantPrice     float64
+		wantEndAt     string
+	}{
+		{"guest gets promotion", "", 0, 85, future},
+		{"customer without group gets promotion", "user-1", 0, 85, future},
+		{"group price is lower", "vip-user", 0, 80, ""},
+		{"item discount is lower", "vip-user", 75, 75, ""},
+		{"promotion lower than item discount", "", 90, 85, future},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			setupPriceLists(t, ctx, future)
+
+			item := &model.ProductItem{Uuid: "sku1", Price: 100, DiscountPrice: tt.discountPrice}
+			err := NewPriceService().ResolvePrices(ctx, tt.userId, []*model.ProductItem{item})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if item.SalePrice() != tt.wantPrice {
+				t.Errorf("price = %v, want %v", item.SalePrice(), tt.wantPrice)
+			}
+			if item.DiscountEndAt != tt.wantEndAt {
+				t.Errorf("discount end at = %q, want %q", item.DiscountEndAt, tt.wantEndAt)
+			}
+		})
+	}
+}
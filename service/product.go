@@ -49,6 +49,13 @@ func (p *ProductService) ProductCreate(ctx *app.Context, params *model.ReqProduc
 		UpdatedAt:           now,
 	}
 
+	if params.ProductType == model.ProductTypeGroup {
+		product.GroupPriceType = model.ProductGroupPriceSum
+		if params.GroupPriceType == model.ProductGroupPriceFixed {
+			product.GroupPriceType = model.ProductGroupPriceFixed
+		}
+	}
+
 	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
 
 		// 查询产品别名是否存在
@@ -98,6 +105,32 @@ func (p *ProductService) ProductCreate(ctx *app.Context, params *model.ReqProduc
 			}
 			productItemList = append(productItemList, &productItem)
 		}
+
+		if params.ProductType == model.ProductTypeGroup { // 组合产品
+			productItem := model.ProductItem{
+				ProductBase:   productBase,
+				Uuid:          uuid.New().String(),
+				ProductUuid:   product.Uuid,
+				Price:         params.Price,
+				Discount:      params.Discount,
+				DiscountPrice: params.DiscountPrice,
+				Description:   params.Description,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			}
+
+			// 库存由组件库存计算，组合产品本身不记库存
+			price, err := NewProductGroupService().CreateGroupItems(ctx, tx, product, productItem.Uuid, params.GroupItems)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+			if product.GroupPriceType == model.ProductGroupPriceSum {
+				productItem.Price = price
+			}
+			productItemList = append(productItemList, &productItem)
+		}
+
 		err = tx.Create(&productItemList).Error
 		if err != nil {
 			ctx.Logger.Error("Failed to create product item", err)
@@ -417,6 +450,11 @@ func (p *ProductService) GetProductItemByProductUUIDList(ctx *app.Context, prodo
 		return nil, errors.New("failed to get product item by product uuid list")
 	}
 
+	err = NewProductGroupService().FillGroupItems(ctx, productItemList)
+	if err != nil {
+		return nil, err
+	}
+
 	mProductItem := make(map[string][]*model.ProductItem, 0)
 	for _, productItem := range productItemList {
 		if _, ok := mProductItem[productItem.ProductUuid]; !ok {
@@ -452,6 +490,11 @@ func (p *ProductService) GetProductSkuList(ctx *app.Context, params *model.ReqPr
 		return nil, errors.New("failed to get product list")
 	}
 
+	err = NewProductGroupService().FillGroupItems(ctx, productList)
+	if err != nil {
+		return nil, err
+	}
+
 	productUuids := make([]string, 0)
 	mProduct := make(map[string]bool, 0)
 	imageUuids := make([]string, 0)
@@ -647,6 +690,11 @@ func (p *ProductService) GetShowProductInfo(ctx *app.Context, uuid string) (r *m
 		return nil, errors.New("failed to get product item by product uuid")
 	}
 
+	err = NewPriceService().ResolveItemResPrices(ctx, ctx.GetString("user_id"), productItems)
+	if err != nil {
+		return nil, err
+	}
+
 	productShow := &model.ProductShow{
 		ProductUuid:         product.Uuid,
 		ProductType:         product.ProductType,
@@ -660,6 +708,8 @@ func (p *ProductService) GetShowProductInfo(ctx *app.Context, uuid string) (r *m
 	if len(productItems) > 0 {
 		sort.Sort(model.ProductItemResByPrice(productItems))
 		productShow.Price = productItems[0].Price
+		productShow.Discount = productItems[0].Discount
+		productShow.DiscountPrice = productItems[0].DiscountPrice
 		productShow.ProductItemUuid = productItems[0].Uuid
 	}
 
@@ -748,6 +798,12 @@ func (p *ProductService) GetProductItemByProductUUID(ctx *app.Context, productUu
 		ctx.Logger.Error("Failed to get product item by product uuid", err)
 		return nil, errors.New("failed to get product item by product uuid")
 	}
+
+	err = NewProductGroupService().FillGroupItems(ctx, productItemList)
+	if err != nil {
+		return nil, err
+	}
+
 	imageUuids := make([]string, 0)
 	mImage := make(map[string]bool, 0)
 
@@ -815,6 +871,11 @@ func (p *ProductService) GetProductItemByUUIDList(ctx *app.Context, uuidList []s
 		return nil, errors.New("failed to get product item list by UUID list")
 	}
 
+	err = NewProductGroupService().FillGroupItems(ctx, productItemList)
+	if err != nil {
+		return nil, err
+	}
+
 	imageUuids := make([]string, 0)
 	mImage := make(map[string]bool, 0)
 	mProductImages := make(map[string][]string, 0)
@@ -947,6 +1008,15 @@ func (p *ProductService) GetShowProductList(ctx *app.Context, params *model.ReqP
 		return nil, errors.New("failed to get product item by product uuid list")
 	}
 
+	allProductItems := make([]*model.ProductItem, 0)
+	for _, productItems := range productItemsMap {
+		allProductItems = append(allProductItems, productItems...)
+	}
+	err = NewPriceService().ResolvePrices(ctx, ctx.GetString("user_id"), allProductItems)
+	if err != nil {
+		return nil, err
+	}
+
 	for _, product := range productList {
 		productRes := &model.ProductShow{
 
@@ -1063,6 +1133,7 @@ func (p *ProductService) UpdateProduct(ctx *app.Context, params *model.ReqProduc
 		ctx.Logger.Error("Failed to update product", err)
 		return errors.New("failed to update product")
 	}
+	NewWebhookService().PublishProduct(ctx, params.Uuid)
 
 	return nil
 }
@@ -1089,22 +1160,43 @@ func (p *ProductService) UpdateProductSku(ctx *app.Context, params *model.ReqPro
 		productItem.DiscountPrice = params.DiscountPrice
 	}
 
-	if params.Stock > 0 {
-		productItem.Stock = params.Stock
-	}
-
 	if params.Description != "" {
 		productItem.Description = params.Description
 	}
 
+	if params.Stock != nil && *params.Stock < 0 {
+		return errors.New("库存不能小于0")
+	}
+
 	productItem.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
 
-	err = ctx.DB.Where("uuid = ?", params.Uuid).Updates(&productItem).Error
+	var inventoryLog *model.InventoryLog
+	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
+		// 库存通过库存流水修改
+		err := tx.Where("uuid = ?", params.Uuid).Omit("stock").Updates(&productItem).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update product item", err)
+			tx.Rollback()
+			return errors.New("failed to update product item")
+		}
+
+		if params.Stock != nil {
+			inventoryLog, err = NewInventoryService().SetStock(ctx, tx, params.Uuid, *params.Stock, model.InventorySourceManual, "", ctx.GetString("user_id"), "")
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		ctx.Logger.Error("Failed to update product item", err)
-		return errors.New("failed to update product item")
+		return err
 	}
 
+	NewInventoryService().NotifyLowStock(ctx, []*model.InventoryLog{inventoryLog})
+	NewWebhookService().PublishProduct(ctx, productItem.ProductUuid)
+
 	return nil
 }
 
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ProductGroupService struct {
+}
+
+func NewProductGroupService() *ProductGroupService {
+	return &ProductGroupService{}
+}
+
+// CreateGroupItems 在事务中创建组合产品组件，返回组件价格之和
+func (s *ProductGroupService) CreateGroupItems(ctx *app.Context, tx *gorm.DB, product *model.Product, productItemUuid string, items []*model.ReqProductGroupItemCreate) (float64, error) {
+	if len(items) == 0 {
+		return 0, errors.New("group product requires at least one item")
+	}
+
+	componentUuids := make([]string, 0)
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return 0, errors.New("group item quantity must be greater than 0")
+		}
+		componentUuids = append(componentUuids, item.ProductItemUuid)
+	}
+
+	components := make([]*model.ProductItem, 0)
+	err := tx.Where("uuid IN (?)", componentUuids).Find(&components).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get product item list by UUID list", err)
+		return 0, errors.New("failed to get product item list by UUID list")
+	}
+
+	componentMap := make(map[string]*model.ProductItem)
+	for _, component := range components {
+		componentMap[component.Uuid] = component
+	}
+
+	// 组件不能是组合产品
+	var nested int64
+	err = tx.Model(&model.ProductGroupItem{}).Where("product_item_uuid IN (?)", componentUuids).Count(&nested).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to count nested group items", err)
+		return 0, errors.New("failed to count nested group items")
+	}
+	if nested > 0 {
+		return 0, errors.New("group product can not contain another group product")
+	}
+
+	now := time.Now().Format(time.DateTime)
+	var price float64
+	groupItems := make([]*model.ProductGroupItem, 0)
+	for _, item := range items {
+		component, ok := componentMap[item.ProductItemUuid]
+		if !ok {
+			return 0, errors.New("group item not found")
+		}
+
+		price += component.Price * float64(item.Quantity)
+		groupItems = append(groupItems, &model.ProductGroupItem{
+			Uuid:              uuid.New().String(),
+			ProductUuid:       product.Uuid,
+			ProductItemUuid:   productItemUuid,
+			ComponentItemUuid: item.ProductItemUuid,
+			Quantity:          item.Quantity,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		})
+	}
+
+	err = tx.Create(&groupItems).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create product group items", err)
+		return 0, errors.New("failed to create product group items")
+	}
+
+	return price, nil
+}
+
+// GetGroupItemMap 根据组合产品SKU uuid列表获取组件，key为组合产品SKU uuid
+func (s *ProductGroupService) GetGroupItemMap(ctx *app.Context, productItemUuids []string) (map[string][]*model.ProductGroupItem, error) {
+	groupItems := make([]*model.ProductGroupItem, 0)
+	res := make(map[string][]*model.ProductGroupItem)
+	if len(productItemUuids) == 0 {
+		return res, nil
+	}
+
+	err := ctx.DB.Where("product_item_uuid IN (?)", productItemUuids).Find(&groupItems).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get product group items", err)
+		return nil, errors.New("failed to get product group items")
+	}
+
+	for _, groupItem := range groupItems {
+		res[groupItem.ProductItemUuid] = append(res[groupItem.ProductItemUuid], groupItem)
+	}
+
+	return res, nil
+}
+
+// FillGroupItems 计算组合产品SKU的价格和可售库存
+// 可售库存为各组件库存除以组件数量的最小值，定价方式为sum时价格为组件价格之和
+func (s *ProductGroupService) FillGroupItems(ctx *app.Context, items []*model.ProductItem) error {
+	itemUuids := make([]string, 0)
+	for _, item := range items {
+		itemUuids = append(itemUuids, item.Uuid)
+	}
+
+	groupMap, err := s.GetGroupItemMap(ctx, itemUuids)
+	if err != nil {
+		return err
+	}
+	if len(groupMap) == 0 {
+		return nil
+	}
+
+	componentUuids := make([]string, 0)
+	productUuids := make([]string, 0)
+	for _, item := range items {
+		groupItems, ok := groupMap[item.Uuid]
+		if !ok {
+			continue
+		}
+		productUuids = append(productUuids, item.ProductUuid)
+		for _, groupItem := range groupItems {
+			componentUuids = append(componentUuids, groupItem.ComponentItemUuid)
+		}
+	}
+
+	components := make([]*model.ProductItem, 0)
+	err = ctx.DB.Where("uuid IN (?)", componentUuids).Find(&components).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get product item list by UUID list", err)
+		return errors.New("failed to get product item list by UUID list")
+	}
+	componentMap := make(map[string]*model.ProductItem)
+	for _, component := range components {
+		componentMap[component.Uuid] = component
+	}
+
+	products := make([]*model.Product, 0)
+	err = ctx.DB.Where("uuid IN (?)", productUuids).Find(&products).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get product list by UUID list", err)
+		return errors.New("failed to get product list by UUID list")
+	}
+	productMap := make(map[string]*model.Product)
+	for _, product := range products {
+		productMap[product.Uuid] = product
+	}
+
+	for _, item := range items {
+		groupItems, ok := groupMap[item.Uuid]
+		if !ok {
+			continue
+		}
+
+		var (
+			stock int64 = math.MaxInt64
+			price float64
+		)
+		for _, groupItem := range groupItems {
+			component, ok := componentMap[groupItem.ComponentItemUuid]
+			if !ok || groupItem.Quantity <= 0 {
+				stock = 0
+				continue
+			}
+			if available := component.Stock / groupItem.Quantity; available < stock {
+				stock = available
+			}
+			price += component.Price * float64(groupItem.Quantity)
+		}
+		if stock < 0 {
+			stock = 0
+		}
+
+		item.Stock = stock
+		if product, ok := productMap[item.ProductUuid]; ok && product.GroupPriceType != model.ProductGroupPriceFixed {
+			item.Price = price
+		}
+	}
+
+	return nil
+}
+
+// GetProductGroupInfo 获取组合产品信息
+func (s *ProductGroupService) GetProductGroupInfo(ctx *app.Context, productUuid string) (*model.ProductGroupRes, error) {
+	product, productItem, err := s.getGroupProduct(ctx, productUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.FillGroupItems(ctx, []*model.ProductItem{productItem})
+	if err != nil {
+		return nil, err
+	}
+
+	groupMap, err := s.GetGroupItemMap(ctx, []string{productItem.Uuid})
+	if err != nil {
+		return nil, err
+	}
+
+	componentUuids := make([]string, 0)
+	for _, groupItem := range groupMap[productItem.Uuid] {
+		componentUuids = append(componentUuids, groupItem.ComponentItemUuid)
+	}
+
+	componentMap, err := NewProductService().GetProductItemByUUIDList(ctx, componentUuids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.ProductGroupRes{
+		ProductUuid:     product.Uuid,
+		ProductItemUuid: productItem.Uuid,
+		GroupPriceType:  product.GroupPriceType,
+		Price:           productItem.Price,
+		Stock:           productItem.Stock,
+		Items:           make([]*model.ProductGroupItemRes, 0),
+	}
+
+	for _, groupItem := range groupMap[productItem.Uuid] {
+		itemRes := &model.ProductGroupItemRes{
+			ProductGroupItem: *groupItem,
+		}
+		if component, ok := componentMap[groupItem.ComponentItemUuid]; ok {
+			itemRes.ComponentItem = component
+		}
+		res.Items = append(res.Items, itemRes)
+	}
+
+	return res, nil
+}
+
+// UpdateProductGroup 更新组合产品的组件和定价方式
+func (s *ProductGroupService) UpdateProductGroup(ctx *app.Context, params *model.ReqProductGroupUpdate) error {
+	product, productItem, err := s.getGroupProduct(ctx, params.ProductUuid)
+	if err != nil {
+		return err
+	}
+
+	priceType := params.GroupPriceType
+	if priceType == "" {
+		priceType = product.GroupPriceType
+	}
+	if priceType != model.ProductGroupPriceFixed {
+		priceType = model.ProductGroupPriceSum
+	}
+
+	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("product_item_uuid = ?", productItem.Uuid).Delete(&model.ProductGroupItem{}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to delete product group items", err)
+			tx.Rollback()
+			return errors.New("failed to delete product group items")
+		}
+
+		price, err := s.CreateGroupItems(ctx, tx, product, productItem.Uuid, params.Items)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if priceType == model.ProductGroupPriceFixed {
+			price = params.Price
+		}
+
+		now := time.Now().Format(time.DateTime)
+		err = tx.Model(&model.Product{}).Where("uuid = ?", product.Uuid).Updates(map[string]interface{}{
+			"group_price_type": priceType,
+			"updated_at":       now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update product", err)
+			tx.Rollback()
+			return errors.New("failed to update product")
+		}
+
+		err = tx.Model(&model.ProductItem{}).Where("uuid = ?", productItem.Uuid).Updates(map[string]interface{}{
+			"price":      price,
+			"updated_at": now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update product item", err)
+			tx.Rollback()
+			return errors.New("failed to update product item")
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// 获取组合产品及其SKU
+func (s *ProductGroupService) getGroupProduct(ctx *app.Context, productUuid string) (*model.Product, *model.ProductItem, error) {
+	product := &model.Product{}
+	err := ctx.DB.Where("uuid = ?", productUuid).First(product).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, errors.New("product not found")
+		}
+		ctx.Logger.Error("Failed to get product by UUID", err)
+		return nil, nil, errors.New("failed to get product by UUID")
+	}
+
+	if product.ProductType != model.ProductTypeGroup {
+		return nil, nil, errors.New("product is not a group product")
+	}
+
+	productItem := &model.ProductItem{}
+	err = ctx.DB.Where("product_uuid = ?", product.Uuid).First(productItem).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, errors.New("product item not found")
+		}
+		ctx.Logger.Error("Failed to get product item by product uuid", err)
+		return nil, nil, errors.New("failed to get product item by product uuid")
+	}
+
+	return product, productItem, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/testutil"
+
+	"gorm.io/gorm"
+)
+
+// 组合产品bundle1由2个itemA和1个itemB组成
+func setupBundle(t *testing.T, ctx *app.Context) {
+	t.Helper()
+	rows := []interface{}{
+		&model.Product{Uuid: "bundle", Name: "bundle", GroupPriceType: model.ProductGroupPriceSum,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.ProductItem{Uuid: "bundle1", ProductUuid: "bundle", CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.ProductItem{Uuid: "itemA", ProductUuid: "productA", Stock: 10, Price: 5, CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.ProductItem{Uuid: "itemB", ProductUuid: "productB", Stock: 3, Price: 20, CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.ProductGroupItem{Uuid: "g1", ProductUuid: "bundle", ProductItemUuid: "bundle1", ComponentItemUuid: "itemA", Quantity: 2,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.ProductGroupItem{Uuid: "g2", ProductUuid: "bundle", ProductItemUuid: "bundle1", ComponentItemUuid: "itemB", Quantity: 1,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+	}
+	for _, row := range rows {
+		err := ctx.DB.Create(row).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDeductStockBundle(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []*model.OrderItem
+		wantErr bool
+		wantA   int64
+		wantB   int64
+	}{
+		{"one bundle", []*model.OrderItem{{ProductItemID: "bundle1", Quantity: 1}}, false, 8, 2},
+		{"bundle and component", []*model.OrderItem{{ProductItemID: "bundle1", Quantity: 2}, {ProductItemID: "itemA", Quantity: 1}}, false, 5, 1},
+		{"all component stock", []*model.OrderItem{{ProductItemID: "bundle1", Quantity: 3}}, false, 4, 0},
+		{"insufficient component", []*model.OrderItem{{ProductItemID: "bundle1", Quantity: 4}}, true, 10, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			setupBundle(t, ctx)
+
+			order := &model.Order{OrderNo: "SG1", UserID: "customer1"}
+			err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+				_, err := NewOrderService().deductStock(ctx, tx, order, tt.items)
+				return err
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+
+			for uuid, want := range map[string]int64{"itemA": tt.wantA, "itemB": tt.wantB} {
+				item := &model.ProductItem{}
+				err := ctx.DB.Where("uuid = ?", uuid).First(item).Error
+				if err != nil {
+					t.Fatal(err)
+				}
+				if item.Stock != want {
+					t.Errorf("%s stock = %d, want %d", uuid, item.Stock, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFillGroupItems(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	setupBundle(t, ctx)
+
+	items := []*model.ProductItem{
+		{Uuid: "bundle1", ProductUuid: "bundle"},
+		{Uuid: "itemA", ProductUuid: "productA", Stock: 10, Price: 5},
+	}
+	err := NewProductGroupService().FillGroupItems(ctx, items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items[0].Stock != 3 || items[0].Price != 30 {
+		t.Errorf("bundle stock = %d, price = %v, want 3, 30", items[0].Stock, items[0].Price)
+	}
+	if items[1].Stock != 10 || items[1].Price != 5 {
+		t.Errorf("component changed: stock = %d, price = %v", items[1].Stock, items[1].Price)
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/testutil"
+)
+
+func TestUpdateProductSkuStock(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+	tests := []struct {
+		name  string
+		stock *int64
+		want  int64
+	}{
+		{"stock not set", nil, 5},
+		{"set to zero", int64Ptr(0), 0},
+		{"set to positive", int64Ptr(8), 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			err := ctx.DB.Create(&model.ProductItem{Uuid: "item1", ProductUuid: "product1", Stock: 5,
+				CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = NewProductService().UpdateProductSku(ctx, &model.ReqProductItemUpdate{Uuid: "item1", Stock: tt.stock})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			item := &model.ProductItem{}
+			err = ctx.DB.Where("uuid = ?", "item1").First(item).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+			if item.Stock != tt.want {
+				t.Errorf("stock = %d, want %d", item.Stock, tt.want)
+			}
+		})
+	}
+
+	ctx := testutil.NewContext(t)
+	err := NewProductService().UpdateProductSku(ctx, &model.ReqProductItemUpdate{Uuid: "item1", Stock: int64Ptr(-1)})
+	if err == nil {
+		t.Error("negative stock should be rejected")
+	}
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/redisop"
+	"sgin/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+const (
+	rbacVersionKey  = "sgin:rbac:version"
+	rbacCacheExpire = 10 * time.Minute
+
+	// 接口权限等级
+	APILevelPublic   = 1 // 公开
+	APILevelLogin    = 2 // 登录用户
+	APILevelAdmin    = 3 // 管理员
+	APILevelSuper    = 4 // 超级管理员，只有团队拥有者可以调用
+	APILevelDisabled = 6 // 不可调用
+)
+
+// 权限缓存保存在进程内，按版本号失效
+// 配置了redis时版本号保存在redis中，多实例部署时任一实例修改角色后所有实例的缓存同时失效
+var (
+	rbacLocalVersion int64
+	rbacUserCache    sync.Map // 用户uuid -> *rbacUserPermission
+	rbacAPICache     atomic.Value
+)
+
+// 用户在当前团队的权限
+type rbacUserPermission struct {
+	version  string
+	expireAt time.Time
+	owner    bool
+	menuBits map[string]uint // 菜单uuid -> 权限位
+}
+
+type rbacAPI struct {
+	level     int
+	menuUuids []string
+}
+
+type rbacAPIIndex struct {
+	version  string
+	expireAt time.Time
+	apis     map[string]*rbacAPI // METHOD path -> 接口
+}
+
+type RbacService struct {
+}
+
+func NewRbacService() *RbacService {
+	return &RbacService{}
+}
+
+// CheckPermission 检查用户是否可以调用接口
+// 商城客户不能调用后台接口，未登记的接口拒绝调用
+// 其余接口需要用户在当前团队的角色拥有接口关联菜单的对应权限位，团队拥有者拥有全部权限
+func (s *RbacService) CheckPermission(ctx *app.Context, userId, audience, method, path string) (bool, error) {
+	if audience == utils.TokenAudienceCustomer {
+		return false, nil
+	}
+
+	version, err := s.version(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	api, err := s.getAPI(ctx, version, method, path)
+	if err != nil {
+		return false, err
+	}
+	if api == nil {
+		return false, nil
+	}
+
+	switch api.level {
+	case APILevelPublic, APILevelLogin:
+		return true, nil
+	case APILevelDisabled:
+		return false, nil
+	}
+
+	perm, err := s.getUserPermission(ctx, version, userId)
+	if err != nil {
+		return false, err
+	}
+	if perm.owner {
+		return true, nil
+	}
+	if api.level == APILevelSuper {
+		return false, nil
+	}
+
+	bit := model.ActionPermissionBit(method, path)
+	for _, menuUuid := range api.menuUuids {
+		if perm.menuBits[menuUuid]&bit != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// InvalidateCache 角色、权限、菜单接口关联或团队成员变更后调用，使所有用户的权限缓存失效
+// 使用casbin校验时同时重新生成策略
+func (s *RbacService) InvalidateCache(ctx *app.Context) {
+	atomic.AddInt64(&rbacLocalVersion, 1)
+	if ctx.Redis != nil {
+		_, err := ctx.Redis.Incr(ctx.Ctx, rbacVersionKey)
+		if err != nil {
+			ctx.Logger.Error("Failed to invalidate permission cache", err)
+		}
+	}
+
+	if ctx.Config.Auth.Authorizer == AuthorizerCasbin {
+		_, err := NewCasbinService().SyncPolicies(ctx)
+		if err != nil {
+			ctx.Logger.Error("Failed to sync casbin policy", err)
+		}
+	}
+}
+
+func (s *RbacService) version(ctx *app.Context) (string, error) {
+	local := strconv.FormatInt(atomic.LoadInt64(&rbacLocalVersion), 10)
+	if ctx.Redis == nil {
+		return local, nil
+	}
+
+	version, err := ctx.Redis.Get(ctx.Ctx, rbacVersionKey)
+	if err != nil {
+		if errors.Is(err, redisop.Nil) {
+			return "0", nil
+		}
+		ctx.Logger.Error("Failed to get permission cache version", err)
+		return "", errors.New("failed to check permission")
+	}
+	return version, nil
+}
+
+func (s *RbacService) getAPI(ctx *app.Context, version, method, path string) (*rbacAPI, error) {
+	index, _ := rbacAPICache.Load().(*rbacAPIIndex)
+	if index == nil || index.version != version || time.Now().After(index.expireAt) {
+		apis := make([]*model.API, 0)
+		err := ctx.DB.Where("status <> ?", APIStatusDeleted).Find(&apis).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get API list", err)
+			return nil, errors.New("failed to check permission")
+		}
+
+		menuAPIs := make([]*model.MenuAPI, 0)
+		err = ctx.DB.Find(&menuAPIs).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get menu API list", err)
+			return nil, errors.New("failed to check permission")
+		}
+		apiMenus := make(map[string][]string)
+		for _, menuAPI := range menuAPIs {
+			apiMenus[menuAPI.APIUUID] = append(apiMenus[menuAPI.APIUUID], menuAPI.MenuUUID)
+		}
+
+		index = &rbacAPIIndex{
+			version:  version,
+			expireAt: time.Now().Add(rbacCacheExpire),
+			apis:     make(map[string]*rbacAPI),
+		}
+		for _, api := range apis {
+			index.apis[api.Method+" "+api.Path] = &rbacAPI{
+				level:     apiLevel(api),
+				menuUuids: apiMenus[api.UUID],
+			}
+		}
+		rbacAPICache.Store(index)
+	}
+
+	return index.apis[method+" "+path], nil
+}
+
+// 同步新增还未确认权限的接口只有团队拥有者可以调用
+func apiLevel(api *model.API) int {
+	if api.Status == APIStatusPending {
+		return APILevelSuper
+	}
+	return api.PermissionLevel
+}
+
+func (s *RbacService) getUserPermission(ctx *app.Context, version, userId string) (*rbacUserPermission, error) {
+	if v, ok := rbacUserCache.Load(userId); ok {
+		perm := v.(*rbacUserPermission)
+		if perm.version == version && time.Now().Before(perm.expireAt) {
+			return perm, nil
+		}
+	}
+
+	perm, err := s.loadUserPermission(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	perm.version = version
+	perm.expireAt = time.Now().Add(rbacCacheExpire)
+	rbacUserCache.Store(userId, perm)
+	return perm, nil
+}
+
+// 查询用户在当前团队的角色和权限
+func (s *RbacService) loadUserPermission(ctx *app.Context, userId string) (*rbacUserPermission, error) {
+	perm := &rbacUserPermission{menuBits: make(map[string]uint)}
+
+	teamMember := &model.TeamMember{}
+	err := ctx.DB.Where("user_uuid = ? AND is_current_team = ?", userId, true).First(teamMember).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// 没有加入团队的用户没有角色
+			return perm, nil
+		}
+		ctx.Logger.Error("Failed to get current team", err)
+		return nil, errors.New("failed to check permission")
+	}
+
+	var count int64
+	err = ctx.DB.Model(&model.Team{}).Where("uuid = ? AND owner_uuid = ?", teamMember.TeamUUID, userId).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get team owner", err)
+		return nil, errors.New("failed to check permission")
+	}
+	if count > 0 {
+		perm.owner = true
+		return perm, nil
+	}
+
+	// 团队成员角色和用户角色中属于当前团队或不属于任何团队的启用角色
+	roleUuids := make([]string, 0)
+	err = ctx.DB.Model(&model.UserRole{}).Where("user_uuid = ?", userId).Pluck("role_uuid", &roleUuids).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get user roles", err)
+		return nil, errors.New("failed to check permission")
+	}
+	if teamMember.Role != "" {
+		roleUuids = append(roleUuids, teamMember.Role)
+	}
+	if len(roleUuids) > 0 {
+		activeRoleUuids := make([]string, 0)
+		err = ctx.DB.Model(&model.Role{}).
+			Where("uuid IN (?) AND is_active = ? AND (team_uuid = ? OR team_uuid = ?)", roleUuids, true, teamMember.TeamUUID, "").
+			Pluck("uuid", &activeRoleUuids).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get roles", err)
+			return nil, errors.New("failed to check permission")
+		}
+
+		if len(activeRoleUuids) > 0 {
+			roleMenus := make([]*model.RoleMenuPermission, 0)
+			err = ctx.DB.Where("role_uuid IN (?)", activeRoleUuids).Find(&roleMenus).Error
+			if err != nil {
+				ctx.Logger.Error("Failed to get role menus", err)
+				return nil, errors.New("failed to check permission")
+			}
+			for _, roleMenu := range roleMenus {
+				perm.menuBits[roleMenu.MenuUUID] |= roleMenu.Permission
+			}
+		}
+	}
+
+	// 直接授予用户的权限，按权限关联的菜单合并权限位
+	permissionUuids := make([]string, 0)
+	err = ctx.DB.Model(&model.UserPermission{}).Where("user_uuid = ?", userId).Pluck("permission_uuid", &permissionUuids).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get user permissions", err)
+		return nil, errors.New("failed to check permission")
+	}
+	if len(permissionUuids) > 0 {
+		permissions := make([]*model.Permission, 0)
+		err = ctx.DB.Where("uuid IN (?)", permissionUuids).Find(&permissions).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get permissions", err)
+			return nil, errors.New("failed to check permission")
+		}
+		bits := make(map[string]uint)
+		for _, permission := range permissions {
+			bits[permission.Uuid] = permission.Bit
+		}
+
+		permissionMenus := make([]*model.PermissionMenu, 0)
+		err = ctx.DB.Where("permission_uuid IN (?)", permissionUuids).Find(&permissionMenus).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get permission menus", err)
+			return nil, errors.New("failed to check permission")
+		}
+		for _, permissionMenu := range permissionMenus {
+			perm.menuBits[permissionMenu.MenuUuid] |= bits[permissionMenu.PermissionUuid]
+		}
+	}
+
+	return perm, nil
+}
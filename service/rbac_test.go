@@ -0,0 +1,75 @@
+package service
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/utils"
+)
+
+// 使用进程内缓存的接口列表，不查询数据库
+func storeAPIIndex(apis map[string]*rbacAPI) {
+	rbacAPICache.Store(&rbacAPIIndex{
+		version:  strconv.FormatInt(atomic.LoadInt64(&rbacLocalVersion), 10),
+		expireAt: time.Now().Add(time.Minute),
+		apis:     apis,
+	})
+}
+
+func TestCheckPermission(t *testing.T) {
+	storeAPIIndex(map[string]*rbacAPI{
+		"POST /api/v1/user/delete":  {level: APILevelLogin},
+		"POST /api/v1/public/info":  {level: APILevelPublic},
+		"POST /api/v1/user/disable": {level: APILevelDisabled},
+	})
+	ctx := &app.Context{}
+
+	tests := []struct {
+		name     string
+		audience string
+		path     string
+		allowed  bool
+	}{
+		{"customer on unregistered route", utils.TokenAudienceCustomer, "/api/v1/product/delete", false},
+		{"customer on login route", utils.TokenAudienceCustomer, "/api/v1/user/delete", false},
+		{"customer on public route", utils.TokenAudienceCustomer, "/api/v1/public/info", false},
+		{"admin on unregistered route", utils.TokenAudienceAdmin, "/api/v1/product/delete", false},
+		{"admin on login route", utils.TokenAudienceAdmin, "/api/v1/user/delete", true},
+		{"admin on public route", utils.TokenAudienceAdmin, "/api/v1/public/info", true},
+		{"admin on disabled route", utils.TokenAudienceAdmin, "/api/v1/user/disable", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := NewRbacService().CheckPermission(ctx, "user1", tt.audience, "POST", tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if allowed != tt.allowed {
+				t.Errorf("allowed = %v, want %v", allowed, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestAPILevel(t *testing.T) {
+	tests := []struct {
+		status int
+		level  int
+		want   int
+	}{
+		{APIStatusEnabled, APILevelLogin, APILevelLogin},
+		{APIStatusEnabled, APILevelAdmin, APILevelAdmin},
+		{APIStatusPending, APILevelPublic, APILevelSuper},
+		{APIStatusPending, APILevelAdmin, APILevelSuper},
+	}
+	for _, tt := range tests {
+		got := apiLevel(&model.API{Status: tt.status, PermissionLevel: tt.level})
+		if got != tt.want {
+			t.Errorf("apiLevel(status=%d, level=%d) = %d, want %d", tt.status, tt.level, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,782 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/mail"
+	paymentmethod "sgin/pkg/payment-method"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var returnMailContent = `
+<html>
+<body>
+    <h2>%s</h2>
+    <p>订单号：%s</p>
+    <p>退货申请：%s</p>
+    <p>%s</p>
+</body>
+</html>
+`
+
+// 退货申请各状态的通知邮件标题
+var returnMailSubjects = map[string]string{
+	model.ReturnStatusRequested: "退货申请已提交",
+	model.ReturnStatusApproved:  "退货申请已通过，请寄回商品",
+	model.ReturnStatusRejected:  "退货申请未通过",
+	model.ReturnStatusReceived:  "我们已收到您的退货",
+	model.ReturnStatusRefunded:  "退款已处理",
+	model.ReturnStatusExchanged: "换货订单已创建",
+}
+
+type ReturnService struct {
+}
+
+func NewReturnService() *ReturnService {
+	return &ReturnService{}
+}
+
+// CreateReturn 买家申请退货，只能退已发货的商品
+func (s *ReturnService) CreateReturn(ctx *app.Context, params *model.ReqReturnCreate, userId string) (*model.ReturnRequestRes, error) {
+	resolution := params.Resolution
+	if resolution == "" {
+		resolution = model.ReturnResolutionRefund
+	}
+	if resolution != model.ReturnResolutionRefund && resolution != model.ReturnResolutionExchange {
+		return nil, errors.New("invalid return resolution")
+	}
+	if len(params.Items) == 0 {
+		return nil, errors.New("return items are required")
+	}
+
+	order, err := NewOrderService().GetOrderByID(ctx, params.OrderNo)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userId {
+		return nil, errors.New("order not found")
+	}
+	if order.IsVirtual {
+		return nil, errors.New("virtual order does not support return")
+	}
+	if order.Status != model.OrderStatusPaid && order.Status != model.OrderStatusDelivered && order.Status != model.OrderStatusCompleted {
+		return nil, errors.New("order status does not allow return")
+	}
+
+	images, err := json.Marshal(params.Images)
+	if err != nil {
+		return nil, errors.New("invalid return images")
+	}
+
+	now := time.Now().Format(time.DateTime)
+	ret := &model.ReturnRequest{
+		Uuid:        uuid.New().String(),
+		OrderNo:     order.OrderNo,
+		UserID:      userId,
+		Reason:      params.Reason,
+		Description: params.Description,
+		Images:      string(images),
+		Resolution:  resolution,
+		Status:      model.ReturnStatusRequested,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	items := make([]*model.ReturnItem, 0)
+	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
+		// 锁定订单，避免并发申请超出可退数量
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_no = ?", order.OrderNo).First(&model.Order{}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to lock order", err)
+			tx.Rollback()
+			return errors.New("failed to lock order")
+		}
+
+		returnable, orderItemMap, err := s.returnableQuantities(ctx, tx, order.OrderNo)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		for _, param := range params.Items {
+			orderItem, ok := orderItemMap[param.OrderItemID]
+			if !ok {
+				tx.Rollback()
+				return errors.New("order item not found")
+			}
+			if param.Quantity <= 0 || param.Quantity > returnable[param.OrderItemID] {
+				tx.Rollback()
+				return errors.New("return quantity exceeds shipped quantity")
+			}
+			returnable[param.OrderItemID] -= param.Quantity
+
+			amount := s.itemAmount(orderItem, param.Quantity)
+			ret.RefundAmount += amount
+			items = append(items, &model.ReturnItem{
+				ReturnUuid:      ret.Uuid,
+				OrderNo:         order.OrderNo,
+				OrderItemID:     orderItem.ID,
+				ProductItemUuid: orderItem.ProductItemID,
+				Quantity:        param.Quantity,
+				Amount:          amount,
+				CreatedAt:       now,
+			})
+		}
+		ret.RefundAmount = math.Round(ret.RefundAmount*100) / 100
+
+		err = tx.Create(ret).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create return request", err)
+			tx.Rollback()
+			return errors.New("failed to create return request")
+		}
+
+		err = tx.Create(&items).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create return items", err)
+			tx.Rollback()
+			return errors.New("failed to create return items")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.SendReturnMail(ctx, order, ret)
+
+	res, err := s.fillReturnRes(ctx, []*model.ReturnRequest{ret})
+	if err != nil {
+		return nil, err
+	}
+	return res[0], nil
+}
+
+// CancelReturn 买家取消待审核的退货申请
+func (s *ReturnService) CancelReturn(ctx *app.Context, returnUuid, userId string) error {
+	now := time.Now().Format(time.DateTime)
+	result := ctx.DB.Model(&model.ReturnRequest{}).
+		Where("uuid = ? AND user_id = ? AND status = ?", returnUuid, userId, model.ReturnStatusRequested).
+		Updates(map[string]interface{}{
+			"status":       model.ReturnStatusCanceled,
+			"completed_at": now,
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to cancel return request", result.Error)
+		return errors.New("failed to cancel return request")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("return request can not be canceled")
+	}
+	return nil
+}
+
+// ApproveReturn 同意退货申请，等待买家寄回商品
+func (s *ReturnService) ApproveReturn(ctx *app.Context, params *model.ReqReturnReviewParam, operator string) error {
+	return s.review(ctx, params, operator, model.ReturnStatusApproved)
+}
+
+// RejectReturn 拒绝退货申请
+func (s *ReturnService) RejectReturn(ctx *app.Context, params *model.ReqReturnReviewParam, operator string) error {
+	return s.review(ctx, params, operator, model.ReturnStatusRejected)
+}
+
+func (s *ReturnService) review(ctx *app.Context, params *model.ReqReturnReviewParam, operator, status string) error {
+	now := time.Now().Format(time.DateTime)
+	updates := map[string]interface{}{
+		"status":       status,
+		"admin_remark": params.Remark,
+		"operator":     operator,
+		"reviewed_at":  now,
+		"updated_at":   now,
+	}
+	if status == model.ReturnStatusRejected {
+		updates["completed_at"] = now
+	}
+
+	return s.transition(ctx, params.Uuid, model.ReturnStatusRequested, updates, nil)
+}
+
+// ReceiveReturn 确认收到退货，可选择将商品退回库存
+func (s *ReturnService) ReceiveReturn(ctx *app.Context, params *model.ReqReturnReceiveParam, operator string) error {
+	now := time.Now().Format(time.DateTime)
+	updates := map[string]interface{}{
+		"status":      model.ReturnStatusReceived,
+		"restocked":   params.Restock,
+		"operator":    operator,
+		"received_at": now,
+		"updated_at":  now,
+	}
+	if params.Remark != "" {
+		updates["admin_remark"] = params.Remark
+	}
+
+	return s.transition(ctx, params.Uuid, model.ReturnStatusApproved, updates, func(tx *gorm.DB, ret *model.ReturnRequest) error {
+		if !params.Restock {
+			return nil
+		}
+		return s.restock(ctx, tx, ret, operator)
+	})
+}
+
+// RefundReturn 退货退款，支持原路退款的支付方式直接调用渠道退款，否则记录为线下退款
+func (s *ReturnService) RefundReturn(ctx *app.Context, params *model.ReqReturnRefundParam, operator string) (*model.Refund, error) {
+	ret, err := s.getReturn(ctx, params.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != model.ReturnStatusReceived {
+		return nil, errors.New("return request is not received")
+	}
+
+	amount := params.Amount
+	if amount <= 0 {
+		amount = ret.RefundAmount
+	}
+
+	payment := &model.Payment{}
+	err = ctx.DB.Where("order_id = ? AND status = ?", ret.OrderNo, model.PaymentStatusPaid).First(payment).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("paid payment not found")
+		}
+		ctx.Logger.Error("Failed to get payment by order", err)
+		return nil, errors.New("failed to get payment by order")
+	}
+
+	var refunded float64
+	err = ctx.DB.Model(&model.Refund{}).Where("order_id = ? AND status IN (?)", ret.OrderNo, []string{model.RefundStatusSucceeded, model.RefundStatusManual}).
+		Select("COALESCE(SUM(amount), 0)").Scan(&refunded).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get refunded amount", err)
+		return nil, errors.New("failed to get refunded amount")
+	}
+	if amount > payment.Amount-refunded+0.001 {
+		return nil, errors.New("refund amount exceeds paid amount")
+	}
+
+	now := time.Now().Format(time.DateTime)
+	refund := &model.Refund{
+		Uuid:        uuid.New().String(),
+		UserID:      ret.UserID,
+		OrderID:     ret.OrderNo,
+		PaymentUuid: payment.Uuid,
+		ReturnUuid:  ret.Uuid,
+		Amount:      amount,
+		Method:      payment.Method,
+		Status:      model.RefundStatusManual,
+		Reason:      ret.Reason,
+		Operator:    operator,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	gateway, err := s.refundPayment(ctx, payment.Method)
+	if err != nil {
+		return nil, err
+	}
+	if gateway != nil {
+		refundNo, err := gateway.Refund(ctx, ret.OrderNo, refund.Uuid, amount, ret.Reason)
+		if err != nil {
+			refund.Status = model.RefundStatusFailed
+			if err := ctx.DB.Create(refund).Error; err != nil {
+				ctx.Logger.Error("Failed to create refund", err)
+			}
+			return nil, errors.New("failed to refund payment")
+		}
+		refund.Status = model.RefundStatusSucceeded
+		refund.ChannelRefundNo = refundNo
+	}
+
+	// 渠道已退款时先保存退款记录，避免退货申请更新失败后丢失
+	err = ctx.DB.Create(refund).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create refund", err)
+		return nil, errors.New("failed to create refund")
+	}
+	NewWebhookService().Publish(ctx, refund.TenantUuid, model.WebhookEventRefundCreated, refund)
+
+	updates := map[string]interface{}{
+		"status":        model.ReturnStatusRefunded,
+		"refund_amount": amount,
+		"operator":      operator,
+		"completed_at":  now,
+		"updated_at":    now,
+	}
+	if params.Remark != "" {
+		updates["admin_remark"] = params.Remark
+	}
+
+	err = s.transition(ctx, ret.Uuid, model.ReturnStatusReceived, updates, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// ExchangeReturn 换货，为退货商品创建一个已支付的零元订单，按正常流程发货
+func (s *ReturnService) ExchangeReturn(ctx *app.Context, params *model.ReqReturnReviewParam, operator string) (*model.Order, error) {
+	ret, err := s.getReturn(ctx, params.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != model.ReturnStatusReceived {
+		return nil, errors.New("return request is not received")
+	}
+
+	order, err := NewOrderService().GetOrderByID(ctx, ret.OrderNo)
+	if err != nil {
+		return nil, err
+	}
+
+	orderNo, err := NewOrderService().NewOrderNo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Format(time.DateTime)
+	exchange := &model.Order{
+		Uuid:             uuid.New().String(),
+		OrderNo:          orderNo,
+		UserID:           order.UserID,
+		Status:           model.OrderStatusPaid,
+		ReceiverName:     order.ReceiverName,
+		ReceiverPhone:    order.ReceiverPhone,
+		ReceiverEmail:    order.ReceiverEmail,
+		ReceiverCountry:  order.ReceiverCountry,
+		ReceiverProvince: order.ReceiverProvince,
+		ReceiverCity:     order.ReceiverCity,
+		ReceiverAddress:  order.ReceiverAddress,
+		ReceiverZip:      order.ReceiverZip,
+		ReceiverRemark:   "exchange:" + ret.Uuid,
+		PaidAt:           now,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	updates := map[string]interface{}{
+		"status":            model.ReturnStatusExchanged,
+		"exchange_order_no": exchange.OrderNo,
+		"refund_amount":     0,
+		"operator":          operator,
+		"completed_at":      now,
+		"updated_at":        now,
+	}
+	if params.Remark != "" {
+		updates["admin_remark"] = params.Remark
+	}
+
+	inventoryLogs := make([]*model.InventoryLog, 0)
+	err = s.transition(ctx, ret.Uuid, model.ReturnStatusReceived, updates, func(tx *gorm.DB, ret *model.ReturnRequest) error {
+		returnItems := make([]*model.ReturnItem, 0)
+		err := tx.Where("return_uuid = ?", ret.Uuid).Find(&returnItems).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get return items", err)
+			return errors.New("failed to get return items")
+		}
+
+		orderItems := make([]*model.OrderItem, 0)
+		for _, item := range returnItems {
+			orderItem := &model.OrderItem{}
+			err := tx.Where("id = ?", item.OrderItemID).First(orderItem).Error
+			if err != nil {
+				ctx.Logger.Error("Failed to get order item", err)
+				return errors.New("failed to get order item")
+			}
+			orderItems = append(orderItems, &model.OrderItem{
+				OrderID:        exchange.OrderNo,
+				ProductItemID:  item.ProductItemUuid,
+				Quantity:       item.Quantity,
+				Price:          orderItem.Price,
+				DiscountAmount: orderItem.Price * float64(item.Quantity),
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			})
+		}
+
+		err = tx.Create(exchange).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create exchange order", err)
+			return errors.New("failed to create exchange order")
+		}
+
+		err = tx.Create(&orderItems).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create exchange order items", err)
+			return errors.New("failed to create exchange order items")
+		}
+
+		inventoryLogs, err = NewOrderService().deductStock(ctx, tx, exchange, orderItems)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	NewInventoryService().NotifyLowStock(ctx, inventoryLogs)
+
+	return exchange, nil
+}
+
+// 在事务中校验并更新退货申请状态，状态变化后通知买家
+func (s *ReturnService) transition(ctx *app.Context, returnUuid, from string, updates map[string]interface{}, fn func(tx *gorm.DB, ret *model.ReturnRequest) error) error {
+	ret := &model.ReturnRequest{}
+	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", returnUuid).First(ret).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.New("return request not found")
+			}
+			ctx.Logger.Error("Failed to get return request by UUID", err)
+			return errors.New("failed to get return request by UUID")
+		}
+		if ret.Status != from {
+			return errors.New("return request status is " + ret.Status)
+		}
+
+		if fn != nil {
+			err = fn(tx, ret)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+
+		err = tx.Model(&model.ReturnRequest{}).Where("uuid = ?", returnUuid).Updates(updates).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update return request", err)
+			tx.Rollback()
+			return errors.New("failed to update return request")
+		}
+
+		return tx.Where("uuid = ?", returnUuid).First(ret).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	order, err := NewOrderService().GetOrderByID(ctx, ret.OrderNo)
+	if err == nil {
+		s.SendReturnMail(ctx, order, ret)
+	}
+	return nil
+}
+
+// 退货商品退回库存，组合产品退回各组件库存
+func (s *ReturnService) restock(ctx *app.Context, tx *gorm.DB, ret *model.ReturnRequest, operator string) error {
+	items := make([]*model.ReturnItem, 0)
+	err := tx.Where("return_uuid = ?", ret.Uuid).Find(&items).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get return items", err)
+		return errors.New("failed to get return items")
+	}
+
+	itemUuids := make([]string, 0)
+	for _, item := range items {
+		itemUuids = append(itemUuids, item.ProductItemUuid)
+	}
+	groupMap, err := NewProductGroupService().GetGroupItemMap(ctx, itemUuids)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if groupItems, ok := groupMap[item.ProductItemUuid]; ok {
+			for _, groupItem := range groupItems {
+				_, err := NewInventoryService().AdjustStock(ctx, tx, groupItem.ComponentItemUuid, int64(item.Quantity)*groupItem.Quantity, model.InventorySourceRefund, ret.Uuid, operator, "group:"+item.ProductItemUuid)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		_, err := NewInventoryService().AdjustStock(ctx, tx, item.ProductItemUuid, int64(item.Quantity), model.InventorySourceRefund, ret.Uuid, operator, "return")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 订单商品可退数量，已发货数量减去未取消、未拒绝的退货数量
+func (s *ReturnService) returnableQuantities(ctx *app.Context, tx *gorm.DB, orderNo string) (map[int64]int, map[int64]*model.OrderItem, error) {
+	remaining, orderItemMap, err := NewShipmentService().remainingQuantities(ctx, tx, orderNo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	returnable := make(map[int64]int)
+	for id, orderItem := range orderItemMap {
+		returnable[id] = orderItem.Quantity - remaining[id]
+	}
+
+	returned := make([]*model.ReturnItem, 0)
+	err = tx.Model(&model.ReturnItem{}).
+		Select("return_items.order_item_id, return_items.quantity").
+		Joins("JOIN return_requests ON return_requests.uuid = return_items.return_uuid").
+		Where("return_items.order_no = ?", orderNo).
+		Where("return_requests.status NOT IN (?)", []string{model.ReturnStatusRejected, model.ReturnStatusCanceled}).
+		Scan(&returned).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get returned items", err)
+		return nil, nil, errors.New("failed to get returned items")
+	}
+	for _, item := range returned {
+		returnable[item.OrderItemID] -= item.Quantity
+	}
+
+	return returnable, orderItemMap, nil
+}
+
+// 按订单商品实付金额计算退款金额
+func (s *ReturnService) itemAmount(orderItem *model.OrderItem, quantity int) float64 {
+	if orderItem.Quantity == 0 {
+		return 0
+	}
+	return math.Round(orderItem.TotalAmount/float64(orderItem.Quantity)*float64(quantity)*100) / 100
+}
+
+// 获取支持原路退款的支付方式，不支持时返回nil
+func (s *ReturnService) refundPayment(ctx *app.Context, code string) (paymentmethod.RefundPayment, error) {
+	var gateway paymentmethod.RefundPayment
+	switch code {
+	case "alipay":
+		gateway = &paymentmethod.Alipay{}
+	default:
+		return nil, nil
+	}
+
+	payment, err := NewPaymentMethodService().GetPaymentMethodInfo(ctx, "", code)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal([]byte(payment.Config), gateway)
+	if err != nil {
+		ctx.Logger.Error("Failed to unmarshal payment method config", err)
+		return nil, errors.New("failed to unmarshal payment method config")
+	}
+
+	return gateway, nil
+}
+
+// GetReturnInfo 获取退货申请详情，userId不为空时只能查看自己的申请
+func (s *ReturnService) GetReturnInfo(ctx *app.Context, returnUuid, userId string) (*model.ReturnRequestRes, error) {
+	ret, err := s.getReturn(ctx, returnUuid)
+	if err != nil {
+		return nil, err
+	}
+	if userId != "" && ret.UserID != userId {
+		return nil, errors.New("return request not found")
+	}
+
+	res, err := s.fillReturnRes(ctx, []*model.ReturnRequest{ret})
+	if err != nil {
+		return nil, err
+	}
+	return res[0], nil
+}
+
+// GetReturnList 获取退货申请列表
+func (s *ReturnService) GetReturnList(ctx *app.Context, params *model.ReqReturnQueryParam) (*model.PagedResponse, error) {
+	var (
+		returns []*model.ReturnRequest
+		total   int64
+	)
+
+	db := ctx.DB.Model(&model.ReturnRequest{})
+	if params.OrderNo != "" {
+		db = db.Where("order_no = ?", params.OrderNo)
+	}
+	if params.UserID != "" {
+		db = db.Where("user_id = ?", params.UserID)
+	}
+	if params.Status != "" {
+		db = db.Where("status = ?", params.Status)
+	}
+	if params.Reason != "" {
+		db = db.Where("reason = ?", params.Reason)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get return request count", err)
+		return nil, errors.New("failed to get return request count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&returns).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get return request list", err)
+		return nil, errors.New("failed to get return request list")
+	}
+
+	res, err := s.fillReturnRes(ctx, returns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     res,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// GetReturnReasonReport 按产品统计退货原因，不包含买家取消的申请
+func (s *ReturnService) GetReturnReasonReport(ctx *app.Context, params *model.ReqReturnReportParam) ([]*model.ReturnReasonReport, error) {
+	db := ctx.DB.Model(&model.ReturnItem{}).
+		Joins("JOIN return_requests ON return_requests.uuid = return_items.return_uuid").
+		Joins("LEFT JOIN product_items ON product_items.uuid = return_items.product_item_uuid").
+		Joins("LEFT JOIN products ON products.uuid = product_items.product_uuid").
+		Where("return_requests.status <> ?", model.ReturnStatusCanceled)
+	if params.StartAt != "" {
+		db = db.Where("return_requests.created_at >= ?", params.StartAt)
+	}
+	if params.EndAt != "" {
+		db = db.Where("return_requests.created_at <= ?", params.EndAt)
+	}
+	if params.ProductUuid != "" {
+		db = db.Where("products.uuid = ?", params.ProductUuid)
+	}
+
+	reports := make([]*model.ReturnReasonReport, 0)
+	err := db.Select("products.uuid AS product_uuid, products.name AS product_name, return_items.product_item_uuid, return_requests.reason, " +
+		"COUNT(DISTINCT return_requests.uuid) AS return_count, SUM(return_items.quantity) AS quantity").
+		Group("products.uuid, products.name, return_items.product_item_uuid, return_requests.reason").
+		Order("quantity DESC").Scan(&reports).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get return reason report", err)
+		return nil, errors.New("failed to get return reason report")
+	}
+
+	return reports, nil
+}
+
+func (s *ReturnService) getReturn(ctx *app.Context, returnUuid string) (*model.ReturnRequest, error) {
+	ret := &model.ReturnRequest{}
+	err := ctx.DB.Where("uuid = ?", returnUuid).First(ret).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("return request not found")
+		}
+		ctx.Logger.Error("Failed to get return request by UUID", err)
+		return nil, errors.New("failed to get return request by UUID")
+	}
+	return ret, nil
+}
+
+// 填充退货商品和退款记录
+func (s *ReturnService) fillReturnRes(ctx *app.Context, returns []*model.ReturnRequest) ([]*model.ReturnRequestRes, error) {
+	res := make([]*model.ReturnRequestRes, 0)
+	if len(returns) == 0 {
+		return res, nil
+	}
+
+	returnUuids := make([]string, 0)
+	for _, ret := range returns {
+		returnUuids = append(returnUuids, ret.Uuid)
+	}
+
+	items := make([]*model.ReturnItem, 0)
+	err := ctx.DB.Where("return_uuid IN (?)", returnUuids).Find(&items).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get return items", err)
+		return nil, errors.New("failed to get return items")
+	}
+
+	refunds := make([]*model.Refund, 0)
+	err = ctx.DB.Where("return_uuid IN (?)", returnUuids).Order("id ASC").Find(&refunds).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get refunds", err)
+		return nil, errors.New("failed to get refunds")
+	}
+
+	itemUuids := make([]string, 0)
+	for _, item := range items {
+		itemUuids = append(itemUuids, item.ProductItemUuid)
+	}
+	productItemMap, err := NewProductService().GetProductItemByUUIDList(ctx, itemUuids)
+	if err != nil {
+		return nil, err
+	}
+
+	itemMap := make(map[string][]*model.ReturnItemRes)
+	for _, item := range items {
+		itemMap[item.ReturnUuid] = append(itemMap[item.ReturnUuid], &model.ReturnItemRes{
+			ReturnItem:  *item,
+			ProductItem: productItemMap[item.ProductItemUuid],
+		})
+	}
+	refundMap := make(map[string][]*model.Refund)
+	for _, refund := range refunds {
+		refundMap[refund.ReturnUuid] = append(refundMap[refund.ReturnUuid], refund)
+	}
+
+	for _, ret := range returns {
+		res = append(res, &model.ReturnRequestRes{
+			ReturnRequest: *ret,
+			Items:         itemMap[ret.Uuid],
+			Refunds:       refundMap[ret.Uuid],
+		})
+	}
+	return res, nil
+}
+
+// SendReturnMail 退货申请状态变化时邮件通知买家
+func (s *ReturnService) SendReturnMail(ctx *app.Context, order *model.Order, ret *model.ReturnRequest) {
+	if ctx.Config.MailConfig.Host == "" {
+		return
+	}
+
+	subject, ok := returnMailSubjects[ret.Status]
+	if !ok {
+		return
+	}
+
+	mailTo := order.ReceiverEmail
+	if mailTo == "" {
+		mailTo = NewCustomerService().GetContactEmail(ctx, order.UserID)
+		if mailTo == "" {
+			return
+		}
+	}
+
+	message := ret.AdminRemark
+	switch ret.Status {
+	case model.ReturnStatusRefunded:
+		message = fmt.Sprintf("退款金额：%.2f %s", ret.RefundAmount, ret.AdminRemark)
+	case model.ReturnStatusExchanged:
+		message = fmt.Sprintf("换货订单：%s %s", ret.ExchangeOrderNo, ret.AdminRemark)
+	}
+
+	mailConfig := ctx.Config.MailConfig
+	logger := ctx.Logger
+	go func() {
+		err := mail.Send(&mail.Options{
+			MailHost: mailConfig.Host,
+			MailPort: mailConfig.Port,
+			MailUser: mailConfig.Username,
+			MailPass: mailConfig.Password,
+			MailTo:   mailTo,
+			Subject:  subject,
+			Body:     fmt.Sprintf(returnMailContent, subject, order.OrderNo, ret.Uuid, message),
+		})
+		if err != nil {
+			logger.Error("Failed to send return mail", err)
+		}
+	}()
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/testutil"
+)
+
+// 已发货订单的退货申请，订单已付款100元，线下付款方式不支持原路退款
+func setupReturn(t *testing.T, ctx *app.Context, status string) {
+	t.Helper()
+	rows := []interface{}{
+		&model.Order{OrderNo: "SG1", UserID: "customer1", Status: model.OrderStatusDelivered,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.Payment{Uuid: "pay1", OrderID: "SG1", UserID: "customer1", Amount: 100, Method: "offline", Status: model.PaymentStatusPaid,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.ReturnRequest{Uuid: "ret1", OrderNo: "SG1", UserID: "customer1", Resolution: model.ReturnResolutionRefund,
+			Status: status, RefundAmount: 60, CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+	}
+	for _, row := range rows {
+		err := ctx.DB.Create(row).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func getReturn(t *testing.T, ctx *app.Context) *model.ReturnRequest {
+	t.Helper()
+	ret := &model.ReturnRequest{}
+	err := ctx.DB.Where("uuid = ?", "ret1").First(ret).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+// 退货申请只能按 待审核 -> 已同意 -> 已收货 -> 已退款 的顺序处理
+func TestReturnTransitions(t *testing.T) {
+	approve := func(ctx *app.Context) error {
+		return NewReturnService().ApproveReturn(ctx, &model.ReqReturnReviewParam{Uuid: "ret1"}, "admin")
+	}
+	reject := func(ctx *app.Context) error {
+		return NewReturnService().RejectReturn(ctx, &model.ReqReturnReviewParam{Uuid: "ret1"}, "admin")
+	}
+	cancel := func(ctx *app.Context) error {
+		return NewReturnService().CancelReturn(ctx, "ret1", "customer1")
+	}
+	receive := func(ctx *app.Context) error {
+		return NewReturnService().ReceiveReturn(ctx, &model.ReqReturnReceiveParam{Uuid: "ret1"}, "admin")
+	}
+	refund := func(ctx *app.Context) error {
+		_, err := NewReturnService().RefundReturn(ctx, &model.ReqReturnRefundParam{Uuid: "ret1"}, "admin")
+		return err
+	}
+
+	tests := []struct {
+		name       string
+		status     string
+		action     func(ctx *app.Context) error
+		wantErr    string
+		wantStatus string
+	}{
+		{"approve requested", model.ReturnStatusRequested, approve, "", model.ReturnStatusApproved},
+		{"reject requested", model.ReturnStatusRequested, reject, "", model.ReturnStatusRejected},
+		{"cancel requested", model.ReturnStatusRequested, cancel, "", model.ReturnStatusCanceled},
+		{"receive requested", model.ReturnStatusRequested, receive, "return request status is requested", model.ReturnStatusRequested},
+		{"refund requested", model.ReturnStatusRequested, refund, "return request is not received", model.ReturnStatusRequested},
+		{"cancel approved", model.ReturnStatusApproved, cancel, "return request can not be canceled", model.ReturnStatusApproved},
+		{"reject approved", model.ReturnStatusApproved, reject, "return request status is approved", model.ReturnStatusApproved},
+		{"receive approved", model.ReturnStatusApproved, receive, "", model.ReturnStatusReceived},
+		{"refund received", model.ReturnStatusReceived, refund, "", model.ReturnStatusRefunded},
+		{"refund refunded", model.ReturnStatusRefunded, refund, "return request is not received", model.ReturnStatusRefunded},
+		{"approve rejected", model.ReturnStatusRejected, approve, "return request status is rejected", model.ReturnStatusRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			setupReturn(t, ctx, tt.status)
+
+			err := tt.action(ctx)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Fatalf("err = %q, want %q", got, tt.wantErr)
+			}
+			if status := getReturn(t, ctx).Status; status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// 退款金额默认为退货商品金额，累计退款不能超过付款金额，不支持原路退款时记录为线下退款
+func TestRefundReturn(t *testing.T) {
+	tests := []struct {
+		name       string
+		refunded   float64
+		amount     float64
+		wantErr    string
+		wantAmount float64
+	}{
+		{"default amount", 0, 0, "", 60},
+		{"custom amount", 0, 40, "", 40},
+		{"remaining amount", 50, 50, "", 50},
+		{"exceeds paid amount", 50, 0, "refund amount exceeds paid amount", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			setupReturn(t, ctx, model.ReturnStatusReceived)
+			if tt.refunded > 0 {
+				err := ctx.DB.Create(&model.Refund{Uuid: "refund0", OrderID: "SG1", Amount: tt.refunded, Status: model.RefundStatusManual,
+					CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}).Error
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			refund, err := NewReturnService().RefundReturn(ctx, &model.ReqReturnRefundParam{Uuid: "ret1", Amount: tt.amount}, "admin")
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Fatalf("err = %q, want %q", got, tt.wantErr)
+			}
+			if err != nil {
+				if status := getReturn(t, ctx).Status; status != model.ReturnStatusReceived {
+					t.Errorf("status = %s, want %s", status, model.ReturnStatusReceived)
+				}
+				return
+			}
+			if refund.Amount != tt.wantAmount || refund.Status != model.RefundStatusManual || refund.PaymentUuid != "pay1" {
+				t.Errorf("refund = %v %s %s, want %v manual pay1", refund.Amount, refund.Status, refund.PaymentUuid, tt.wantAmount)
+			}
+			if ret := getReturn(t, ctx); ret.RefundAmount != tt.wantAmount {
+				t.Errorf("return refund amount = %v, want %v", ret.RefundAmount, tt.wantAmount)
+			}
+		})
+	}
+}
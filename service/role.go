@@ -28,6 +28,7 @@ func (s *RoleService) CreateRole(ctx *app.Context, role *model.Role) error {
 		ctx.Logger.Error("Failed to create role", err)
 		return errors.New("failed to create role")
 	}
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -52,6 +53,7 @@ func (s *RoleService) UpdateRole(ctx *app.Context, role *model.Role) error {
 		return errors.New("failed to update role")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -62,6 +64,7 @@ func (s *RoleService) DeleteRole(ctx *app.Context, uuid string) error {
 		return errors.New("failed to delete role")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -26,6 +26,7 @@ func (s *RoleMenuPermissionService) CreateRoleMenuPermission(ctx *app.Context, r
 		ctx.Logger.Error("Failed to create role menu permission", err)
 		return errors.New("failed to create role menu permission")
 	}
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -50,6 +51,7 @@ func (s *RoleMenuPermissionService) UpdateRoleMenuPermission(ctx *app.Context, r
 		return errors.New("failed to update role menu permission")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -60,6 +62,7 @@ func (s *RoleMenuPermissionService) DeleteRoleMenuPermission(ctx *app.Context, u
 		return errors.New("failed to delete role menu permission")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
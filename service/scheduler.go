@@ -0,0 +1,92 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"sgin/pkg/app"
+)
+
+// 调度任务锁前缀，多实例部署时同一任务只有一个实例执行
+const schedulerLockKey = "sgin:scheduler:lock:"
+
+// 调度任务
+type schedulerTask struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx *app.Context)
+}
+
+var (
+	schedulerMu      sync.Mutex
+	schedulerLastRun = make(map[string]time.Time)
+)
+
+// StartScheduler 启动后台调度任务，每分钟检查一次到期的任务
+func StartScheduler(a *app.App) {
+	pollInterval := a.Config.Shipping.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 30
+	}
+
+	tasks := []*schedulerTask{
+		{
+			Name:     "subscription_renewal",
+			Interval: time.Minute,
+			Run:      NewSubscriptionService().ProcessRenewals,
+		},
+		{
+			Name:     "shipment_tracking",
+			Interval: time.Duration(pollInterval) * time.Minute,
+			Run:      NewCarrierService().PollTracking,
+		},
+		{
+			Name:     "webhook_delivery",
+			Interval: time.Minute,
+			Run:      NewWebhookService().ProcessDeliveries,
+		},
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			for _, task := range tasks {
+				runSchedulerTask(a, task)
+			}
+		}
+	}()
+}
+
+func runSchedulerTask(a *app.App, task *schedulerTask) {
+	ctx := a.NewContext()
+	defer func() {
+		if err := recover(); err != nil {
+			ctx.Logger.Error("Scheduler task "+task.Name+" panic", err)
+		}
+	}()
+
+	schedulerMu.Lock()
+	due := time.Since(schedulerLastRun[task.Name]) >= task.Interval-time.Second
+	if due {
+		schedulerLastRun[task.Name] = time.Now()
+	}
+	schedulerMu.Unlock()
+	if !due {
+		return
+	}
+
+	if ctx.Redis != nil {
+		ok, err := ctx.Redis.SetNX(ctx.Ctx, schedulerLockKey+task.Name, ctx.TraceID, task.Interval-10*time.Second)
+		if err != nil {
+			ctx.Logger.Error("Failed to acquire scheduler lock", err)
+			return
+		}
+		if !ok {
+			return
+		}
+	}
+
+	task.Run(ctx)
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/utils"
+
+	"github.com/google/uuid"
+	"github.com/mileusna/useragent"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const sessionRevokedKeyPrefix = "sgin:session:revoked:"
+
+type SessionService struct {
+}
+
+func NewSessionService() *SessionService {
+	return &SessionService{}
+}
+
+// CreateSession 登录成功后创建会话，返回访问token和刷新token
+func (s *SessionService) CreateSession(ctx *app.Context, userId, audience string) (*model.ResUserLogin, error) {
+	uaString := ctx.GetHeader("User-Agent")
+	ua := useragent.Parse(uaString)
+	if len(uaString) > 500 {
+		uaString = uaString[:500]
+	}
+
+	secret, err := s.newSecret()
+	if err != nil {
+		ctx.Logger.Error("Failed to generate refresh token", err)
+		return nil, errors.New("failed to create session")
+	}
+
+	now := time.Now()
+	session := &model.UserSession{
+		Uuid:             uuid.New().String(),
+		UserID:           userId,
+		Audience:         audience,
+		RefreshTokenHash: s.hashSecret(secret),
+		Ip:               ctx.ClientIP(),
+		UserAgent:        uaString,
+		Browser:          strings.TrimSpace(ua.Name + " " + ua.Version),
+		Os:               ua.OS,
+		Device:           ua.Device,
+		LastActiveAt:     now.Format(time.DateTime),
+		ExpiresAt:        now.Add(s.refreshTokenExpire(ctx)).Format(time.DateTime),
+		CreatedAt:        now.Format(time.DateTime),
+		UpdatedAt:        now.Format(time.DateTime),
+	}
+	err = ctx.DB.Create(session).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create session", err)
+		return nil, errors.New("failed to create session")
+	}
+
+	return s.issueTokens(ctx, session, secret)
+}
+
+// RefreshSession 使用刷新token换取新的访问token，刷新token同时轮换
+// 已轮换的旧刷新token再次使用时视为泄露，撤销整个会话
+func (s *SessionService) RefreshSession(ctx *app.Context, refreshToken string) (*model.ResUserLogin, error) {
+	sessionUuid, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || sessionUuid == "" || secret == "" {
+		return nil, errors.New("invalid refresh token")
+	}
+
+	var (
+		res    *model.ResUserLogin
+		reused bool
+	)
+	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+		session := &model.UserSession{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("uuid = ?", sessionUuid).First(session).Error
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("invalid refresh token")
+			}
+			ctx.Logger.Error("Failed to get session", err)
+			return errors.New("failed to get session")
+		}
+
+		if session.RevokedAt != "" || session.ExpiresAt < time.Now().Format(time.DateTime) {
+			tx.Rollback()
+			return errors.New("session is expired")
+		}
+
+		if s.hashSecret(secret) != session.RefreshTokenHash {
+			reused = true
+			tx.Rollback()
+			return errors.New("invalid refresh token")
+		}
+
+		newSecret, err := s.newSecret()
+		if err != nil {
+			ctx.Logger.Error("Failed to generate refresh token", err)
+			tx.Rollback()
+			return errors.New("failed to refresh session")
+		}
+
+		now := time.Now().Format(time.DateTime)
+		err = tx.Model(&model.UserSession{}).Where("id = ?", session.ID).Updates(map[string]interface{}{
+			"refresh_token_hash": s.hashSecret(newSecret),
+			"ip":                 ctx.ClientIP(),
+			"last_active_at":     now,
+			"updated_at":         now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update session", err)
+			tx.Rollback()
+			return errors.New("failed to refresh session")
+		}
+
+		res, err = s.issueTokens(ctx, session, newSecret)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		return nil
+	})
+	if reused {
+		ctx.Logger.Warn("Refresh token reused, revoking session " + sessionUuid)
+		s.revoke(ctx, ctx.DB.Where("uuid = ?", sessionUuid))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetSessionList 获取用户的有效会话列表
+func (s *SessionService) GetSessionList(ctx *app.Context, userId, currentSessionUuid string) ([]*model.UserSessionRes, error) {
+	sessions := make([]*model.UserSession, 0)
+	err := ctx.DB.Where("user_id = ? AND revoked_at = ? AND expires_at >= ?", userId, "", time.Now().Format(time.DateTime)).
+		Order("last_active_at DESC").Find(&sessions).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get session list", err)
+		return nil, errors.New("failed to get session list")
+	}
+
+	res := make([]*model.UserSessionRes, 0)
+	for _, session := range sessions {
+		res = append(res, &model.UserSessionRes{
+			UserSession: *session,
+			Current:     session.Uuid == currentSessionUuid,
+		})
+	}
+	return res, nil
+}
+
+// RevokeSession 撤销用户的一个会话
+func (s *SessionService) RevokeSession(ctx *app.Context, userId, sessionUuid string) error {
+	return s.revoke(ctx, ctx.DB.Where("user_id = ? AND uuid = ?", userId, sessionUuid))
+}
+
+// RevokeAllSessions 撤销用户的所有会话，修改密码后调用
+func (s *SessionService) RevokeAllSessions(ctx *app.Context, userId string) error {
+	return s.revoke(ctx, ctx.DB.Where("user_id = ?", userId))
+}
+
+// ParseToken 解析访问token，会话已撤销时返回错误
+func (s *SessionService) ParseToken(ctx *app.Context, token string) (*utils.TokenClaims, error) {
+	claims, err := utils.ParseTokenClaims(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.SessionID == "" {
+		return nil, errors.New("invalid token")
+	}
+
+	// 配置了redis时查询撤销名单，否则查询会话表
+	if ctx.Redis != nil {
+		revoked, err := ctx.Redis.Exists(ctx.Ctx, sessionRevokedKeyPrefix+claims.SessionID)
+		if err != nil {
+			ctx.Logger.Error("Failed to check session denylist", err)
+			return nil, errors.New("failed to check session")
+		}
+		if revoked {
+			return nil, errors.New("session is revoked")
+		}
+		return claims, nil
+	}
+
+	var count int64
+	err = ctx.DB.Model(&model.UserSession{}).Where("uuid = ? AND revoked_at = ?", claims.SessionID, "").Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to check session", err)
+		return nil, errors.New("failed to check session")
+	}
+	if count == 0 {
+		return nil, errors.New("session is revoked")
+	}
+	return claims, nil
+}
+
+// 撤销查询到的有效会话，并加入redis撤销名单直到已签发的访问token过期
+func (s *SessionService) revoke(ctx *app.Context, query *gorm.DB) error {
+	sessionUuids := make([]string, 0)
+	err := query.Model(&model.UserSession{}).Where("revoked_at = ?", "").Pluck("uuid", &sessionUuids).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get sessions", err)
+		return errors.New("failed to revoke session")
+	}
+	if len(sessionUuids) == 0 {
+		return nil
+	}
+
+	now := time.Now().Format(time.DateTime)
+	err = ctx.DB.Model(&model.UserSession{}).Where("uuid IN (?)", sessionUuids).Updates(map[string]interface{}{
+		"revoked_at": now,
+		"updated_at": now,
+	}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to revoke sessions", err)
+		return errors.New("failed to revoke session")
+	}
+
+	if ctx.Redis != nil {
+		for _, sessionUuid := range sessionUuids {
+			err = ctx.Redis.Set(ctx.Ctx, sessionRevokedKeyPrefix+sessionUuid, now, s.accessTokenExpire(ctx))
+			if err != nil {
+				ctx.Logger.Error("Failed to add session to denylist", err)
+				return errors.New("failed to revoke session")
+			}
+		}
+	}
+	return nil
+}
+
+func (s *SessionService) issueTokens(ctx *app.Context, session *model.UserSession, secret string) (*model.ResUserLogin, error) {
+	expire := s.accessTokenExpire(ctx)
+	token, err := utils.GenerateAccessToken(session.UserID, session.Audience, session.Uuid, expire)
+	if err != nil {
+		ctx.Logger.Error("Failed to generate access token", err)
+		return nil, errors.New("failed to generate token")
+	}
+
+	return &model.ResUserLogin{
+		Step:         model.LoginStepDone,
+		Token:        token,
+		RefreshToken: session.Uuid + "." + secret,
+		ExpiresIn:    int64(expire.Seconds()),
+	}, nil
+}
+
+func (s *SessionService) newSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *SessionService) hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *SessionService) accessTokenExpire(ctx *app.Context) time.Duration {
+	if ctx.Config.Auth.AccessTokenExpire > 0 {
+		return time.Duration(ctx.Config.Auth.AccessTokenExpire) * time.Minute
+	}
+	return 15 * time.Minute
+}
+
+func (s *SessionService) refreshTokenExpire(ctx *app.Context) time.Duration {
+	if ctx.Config.Auth.RefreshTokenExpire > 0 {
+		return time.Duration(ctx.Config.Auth.RefreshTokenExpire) * time.Hour
+	}
+	return 720 * time.Hour
+}
@@ -0,0 +1,390 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/mail"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var shipmentMailContent = `
+<html>
+<body>
+    <h2>您的订单已发货</h2>
+    <p>订单号：%s</p>
+    <p>承运商：%s</p>
+    <p>物流单号：%s</p>
+    <ul>%s</ul>
+</body>
+</html>
+`
+
+type ShipmentService struct {
+}
+
+func NewShipmentService() *ShipmentService {
+	return &ShipmentService{}
+}
+
+// CreateShipment 创建发货包裹，订单商品全部发出后订单变为已发货
+func (s *ShipmentService) CreateShipment(ctx *app.Context, params *model.ReqShipmentCreate, operator string) (*model.ShipmentRes, error) {
+	return s.createShipment(ctx, params, operator, nil)
+}
+
+// 创建发货包裹，label不为空时记录面单信息
+func (s *ShipmentService) createShipment(ctx *app.Context, params *model.ReqShipmentCreate, operator string, label *model.Shipment) (*model.ShipmentRes, error) {
+	var (
+		order *model.Order
+		res   *model.ShipmentRes
+	)
+
+	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+		order = &model.Order{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_no = ?", params.OrderNo).First(order).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.New("order not found")
+			}
+			ctx.Logger.Error("Failed to get order by order no", err)
+			return errors.New("failed to get order by order no")
+		}
+
+		if order.IsVirtual {
+			return errors.New("virtual order does not need shipping")
+		}
+		if order.Status != model.OrderStatusPaid {
+			return errors.New("order is not waiting for shipment")
+		}
+
+		remaining, orderItemMap, err := s.remainingQuantities(ctx, tx, order.OrderNo)
+		if err != nil {
+			return err
+		}
+
+		reqItems := params.Items
+		if len(reqItems) == 0 {
+			for orderItemId, quantity := range remaining {
+				if quantity > 0 {
+					reqItems = append(reqItems, &model.ReqShipmentItem{OrderItemID: orderItemId, Quantity: quantity})
+				}
+			}
+		}
+		if len(reqItems) == 0 {
+			return errors.New("all order items have been shipped")
+		}
+
+		now := time.Now().Format(time.DateTime)
+		shipment := &model.Shipment{
+			Uuid:       uuid.New().String(),
+			OrderNo:    order.OrderNo,
+			Carrier:    params.Carrier,
+			TrackingNo: params.TrackingNo,
+			Status:     model.ShipmentStatusShipped,
+			Remark:     params.Remark,
+			Operator:   operator,
+			ShippedAt:  now,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		if label != nil {
+			shipment.Service = label.Service
+			shipment.LabelResourceUuid = label.LabelResourceUuid
+			shipment.LabelFormat = label.LabelFormat
+		}
+
+		items := make([]*model.ShipmentItem, 0)
+		for _, reqItem := range reqItems {
+			orderItem, ok := orderItemMap[reqItem.OrderItemID]
+			if !ok {
+				return errors.New("order item not found")
+			}
+			if reqItem.Quantity <= 0 || reqItem.Quantity > remaining[reqItem.OrderItemID] {
+				return fmt.Errorf("invalid shipment quantity for order item %d", reqItem.OrderItemID)
+			}
+			remaining[reqItem.OrderItemID] -= reqItem.Quantity
+
+			items = append(items, &model.ShipmentItem{
+				ShipmentUuid:    shipment.Uuid,
+				OrderNo:         order.OrderNo,
+				OrderItemID:     orderItem.ID,
+				ProductItemUuid: orderItem.ProductItemID,
+				Quantity:        reqItem.Quantity,
+				CreatedAt:       now,
+			})
+		}
+
+		err = tx.Create(shipment).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create shipment", err)
+			tx.Rollback()
+			return errors.New("failed to create shipment")
+		}
+
+		err = tx.Create(&items).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create shipment items", err)
+			tx.Rollback()
+			return errors.New("failed to create shipment items")
+		}
+
+		shippedAll := true
+		for _, quantity := range remaining {
+			if quantity > 0 {
+				shippedAll = false
+				break
+			}
+		}
+		if shippedAll {
+			order.Status = model.OrderStatusDelivered
+			order.DeliveredAt = now
+			err = tx.Model(&model.Order{}).Where("order_no = ?", order.OrderNo).Updates(map[string]interface{}{
+				"status":       order.Status,
+				"delivered_at": now,
+				"updated_at":   now,
+			}).Error
+			if err != nil {
+				ctx.Logger.Error("Failed to update order status", err)
+				tx.Rollback()
+				return errors.New("failed to update order status")
+			}
+		}
+
+		res = &model.ShipmentRes{
+			Shipment: *shipment,
+			Items:    items,
+			Events:   make([]*model.ShipmentEvent, 0),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.SendShipmentMail(ctx, order, res)
+	NewWebhookService().Publish(ctx, order.TenantUuid, model.WebhookEventOrderShipped, map[string]interface{}{
+		"order_no": order.OrderNo,
+		"status":   order.Status,
+		"shipment": res,
+	})
+
+	return res, nil
+}
+
+// 订单商品的未发货数量
+func (s *ShipmentService) remainingQuantities(ctx *app.Context, tx *gorm.DB, orderNo string) (map[int64]int, map[int64]*model.OrderItem, error) {
+	orderItems := make([]*model.OrderItem, 0)
+	err := tx.Where("order_id = ?", orderNo).Find(&orderItems).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get order items", err)
+		return nil, nil, errors.New("failed to get order items")
+	}
+
+	shipped := make([]*model.ShipmentItem, 0)
+	err = tx.Where("order_no = ?", orderNo).Find(&shipped).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get shipment items", err)
+		return nil, nil, errors.New("failed to get shipment items")
+	}
+
+	remaining := make(map[int64]int)
+	orderItemMap := make(map[int64]*model.OrderItem)
+	for _, orderItem := range orderItems {
+		remaining[orderItem.ID] = orderItem.Quantity
+		orderItemMap[orderItem.ID] = orderItem
+	}
+	for _, item := range shipped {
+		remaining[item.OrderItemID] -= item.Quantity
+	}
+
+	return remaining, orderItemMap, nil
+}
+
+// GetShipmentList 获取发货包裹列表
+func (s *ShipmentService) GetShipmentList(ctx *app.Context, params *model.ReqShipmentQueryParam) (*model.PagedResponse, error) {
+	var (
+		shipments []*model.Shipment
+		total     int64
+	)
+
+	db := ctx.DB.Model(&model.Shipment{})
+	if params.OrderNo != "" {
+		db = db.Where("order_no = ?", params.OrderNo)
+	}
+	if params.TrackingNo != "" {
+		db = db.Where("tracking_no = ?", params.TrackingNo)
+	}
+	if params.Status != "" {
+		db = db.Where("status = ?", params.Status)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get shipment count", err)
+		return nil, errors.New("failed to get shipment count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&shipments).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get shipment list", err)
+		return nil, errors.New("failed to get shipment list")
+	}
+
+	res, err := s.fillShipmentRes(ctx, shipments)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     res,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// GetShipmentsByOrderNo 获取订单的发货包裹
+func (s *ShipmentService) GetShipmentsByOrderNo(ctx *app.Context, orderNo string) ([]*model.ShipmentRes, error) {
+	shipments := make([]*model.Shipment, 0)
+	err := ctx.DB.Where("order_no = ?", orderNo).Order("id ASC").Find(&shipments).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get shipments by order no", err)
+		return nil, errors.New("failed to get shipments by order no")
+	}
+
+	return s.fillShipmentRes(ctx, shipments)
+}
+
+// GetOrderTracking 获取用户订单的物流信息
+func (s *ShipmentService) GetOrderTracking(ctx *app.Context, orderNo, userId string) (*model.OrderTrackingRes, error) {
+	order, err := NewOrderService().GetOrderByID(ctx, orderNo)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userId {
+		return nil, errors.New("order not found")
+	}
+
+	shipments, err := s.GetShipmentsByOrderNo(ctx, order.OrderNo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.OrderTrackingRes{
+		OrderNo:     order.OrderNo,
+		Status:      order.Status,
+		DeliveredAt: order.DeliveredAt,
+		Shipments:   shipments,
+	}, nil
+}
+
+func (s *ShipmentService) fillShipmentRes(ctx *app.Context, shipments []*model.Shipment) ([]*model.ShipmentRes, error) {
+	res := make([]*model.ShipmentRes, 0)
+	if len(shipments) == 0 {
+		return res, nil
+	}
+
+	shipmentUuids := make([]string, 0)
+	for _, shipment := range shipments {
+		shipmentUuids = append(shipmentUuids, shipment.Uuid)
+	}
+
+	items := make([]*model.ShipmentItem, 0)
+	err := ctx.DB.Where("shipment_uuid IN (?)", shipmentUuids).Find(&items).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get shipment items", err)
+		return nil, errors.New("failed to get shipment items")
+	}
+
+	itemMap := make(map[string][]*model.ShipmentItem)
+	for _, item := range items {
+		itemMap[item.ShipmentUuid] = append(itemMap[item.ShipmentUuid], item)
+	}
+
+	events := make([]*model.ShipmentEvent, 0)
+	err = ctx.DB.Where("shipment_uuid IN (?)", shipmentUuids).Order("occurred_at DESC").Find(&events).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get shipment events", err)
+		return nil, errors.New("failed to get shipment events")
+	}
+
+	eventMap := make(map[string][]*model.ShipmentEvent)
+	for _, event := range events {
+		eventMap[event.ShipmentUuid] = append(eventMap[event.ShipmentUuid], event)
+	}
+
+	for _, shipment := range shipments {
+		shipmentItems, ok := itemMap[shipment.Uuid]
+		if !ok {
+			shipmentItems = make([]*model.ShipmentItem, 0)
+		}
+		shipmentEvents, ok := eventMap[shipment.Uuid]
+		if !ok {
+			shipmentEvents = make([]*model.ShipmentEvent, 0)
+		}
+		res = append(res, &model.ShipmentRes{
+			Shipment: *shipment,
+			Items:    shipmentItems,
+			Events:   shipmentEvents,
+		})
+	}
+
+	return res, nil
+}
+
+// SendShipmentMail 发送发货通知邮件
+func (s *ShipmentService) SendShipmentMail(ctx *app.Context, order *model.Order, shipment *model.ShipmentRes) {
+	if ctx.Config.MailConfig.Host == "" {
+		return
+	}
+
+	mailTo := order.ReceiverEmail
+	if mailTo == "" {
+		mailTo = NewCustomerService().GetContactEmail(ctx, order.UserID)
+		if mailTo == "" {
+			return
+		}
+	}
+
+	itemUuids := make([]string, 0)
+	for _, item := range shipment.Items {
+		itemUuids = append(itemUuids, item.ProductItemUuid)
+	}
+	productItemMap, err := NewProductService().GetProductItemByUUIDList(ctx, itemUuids)
+	if err != nil {
+		return
+	}
+
+	lines := make([]string, 0)
+	for _, item := range shipment.Items {
+		name := item.ProductItemUuid
+		if productItem, ok := productItemMap[item.ProductItemUuid]; ok {
+			name = productItem.Name
+		}
+		lines = append(lines, fmt.Sprintf("<li>%s × %d</li>", name, item.Quantity))
+	}
+
+	mailConfig := ctx.Config.MailConfig
+	logger := ctx.Logger
+	go func() {
+		err := mail.Send(&mail.Options{
+			MailHost: mailConfig.Host,
+			MailPort: mailConfig.Port,
+			MailUser: mailConfig.Username,
+			MailPass: mailConfig.Password,
+			MailTo:   mailTo,
+			Subject:  "订单发货通知",
+			Body:     fmt.Sprintf(shipmentMailContent, order.OrderNo, shipment.Carrier, shipment.TrackingNo, strings.Join(lines, "")),
+		})
+		if err != nil {
+			logger.Error("Failed to send shipment mail", err)
+		}
+	}()
+}
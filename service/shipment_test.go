@@ -0,0 +1,132 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/testutil"
+)
+
+// 创建订单，两个商品，数量分别为2和1，返回订单商品ID
+func setupShipmentOrder(t *testing.T, ctx *app.Context, order *model.Order) []int64 {
+	t.Helper()
+	order.OrderNo = "SG1"
+	order.UserID = "customer1"
+	order.CreatedAt = "2026-01-01 00:00:00"
+	order.UpdatedAt = "2026-01-01 00:00:00"
+	err := ctx.DB.Create(order).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := make([]int64, 0)
+	for i, quantity := range []int{2, 1} {
+		item := &model.OrderItem{OrderID: "SG1", ProductItemID: []string{"item1", "item2"}[i], Quantity: quantity,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}
+		err = ctx.DB.Create(item).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+// 按包裹发货：校验订单状态和发货数量，商品全部发出后订单变为已发货
+func TestCreateShipment(t *testing.T) {
+	tests := []struct {
+		name       string
+		order      model.Order
+		shipments  [][]int // 每个包裹中两个订单商品的发货数量，为空时发出所有未发货商品
+		wantErr    string
+		wantStatus string
+	}{
+		{"ship all", model.Order{Status: model.OrderStatusPaid}, [][]int{nil}, "", model.OrderStatusDelivered},
+		{"partial shipment", model.Order{Status: model.OrderStatusPaid}, [][]int{{1, 0}}, "", model.OrderStatusPaid},
+		{"split into parcels", model.Order{Status: model.OrderStatusPaid}, [][]int{{1, 1}, {1, 0}}, "", model.OrderStatusDelivered},
+		{"rest after partial", model.Order{Status: model.OrderStatusPaid}, [][]int{{2, 0}, nil}, "", model.OrderStatusDelivered},
+		{"over shipment", model.Order{Status: model.OrderStatusPaid}, [][]int{{1, 0}, {2, 0}}, "invalid shipment quantity", model.OrderStatusPaid},
+		{"unpaid order", model.Order{Status: model.OrderStatusPending}, [][]int{nil}, "order is not waiting for shipment", model.OrderStatusPending},
+		{"virtual order", model.Order{Status: model.OrderStatusPaid, IsVirtual: true}, [][]int{nil}, "virtual order does not need shipping", model.OrderStatusPaid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			order := tt.order
+			ids := setupShipmentOrder(t, ctx, &order)
+
+			var err error
+			for _, quantities := range tt.shipments {
+				params := &model.ReqShipmentCreate{OrderNo: "SG1", Carrier: "sf", TrackingNo: "T1"}
+				for i, quantity := range quantities {
+					if quantity > 0 {
+						params.Items = append(params.Items, &model.ReqShipmentItem{OrderItemID: ids[i], Quantity: quantity})
+					}
+				}
+				_, err = NewShipmentService().CreateShipment(ctx, params, "admin")
+				if err != nil {
+					break
+				}
+			}
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if (tt.wantErr == "") != (got == "") || !strings.HasPrefix(got, tt.wantErr) {
+				t.Fatalf("err = %q, want %q", got, tt.wantErr)
+			}
+
+			saved := &model.Order{}
+			err = ctx.DB.Where("order_no = ?", "SG1").First(saved).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", saved.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// 包裹记录承运商和物流单号，发货数量按包裹累计
+func TestCreateShipmentRecordsCarrier(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	ids := setupShipmentOrder(t, ctx, &model.Order{Status: model.OrderStatusPaid})
+
+	_, err := NewShipmentService().CreateShipment(ctx, &model.ReqShipmentCreate{OrderNo: "SG1", Carrier: "sf", TrackingNo: "SF1",
+		Items: []*model.ReqShipmentItem{{OrderItemID: ids[0], Quantity: 2}}}, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewShipmentService().CreateShipment(ctx, &model.ReqShipmentCreate{OrderNo: "SG1", Carrier: "ems", TrackingNo: "EMS1"}, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shipments, err := NewShipmentService().GetShipmentsByOrderNo(ctx, "SG1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		carrier, trackingNo string
+		orderItemID         int64
+		quantity            int
+	}{
+		{"sf", "SF1", ids[0], 2},
+		{"ems", "EMS1", ids[1], 1},
+	}
+	if len(shipments) != len(want) {
+		t.Fatalf("shipments = %d, want %d", len(shipments), len(want))
+	}
+	for i, w := range want {
+		s := shipments[i]
+		if s.Carrier != w.carrier || s.TrackingNo != w.trackingNo {
+			t.Errorf("shipment %d = %s %s, want %s %s", i, s.Carrier, s.TrackingNo, w.carrier, w.trackingNo)
+		}
+		if len(s.Items) != 1 || s.Items[0].OrderItemID != w.orderItemID || s.Items[0].Quantity != w.quantity {
+			t.Errorf("shipment %d items = %+v", i, s.Items)
+		}
+	}
+}
@@ -0,0 +1,712 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/mail"
+	paymentmethod "sgin/pkg/payment-method"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// 扣款失败后的重试间隔，超过次数后取消订阅
+var subscriptionRetryDelays = []time.Duration{
+	24 * time.Hour,
+	3 * 24 * time.Hour,
+	5 * 24 * time.Hour,
+}
+
+var subscriptionDunningMailContent = `
+<html>
+<body>
+    <h2>订阅续费扣款失败</h2>
+    <p>订阅计划：%s</p>
+    <p>续费订单：%s</p>
+    <p>%s</p>
+</body>
+</html>
+`
+
+type SubscriptionService struct {
+}
+
+func NewSubscriptionService() *SubscriptionService {
+	return &SubscriptionService{}
+}
+
+// CreateSubscriptionPlan 创建订阅计划
+func (s *SubscriptionService) CreateSubscriptionPlan(ctx *app.Context, params *model.ReqSubscriptionPlanCreate) (*model.SubscriptionPlan, error) {
+	if !s.validInterval(params.Interval) {
+		return nil, errors.New("invalid subscription interval")
+	}
+
+	productItem := &model.ProductItem{}
+	err := ctx.DB.Where("uuid = ? AND product_uuid = ?", params.ProductItemUuid, params.ProductUuid).First(productItem).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("product item not found")
+		}
+		ctx.Logger.Error("Failed to get product item by UUID", err)
+		return nil, errors.New("failed to get product item by UUID")
+	}
+
+	currencyCode := params.CurrencyCode
+	if currencyCode == "" {
+		currencyCode = productItem.CurrencyCode
+	}
+
+	intervalCount := params.IntervalCount
+	if intervalCount <= 0 {
+		intervalCount = 1
+	}
+
+	now := time.Now().Format(time.DateTime)
+	plan := &model.SubscriptionPlan{
+		Uuid:            uuid.New().String(),
+		ProductUuid:     params.ProductUuid,
+		ProductItemUuid: params.ProductItemUuid,
+		Name:            params.Name,
+		Interval:        params.Interval,
+		IntervalCount:   intervalCount,
+		TrialDays:       params.TrialDays,
+		Price:           params.Price,
+		CurrencyCode:    currencyCode,
+		Status:          model.SubscriptionPlanStatusEnabled,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	err = ctx.DB.Create(plan).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create subscription plan", err)
+		return nil, errors.New("failed to create subscription plan")
+	}
+
+	return plan, nil
+}
+
+// UpdateSubscriptionPlan 更新订阅计划，已有订阅从下一期开始按新价格续费
+func (s *SubscriptionService) UpdateSubscriptionPlan(ctx *app.Context, params *model.ReqSubscriptionPlanUpdate) error {
+	if params.Interval != "" && !s.validInterval(params.Interval) {
+		return errors.New("invalid subscription interval")
+	}
+
+	updates := map[string]interface{}{
+		"updated_at": time.Now().Format(time.DateTime),
+	}
+	if params.Name != "" {
+		updates["name"] = params.Name
+	}
+	if params.Interval != "" {
+		updates["interval"] = params.Interval
+	}
+	if params.IntervalCount > 0 {
+		updates["interval_count"] = params.IntervalCount
+	}
+	if params.TrialDays != nil {
+		updates["trial_days"] = *params.TrialDays
+	}
+	if params.Price > 0 {
+		updates["price"] = params.Price
+	}
+	if params.Status == model.SubscriptionPlanStatusEnabled || params.Status == model.SubscriptionPlanStatusDisabled {
+		updates["status"] = params.Status
+	}
+
+	result := ctx.DB.Model(&model.SubscriptionPlan{}).Where("uuid = ?", params.Uuid).Updates(updates)
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to update subscription plan", result.Error)
+		return errors.New("failed to update subscription plan")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("subscription plan not found")
+	}
+
+	return nil
+}
+
+// GetSubscriptionPlanByUUID 获取订阅计划
+func (s *SubscriptionService) GetSubscriptionPlanByUUID(ctx *app.Context, planUuid string) (*model.SubscriptionPlan, error) {
+	plan := &model.SubscriptionPlan{}
+	err := ctx.DB.Where("uuid = ?", planUuid).First(plan).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("subscription plan not found")
+		}
+		ctx.Logger.Error("Failed to get subscription plan by UUID", err)
+		return nil, errors.New("failed to get subscription plan by UUID")
+	}
+	return plan, nil
+}
+
+// GetSubscriptionPlanList 获取订阅计划列表
+func (s *SubscriptionService) GetSubscriptionPlanList(ctx *app.Context, params *model.ReqSubscriptionPlanQueryParam) (*model.PagedResponse, error) {
+	var (
+		plans []*model.SubscriptionPlan
+		total int64
+	)
+
+	db := ctx.DB.Model(&model.SubscriptionPlan{})
+	if params.ProductUuid != "" {
+		db = db.Where("product_uuid = ?", params.ProductUuid)
+	}
+	if params.Status > 0 {
+		db = db.Where("status = ?", params.Status)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get subscription plan count", err)
+		return nil, errors.New("failed to get subscription plan count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&plans).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get subscription plan list", err)
+		return nil, errors.New("failed to get subscription plan list")
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     plans,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// Subscribe 客户订阅，有试用期时试用结束后首次扣款，否则由调度任务立即扣款
+func (s *SubscriptionService) Subscribe(ctx *app.Context, userId string, params *model.ReqSubscriptionCreate) (*model.Subscription, error) {
+	plan, err := s.GetSubscriptionPlanByUUID(ctx, params.PlanUuid)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Status != model.SubscriptionPlanStatusEnabled {
+		return nil, errors.New("subscription plan is disabled")
+	}
+
+	if _, err := s.recurringPayment(ctx, params.PaymentMethod); err != nil {
+		return nil, err
+	}
+
+	productItemMap, err := NewProductService().GetProductItemByUUIDList(ctx, []string{plan.ProductItemUuid})
+	if err != nil {
+		return nil, err
+	}
+	productItem, ok := productItemMap[plan.ProductItemUuid]
+	if !ok {
+		return nil, errors.New("product item not found")
+	}
+	if !NewOrderService().isVirtualOrder([]*model.ProductItemRes{productItem}) && params.Receiver.ReceiverAddress == "" {
+		return nil, errors.New("receiver address is required")
+	}
+
+	quantity := params.Quantity
+	if quantity <= 0 {
+		quantity = 1
+	}
+
+	now := time.Now()
+	subscription := &model.Subscription{
+		Uuid:               uuid.New().String(),
+		UserID:             userId,
+		PlanUuid:           plan.Uuid,
+		ProductItemUuid:    plan.ProductItemUuid,
+		Quantity:           quantity,
+		Status:             model.SubscriptionStatusActive,
+		PaymentMethod:      params.PaymentMethod,
+		AgreementNo:        params.AgreementNo,
+		OrderReceiver:      params.Receiver,
+		CurrentPeriodStart: now.Format(time.DateTime),
+		NextBillingAt:      now.Format(time.DateTime),
+		CreatedAt:          now.Format(time.DateTime),
+		UpdatedAt:          now.Format(time.DateTime),
+	}
+	if plan.TrialDays > 0 {
+		trialEnd := now.AddDate(0, 0, plan.TrialDays).Format(time.DateTime)
+		subscription.Status = model.SubscriptionStatusTrialing
+		subscription.TrialEndAt = trialEnd
+		subscription.CurrentPeriodEnd = trialEnd
+		subscription.NextBillingAt = trialEnd
+	}
+
+	err = ctx.DB.Create(subscription).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create subscription", err)
+		return nil, errors.New("failed to create subscription")
+	}
+
+	return subscription, nil
+}
+
+// GetSubscriptionList 获取订阅列表
+func (s *SubscriptionService) GetSubscriptionList(ctx *app.Context, params *model.ReqSubscriptionQueryParam) (*model.PagedResponse, error) {
+	var (
+		subscriptions []*model.Subscription
+		total         int64
+	)
+
+	db := ctx.DB.Model(&model.Subscription{})
+	if params.UserID != "" {
+		db = db.Where("user_id = ?", params.UserID)
+	}
+	if params.PlanUuid != "" {
+		db = db.Where("plan_uuid = ?", params.PlanUuid)
+	}
+	if params.Status != "" {
+		db = db.Where("status = ?", params.Status)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get subscription count", err)
+		return nil, errors.New("failed to get subscription count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&subscriptions).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get subscription list", err)
+		return nil, errors.New("failed to get subscription list")
+	}
+
+	planUuids := make([]string, 0)
+	for _, subscription := range subscriptions {
+		planUuids = append(planUuids, subscription.PlanUuid)
+	}
+
+	plans := make([]*model.SubscriptionPlan, 0)
+	if len(planUuids) > 0 {
+		err = ctx.DB.Where("uuid IN (?)", planUuids).Find(&plans).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get subscription plan list", err)
+			return nil, errors.New("failed to get subscription plan list")
+		}
+	}
+	planMap := make(map[string]*model.SubscriptionPlan)
+	for _, plan := range plans {
+		planMap[plan.Uuid] = plan
+	}
+
+	res := make([]*model.SubscriptionRes, 0)
+	for _, subscription := range subscriptions {
+		res = append(res, &model.SubscriptionRes{
+			Subscription: *subscription,
+			Plan:         planMap[subscription.PlanUuid],
+		})
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     res,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// PauseSubscription 暂停订阅，暂停期间不扣款
+func (s *SubscriptionService) PauseSubscription(ctx *app.Context, userId, subscriptionUuid string) error {
+	subscription, err := s.getUserSubscription(ctx, userId, subscriptionUuid)
+	if err != nil {
+		return err
+	}
+
+	switch subscription.Status {
+	case model.SubscriptionStatusActive, model.SubscriptionStatusTrialing, model.SubscriptionStatusPastDue:
+	default:
+		return errors.New("subscription can not be paused")
+	}
+
+	now := time.Now().Format(time.DateTime)
+	return s.updateSubscription(ctx, subscription.Uuid, map[string]interface{}{
+		"status":     model.SubscriptionStatusPaused,
+		"paused_at":  now,
+		"updated_at": now,
+	})
+}
+
+// ResumeSubscription 恢复订阅，已过扣款时间的立即扣款
+func (s *SubscriptionService) ResumeSubscription(ctx *app.Context, userId, subscriptionUuid string) error {
+	subscription, err := s.getUserSubscription(ctx, userId, subscriptionUuid)
+	if err != nil {
+		return err
+	}
+	if subscription.Status != model.SubscriptionStatusPaused {
+		return errors.New("subscription is not paused")
+	}
+
+	now := time.Now().Format(time.DateTime)
+	nextBillingAt := subscription.NextBillingAt
+	if nextBillingAt < now {
+		nextBillingAt = now
+	}
+
+	return s.updateSubscription(ctx, subscription.Uuid, map[string]interface{}{
+		"status":          model.SubscriptionStatusActive,
+		"next_billing_at": nextBillingAt,
+		"retry_count":     0,
+		"paused_at":       "",
+		"updated_at":      now,
+	})
+}
+
+// CancelSubscription 取消订阅，未支付的续费订单同时关闭
+func (s *SubscriptionService) CancelSubscription(ctx *app.Context, userId, subscriptionUuid string) error {
+	subscription, err := s.getUserSubscription(ctx, userId, subscriptionUuid)
+	if err != nil {
+		return err
+	}
+	if subscription.Status == model.SubscriptionStatusCanceled {
+		return errors.New("subscription is already canceled")
+	}
+
+	return s.cancel(ctx, subscription)
+}
+
+// ProcessRenewals 为到期的订阅生成续费订单并扣款
+func (s *SubscriptionService) ProcessRenewals(ctx *app.Context) {
+	subscriptions := make([]*model.Subscription, 0)
+	err := ctx.DB.Where("status IN (?) AND next_billing_at <= ?", []string{
+		model.SubscriptionStatusActive,
+		model.SubscriptionStatusTrialing,
+		model.SubscriptionStatusPastDue,
+	}, time.Now().Format(time.DateTime)).Order("next_billing_at ASC").Limit(100).Find(&subscriptions).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get due subscriptions", err)
+		return
+	}
+
+	// 续费订单等数据写入订阅所属的租户
+	for _, subscription := range subscriptions {
+		err := s.renew(ctx.WithTenant(subscription.TenantUuid), subscription)
+		if err != nil {
+			ctx.Logger.Error("Failed to renew subscription "+subscription.Uuid, err)
+		}
+	}
+}
+
+// 续费一期
+func (s *SubscriptionService) renew(ctx *app.Context, subscription *model.Subscription) error {
+	plan, err := s.GetSubscriptionPlanByUUID(ctx, subscription.PlanUuid)
+	if err != nil {
+		return err
+	}
+
+	// 上次已扣款但订单没有标记支付，只重试标记，不再重复扣款
+	if subscription.ChargedOrderNo != "" {
+		return s.settleRenewal(ctx, subscription, plan)
+	}
+
+	orderNo, err := s.renewalOrder(ctx, subscription, plan)
+	if err != nil {
+		return s.renewFailed(ctx, subscription, plan, "", "续费订单创建失败", "", err)
+	}
+
+	gateway, err := s.recurringPayment(ctx, subscription.PaymentMethod)
+	if err != nil {
+		return s.chargeFailed(ctx, subscription, plan, orderNo, err)
+	}
+
+	transactionNo, err := gateway.Charge(ctx, subscription.AgreementNo, orderNo, plan.CurrencyCode, plan.Price*float64(subscription.Quantity), plan.Name)
+	if err != nil {
+		return s.chargeFailed(ctx, subscription, plan, orderNo, err)
+	}
+
+	// 标记订单支付前先记录扣款结果
+	err = s.updateSubscription(ctx, subscription.Uuid, map[string]interface{}{
+		"charged_order_no":       orderNo,
+		"charged_transaction_no": transactionNo,
+		"updated_at":             time.Now().Format(time.DateTime),
+	})
+	if err != nil {
+		ctx.Logger.Error("Subscription "+subscription.Uuid+" charged order "+orderNo+" transaction "+transactionNo+" but failed to record it", err)
+		return err
+	}
+	subscription.ChargedOrderNo = orderNo
+	subscription.ChargedTransactionNo = transactionNo
+
+	return s.settleRenewal(ctx, subscription, plan)
+}
+
+// 已扣款的续费订单标记为已支付，订阅进入下一周期
+func (s *SubscriptionService) settleRenewal(ctx *app.Context, subscription *model.Subscription, plan *model.SubscriptionPlan) error {
+	err := NewOrderService().MarkOrderPaid(ctx, subscription.ChargedOrderNo, subscription.PaymentMethod, subscription.ChargedTransactionNo)
+	if err != nil {
+		return err
+	}
+
+	// 新周期从原定扣款时间开始，避免周期随扣款延迟漂移
+	start, err := time.ParseInLocation(time.DateTime, subscription.NextBillingAt, time.Local)
+	if err != nil {
+		start = time.Now()
+	}
+	end := plan.NextPeriod(start)
+
+	return s.updateSubscription(ctx, subscription.Uuid, map[string]interface{}{
+		"status":                 model.SubscriptionStatusActive,
+		"current_period_start":   start.Format(time.DateTime),
+		"current_period_end":     end.Format(time.DateTime),
+		"next_billing_at":        end.Format(time.DateTime),
+		"retry_count":            0,
+		"last_order_no":          subscription.ChargedOrderNo,
+		"charged_order_no":       "",
+		"charged_transaction_no": "",
+		"updated_at":             time.Now().Format(time.DateTime),
+	})
+}
+
+// 获取续费订单，重试时沿用未支付的订单，支付渠道按订单号去重
+func (s *SubscriptionService) renewalOrder(ctx *app.Context, subscription *model.Subscription, plan *model.SubscriptionPlan) (string, error) {
+	if subscription.LastOrderNo != "" {
+		order, err := NewOrderService().GetOrderByID(ctx, subscription.LastOrderNo)
+		if err == nil && order.Status == model.OrderStatusPending {
+			return order.OrderNo, nil
+		}
+	}
+
+	productItemMap, err := NewProductService().GetProductItemByUUIDList(ctx, []string{plan.ProductItemUuid})
+	if err != nil {
+		return "", err
+	}
+	productItem, ok := productItemMap[plan.ProductItemUuid]
+	if !ok {
+		return "", errors.New("product item not found")
+	}
+
+	orderNo, err := NewOrderService().NewOrderNo(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().Format(time.DateTime)
+	order := &model.Order{
+		Uuid:             uuid.New().String(),
+		OrderNo:          orderNo,
+		UserID:           subscription.UserID,
+		Status:           model.OrderStatusPending,
+		IsVirtual:        NewOrderService().isVirtualOrder([]*model.ProductItemRes{productItem}),
+		ReceiverName:     subscription.ReceiverName,
+		ReceiverPhone:    subscription.ReceiverPhone,
+		ReceiverEmail:    subscription.ReceiverEmail,
+		ReceiverCountry:  subscription.ReceiverCountry,
+		ReceiverProvince: subscription.ReceiverProvince,
+		ReceiverCity:     subscription.ReceiverCity,
+		ReceiverAddress:  subscription.ReceiverAddress,
+		ReceiverZip:      subscription.ReceiverZip,
+		ReceiverRemark:   subscription.ReceiverRemark,
+		TotalAmount:      plan.Price * float64(subscription.Quantity),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	orderItems := []*model.OrderItem{{
+		OrderID:       order.OrderNo,
+		ProductItemID: plan.ProductItemUuid,
+		Quantity:      subscription.Quantity,
+		Price:         plan.Price,
+		TotalAmount:   order.TotalAmount,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}}
+
+	inventoryLogs := make([]*model.InventoryLog, 0)
+	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(order).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create order", err)
+			tx.Rollback()
+			return errors.New("failed to create order")
+		}
+
+		err = tx.Create(orderItems).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create order items", err)
+			tx.Rollback()
+			return errors.New("failed to create order items")
+		}
+
+		inventoryLogs, err = NewOrderService().deductStock(ctx, tx, order, orderItems)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		err = tx.Create(&model.Payment{
+			Uuid:      uuid.New().String(),
+			UserID:    subscription.UserID,
+			OrderID:   order.OrderNo,
+			Amount:    order.TotalAmount,
+			Status:    model.PaymentStatusPending,
+			Method:    subscription.PaymentMethod,
+			Channel:   "recurring",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create payment", err)
+			tx.Rollback()
+			return errors.New("failed to create payment")
+		}
+
+		err = tx.Model(&model.Subscription{}).Where("uuid = ?", subscription.Uuid).Updates(map[string]interface{}{
+			"last_order_no": order.OrderNo,
+			"updated_at":    now,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update subscription", err)
+			tx.Rollback()
+			return errors.New("failed to update subscription")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	subscription.LastOrderNo = order.OrderNo
+	NewInventoryService().NotifyLowStock(ctx, inventoryLogs)
+
+	return order.OrderNo, nil
+}
+
+// 扣款失败，按重试间隔延后扣款，超过重试次数后取消订阅
+func (s *SubscriptionService) chargeFailed(ctx *app.Context, subscription *model.Subscription, plan *model.SubscriptionPlan, orderNo string, chargeErr error) error {
+	return s.renewFailed(ctx, subscription, plan, orderNo, "扣款失败", "请确认代扣账户余额充足。", chargeErr)
+}
+
+// 续费失败，按重试间隔延后续费并通知客户，超过重试次数后取消订阅
+func (s *SubscriptionService) renewFailed(ctx *app.Context, subscription *model.Subscription, plan *model.SubscriptionPlan, orderNo, reason, hint string, renewErr error) error {
+	ctx.Logger.Error("Failed to renew subscription "+subscription.Uuid, renewErr)
+
+	if subscription.RetryCount >= len(subscriptionRetryDelays) {
+		s.sendDunningMail(ctx, subscription, plan, orderNo, fmt.Sprintf("多次%s，订阅已取消。", reason))
+		return s.cancel(ctx, subscription)
+	}
+
+	nextBillingAt := time.Now().Add(subscriptionRetryDelays[subscription.RetryCount])
+	err := s.updateSubscription(ctx, subscription.Uuid, map[string]interface{}{
+		"status":          model.SubscriptionStatusPastDue,
+		"retry_count":     subscription.RetryCount + 1,
+		"next_billing_at": nextBillingAt.Format(time.DateTime),
+		"updated_at":      time.Now().Format(time.DateTime),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.sendDunningMail(ctx, subscription, plan, orderNo, fmt.Sprintf("%s，我们将在 %s 再次尝试续费。%s", reason, nextBillingAt.Format(time.DateTime), hint))
+	return renewErr
+}
+
+// 取消订阅并关闭未支付的续费订单
+func (s *SubscriptionService) cancel(ctx *app.Context, subscription *model.Subscription) error {
+	// 已扣款的订单等待标记支付，不能关闭
+	if subscription.LastOrderNo != "" && subscription.LastOrderNo != subscription.ChargedOrderNo {
+		err := NewOrderService().CloseOrder(ctx, subscription.LastOrderNo)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().Format(time.DateTime)
+	return s.updateSubscription(ctx, subscription.Uuid, map[string]interface{}{
+		"status":      model.SubscriptionStatusCanceled,
+		"canceled_at": now,
+		"updated_at":  now,
+	})
+}
+
+// 发送扣款失败通知邮件
+func (s *SubscriptionService) sendDunningMail(ctx *app.Context, subscription *model.Subscription, plan *model.SubscriptionPlan, orderNo, message string) {
+	if ctx.Config.MailConfig.Host == "" {
+		return
+	}
+
+	mailTo := subscription.ReceiverEmail
+	if mailTo == "" {
+		mailTo = NewCustomerService().GetContactEmail(ctx, subscription.UserID)
+		if mailTo == "" {
+			return
+		}
+	}
+
+	mailConfig := ctx.Config.MailConfig
+	logger := ctx.Logger
+	go func() {
+		err := mail.Send(&mail.Options{
+			MailHost: mailConfig.Host,
+			MailPort: mailConfig.Port,
+			MailUser: mailConfig.Username,
+			MailPass: mailConfig.Password,
+			MailTo:   mailTo,
+			Subject:  "订阅续费扣款失败",
+			Body:     fmt.Sprintf(subscriptionDunningMailContent, plan.Name, orderNo, message),
+		})
+		if err != nil {
+			logger.Error("Failed to send dunning mail", err)
+		}
+	}()
+}
+
+// 获取支持周期扣款的支付方式
+func (s *SubscriptionService) recurringPayment(ctx *app.Context, code string) (paymentmethod.RecurringPayment, error) {
+	payment, err := NewPaymentMethodService().GetPaymentMethodInfo(ctx, "", code)
+	if err != nil {
+		return nil, err
+	}
+
+	var gateway paymentmethod.RecurringPayment
+	switch code {
+	case "alipay":
+		gateway = &paymentmethod.Alipay{}
+	default:
+		return nil, errors.New("payment method does not support recurring payment")
+	}
+
+	err = json.Unmarshal([]byte(payment.Config), gateway)
+	if err != nil {
+		ctx.Logger.Error("Failed to unmarshal payment method config", err)
+		return nil, errors.New("failed to unmarshal payment method config")
+	}
+
+	return gateway, nil
+}
+
+// 获取用户的订阅，userId为空时不校验用户
+func (s *SubscriptionService) getUserSubscription(ctx *app.Context, userId, subscriptionUuid string) (*model.Subscription, error) {
+	subscription := &model.Subscription{}
+	db := ctx.DB.Where("uuid = ?", subscriptionUuid)
+	if userId != "" {
+		db = db.Where("user_id = ?", userId)
+	}
+	err := db.First(subscription).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("subscription not found")
+		}
+		ctx.Logger.Error("Failed to get subscription by UUID", err)
+		return nil, errors.New("failed to get subscription by UUID")
+	}
+	return subscription, nil
+}
+
+func (s *SubscriptionService) updateSubscription(ctx *app.Context, subscriptionUuid string, updates map[string]interface{}) error {
+	err := ctx.DB.Model(&model.Subscription{}).Where("uuid = ?", subscriptionUuid).Updates(updates).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to update subscription", err)
+		return errors.New("failed to update subscription")
+	}
+	return nil
+}
+
+func (s *SubscriptionService) validInterval(interval string) bool {
+	switch interval {
+	case model.SubscriptionIntervalDay, model.SubscriptionIntervalWeek, model.SubscriptionIntervalMonth, model.SubscriptionIntervalYear:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/testutil"
+)
+
+// 创建按月续费的订阅，扣款方式不支持代扣，扣款总是失败
+func setupSubscription(t *testing.T, ctx *app.Context, subscription *model.Subscription) {
+	t.Helper()
+	rows := []interface{}{
+		&model.ProductItem{Uuid: "item1", ProductUuid: "product1", Stock: 10, Price: 30,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+		&model.SubscriptionPlan{Uuid: "plan1", ProductUuid: "product1", ProductItemUuid: "item1", Name: "monthly",
+			Interval: model.SubscriptionIntervalMonth, IntervalCount: 1, Price: 30, Status: 1,
+			CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+	}
+	for _, row := range rows {
+		err := ctx.DB.Create(row).Error
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	subscription.Uuid = "sub1"
+	subscription.UserID = "customer1"
+	subscription.PlanUuid = "plan1"
+	subscription.ProductItemUuid = "item1"
+	subscription.Quantity = 1
+	subscription.PaymentMethod = "unsupported"
+	subscription.NextBillingAt = "2026-01-01 00:00:00"
+	subscription.CreatedAt = "2026-01-01 00:00:00"
+	subscription.UpdatedAt = "2026-01-01 00:00:00"
+	err := ctx.DB.Create(subscription).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func getSubscription(t *testing.T, ctx *app.Context) *model.Subscription {
+	t.Helper()
+	subscription := &model.Subscription{}
+	err := ctx.DB.Where("uuid = ?", "sub1").First(subscription).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	return subscription
+}
+
+func TestRenewBackoff(t *testing.T) {
+	tests := []struct {
+		retryCount int
+		wantStatus string
+		wantRetry  int
+		wantDelay  time.Duration
+	}{
+		{0, model.SubscriptionStatusPastDue, 1, 24 * time.Hour},
+		{1, model.SubscriptionStatusPastDue, 2, 3 * 24 * time.Hour},
+		{2, model.SubscriptionStatusPastDue, 3, 5 * 24 * time.Hour},
+		{3, model.SubscriptionStatusCanceled, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantStatus, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			subscription := &model.Subscription{Status: model.SubscriptionStatusActive, RetryCount: tt.retryCount}
+			setupSubscription(t, ctx, subscription)
+
+			start := time.Now()
+			err := NewSubscriptionService().renew(ctx, subscription)
+			if tt.wantStatus == model.SubscriptionStatusPastDue && err == nil {
+				t.Fatal("renew should return the charge error")
+			}
+
+			got := getSubscription(t, ctx)
+			if got.Status != tt.wantStatus || got.RetryCount != tt.wantRetry {
+				t.Errorf("status = %s, retry = %d, want %s, %d", got.Status, got.RetryCount, tt.wantStatus, tt.wantRetry)
+			}
+			if tt.wantDelay > 0 {
+				next, err := time.ParseInLocation(time.DateTime, got.NextBillingAt, time.Local)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if d := next.Sub(start); d < tt.wantDelay-time.Second || d > tt.wantDelay+time.Second {
+					t.Errorf("next billing after %v, want %v", d, tt.wantDelay)
+				}
+			}
+
+			// 取消订阅时关闭未支付的续费订单
+			order := &model.Order{}
+			err = ctx.DB.Where("order_no = ?", got.LastOrderNo).First(order).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantOrder := model.OrderStatusPending
+			if tt.wantStatus == model.SubscriptionStatusCanceled {
+				wantOrder = model.OrderStatusClosed
+			}
+			if order.Status != wantOrder {
+				t.Errorf("order status = %s, want %s", order.Status, wantOrder)
+			}
+		})
+	}
+}
+
+// 重试时沿用未支付的续费订单，不重复创建订单和扣减库存
+func TestRenewReusesPendingOrder(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	subscription := &model.Subscription{Status: model.SubscriptionStatusActive}
+	setupSubscription(t, ctx, subscription)
+
+	for i := 0; i < 2; i++ {
+		subscription = getSubscription(t, ctx)
+		NewSubscriptionService().renew(ctx, subscription)
+	}
+
+	var count int64
+	err := ctx.DB.Model(&model.Order{}).Count(&count).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("orders = %d, want 1", count)
+	}
+	item := &model.ProductItem{}
+	err = ctx.DB.Where("uuid = ?", "item1").First(item).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Stock != 9 {
+		t.Errorf("stock = %d, want 9", item.Stock)
+	}
+}
+
+// 已扣款未标记支付的订阅只标记订单支付，不再次扣款
+func TestRenewSettlesChargedOrder(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	subscription := &model.Subscription{Status: model.SubscriptionStatusPastDue, RetryCount: 1}
+	setupSubscription(t, ctx, subscription)
+
+	orderNo, err := NewSubscriptionService().renewalOrder(ctx, subscription, &model.SubscriptionPlan{
+		Uuid: "plan1", ProductItemUuid: "item1", Price: 30,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ctx.DB.Model(&model.Subscription{}).Where("uuid = ?", "sub1").Updates(map[string]interface{}{
+		"charged_order_no":       orderNo,
+		"charged_transaction_no": "tx1",
+	}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 扣款方式不支持代扣，再次扣款会失败
+	err = NewSubscriptionService().renew(ctx, getSubscription(t, ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := getSubscription(t, ctx)
+	if got.Status != model.SubscriptionStatusActive || got.RetryCount != 0 || got.ChargedOrderNo != "" || got.LastOrderNo != orderNo {
+		t.Errorf("subscription = %s retry %d charged %q last %q", got.Status, got.RetryCount, got.ChargedOrderNo, got.LastOrderNo)
+	}
+	if got.CurrentPeriodStart != "2026-01-01 00:00:00" || got.NextBillingAt != "2026-02-01 00:00:00" {
+		t.Errorf("period = %s - %s, want 2026-01-01 00:00:00 - 2026-02-01 00:00:00", got.CurrentPeriodStart, got.NextBillingAt)
+	}
+	order := &model.Order{}
+	err = ctx.DB.Where("order_no = ?", orderNo).First(order).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != model.OrderStatusPaid {
+		t.Errorf("order status = %s, want paid", order.Status)
+	}
+}
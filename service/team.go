@@ -18,17 +18,61 @@ func NewTeamService() *TeamService {
 	return &TeamService{}
 }
 
-func (s *TeamService) CreateTeam(ctx *app.Context, team *model.Team) error {
-	team.UUID = uuid.New().String()
+// CreateTeam 创建团队，当前用户为拥有者并加入团队，还没有当前团队时切换到新团队
+func (s *TeamService) CreateTeam(ctx *app.Context, params *model.ReqTeamCreate) (*model.Team, error) {
+	userId := ctx.GetString("user_id")
+	team := &model.Team{
+		UUID:      uuid.New().String(),
+		Icon:      params.Icon,
+		OwnerUuid: userId,
+		Name:      params.Name,
+		Desc:      params.Desc,
+		Domain:    NewTenantService().normalizeHost(params.Domain),
+		IsActive:  true,
+		Creater:   userId,
+	}
+	err := NewTenantService().CheckDomain(ctx, team.UUID, team.Domain)
+	if err != nil {
+		return nil, err
+	}
 	team.CreatedAt = time.Now()
 	team.UpdatedAt = team.CreatedAt
 
-	err := ctx.DB.Create(team).Error
+	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&model.TeamMember{}).Where("user_uuid = ? AND is_current_team = ?", userId, true).Count(&count).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get current team", err)
+			return errors.New("failed to create team")
+		}
+
+		err = tx.Create(team).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create team", err)
+			return errors.New("failed to create team")
+		}
+
+		err = tx.Create(&model.TeamMember{
+			UUID:          uuid.New().String(),
+			TeamUUID:      team.UUID,
+			UserUUID:      userId,
+			IsCurrentTeam: count == 0,
+			CreatedAt:     team.CreatedAt,
+			UpdatedAt:     team.CreatedAt,
+		}).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to create team member", err)
+			return errors.New("failed to create team")
+		}
+		return nil
+	})
 	if err != nil {
-		ctx.Logger.Error("Failed to create team", err)
-		return errors.New("failed to create team")
+		return nil, err
 	}
-	return nil
+
+	NewTenantService().ClearHostCache()
+	NewRbacService().InvalidateCache(ctx)
+	return team, nil
 }
 
 func (s *TeamService) GetTeamByUUID(ctx *app.Context, uuid string) (*model.Team, error) {
@@ -44,15 +88,33 @@ func (s *TeamService) GetTeamByUUID(ctx *app.Context, uuid string) (*model.Team,
 	return team, nil
 }
 
-func (s *TeamService) UpdateTeam(ctx *app.Context, team *model.Team) error {
-	team.UpdatedAt = time.Now()
-	err := ctx.DB.Save(team).Error
+// UpdateTeam 更新用户当前团队的信息，拥有者只能通过转让修改
+func (s *TeamService) UpdateTeam(ctx *app.Context, params *model.ReqTeamUpdate) (*model.Team, error) {
+	teamMember, err := NewTeamMemberService().GetUserCurrentTeam(ctx, ctx.GetString("user_id"))
+	if err != nil {
+		return nil, errors.New("user has no current team")
+	}
+
+	domain := NewTenantService().normalizeHost(params.Domain)
+	err = NewTenantService().CheckDomain(ctx, teamMember.TeamUUID, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ctx.DB.Model(&model.Team{}).Where("uuid = ?", teamMember.TeamUUID).Updates(map[string]interface{}{
+		"name":       params.Name,
+		"icon":       params.Icon,
+		"desc":       params.Desc,
+		"domain":     domain,
+		"updated_at": time.Now(),
+	}).Error
 	if err != nil {
 		ctx.Logger.Error("Failed to update team", err)
-		return errors.New("failed to update team")
+		return nil, errors.New("failed to update team")
 	}
 
-	return nil
+	NewTenantService().ClearHostCache()
+	return s.GetTeamByUUID(ctx, teamMember.TeamUUID)
 }
 
 func (s *TeamService) DeleteTeam(ctx *app.Context, uuid string) error {
@@ -62,6 +124,8 @@ func (s *TeamService) DeleteTeam(ctx *app.Context, uuid string) error {
 		return errors.New("failed to delete team")
 	}
 
+	NewTenantService().ClearHostCache()
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -94,3 +158,44 @@ func (s *TeamService) GetTeamList(ctx *app.Context, param *model.ReqTeamQueryPar
 
 	return
 }
+
+// TransferOwner 转让团队拥有者，只有当前拥有者可以转让，新拥有者需要是团队成员
+func (s *TeamService) TransferOwner(ctx *app.Context, params *model.ReqTeamOwnerTransfer) error {
+	userId := ctx.GetString("user_id")
+	team, err := s.GetTeamByUUID(ctx, params.TeamUuid)
+	if err != nil {
+		return err
+	}
+	if team.OwnerUuid != userId {
+		return errors.New("只有团队拥有者可以转让团队")
+	}
+	if params.UserUuid == userId {
+		return errors.New("不能转让给自己")
+	}
+
+	var count int64
+	err = ctx.DB.Model(&model.TeamMember{}).Where("team_uuid = ? AND user_uuid = ?", team.UUID, params.UserUuid).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get team member", err)
+		return errors.New("failed to transfer team owner")
+	}
+	if count == 0 {
+		return errors.New("新拥有者不是团队成员")
+	}
+
+	// 按原拥有者更新，避免同时转让
+	result := ctx.DB.Model(&model.Team{}).Where("uuid = ? AND owner_uuid = ?", team.UUID, userId).Updates(map[string]interface{}{
+		"owner_uuid": params.UserUuid,
+		"updated_at": time.Now(),
+	})
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to transfer team owner", result.Error)
+		return errors.New("failed to transfer team owner")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("只有团队拥有者可以转让团队")
+	}
+
+	NewRbacService().InvalidateCache(ctx)
+	return nil
+}
@@ -0,0 +1,522 @@
+package service
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/mail"
+	"sgin/pkg/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var teamInvitationMailContent = `
+<html>
+<body>
+    <h2>团队邀请</h2>
+    <p>%s 邀请您加入团队 <strong>%s</strong>。</p>
+    <p>%s</p>
+    <p>邀请在 %s 前有效，如果您不想加入，可以忽略或拒绝本邀请。</p>
+</body>
+</html>
+`
+
+type TeamInvitationService struct {
+}
+
+func NewTeamInvitationService() *TeamInvitationService {
+	return &TeamInvitationService{}
+}
+
+// CreateInvitation 邀请邮箱加入团队，同一邮箱之前未处理的邀请会被撤销
+func (s *TeamInvitationService) CreateInvitation(ctx *app.Context, params *model.ReqTeamInvitationCreate) (*model.TeamInvitation, error) {
+	userId := ctx.GetString("user_id")
+	teamUuid, err := s.getTeamUuid(ctx, userId, params.TeamUuid)
+	if err != nil {
+		return nil, err
+	}
+	team, err := NewTeamService().GetTeamByUUID(ctx, teamUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Role != "" {
+		var count int64
+		err = ctx.DB.Model(&model.Role{}).Where("uuid = ? AND (team_uuid = ? OR team_uuid = ?)", params.Role, teamUuid, "").Count(&count).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get role", err)
+			return nil, errors.New("failed to create invitation")
+		}
+		if count == 0 {
+			return nil, errors.New("角色不存在")
+		}
+	}
+
+	email := strings.ToLower(strings.TrimSpace(params.Email))
+	user := &model.User{}
+	err = ctx.DB.Where("email = ?", email).First(user).Error
+	if err == nil {
+		var count int64
+		err = ctx.DB.Model(&model.TeamMember{}).Where("team_uuid = ? AND user_uuid = ?", teamUuid, user.Uuid).Count(&count).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get team member", err)
+			return nil, errors.New("failed to create invitation")
+		}
+		if count > 0 {
+			return nil, errors.New("该用户已是团队成员")
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.Logger.Error("Failed to get user by email", err)
+		return nil, errors.New("failed to create invitation")
+	}
+
+	expire := ctx.Config.TeamInvitation.Expire
+	if expire <= 0 {
+		expire = 72
+	}
+	now := time.Now()
+	invitation := &model.TeamInvitation{
+		Uuid:        uuid.New().String(),
+		TeamUuid:    teamUuid,
+		Email:       email,
+		Role:        params.Role,
+		InviterUuid: userId,
+		Status:      model.TeamInvitationStatusPending,
+		ExpiredAt:   now.Add(time.Duration(expire) * time.Hour).Format(time.DateTime),
+		CreatedAt:   now.Format(time.DateTime),
+		UpdatedAt:   now.Format(time.DateTime),
+	}
+
+	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.TeamInvitation{}).
+			Where("team_uuid = ? AND email = ? AND status = ?", teamUuid, email, model.TeamInvitationStatusPending).
+			Updates(map[string]interface{}{
+				"status":     model.TeamInvitationStatusRevoked,
+				"handled_at": invitation.CreatedAt,
+				"updated_at": invitation.CreatedAt,
+			}).Error
+		if err != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to revoke old invitations", err)
+			return errors.New("failed to create invitation")
+		}
+
+		err = tx.Create(invitation).Error
+		if err != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to create invitation", err)
+			return errors.New("failed to create invitation")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.sendMail(ctx, invitation, team)
+	if err != nil {
+		// 邮件发送失败时邀请不可用，删除邀请
+		ctx.DB.Where("uuid = ?", invitation.Uuid).Delete(&model.TeamInvitation{})
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// GetInvitationList 获取团队的邀请列表
+func (s *TeamInvitationService) GetInvitationList(ctx *app.Context, params *model.ReqTeamInvitationQueryParam) (*model.PagedResponse, error) {
+	var (
+		invitations []*model.TeamInvitation
+		total       int64
+	)
+
+	teamUuid, err := s.getTeamUuid(ctx, ctx.GetString("user_id"), params.TeamUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	db := ctx.DB.Model(&model.TeamInvitation{}).Where("team_uuid = ?", teamUuid)
+	if params.Email != "" {
+		db = db.Where("email LIKE ?", "%"+params.Email+"%")
+	}
+	if params.Status != "" {
+		db = db.Where("status = ?", params.Status)
+	}
+
+	err = db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get invitation count", err)
+		return nil, errors.New("failed to get invitation list")
+	}
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&invitations).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get invitation list", err)
+		return nil, errors.New("failed to get invitation list")
+	}
+
+	roleUuids := make([]string, 0)
+	userUuids := make([]string, 0)
+	for _, invitation := range invitations {
+		roleUuids = append(roleUuids, invitation.Role)
+		userUuids = append(userUuids, invitation.InviterUuid)
+	}
+	roleNames := make(map[string]string)
+	if len(roleUuids) > 0 {
+		roles := make([]*model.Role, 0)
+		err = ctx.DB.Where("uuid IN (?)", roleUuids).Find(&roles).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get roles", err)
+			return nil, errors.New("failed to get invitation list")
+		}
+		for _, role := range roles {
+			roleNames[role.Uuid] = role.Name
+		}
+	}
+	userNames := make(map[string]string)
+	if len(userUuids) > 0 {
+		users := make([]*model.User, 0)
+		err = ctx.DB.Where("uuid IN (?)", userUuids).Find(&users).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get users", err)
+			return nil, errors.New("failed to get invitation list")
+		}
+		for _, user := range users {
+			userNames[user.Uuid] = user.Username
+		}
+	}
+
+	now := time.Now().Format(time.DateTime)
+	res := make([]*model.TeamInvitationRes, 0, len(invitations))
+	for _, invitation := range invitations {
+		res = append(res, &model.TeamInvitationRes{
+			TeamInvitation: *invitation,
+			RoleName:       roleNames[invitation.Role],
+			InviterName:    userNames[invitation.InviterUuid],
+			Expired:        invitation.Status == model.TeamInvitationStatusPending && invitation.ExpiredAt < now,
+		})
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+		Data:     res,
+	}, nil
+}
+
+// RevokeInvitation 撤销未处理的邀请
+func (s *TeamInvitationService) RevokeInvitation(ctx *app.Context, invitationUuid string) error {
+	invitation := &model.TeamInvitation{}
+	err := ctx.DB.Where("uuid = ?", invitationUuid).First(invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invitation not found")
+		}
+		ctx.Logger.Error("Failed to get invitation", err)
+		return errors.New("failed to revoke invitation")
+	}
+	_, err = s.getTeamUuid(ctx, ctx.GetString("user_id"), invitation.TeamUuid)
+	if err != nil {
+		return err
+	}
+
+	return s.handle(ctx, invitation, model.TeamInvitationStatusRevoked)
+}
+
+// GetInvitationInfo 通过邀请token查看邀请
+func (s *TeamInvitationService) GetInvitationInfo(ctx *app.Context, token string) (*model.TeamInvitationInfo, error) {
+	invitation, err := s.getInvitationByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := NewTeamService().GetTeamByUUID(ctx, invitation.TeamUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &model.TeamInvitationInfo{
+		TeamName:  team.Name,
+		Email:     invitation.Email,
+		Status:    invitation.Status,
+		ExpiredAt: invitation.ExpiredAt,
+	}
+	if invitation.Role != "" {
+		role := &model.Role{}
+		err = ctx.DB.Where("uuid = ?", invitation.Role).First(role).Error
+		if err == nil {
+			info.RoleName = role.Name
+		}
+	}
+
+	var count int64
+	err = ctx.DB.Model(&model.User{}).Where("email = ?", invitation.Email).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get user by email", err)
+		return nil, errors.New("failed to get invitation")
+	}
+	info.HasAccount = count > 0
+	return info, nil
+}
+
+// AcceptInvitation 接受邀请加入团队，邮箱没有账号时创建账号，返回加入团队的用户
+func (s *TeamInvitationService) AcceptInvitation(ctx *app.Context, params *model.ReqTeamInvitationAccept) (*model.User, error) {
+	invitation, err := s.getPendingInvitation(ctx, params.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &model.User{}
+	err = ctx.DB.Where("email = ?", invitation.Email).First(user).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.Logger.Error("Failed to get user by email", err)
+		return nil, errors.New("failed to accept invitation")
+	}
+	newUser := errors.Is(err, gorm.ErrRecordNotFound)
+	if newUser {
+		if params.Username == "" || params.Password == "" {
+			return nil, errors.New("请填写用户名和密码")
+		}
+		var count int64
+		err = ctx.DB.Model(&model.User{}).Where("username = ?", params.Username).Count(&count).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get user by username", err)
+			return nil, errors.New("failed to accept invitation")
+		}
+		if count > 0 {
+			return nil, errors.New(params.Username + "用户名已存在")
+		}
+
+		now := time.Now().Format(time.DateTime)
+		user = &model.User{
+			Uuid:      uuid.New().String(),
+			Username:  params.Username,
+			Password:  utils.HashPasswordWithSalt(params.Password, ctx.Config.PasswdKey),
+			Email:     invitation.Email,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+
+	err = ctx.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now().Format(time.DateTime)
+
+		// 邀请只能处理一次
+		result := tx.Model(&model.TeamInvitation{}).
+			Where("uuid = ? AND status = ?", invitation.Uuid, model.TeamInvitationStatusPending).
+			Updates(map[string]interface{}{
+				"status":     model.TeamInvitationStatusAccepted,
+				"user_uuid":  user.Uuid,
+				"handled_at": now,
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to update invitation", result.Error)
+			return errors.New("failed to accept invitation")
+		}
+		if result.RowsAffected == 0 {
+			tx.Rollback()
+			return errors.New("邀请已处理")
+		}
+
+		if newUser {
+			err := tx.Create(user).Error
+			if err != nil {
+				tx.Rollback()
+				ctx.Logger.Error("Failed to create user", err)
+				return errors.New("failed to create user")
+			}
+		}
+
+		var count int64
+		err := tx.Model(&model.TeamMember{}).Where("team_uuid = ? AND user_uuid = ?", invitation.TeamUuid, user.Uuid).Count(&count).Error
+		if err != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to get team member", err)
+			return errors.New("failed to accept invitation")
+		}
+		if count > 0 {
+			return nil
+		}
+
+		// 没有当前团队时将邀请的团队设为当前团队
+		var current int64
+		err = tx.Model(&model.TeamMember{}).Where("user_uuid = ? AND is_current_team = ?", user.Uuid, true).Count(&current).Error
+		if err != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to get current team", err)
+			return errors.New("failed to accept invitation")
+		}
+
+		err = tx.Create(&model.TeamMember{
+			UUID:          uuid.New().String(),
+			TeamUUID:      invitation.TeamUuid,
+			UserUUID:      user.Uuid,
+			Role:          invitation.Role,
+			IsCurrentTeam: current == 0,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to create team member", err)
+			return errors.New("failed to accept invitation")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	NewRbacService().InvalidateCache(ctx)
+	user.Password = ""
+	return user, nil
+}
+
+// DeclineInvitation 拒绝邀请
+func (s *TeamInvitationService) DeclineInvitation(ctx *app.Context, token string) error {
+	invitation, err := s.getPendingInvitation(ctx, token)
+	if err != nil {
+		return err
+	}
+	return s.handle(ctx, invitation, model.TeamInvitationStatusDeclined)
+}
+
+// 将未处理的邀请改为指定状态
+func (s *TeamInvitationService) handle(ctx *app.Context, invitation *model.TeamInvitation, status string) error {
+	now := time.Now().Format(time.DateTime)
+	result := ctx.DB.Model(&model.TeamInvitation{}).
+		Where("uuid = ? AND status = ?", invitation.Uuid, model.TeamInvitationStatusPending).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"handled_at": now,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to update invitation", result.Error)
+		return errors.New("failed to update invitation")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("邀请已处理")
+	}
+	return nil
+}
+
+// 获取未过期且未处理的邀请
+func (s *TeamInvitationService) getPendingInvitation(ctx *app.Context, token string) (*model.TeamInvitation, error) {
+	invitation, err := s.getInvitationByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.Status != model.TeamInvitationStatusPending {
+		return nil, errors.New("邀请已处理")
+	}
+	if invitation.ExpiredAt < time.Now().Format(time.DateTime) {
+		return nil, errors.New("邀请已过期")
+	}
+	return invitation, nil
+}
+
+// token格式为 邀请uuid.签名，签名包含邮箱，邀请邮箱不同时token无效
+func (s *TeamInvitationService) getInvitationByToken(ctx *app.Context, token string) (*model.TeamInvitation, error) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("邀请链接无效")
+	}
+
+	invitation := &model.TeamInvitation{}
+	err := ctx.DB.Where("uuid = ?", parts[0]).First(invitation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("邀请链接无效")
+		}
+		ctx.Logger.Error("Failed to get invitation", err)
+		return nil, errors.New("failed to get invitation")
+	}
+	if !hmac.Equal([]byte(s.sign(ctx, invitation)), []byte(parts[1])) {
+		return nil, errors.New("邀请链接无效")
+	}
+	return invitation, nil
+}
+
+func (s *TeamInvitationService) token(ctx *app.Context, invitation *model.TeamInvitation) string {
+	return invitation.Uuid + "." + s.sign(ctx, invitation)
+}
+
+func (s *TeamInvitationService) sign(ctx *app.Context, invitation *model.TeamInvitation) string {
+	return utils.SignBody([]byte(fmt.Sprintf("invitation:%s:%s:%s", invitation.Uuid, invitation.TeamUuid, invitation.Email)), []byte(ctx.Config.PasswdKey))
+}
+
+func (s *TeamInvitationService) sendMail(ctx *app.Context, invitation *model.TeamInvitation, team *model.Team) error {
+	inviter := ""
+	user := &model.User{}
+	err := ctx.DB.Where("uuid = ?", invitation.InviterUuid).First(user).Error
+	if err == nil {
+		inviter = user.Username
+	}
+
+	token := s.token(ctx, invitation)
+	link := fmt.Sprintf("邀请码：<strong>%s</strong>", token)
+	if acceptURL := ctx.Config.TeamInvitation.AcceptURL; acceptURL != "" {
+		sep := "?"
+		if strings.Contains(acceptURL, "?") {
+			sep = "&"
+		}
+		href := acceptURL + sep + "token=" + url.QueryEscape(token)
+		link = fmt.Sprintf(`请点击 <a href="%s">%s</a> 接受邀请。`, href, href)
+	}
+
+	err = mail.Send(&mail.Options{
+		MailHost: ctx.Config.MailConfig.Host,
+		MailPort: ctx.Config.MailConfig.Port,
+		MailUser: ctx.Config.MailConfig.Username,
+		MailPass: ctx.Config.MailConfig.Password,
+		MailTo:   invitation.Email,
+		Subject:  "团队邀请",
+		Body:     fmt.Sprintf(teamInvitationMailContent, inviter, team.Name, link, invitation.ExpiredAt),
+	})
+	if err != nil {
+		ctx.Logger.Error("Failed to send invitation mail", err)
+		return errors.New("failed to send invitation mail")
+	}
+	return nil
+}
+
+// 获取要管理的团队，为空时使用当前团队，只能管理自己加入的团队
+func (s *TeamInvitationService) getTeamUuid(ctx *app.Context, userId, teamUuid string) (string, error) {
+	if teamUuid == "" {
+		current, err := NewTenantService().GetTenantByUser(ctx, userId)
+		if err != nil {
+			return "", err
+		}
+		if current == "" {
+			return "", errors.New("请先选择团队")
+		}
+		return current, nil
+	}
+
+	var count int64
+	err := ctx.DB.Model(&model.TeamMember{}).Where("team_uuid = ? AND user_uuid = ?", teamUuid, userId).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get team member", err)
+		return "", errors.New("failed to get team")
+	}
+	if count == 0 {
+		err = ctx.DB.Model(&model.Team{}).Where("uuid = ? AND owner_uuid = ?", teamUuid, userId).Count(&count).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to get team", err)
+			return "", errors.New("failed to get team")
+		}
+		if count == 0 {
+			return "", errors.New("不是团队成员")
+		}
+	}
+	return teamUuid, nil
+}
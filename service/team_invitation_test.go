@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/testutil"
+)
+
+// 创建团队和邀请，返回邀请token
+func setupInvitation(t *testing.T, ctx *app.Context, invitation *model.TeamInvitation) (*model.Team, string) {
+	t.Helper()
+	ctx.Set("user_id", "owner")
+	team, err := NewTeamService().CreateTeam(ctx, &model.ReqTeamCreate{Name: "shop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invitation.Uuid = "inv1"
+	invitation.TeamUuid = team.UUID
+	invitation.InviterUuid = "owner"
+	invitation.CreatedAt = "2026-01-01 00:00:00"
+	invitation.UpdatedAt = "2026-01-01 00:00:00"
+	err = ctx.DB.Create(invitation).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	return team, NewTeamInvitationService().token(ctx, invitation)
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	future := time.Now().Add(time.Hour).Format(time.DateTime)
+	past := time.Now().Add(-time.Hour).Format(time.DateTime)
+	tests := []struct {
+		name       string
+		invitation model.TeamInvitation
+		token      func(token string) string
+		params     model.ReqTeamInvitationAccept
+		wantErr    string
+	}{
+		{"new user", model.TeamInvitation{Email: "new@example.com", Status: model.TeamInvitationStatusPending, ExpiredAt: future},
+			nil, model.ReqTeamInvitationAccept{Username: "new", Password: "secret"}, ""},
+		{"existing user", model.TeamInvitation{Email: "member@example.com", Status: model.TeamInvitationStatusPending, ExpiredAt: future},
+			nil, model.ReqTeamInvitationAccept{}, ""},
+		{"new user without password", model.TeamInvitation{Email: "new@example.com", Status: model.TeamInvitationStatusPending, ExpiredAt: future},
+			nil, model.ReqTeamInvitationAccept{Username: "new"}, "请填写用户名和密码"},
+		{"expired", model.TeamInvitation{Email: "member@example.com", Status: model.TeamInvitationStatusPending, ExpiredAt: past},
+			nil, model.ReqTeamInvitationAccept{}, "邀请已过期"},
+		{"revoked", model.TeamInvitation{Email: "member@example.com", Status: model.TeamInvitationStatusRevoked, ExpiredAt: future},
+			nil, model.ReqTeamInvitationAccept{}, "邀请已处理"},
+		{"bad sign", model.TeamInvitation{Email: "member@example.com", Status: model.TeamInvitationStatusPending, ExpiredAt: future},
+			func(token string) string { return token + "0" }, model.ReqTeamInvitationAccept{}, "邀请链接无效"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			err := ctx.DB.Create(&model.User{Uuid: "member", Username: "member", Email: "member@example.com", Phone: "13800000001",
+				CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+			invitation := tt.invitation
+			team, token := setupInvitation(t, ctx, &invitation)
+			if tt.token != nil {
+				token = tt.token(token)
+			}
+
+			params := tt.params
+			params.Token = token
+			user, err := NewTeamInvitationService().AcceptInvitation(ctx, &params)
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Fatalf("err = %q, want %q", got, tt.wantErr)
+			}
+
+			saved := &model.TeamInvitation{}
+			err = ctx.DB.Where("uuid = ?", "inv1").First(saved).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != "" {
+				if saved.Status != tt.invitation.Status {
+					t.Errorf("status = %s, want %s", saved.Status, tt.invitation.Status)
+				}
+				return
+			}
+
+			if user.Email != invitation.Email || saved.Status != model.TeamInvitationStatusAccepted || saved.UserUuid != user.Uuid {
+				t.Errorf("user = %s %s, invitation = %s %s", user.Uuid, user.Email, saved.Status, saved.UserUuid)
+			}
+			member, err := NewTeamMemberService().GetUserCurrentTeam(ctx, user.Uuid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if member.TeamUUID != team.UUID {
+				t.Errorf("current team = %q, want %q", member.TeamUUID, team.UUID)
+			}
+
+			// 邀请只能接受一次
+			_, err = NewTeamInvitationService().AcceptInvitation(ctx, &params)
+			if err == nil || err.Error() != "邀请已处理" {
+				t.Errorf("accept again: err = %v", err)
+			}
+		})
+	}
+}
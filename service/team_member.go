@@ -51,6 +51,7 @@ func (s *TeamMemberService) CreateTeamMember(ctx *app.Context, teamMember *model
 		ctx.Logger.Error("Failed to create team member", err)
 		return errors.New("failed to create team member")
 	}
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -75,6 +76,7 @@ func (s *TeamMemberService) UpdateTeamMember(ctx *app.Context, teamMember *model
 		return errors.New("failed to update team member")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -139,6 +141,7 @@ func (s *TeamMemberService) DeleteTeamMember(ctx *app.Context, uuid string) erro
 		return errors.New("failed to delete team member")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -328,6 +331,7 @@ func (s *TeamMemberService) SwitchTeam(ctx *app.Context, userId string, params *
 		return errors.New("failed to switch team")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
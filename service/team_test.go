@@ -0,0 +1,113 @@
+package service
+
+import (
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/testutil"
+)
+
+func TestCreateTeamOwner(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	ctx.Set("user_id", "user-1")
+
+	team, err := NewTeamService().CreateTeam(ctx, &model.ReqTeamCreate{Name: "shop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team.OwnerUuid != "user-1" {
+		t.Errorf("owner = %q, want user-1", team.OwnerUuid)
+	}
+
+	member, err := NewTeamMemberService().GetUserCurrentTeam(ctx, "user-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if member.TeamUUID != team.UUID {
+		t.Errorf("current team = %q, want %q", member.TeamUUID, team.UUID)
+	}
+}
+
+// 更新只修改当前团队，请求中的拥有者被忽略
+func TestUpdateTeamKeepsOwner(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	ctx.Set("user_id", "owner")
+	team, err := NewTeamService().CreateTeam(ctx, &model.ReqTeamCreate{Name: "shop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewTeamService().CreateTeam(ctx, &model.ReqTeamCreate{Name: "other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ctx.DB.Create(&model.TeamMember{UUID: "m1", TeamUUID: team.UUID, UserUUID: "editor", IsCurrentTeam: true}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.Set("user_id", "editor")
+
+	updated, err := NewTeamService().UpdateTeam(ctx, &model.ReqTeamUpdate{Name: "renamed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.UUID != team.UUID || updated.Name != "renamed" || updated.OwnerUuid != "owner" {
+		t.Errorf("updated team = %+v", updated)
+	}
+
+	got, err := NewTeamService().GetTeamByUUID(ctx, other.UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "other" {
+		t.Errorf("other team changed: %+v", got)
+	}
+}
+
+// 只有拥有者可以把团队转让给其他成员
+func TestTransferOwner(t *testing.T) {
+	tests := []struct {
+		name      string
+		operator  string
+		newOwner  string
+		wantErr   string
+		wantOwner string
+	}{
+		{"owner to member", "owner", "member", "", "member"},
+		{"owner to self", "owner", "owner", "不能转让给自己", "owner"},
+		{"owner to outsider", "owner", "outsider", "新拥有者不是团队成员", "owner"},
+		{"member to self", "member", "member", "只有团队拥有者可以转让团队", "owner"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := testutil.NewContext(t)
+			ctx.Set("user_id", "owner")
+			team, err := NewTeamService().CreateTeam(ctx, &model.ReqTeamCreate{Name: "shop"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ctx.DB.Create(&model.TeamMember{UUID: "m1", TeamUUID: team.UUID, UserUUID: "member"}).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx.Set("user_id", tt.operator)
+			err = NewTeamService().TransferOwner(ctx, &model.ReqTeamOwnerTransfer{TeamUuid: team.UUID, UserUuid: tt.newOwner})
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tt.wantErr {
+				t.Fatalf("err = %q, want %q", got, tt.wantErr)
+			}
+
+			saved, err := NewTeamService().GetTeamByUUID(ctx, team.UUID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.OwnerUuid != tt.wantOwner {
+				t.Errorf("owner = %q, want %q", saved.OwnerUuid, tt.wantOwner)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+
+	"gorm.io/gorm"
+)
+
+const tenantHostCacheExpire = time.Minute
+
+// 商城域名对应的团队，修改团队域名后本实例立即失效，其他实例最长一分钟后生效
+var tenantHostCache sync.Map // 域名 -> *tenantHost
+
+type tenantHost struct {
+	teamUuid string
+	expireAt time.Time
+}
+
+type TenantService struct {
+}
+
+func NewTenantService() *TenantService {
+	return &TenantService{}
+}
+
+// GetTenantByHost 根据商城域名获取租户，域名没有绑定团队时使用配置的默认团队
+func (s *TenantService) GetTenantByHost(ctx *app.Context, host string) (string, error) {
+	host = s.normalizeHost(host)
+	if host == "" {
+		return ctx.Config.Tenant.DefaultTeam, nil
+	}
+
+	if v, ok := tenantHostCache.Load(host); ok {
+		cached := v.(*tenantHost)
+		if time.Now().Before(cached.expireAt) {
+			return cached.teamUuid, nil
+		}
+	}
+
+	team := &model.Team{}
+	teamUuid := ctx.Config.Tenant.DefaultTeam
+	err := ctx.DB.Where("domain = ? AND is_active = ?", host, true).First(team).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.Logger.Error("Failed to get team by domain", err)
+			return "", errors.New("failed to get tenant")
+		}
+	} else {
+		teamUuid = team.UUID
+	}
+
+	tenantHostCache.Store(host, &tenantHost{
+		teamUuid: teamUuid,
+		expireAt: time.Now().Add(tenantHostCacheExpire),
+	})
+	return teamUuid, nil
+}
+
+// GetTenantByUser 获取后台用户的租户，即用户当前选择的团队，没有加入团队时为空
+func (s *TenantService) GetTenantByUser(ctx *app.Context, userId string) (string, error) {
+	teamMember := &model.TeamMember{}
+	err := ctx.DB.Where("user_uuid = ? AND is_current_team = ?", userId, true).First(teamMember).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		ctx.Logger.Error("Failed to get current team", err)
+		return "", errors.New("failed to get tenant")
+	}
+	return teamMember.TeamUUID, nil
+}
+
+// CheckDomain 检查域名是否已被其他团队绑定
+func (s *TenantService) CheckDomain(ctx *app.Context, teamUuid, domain string) error {
+	domain = s.normalizeHost(domain)
+	if domain == "" {
+		return nil
+	}
+
+	var count int64
+	err := ctx.DB.Model(&model.Team{}).Where("domain = ? AND uuid <> ?", domain, teamUuid).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to check team domain", err)
+		return errors.New("failed to check team domain")
+	}
+	if count > 0 {
+		return errors.New("域名已被其他团队使用")
+	}
+	return nil
+}
+
+// ClearHostCache 团队域名修改后清除域名缓存
+func (s *TenantService) ClearHostCache() {
+	tenantHostCache.Range(func(key, value interface{}) bool {
+		tenantHostCache.Delete(key)
+		return true
+	})
+}
+
+// 去掉端口并转为小写
+func (s *TenantService) normalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
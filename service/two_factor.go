@@ -0,0 +1,576 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/mail"
+	"sgin/pkg/totp"
+	"sgin/pkg/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// 登录挑战有效期和最大失败次数
+	loginChallengeExpire      = 5 * time.Minute
+	loginChallengeMaxAttempts = 5
+
+	// 恢复码数量
+	recoveryCodeCount = 10
+)
+
+type TwoFactorService struct {
+}
+
+func NewTwoFactorService() *TwoFactorService {
+	return &TwoFactorService{}
+}
+
+// IsRequired 用户在任一团队或全局拥有要求两步验证的角色时返回true
+func (s *TwoFactorService) IsRequired(ctx *app.Context, userUuid string) (bool, error) {
+	roleUuids := make([]string, 0)
+	err := ctx.DB.Model(&model.TeamMember{}).Where("user_uuid = ?", userUuid).Pluck("role", &roleUuids).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get team member roles", err)
+		return false, errors.New("failed to get user roles")
+	}
+
+	userRoleUuids := make([]string, 0)
+	err = ctx.DB.Model(&model.UserRole{}).Where("user_uuid = ?", userUuid).Pluck("role_uuid", &userRoleUuids).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get user roles", err)
+		return false, errors.New("failed to get user roles")
+	}
+	roleUuids = append(roleUuids, userRoleUuids...)
+	if len(roleUuids) == 0 {
+		return false, nil
+	}
+
+	var count int64
+	err = ctx.DB.Model(&model.Role{}).Where("uuid IN (?) AND require_2fa = ?", roleUuids, true).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to count roles", err)
+		return false, errors.New("failed to get user roles")
+	}
+	return count > 0, nil
+}
+
+// GetStatus 获取用户的两步验证状态
+func (s *TwoFactorService) GetStatus(ctx *app.Context, userUuid string) (*model.ResTwoFactorStatus, error) {
+	required, err := s.IsRequired(ctx, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.ResTwoFactorStatus{Required: required}
+	tf, err := s.getTwoFactor(ctx, userUuid)
+	if err != nil {
+		return nil, err
+	}
+	if tf != nil && tf.Enabled {
+		res.Enabled = true
+		res.Method = tf.Method
+		res.RecoveryCodesCount = len(s.recoveryCodeHashes(tf))
+	}
+	return res, nil
+}
+
+// Setup 开始绑定两步验证，已启用时需要先关闭
+// totp返回密钥和otpauth链接，email发送验证码到用户邮箱，均需调用Enable确认
+func (s *TwoFactorService) Setup(ctx *app.Context, user *model.User, method string) (*model.ResTotpSetup, error) {
+	tf, err := s.getTwoFactor(ctx, user.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if tf != nil && tf.Enabled {
+		return nil, errors.New("两步验证已启用，请先关闭")
+	}
+
+	pending := &model.UserTwoFactor{
+		UserUuid: user.Uuid,
+		Method:   method,
+	}
+
+	var res *model.ResTotpSetup
+	switch method {
+	case model.TwoFactorMethodTotp:
+		secret, err := totp.GenerateSecret()
+		if err != nil {
+			ctx.Logger.Error("Failed to generate totp secret", err)
+			return nil, errors.New("failed to setup two factor")
+		}
+		pending.TotpSecret, err = utils.EncryptString(secret, ctx.Config.PasswdKey)
+		if err != nil {
+			ctx.Logger.Error("Failed to encrypt totp secret", err)
+			return nil, errors.New("failed to setup two factor")
+		}
+		res = &model.ResTotpSetup{
+			Secret: secret,
+			Uri:    totp.ProvisioningURI(secret, s.issuer(ctx), user.Username),
+		}
+	case model.TwoFactorMethodEmail:
+		if user.Email == "" {
+			return nil, errors.New("用户未设置邮箱")
+		}
+	default:
+		return nil, errors.New("不支持的验证方式")
+	}
+
+	// 未启用的设置直接覆盖
+	now := time.Now().Format(time.DateTime)
+	pending.CreatedAt = now
+	pending.UpdatedAt = now
+	err = ctx.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_uuid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"method", "totp_secret", "totp_last_step", "enabled", "recovery_codes", "enabled_at", "updated_at"}),
+	}).Create(pending).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to save two factor", err)
+		return nil, errors.New("failed to setup two factor")
+	}
+
+	if method == model.TwoFactorMethodEmail {
+		err = s.sendEmailCode(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Enable 使用验证码确认绑定并启用两步验证，返回恢复码明文，只返回一次
+func (s *TwoFactorService) Enable(ctx *app.Context, user *model.User, code string) ([]string, error) {
+	tf, err := s.getTwoFactor(ctx, user.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil {
+		return nil, errors.New("请先绑定两步验证")
+	}
+	if tf.Enabled {
+		return nil, errors.New("两步验证已启用")
+	}
+
+	// 绑定时只接受验证码，不接受恢复码
+	ok, err := s.checkMethodCode(ctx, user, tf, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("验证码错误")
+	}
+
+	codes, hashes, err := s.newRecoveryCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Format(time.DateTime)
+	err = ctx.DB.Model(&model.UserTwoFactor{}).Where("id = ?", tf.ID).Updates(map[string]interface{}{
+		"enabled":        true,
+		"enabled_at":     now,
+		"recovery_codes": hashes,
+		"updated_at":     now,
+	}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to enable two factor", err)
+		return nil, errors.New("failed to enable two factor")
+	}
+	return codes, nil
+}
+
+// Disable 关闭两步验证，需要当前密码和验证码，角色要求两步验证时不能关闭
+func (s *TwoFactorService) Disable(ctx *app.Context, user *model.User, password, code string) error {
+	required, err := s.IsRequired(ctx, user.Uuid)
+	if err != nil {
+		return err
+	}
+	if required {
+		return errors.New("当前角色要求两步验证，不能关闭")
+	}
+
+	if !utils.CheckPasswordHashWithSalt(password, user.Password, ctx.Config.PasswdKey) {
+		return errors.New("密码错误")
+	}
+
+	tf, err := s.getTwoFactor(ctx, user.Uuid)
+	if err != nil {
+		return err
+	}
+	if tf == nil || !tf.Enabled {
+		return errors.New("两步验证未启用")
+	}
+
+	err = s.verifyCode(ctx, user, tf, code)
+	if err != nil {
+		return err
+	}
+
+	err = ctx.DB.Where("id = ?", tf.ID).Delete(&model.UserTwoFactor{}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to delete two factor", err)
+		return errors.New("failed to disable two factor")
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码，旧的恢复码全部失效
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx *app.Context, user *model.User, code string) ([]string, error) {
+	tf, err := s.getTwoFactor(ctx, user.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil || !tf.Enabled {
+		return nil, errors.New("两步验证未启用")
+	}
+
+	ok, err := s.checkMethodCode(ctx, user, tf, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("验证码错误")
+	}
+
+	codes, hashes, err := s.newRecoveryCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = ctx.DB.Model(&model.UserTwoFactor{}).Where("id = ?", tf.ID).Updates(map[string]interface{}{
+		"recovery_codes": hashes,
+		"updated_at":     time.Now().Format(time.DateTime),
+	}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to update recovery codes", err)
+		return nil, errors.New("failed to update recovery codes")
+	}
+	return codes, nil
+}
+
+// SendEmailCode 已启用邮箱验证的用户重新获取验证码
+func (s *TwoFactorService) SendEmailCode(ctx *app.Context, user *model.User) error {
+	tf, err := s.getTwoFactor(ctx, user.Uuid)
+	if err != nil {
+		return err
+	}
+	if tf == nil || tf.Method != model.TwoFactorMethodEmail {
+		return errors.New("未使用邮箱验证")
+	}
+	return s.sendEmailCode(ctx, user)
+}
+
+// CreateChallenge 密码验证通过后创建登录挑战，不需要两步验证时返回nil
+// 已启用时使用已绑定的方式，角色要求但未绑定时返回setup挑战，需要先绑定
+func (s *TwoFactorService) CreateChallenge(ctx *app.Context, user *model.User) (*model.ResLoginChallenge, error) {
+	tf, err := s.getTwoFactor(ctx, user.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	method := ""
+	if tf != nil && tf.Enabled {
+		method = tf.Method
+	} else {
+		required, err := s.IsRequired(ctx, user.Uuid)
+		if err != nil {
+			return nil, err
+		}
+		if !required {
+			return nil, nil
+		}
+		method = model.TwoFactorMethodSetup
+	}
+
+	now := time.Now()
+	challenge := &model.LoginChallenge{
+		Uuid:      uuid.New().String(),
+		UserUuid:  user.Uuid,
+		Method:    method,
+		ExpiresAt: now.Add(loginChallengeExpire).Format(time.DateTime),
+		CreatedAt: now.Format(time.DateTime),
+	}
+	err = ctx.DB.Create(challenge).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create login challenge", err)
+		return nil, errors.New("failed to create login challenge")
+	}
+
+	if method == model.TwoFactorMethodEmail {
+		err = s.sendEmailCode(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.ResLoginChallenge{
+		Uuid:      challenge.Uuid,
+		Method:    method,
+		ExpiresIn: int64(loginChallengeExpire.Seconds()),
+	}, nil
+}
+
+// GetChallengeUser 获取有效登录挑战对应的用户，用于记录登录日志
+func (s *TwoFactorService) GetChallengeUser(ctx *app.Context, challengeUuid string) (*model.LoginChallenge, *model.User, error) {
+	challenge := &model.LoginChallenge{}
+	err := ctx.DB.Where("uuid = ?", challengeUuid).First(challenge).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New("登录已失效，请重新登录")
+		}
+		ctx.Logger.Error("Failed to get login challenge", err)
+		return nil, nil, errors.New("failed to get login challenge")
+	}
+	if challenge.CompletedAt != "" || challenge.ExpiresAt < time.Now().Format(time.DateTime) ||
+		challenge.Attempts >= loginChallengeMaxAttempts {
+		return nil, nil, errors.New("登录已失效，请重新登录")
+	}
+
+	user, err := NewUserService().GetUserByUUID(ctx, challenge.UserUuid)
+	if err != nil {
+		return nil, nil, err
+	}
+	return challenge, user, nil
+}
+
+// SetupByChallenge 登录时绑定两步验证，只能用于setup挑战
+func (s *TwoFactorService) SetupByChallenge(ctx *app.Context, challengeUuid, method string) (*model.ResTotpSetup, error) {
+	challenge, user, err := s.GetChallengeUser(ctx, challengeUuid)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.Method != model.TwoFactorMethodSetup {
+		return nil, errors.New("两步验证已绑定")
+	}
+	return s.Setup(ctx, user, method)
+}
+
+// VerifyChallenge 校验登录挑战，setup挑战会同时启用绑定的验证方式并返回恢复码
+// 失败次数达到上限后挑战失效，需要重新登录
+func (s *TwoFactorService) VerifyChallenge(ctx *app.Context, challengeUuid, code string) (*model.User, []string, error) {
+	challenge, user, err := s.GetChallengeUser(ctx, challengeUuid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 先占用一次尝试次数再校验验证码，并发提交不能超过失败次数上限
+	result := ctx.DB.Model(&model.LoginChallenge{}).
+		Where("id = ? AND completed_at = ? AND attempts < ?", challenge.ID, "", loginChallengeMaxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to update login challenge attempts", result.Error)
+		return user, nil, errors.New("failed to verify login challenge")
+	}
+	if result.RowsAffected == 0 {
+		return user, nil, errors.New("登录已失效，请重新登录")
+	}
+
+	var recoveryCodes []string
+	if challenge.Method == model.TwoFactorMethodSetup {
+		recoveryCodes, err = s.Enable(ctx, user, code)
+	} else {
+		tf, e := s.getTwoFactor(ctx, user.Uuid)
+		if e != nil {
+			return nil, nil, e
+		}
+		if tf == nil || !tf.Enabled {
+			return nil, nil, errors.New("登录已失效，请重新登录")
+		}
+		err = s.verifyCode(ctx, user, tf, code)
+	}
+	if err != nil {
+		return user, nil, err
+	}
+
+	// 并发提交同一挑战时只有一个请求能完成登录
+	result = ctx.DB.Model(&model.LoginChallenge{}).Where("id = ? AND completed_at = ?", challenge.ID, "").
+		Update("completed_at", time.Now().Format(time.DateTime))
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to complete login challenge", result.Error)
+		return user, nil, errors.New("failed to complete login challenge")
+	}
+	if result.RowsAffected == 0 {
+		return user, nil, errors.New("登录已失效，请重新登录")
+	}
+	return user, recoveryCodes, nil
+}
+
+// 校验验证码或恢复码，恢复码使用后删除
+func (s *TwoFactorService) verifyCode(ctx *app.Context, user *model.User, tf *model.UserTwoFactor, code string) error {
+	ok, err := s.checkMethodCode(ctx, user, tf, code)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+
+	ok, err = s.useRecoveryCode(ctx, tf, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("验证码错误")
+	}
+	return nil
+}
+
+// 按绑定方式校验验证码
+func (s *TwoFactorService) checkMethodCode(ctx *app.Context, user *model.User, tf *model.UserTwoFactor, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	switch tf.Method {
+	case model.TwoFactorMethodTotp:
+		secret, err := utils.DecryptString(tf.TotpSecret, ctx.Config.PasswdKey)
+		if err != nil {
+			ctx.Logger.Error("Failed to decrypt totp secret", err)
+			return false, errors.New("failed to verify code")
+		}
+		step, ok := totp.ValidateStep(secret, code, time.Now())
+		if !ok {
+			return false, nil
+		}
+		// 记录已使用的时间步，并发或重复提交同一密码时只有一个请求能通过
+		result := ctx.DB.Model(&model.UserTwoFactor{}).Where("id = ? AND totp_last_step < ?", tf.ID, step).
+			Update("totp_last_step", step)
+		if result.Error != nil {
+			ctx.Logger.Error("Failed to update totp last step", result.Error)
+			return false, errors.New("failed to verify code")
+		}
+		return result.RowsAffected > 0, nil
+	case model.TwoFactorMethodEmail:
+		// 恢复码格式与邮箱验证码不同，不消耗验证码错误次数
+		if strings.Contains(code, "-") {
+			return false, nil
+		}
+		err := (&VerificationCodeService{}).CheckVerificationCode(ctx, model.VerificationPurposeLogin, code, user.Email, "", true)
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (s *TwoFactorService) useRecoveryCode(ctx *app.Context, tf *model.UserTwoFactor, code string) (bool, error) {
+	hash := s.hashRecoveryCode(ctx, code)
+	hashes := s.recoveryCodeHashes(tf)
+
+	remain := make([]string, 0, len(hashes))
+	found := false
+	for _, h := range hashes {
+		if !found && hmac.Equal([]byte(h), []byte(hash)) {
+			found = true
+			continue
+		}
+		remain = append(remain, h)
+	}
+	if !found {
+		return false, nil
+	}
+
+	data, _ := json.Marshal(remain)
+	// 条件更新防止同一恢复码被并发使用
+	result := ctx.DB.Model(&model.UserTwoFactor{}).Where("id = ? AND recovery_codes = ?", tf.ID, tf.RecoveryCodes).
+		Updates(map[string]interface{}{
+			"recovery_codes": string(data),
+			"updated_at":     time.Now().Format(time.DateTime),
+		})
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to use recovery code", result.Error)
+		return false, errors.New("failed to verify code")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// 生成恢复码，返回明文和摘要json
+func (s *TwoFactorService) newRecoveryCodes(ctx *app.Context) ([]string, string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		_, err := rand.Read(b)
+		if err != nil {
+			ctx.Logger.Error("Failed to generate recovery code", err)
+			return nil, "", errors.New("failed to generate recovery codes")
+		}
+		h := hex.EncodeToString(b)
+		code := h[:5] + "-" + h[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, s.hashRecoveryCode(ctx, code))
+	}
+	data, _ := json.Marshal(hashes)
+	return codes, string(data), nil
+}
+
+func (s *TwoFactorService) recoveryCodeHashes(tf *model.UserTwoFactor) []string {
+	hashes := make([]string, 0)
+	if tf.RecoveryCodes != "" {
+		_ = json.Unmarshal([]byte(tf.RecoveryCodes), &hashes)
+	}
+	return hashes
+}
+
+func (s *TwoFactorService) hashRecoveryCode(ctx *app.Context, code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return utils.SignBody([]byte("recovery:"+code), []byte(ctx.Config.PasswdKey))
+}
+
+func (s *TwoFactorService) getTwoFactor(ctx *app.Context, userUuid string) (*model.UserTwoFactor, error) {
+	tf := &model.UserTwoFactor{}
+	err := ctx.DB.Where("user_uuid = ?", userUuid).First(tf).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		ctx.Logger.Error("Failed to get two factor", err)
+		return nil, errors.New("failed to get two factor")
+	}
+	return tf, nil
+}
+
+func (s *TwoFactorService) sendEmailCode(ctx *app.Context, user *model.User) error {
+	if ctx.Config.MailConfig.Host == "" {
+		return errors.New("mail is not configured")
+	}
+
+	codeService := &VerificationCodeService{}
+	code, err := codeService.CreateVerificationCode(ctx, model.VerificationPurposeLogin, user.Email, "")
+	if err != nil {
+		return err
+	}
+
+	subject := "登录验证码"
+	err = mail.Send(&mail.Options{
+		MailHost: ctx.Config.MailConfig.Host,
+		MailPort: ctx.Config.MailConfig.Port,
+		MailUser: ctx.Config.MailConfig.Username,
+		MailPass: ctx.Config.MailConfig.Password,
+		MailTo:   user.Email,
+		Subject:  subject,
+		Body:     fmt.Sprintf(customerCodeMailContent, subject, code, codeService.getConfig(ctx).Expire),
+	})
+	if err != nil {
+		ctx.Logger.Error("Failed to send verification code mail", err)
+		return errors.New("failed to send verification code mail")
+	}
+	return nil
+}
+
+// 身份验证器中显示的发行方，使用站点标题
+func (s *TwoFactorService) issuer(ctx *app.Context) string {
+	site, err := NewConfigurationService().GetConfigurationMapByCategory(ctx, model.ConfigCategorySite)
+	if err == nil && site[model.ConfigNameSiteTitle] != "" {
+		return site[model.ConfigNameSiteTitle]
+	}
+	return "sgin"
+}
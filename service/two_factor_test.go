@@ -0,0 +1,102 @@
+package service
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/testutil"
+	"sgin/pkg/totp"
+	"sgin/pkg/utils"
+)
+
+// 创建已启用TOTP的用户，返回密钥
+func setupTotpUser(t *testing.T, ctx *app.Context) (*model.User, string) {
+	t.Helper()
+	user := &model.User{Uuid: "user1", Username: "user1", Email: "user1@example.com",
+		CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}
+	err := ctx.DB.Create(user).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := utils.EncryptString(secret, ctx.Config.PasswdKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ctx.DB.Create(&model.UserTwoFactor{UserUuid: user.Uuid, Method: model.TwoFactorMethodTotp,
+		TotpSecret: encrypted, Enabled: true, CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user, secret
+}
+
+func TestVerifyChallengeTotpReplay(t *testing.T) {
+	a := testutil.NewApp(t)
+	a.Config.PasswdKey = "0123456789abcdef0123456789abcdef"
+	ctx := testutil.Context(a)
+	user, secret := setupTotpUser(t, ctx)
+	code, err := totp.Code(secret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewTwoFactorService()
+	for i, wantOK := range []bool{true, false} {
+		challenge, err := svc.CreateChallenge(ctx, user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = svc.VerifyChallenge(ctx, challenge.Uuid, code)
+		if (err == nil) != wantOK {
+			t.Errorf("verify %d: err = %v, want ok %v", i, err, wantOK)
+		}
+	}
+}
+
+func TestVerifyChallengeConcurrentAttempts(t *testing.T) {
+	a := testutil.NewApp(t)
+	a.Config.PasswdKey = "0123456789abcdef0123456789abcdef"
+	ctx := testutil.Context(a)
+	user, secret := setupTotpUser(t, ctx)
+
+	svc := NewTwoFactorService()
+	challenge, err := svc.CreateChallenge(ctx, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svc.VerifyChallenge(testutil.Context(a), challenge.Uuid, "000000x")
+		}()
+	}
+	wg.Wait()
+
+	var got model.LoginChallenge
+	err = a.DB.Where("uuid = ?", challenge.Uuid).First(&got).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Attempts > loginChallengeMaxAttempts {
+		t.Errorf("attempts = %d, want at most %d", got.Attempts, loginChallengeMaxAttempts)
+	}
+
+	code, err := totp.Code(secret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = svc.VerifyChallenge(ctx, challenge.Uuid, code)
+	if err == nil {
+		t.Error("challenge accepted after attempts exhausted")
+	}
+}
@@ -74,6 +74,11 @@ func (s *UserService) UpdateUser(ctx *app.Context, user *model.User) error {
 		return errors.New("failed to update user")
 	}
 
+	// 修改密码后所有会话需要重新登录
+	if user.Password != "" {
+		return NewSessionService().RevokeAllSessions(ctx, user.Uuid)
+	}
+
 	return nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"sgin/model"
 	"sgin/pkg/app"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -24,6 +25,10 @@ func (s *UserAddressService) CreateUserAddress(ctx *app.Context, address *model.
 		}
 	}
 
+	if address.Uuid == "" {
+		address.Uuid = uuid.New().String()
+	}
+
 	if err := ctx.DB.Create(address).Error; err != nil {
 		ctx.Logger.Error("Failed to create user address", err)
 		return errors.New("failed to create user address")
@@ -80,7 +85,7 @@ func (s *UserAddressService) DeleteUserAddress(ctx *app.Context, uuid string) er
 }
 
 // Helper method to set all addresses for a user to non-default
-func (s *UserAddressService) setAllAddressesNonDefault(ctx *app.Context, userID uint) error {
+func (s *UserAddressService) setAllAddressesNonDefault(ctx *app.Context, userID string) error {
 	if err := ctx.DB.Model(&model.UserAddress{}).Where("user_id = ?", userID).Update("is_default", false).Error; err != nil {
 		ctx.Logger.Error("Failed to set addresses to non-default", err)
 		return errors.New("failed to set addresses to non-default")
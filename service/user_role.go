@@ -26,6 +26,7 @@ func (s *UserRoleService) CreateUserRole(ctx *app.Context, userRole *model.UserR
 		ctx.Logger.Error("Failed to create user role", err)
 		return errors.New("failed to create user role")
 	}
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -50,6 +51,7 @@ func (s *UserRoleService) UpdateUserRole(ctx *app.Context, userRole *model.UserR
 		return errors.New("failed to update user role")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -60,6 +62,7 @@ func (s *UserRoleService) DeleteUserRole(ctx *app.Context, uuid string) error {
 		return errors.New("failed to delete user role")
 	}
 
+	NewRbacService().InvalidateCache(ctx)
 	return nil
 }
 
@@ -1,82 +1,281 @@
 package service
 
 import (
+	"crypto/hmac"
 	"errors"
+	"fmt"
 	"sgin/model"
 	"sgin/pkg/app"
+	"sgin/pkg/config"
 	"sgin/pkg/utils"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+const verificationCodeKeyPrefix = "sgin:vcode:"
+
 type VerificationCodeService struct {
 }
 
-// CreateVerificationCode 创建验证码
-func (v *VerificationCodeService) CreateVerificationCode(ctx *app.Context, email string, phone string) (string, error) {
+// CreateVerificationCode 创建验证码，返回明文验证码用于发送，数据库只保存摘要
+// 同一接收者有发送间隔限制，接收者和IP每小时有发送次数限制
+func (v *VerificationCodeService) CreateVerificationCode(ctx *app.Context, purpose string, email string, phone string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	recipient := email
+	if recipient == "" {
+		recipient = phone
+	}
+	if recipient == "" {
+		return "", errors.New("邮箱和手机号码不能同时为空")
+	}
 
-	// 先获取最新的一条验证码是否过期
-	var vcode model.VerificationCode
-	err := ctx.DB.Where("email = ? OR phone = ?", email, phone).Order("created_at desc").First(&vcode).Error
+	err := v.checkSendLimit(ctx, purpose, recipient, email, phone)
 	if err != nil {
 		return "", err
 	}
 
-	if vcode.Status == 0 && time.Now().Sub(vcode.CreatedAt).Minutes() < 1 {
-		err := errors.New("验证码已发送，请稍后再试")
-		return "", err
+	// 同一用途只保留最新的验证码
+	err = v.recipientQuery(ctx.DB.Model(&model.VerificationCode{}), email, phone).
+		Where("purpose = ? AND status = ?", purpose, model.VerificationCodeStatusUnused).
+		Update("status", model.VerificationCodeStatusExpired).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to expire verification codes", err)
+		return "", errors.New("failed to create verification code")
 	}
 
 	code := utils.GenerateVerificationCode()
 
-	vcode1 := model.VerificationCode{
+	vcode := model.VerificationCode{
 		UUID:      uuid.New().String(),
-		Code:      code,
+		Code:      v.hashCode(ctx, purpose, recipient, code),
+		Purpose:   purpose,
 		Email:     email,
 		Phone:     phone,
+		Ip:        ctx.ClientIP(),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
-		Status:    0,
+		Status:    model.VerificationCodeStatusUnused,
 	}
 
-	err = ctx.DB.Create(&vcode1).Error
-	return code, err
-
+	err = ctx.DB.Create(&vcode).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create verification code", err)
+		return "", errors.New("failed to create verification code")
+	}
+	return code, nil
 }
 
-// CheckVerificationCode 检查验证码
-func (v *VerificationCodeService) CheckVerificationCode(ctx *app.Context, code string, email string, phone string) (bool, error) {
+// CheckVerificationCode 检查验证码，错误次数达到上限后验证码失效
+// consume为true时校验通过后标记为已使用，同一验证码只能使用一次
+func (v *VerificationCodeService) CheckVerificationCode(ctx *app.Context, purpose string, code string, email string, phone string, consume bool) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	recipient := email
+	if recipient == "" {
+		recipient = phone
+	}
+	if recipient == "" || code == "" {
+		return errors.New("验证码错误")
+	}
 
-	var vcode model.VerificationCode
-	err := ctx.DB.Where("code = ? AND (email = ? OR phone = ?)", code, email, phone).First(&vcode).Error
+	vcodes := make([]*model.VerificationCode, 0)
+	err := v.recipientQuery(ctx.DB, email, phone).
+		Where("purpose = ? AND status = ?", purpose, model.VerificationCodeStatusUnused).
+		Order("created_at desc").Limit(1).Find(&vcodes).Error
 	if err != nil {
-		return false, err
+		ctx.Logger.Error("Failed to get verification code", err)
+		return errors.New("failed to get verification code")
+	}
+	if len(vcodes) == 0 {
+		return errors.New("验证码错误")
+	}
+	vcode := vcodes[0]
+
+	conf := v.getConfig(ctx)
+	if time.Since(vcode.CreatedAt) > time.Duration(conf.Expire)*time.Minute {
+		v.updateStatus(ctx, vcode.Id, model.VerificationCodeStatusExpired)
+		return errors.New("验证码已过期")
+	}
+
+	// 先占用一次尝试次数再比较验证码，并发请求不能超过错误次数上限
+	result := ctx.DB.Model(&model.VerificationCode{}).
+		Where("id = ? AND status = ? AND attempts < ?", vcode.Id, model.VerificationCodeStatusUnused, conf.MaxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to update verification code attempts", result.Error)
+		return errors.New("failed to update verification code")
+	}
+	if result.RowsAffected == 0 {
+		v.expireExhausted(ctx, vcode.Id, conf.MaxAttempts)
+		return errors.New("验证码错误次数过多，请重新获取")
 	}
 
-	if vcode.Status == 1 {
-		return false, nil
+	if !hmac.Equal([]byte(vcode.Code), []byte(v.hashCode(ctx, purpose, recipient, code))) {
+		if v.expireExhausted(ctx, vcode.Id, conf.MaxAttempts) {
+			return errors.New("验证码错误次数过多，请重新获取")
+		}
+		return errors.New("验证码错误")
 	}
 
-	if time.Now().Sub(vcode.CreatedAt).Minutes() > 5 {
-		return false, nil
+	if !consume {
+		// 只校验不使用时归还占用的尝试次数
+		err = ctx.DB.Model(&model.VerificationCode{}).Where("id = ? AND attempts > 0", vcode.Id).
+			Update("attempts", gorm.Expr("attempts - 1")).Error
+		if err != nil {
+			ctx.Logger.Error("Failed to update verification code attempts", err)
+		}
+		return nil
 	}
 
-	return true, nil
+	// 并发使用同一验证码时只有一个请求能更新成功
+	result = ctx.DB.Model(&model.VerificationCode{}).
+		Where("id = ? AND status = ?", vcode.Id, model.VerificationCodeStatusUnused).
+		Updates(map[string]interface{}{
+			"status":     model.VerificationCodeStatusUsed,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to update verification code", result.Error)
+		return errors.New("failed to update verification code")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("验证码错误")
+	}
+	return nil
 }
 
-// UpdateVerificationCode 更新验证码
-func (v *VerificationCodeService) UpdateVerificationCode(ctx *app.Context, code string, email string, phone string) error {
+// 检查发送间隔和发送次数，配置了redis时使用redis计数
+func (v *VerificationCodeService) checkSendLimit(ctx *app.Context, purpose, recipient, email, phone string) error {
+	conf := v.getConfig(ctx)
+	ip := ctx.ClientIP()
 
-	var vcode model.VerificationCode
-	err := ctx.DB.Where("code = ? AND email = ? AND phone = ?", code, email, phone).First(&vcode).Error
+	if ctx.Redis != nil {
+		ok, err := ctx.Redis.SetNX(ctx.Ctx, verificationCodeKeyPrefix+"interval:"+purpose+":"+recipient, "1",
+			time.Duration(conf.Interval)*time.Second)
+		if err != nil {
+			ctx.Logger.Error("Failed to check verification code interval", err)
+			return errors.New("failed to create verification code")
+		}
+		if !ok {
+			return errors.New("验证码已发送，请稍后再试")
+		}
+
+		limits := []struct {
+			key   string
+			limit int
+		}{
+			{verificationCodeKeyPrefix + "recipient:" + recipient, conf.RecipientLimit},
+			{verificationCodeKeyPrefix + "ip:" + ip, conf.IpLimit},
+		}
+		for _, l := range limits {
+			n, err := ctx.Redis.Incr(ctx.Ctx, l.key)
+			if err != nil {
+				ctx.Logger.Error("Failed to count verification code", err)
+				return errors.New("failed to create verification code")
+			}
+			if n == 1 {
+				err = ctx.Redis.Expire(ctx.Ctx, l.key, time.Hour)
+				if err != nil {
+					ctx.Logger.Error("Failed to expire verification code counter", err)
+				}
+			}
+			if n > int64(l.limit) {
+				return errors.New("验证码发送次数过多，请稍后再试")
+			}
+		}
+		return nil
+	}
+
+	// 先获取最新的一条验证码，判断是否在发送间隔内
+	vcodes := make([]*model.VerificationCode, 0)
+	err := v.recipientQuery(ctx.DB, email, phone).Where("purpose = ?", purpose).
+		Order("created_at desc").Limit(1).Find(&vcodes).Error
 	if err != nil {
-		return err
+		ctx.Logger.Error("Failed to get verification code", err)
+		return errors.New("failed to create verification code")
+	}
+	if len(vcodes) > 0 && time.Since(vcodes[0].CreatedAt) < time.Duration(conf.Interval)*time.Second {
+		return errors.New("验证码已发送，请稍后再试")
 	}
 
-	vcode.Status = 1
-	vcode.UpdatedAt = time.Now()
+	since := time.Now().Add(-time.Hour)
+	var count int64
+	err = v.recipientQuery(ctx.DB.Model(&model.VerificationCode{}), email, phone).
+		Where("created_at >= ?", since).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to count verification code", err)
+		return errors.New("failed to create verification code")
+	}
+	if count >= int64(conf.RecipientLimit) {
+		return errors.New("验证码发送次数过多，请稍后再试")
+	}
+
+	err = ctx.DB.Model(&model.VerificationCode{}).Where("ip = ? AND created_at >= ?", ip, since).Count(&count).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to count verification code", err)
+		return errors.New("failed to create verification code")
+	}
+	if count >= int64(conf.IpLimit) {
+		return errors.New("验证码发送次数过多，请稍后再试")
+	}
+	return nil
+}
 
-	err = ctx.DB.Save(&vcode).Error
-	return err
+func (v *VerificationCodeService) recipientQuery(db *gorm.DB, email, phone string) *gorm.DB {
+	if email != "" {
+		return db.Where("email = ?", email)
+	}
+	return db.Where("phone = ?", phone)
+}
+
+func (v *VerificationCodeService) updateStatus(ctx *app.Context, id uint, status int) {
+	err := ctx.DB.Model(&model.VerificationCode{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     status,
+		"updated_at": time.Now(),
+	}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to update verification code status", err)
+	}
+}
+
+// 错误次数达到上限的未使用验证码标记为过期，返回是否已达到上限
+func (v *VerificationCodeService) expireExhausted(ctx *app.Context, id uint, maxAttempts int) bool {
+	result := ctx.DB.Model(&model.VerificationCode{}).
+		Where("id = ? AND status = ? AND attempts >= ?", id, model.VerificationCodeStatusUnused, maxAttempts).
+		Updates(map[string]interface{}{
+			"status":     model.VerificationCodeStatusExpired,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to update verification code status", result.Error)
+		return false
+	}
+	return result.RowsAffected > 0
+}
+
+// 验证码摘要，使用密码key做HMAC，防止数据库泄露后被枚举
+func (v *VerificationCodeService) hashCode(ctx *app.Context, purpose, recipient, code string) string {
+	return utils.SignBody([]byte(fmt.Sprintf("%s:%s:%s", purpose, recipient, code)), []byte(ctx.Config.PasswdKey))
+}
+
+func (v *VerificationCodeService) getConfig(ctx *app.Context) config.VerificationCodeConfig {
+	conf := ctx.Config.VerificationCode
+	if conf.Expire <= 0 {
+		conf.Expire = 5
+	}
+	if conf.Interval <= 0 {
+		conf.Interval = 60
+	}
+	if conf.MaxAttempts <= 0 {
+		conf.MaxAttempts = 5
+	}
+	if conf.RecipientLimit <= 0 {
+		conf.RecipientLimit = 10
+	}
+	if conf.IpLimit <= 0 {
+		conf.IpLimit = 20
+	}
+	return conf
 }
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"sync"
+	"testing"
+
+	"sgin/model"
+	"sgin/pkg/testutil"
+)
+
+func TestCheckVerificationCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		codes   []string
+		consume bool
+		wantErr []string
+	}{
+		{"correct code", []string{"right"}, true, []string{""}},
+		{"used code", []string{"right", "right"}, true, []string{"", "验证码错误"}},
+		{"check without consume", []string{"right", "right"}, false, []string{"", ""}},
+		{"wrong then correct", []string{"wrong", "right"}, true, []string{"验证码错误", ""}},
+		{"too many attempts", []string{"wrong", "wrong", "wrong", "right"}, true,
+			[]string{"验证码错误", "验证码错误", "验证码错误次数过多，请重新获取", "验证码错误"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := testutil.NewApp(t)
+			a.Config.VerificationCode.MaxAttempts = 3
+			ctx := testutil.Context(a)
+			svc := &VerificationCodeService{}
+			code, err := svc.CreateVerificationCode(ctx, "register", "user@example.com", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, c := range tt.codes {
+				if c == "right" {
+					c = code
+				}
+				err := svc.CheckVerificationCode(ctx, "register", c, "user@example.com", "", tt.consume)
+				got := ""
+				if err != nil {
+					got = err.Error()
+				}
+				if got != tt.wantErr[i] {
+					t.Errorf("check %d: err = %q, want %q", i, got, tt.wantErr[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckVerificationCodeConcurrentAttempts(t *testing.T) {
+	a := testutil.NewApp(t)
+	a.Config.VerificationCode.MaxAttempts = 3
+	svc := &VerificationCodeService{}
+	code, err := svc.CreateVerificationCode(testutil.Context(a), "register", "user@example.com", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svc.CheckVerificationCode(testutil.Context(a), "register", "000000x", "user@example.com", "", true)
+		}()
+	}
+	wg.Wait()
+
+	var vcode model.VerificationCode
+	err = a.DB.Where("email = ?", "user@example.com").First(&vcode).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vcode.Attempts > 3 {
+		t.Errorf("attempts = %d, want at most 3", vcode.Attempts)
+	}
+	if vcode.Status != model.VerificationCodeStatusExpired {
+		t.Errorf("status = %d, want expired", vcode.Status)
+	}
+	err = svc.CheckVerificationCode(testutil.Context(a), "register", code, "user@example.com", "", true)
+	if err == nil {
+		t.Error("code accepted after attempts exhausted")
+	}
+}
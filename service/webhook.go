@@ -0,0 +1,576 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/app"
+	"sgin/pkg/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	webhookDefaultTimeout     = 10
+	webhookDefaultMaxAttempts = 10
+	// 每次处理的到期投递数量
+	webhookBatchSize = 100
+	// 保存的响应内容长度
+	webhookResponseLimit = 2048
+)
+
+var webhookClient = &http.Client{}
+
+type WebhookService struct {
+}
+
+func NewWebhookService() *WebhookService {
+	return &WebhookService{}
+}
+
+// CreateWebhook 创建webhook订阅
+func (s *WebhookService) CreateWebhook(ctx *app.Context, params *model.ReqWebhookCreate) (*model.Webhook, error) {
+	_, err := NewAppService().GetAppByUUID(ctx, params.AppUuid)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.checkEvents(params.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Format(time.DateTime)
+	webhook := &model.Webhook{
+		Uuid:        uuid.New().String(),
+		AppUuid:     params.AppUuid,
+		Url:         params.Url,
+		Events:      events,
+		Description: params.Description,
+		Status:      model.WebhookStatusEnabled,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	err = ctx.DB.Create(webhook).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create webhook", err)
+		return nil, errors.New("failed to create webhook")
+	}
+	return webhook, nil
+}
+
+// UpdateWebhook 更新webhook订阅
+func (s *WebhookService) UpdateWebhook(ctx *app.Context, params *model.ReqWebhookUpdate) error {
+	updates := map[string]interface{}{
+		"description": params.Description,
+		"updated_at":  time.Now().Format(time.DateTime),
+	}
+	if params.Url != "" {
+		updates["url"] = params.Url
+	}
+	if len(params.Events) > 0 {
+		events, err := s.checkEvents(params.Events)
+		if err != nil {
+			return err
+		}
+		updates["events"] = events
+	}
+	if params.Status != 0 {
+		if params.Status != model.WebhookStatusEnabled && params.Status != model.WebhookStatusDisabled {
+			return errors.New("invalid webhook status")
+		}
+		updates["status"] = params.Status
+	}
+
+	result := ctx.DB.Model(&model.Webhook{}).Where("uuid = ?", params.Uuid).Updates(updates)
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to update webhook", result.Error)
+		return errors.New("failed to update webhook")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("webhook not found")
+	}
+	return nil
+}
+
+// DeleteWebhook 删除webhook订阅，等待重试的投递不再推送，投递记录保留
+func (s *WebhookService) DeleteWebhook(ctx *app.Context, webhookUuid string) error {
+	return ctx.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("uuid = ?", webhookUuid).Delete(&model.Webhook{})
+		if result.Error != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to delete webhook", result.Error)
+			return errors.New("failed to delete webhook")
+		}
+		if result.RowsAffected == 0 {
+			tx.Rollback()
+			return errors.New("webhook not found")
+		}
+
+		err := tx.Model(&model.WebhookDelivery{}).
+			Where("webhook_uuid = ? AND status = ?", webhookUuid, model.WebhookDeliveryStatusPending).
+			Updates(map[string]interface{}{
+				"status":     model.WebhookDeliveryStatusFailed,
+				"error":      "webhook已删除",
+				"updated_at": time.Now().Format(time.DateTime),
+			}).Error
+		if err != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to cancel webhook deliveries", err)
+			return errors.New("failed to delete webhook")
+		}
+		return nil
+	})
+}
+
+// GetWebhookList 获取webhook订阅列表
+func (s *WebhookService) GetWebhookList(ctx *app.Context, params *model.ReqWebhookQueryParam) (*model.PagedResponse, error) {
+	var (
+		webhooks []*model.Webhook
+		total    int64
+	)
+
+	db := ctx.DB.Model(&model.Webhook{})
+	if params.AppUuid != "" {
+		db = db.Where("app_uuid = ?", params.AppUuid)
+	}
+	if params.Event != "" {
+		db = db.Where("events LIKE ?", "%"+params.Event+"%")
+	}
+	if params.Status != 0 {
+		db = db.Where("status = ?", params.Status)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get webhook count", err)
+		return nil, errors.New("failed to get webhook count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&webhooks).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get webhook list", err)
+		return nil, errors.New("failed to get webhook list")
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     webhooks,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// GetDeliveryList 获取投递记录
+func (s *WebhookService) GetDeliveryList(ctx *app.Context, params *model.ReqWebhookDeliveryQueryParam) (*model.PagedResponse, error) {
+	var (
+		deliveries []*model.WebhookDelivery
+		total      int64
+	)
+
+	db := ctx.DB.Model(&model.WebhookDelivery{})
+	if params.WebhookUuid != "" {
+		db = db.Where("webhook_uuid = ?", params.WebhookUuid)
+	}
+	if params.Event != "" {
+		db = db.Where("event = ?", params.Event)
+	}
+	if params.Status != "" {
+		db = db.Where("status = ?", params.Status)
+	}
+
+	err := db.Count(&total).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get webhook delivery count", err)
+		return nil, errors.New("failed to get webhook delivery count")
+	}
+
+	err = db.Order("id DESC").Offset(params.GetOffset()).Limit(params.PageSize).Find(&deliveries).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get webhook delivery list", err)
+		return nil, errors.New("failed to get webhook delivery list")
+	}
+
+	return &model.PagedResponse{
+		Total:    total,
+		Data:     deliveries,
+		Current:  params.Current,
+		PageSize: params.PageSize,
+	}, nil
+}
+
+// GetDeliveryInfo 获取投递详情和每次投递的结果
+func (s *WebhookService) GetDeliveryInfo(ctx *app.Context, deliveryUuid string) (*model.WebhookDeliveryRes, error) {
+	delivery, err := s.getDelivery(ctx, deliveryUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := make([]*model.WebhookDeliveryAttempt, 0)
+	err = ctx.DB.Where("delivery_uuid = ?", deliveryUuid).Order("id").Find(&attempts).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get webhook delivery attempts", err)
+		return nil, errors.New("failed to get webhook delivery attempts")
+	}
+
+	return &model.WebhookDeliveryRes{
+		WebhookDelivery: *delivery,
+		AttemptList:     attempts,
+	}, nil
+}
+
+// Redeliver 手动重新投递，重置重试次数并立即推送，失败后按重试策略继续重试
+func (s *WebhookService) Redeliver(ctx *app.Context, deliveryUuid string) (*model.WebhookDeliveryRes, error) {
+	delivery, err := s.getDelivery(ctx, deliveryUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Format(time.DateTime)
+	err = ctx.DB.Model(&model.WebhookDelivery{}).Where("uuid = ?", delivery.Uuid).Updates(map[string]interface{}{
+		"status":          model.WebhookDeliveryStatusPending,
+		"attempts":        0,
+		"next_attempt_at": now,
+		"updated_at":      now,
+	}).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to reset webhook delivery", err)
+		return nil, errors.New("failed to redeliver webhook")
+	}
+	delivery.Status = model.WebhookDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+
+	err = s.deliver(ctx, delivery)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetDeliveryInfo(ctx, deliveryUuid)
+}
+
+// Publish 发布事件，为订阅了事件的webhook创建投递记录并异步推送，失败后由调度任务重试
+// 在事务提交后调用，tenantUuid为事件数据所属的租户，只推送给该租户的webhook
+func (s *WebhookService) Publish(ctx *app.Context, tenantUuid, event string, data interface{}) {
+	webhooks := make([]*model.Webhook, 0)
+	err := ctx.DB.Where("tenant_uuid = ? AND status = ?", tenantUuid, model.WebhookStatusEnabled).Find(&webhooks).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get webhooks", err)
+		return
+	}
+
+	subscribed := make([]*model.Webhook, 0)
+	for _, webhook := range webhooks {
+		for _, e := range strings.Split(webhook.Events, ",") {
+			if e == event {
+				subscribed = append(subscribed, webhook)
+				break
+			}
+		}
+	}
+	if len(subscribed) == 0 {
+		return
+	}
+
+	now := time.Now().Format(time.DateTime)
+	payload := &model.WebhookPayload{
+		Id:        uuid.New().String(),
+		Event:     event,
+		CreatedAt: now,
+		Data:      data,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		ctx.Logger.Error("Failed to marshal webhook payload", err)
+		return
+	}
+
+	deliveries := make([]*model.WebhookDelivery, 0)
+	for _, webhook := range subscribed {
+		deliveries = append(deliveries, &model.WebhookDelivery{
+			Uuid:          uuid.New().String(),
+			WebhookUuid:   webhook.Uuid,
+			AppUuid:       webhook.AppUuid,
+			EventId:       payload.Id,
+			Event:         event,
+			Url:           webhook.Url,
+			Payload:       string(body),
+			Status:        model.WebhookDeliveryStatusPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+	}
+
+	bg := ctx.Detach().WithTenant(tenantUuid)
+	err = bg.DB.Create(&deliveries).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to create webhook deliveries", err)
+		return
+	}
+
+	go func() {
+		defer func() {
+			if err := recover(); err != nil {
+				bg.Logger.Error("Webhook delivery panic", err)
+			}
+		}()
+		for _, delivery := range deliveries {
+			s.deliver(bg, delivery)
+		}
+	}()
+}
+
+// PublishOrder 发布订单事件，推送内容包含订单商品
+func (s *WebhookService) PublishOrder(ctx *app.Context, event string, order *model.Order) {
+	items, err := NewOrderService().GetOrderItemsByOrderNo(ctx, order.OrderNo)
+	if err != nil {
+		return
+	}
+	s.Publish(ctx, order.TenantUuid, event, &model.OrderRes{
+		Order: *order,
+		Items: items,
+	})
+}
+
+// PublishProduct 发布产品更新事件
+func (s *WebhookService) PublishProduct(ctx *app.Context, productUuid string) {
+	product, err := NewProductService().GetProductInfo(ctx, productUuid)
+	if err != nil {
+		return
+	}
+	s.Publish(ctx, product.TenantUuid, model.WebhookEventProductUpdated, product)
+}
+
+// ProcessDeliveries 调度任务，推送到期的投递
+func (s *WebhookService) ProcessDeliveries(ctx *app.Context) {
+	deliveries := make([]*model.WebhookDelivery, 0)
+	err := ctx.DB.Where("status = ? AND next_attempt_at <= ?", model.WebhookDeliveryStatusPending, time.Now().Format(time.DateTime)).
+		Order("id").Limit(webhookBatchSize).Find(&deliveries).Error
+	if err != nil {
+		ctx.Logger.Error("Failed to get due webhook deliveries", err)
+		return
+	}
+
+	for _, delivery := range deliveries {
+		s.deliver(ctx, delivery)
+	}
+}
+
+// 推送一次，先延后下次投递时间占用投递记录，避免异步推送和调度任务重复推送
+func (s *WebhookService) deliver(ctx *app.Context, delivery *model.WebhookDelivery) error {
+	timeout := time.Duration(ctx.Config.Webhook.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = webhookDefaultTimeout * time.Second
+	}
+
+	lease := time.Now().Add(timeout + time.Minute).Format(time.DateTime)
+	result := ctx.DB.Model(&model.WebhookDelivery{}).
+		Where("uuid = ? AND status = ? AND next_attempt_at = ?", delivery.Uuid, model.WebhookDeliveryStatusPending, delivery.NextAttemptAt).
+		Update("next_attempt_at", lease)
+	if result.Error != nil {
+		ctx.Logger.Error("Failed to claim webhook delivery", result.Error)
+		return errors.New("failed to deliver webhook")
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("webhook正在投递")
+	}
+
+	attempt := &model.WebhookDeliveryAttempt{
+		DeliveryUuid: delivery.Uuid,
+		Attempt:      delivery.Attempts + 1,
+	}
+	secKey, err := s.getSecKey(ctx, delivery)
+	if err != nil {
+		attempt.Error = err.Error()
+		return s.finish(ctx, delivery, attempt, true)
+	}
+
+	start := time.Now()
+	attempt.ResponseStatus, attempt.ResponseBody, err = s.send(ctx, delivery, secKey, timeout)
+	attempt.Duration = time.Since(start).Milliseconds()
+	if err != nil {
+		attempt.Error = err.Error()
+	} else if attempt.ResponseStatus < 200 || attempt.ResponseStatus >= 300 {
+		attempt.Error = fmt.Sprintf("unexpected status %d", attempt.ResponseStatus)
+	}
+	return s.finish(ctx, delivery, attempt, false)
+}
+
+// 获取签名使用的App SecKey，webhook或App已删除、禁用时不再推送
+func (s *WebhookService) getSecKey(ctx *app.Context, delivery *model.WebhookDelivery) (string, error) {
+	webhook := &model.Webhook{}
+	err := ctx.DB.Where("uuid = ?", delivery.WebhookUuid).First(webhook).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.New("webhook已删除")
+		}
+		ctx.Logger.Error("Failed to get webhook", err)
+		return "", errors.New("failed to get webhook")
+	}
+	if webhook.Status != model.WebhookStatusEnabled {
+		return "", errors.New("webhook已禁用")
+	}
+
+	appInfo, err := NewAppService().GetAppByUUID(ctx, delivery.AppUuid)
+	if err != nil {
+		return "", err
+	}
+	if appInfo.Status != model.AppStatusEnabled {
+		return "", errors.New("app未启用")
+	}
+	return appInfo.SecKey, nil
+}
+
+// 发送请求，body使用App的SecKey签名
+func (s *WebhookService) send(ctx *app.Context, delivery *model.WebhookDelivery, secKey string, timeout time.Duration) (int, string, error) {
+	reqCtx, cancel := context.WithTimeout(ctx.Ctx, timeout)
+	defer cancel()
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, delivery.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "sgin-webhook")
+	req.Header.Set("X-App-Id", delivery.AppUuid)
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-Id", delivery.EventId)
+	req.Header.Set("X-Webhook-Delivery", delivery.Uuid)
+	req.Header.Set("X-Timestamp", strconv.FormatInt(time.Now().Unix(), 10))
+	req.Header.Set("X-Signature", utils.SignBody(body, []byte(secKey)))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	return resp.StatusCode, string(respBody), nil
+}
+
+// 保存投递结果，失败时按2^(n-1)分钟后重试，超过最大次数或不能重试时标记失败
+func (s *WebhookService) finish(ctx *app.Context, delivery *model.WebhookDelivery, attempt *model.WebhookDeliveryAttempt, giveUp bool) error {
+	maxAttempts := ctx.Config.Webhook.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = webhookDefaultMaxAttempts
+	}
+
+	now := time.Now()
+	attempt.Error = s.truncate(attempt.Error, 500)
+	attempt.CreatedAt = now.Format(time.DateTime)
+
+	delivery.Attempts = attempt.Attempt
+	delivery.LastAttemptAt = attempt.CreatedAt
+	delivery.ResponseStatus = attempt.ResponseStatus
+	delivery.Error = attempt.Error
+	switch {
+	case attempt.Error == "":
+		delivery.Status = model.WebhookDeliveryStatusSuccess
+	case giveUp || delivery.Attempts >= maxAttempts:
+		delivery.Status = model.WebhookDeliveryStatusFailed
+	default:
+		delivery.NextAttemptAt = now.Add(s.backoff(delivery.Attempts)).Format(time.DateTime)
+	}
+
+	err := ctx.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(attempt).Error
+		if err != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to create webhook delivery attempt", err)
+			return errors.New("failed to save webhook delivery")
+		}
+
+		err = tx.Model(&model.WebhookDelivery{}).Where("uuid = ?", delivery.Uuid).Updates(map[string]interface{}{
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"last_attempt_at": delivery.LastAttemptAt,
+			"response_status": delivery.ResponseStatus,
+			"error":           delivery.Error,
+			"updated_at":      attempt.CreatedAt,
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			ctx.Logger.Error("Failed to update webhook delivery", err)
+			return errors.New("failed to save webhook delivery")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if delivery.Status == model.WebhookDeliveryStatusFailed {
+		ctx.Logger.Error("Webhook delivery failed", "delivery:", delivery.Uuid, "error:", delivery.Error)
+	}
+	return nil
+}
+
+// 第n次失败后等待2^(n-1)分钟，最长约17小时
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 11 {
+		attempts = 11
+	}
+	return time.Duration(1<<(attempts-1)) * time.Minute
+}
+
+// 按字符截断，避免超出字段长度
+func (s *WebhookService) truncate(str string, n int) string {
+	runes := []rune(str)
+	if len(runes) <= n {
+		return str
+	}
+	return string(runes[:n])
+}
+
+func (s *WebhookService) getDelivery(ctx *app.Context, deliveryUuid string) (*model.WebhookDelivery, error) {
+	delivery := &model.WebhookDelivery{}
+	err := ctx.DB.Where("uuid = ?", deliveryUuid).First(delivery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("webhook delivery not found")
+		}
+		ctx.Logger.Error("Failed to get webhook delivery", err)
+		return nil, errors.New("failed to get webhook delivery")
+	}
+	return delivery, nil
+}
+
+// 检查订阅的事件，返回逗号分隔的事件
+func (s *WebhookService) checkEvents(events []string) (string, error) {
+	supported := make(map[string]bool)
+	for _, event := range model.WebhookEvents {
+		supported[event] = true
+	}
+
+	list := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if !supported[event] {
+			return "", errors.New("不支持的事件: " + event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			list = append(list, event)
+		}
+	}
+	if len(list) == 0 {
+		return "", errors.New("请选择订阅的事件")
+	}
+	return strings.Join(list, ","), nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sgin/model"
+	"sgin/pkg/testutil"
+	"sgin/pkg/utils"
+)
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Minute},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{4, 8 * time.Minute},
+		{11, 1024 * time.Minute},
+		{20, 1024 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := NewWebhookService().backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+// 推送内容使用App的SecKey签名，失败后按退避时间重试，超过最大次数或webhook已禁用时标记失败
+func TestWebhookDeliver(t *testing.T) {
+	tests := []struct {
+		name          string
+		webhookStatus int
+		respStatus    int
+		attempts      int
+		wantStatus    string
+		wantSent      bool
+		wantDelay     time.Duration
+	}{
+		{"success", model.WebhookStatusEnabled, http.StatusOK, 0, model.WebhookDeliveryStatusSuccess, true, 0},
+		{"first failure", model.WebhookStatusEnabled, http.StatusInternalServerError, 0, model.WebhookDeliveryStatusPending, true, time.Minute},
+		{"fourth failure", model.WebhookStatusEnabled, http.StatusInternalServerError, 3, model.WebhookDeliveryStatusPending, true, 8 * time.Minute},
+		{"last attempt", model.WebhookStatusEnabled, http.StatusInternalServerError, 9, model.WebhookDeliveryStatusFailed, true, 0},
+		{"webhook disabled", model.WebhookStatusDisabled, http.StatusOK, 0, model.WebhookDeliveryStatusFailed, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				sent      bool
+				body      []byte
+				signature string
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sent = true
+				body, _ = io.ReadAll(r.Body)
+				signature = r.Header.Get("X-Signature")
+				w.WriteHeader(tt.respStatus)
+			}))
+			defer srv.Close()
+
+			ctx := testutil.NewContext(t)
+			rows := []interface{}{
+				&model.App{UUID: "app1", SecKey: "secret", Status: model.AppStatusEnabled},
+				&model.Webhook{Uuid: "hook1", AppUuid: "app1", Url: srv.URL, Events: model.WebhookEventOrderShipped, Status: tt.webhookStatus,
+					CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"},
+			}
+			for _, row := range rows {
+				err := ctx.DB.Create(row).Error
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			delivery := &model.WebhookDelivery{Uuid: "dl1", WebhookUuid: "hook1", AppUuid: "app1", Event: model.WebhookEventOrderShipped,
+				Url: srv.URL, Payload: `{"event":"order.shipped"}`, Status: model.WebhookDeliveryStatusPending, Attempts: tt.attempts,
+				NextAttemptAt: "2026-01-01 00:00:00", CreatedAt: "2026-01-01 00:00:00", UpdatedAt: "2026-01-01 00:00:00"}
+			err := ctx.DB.Create(delivery).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now().Truncate(time.Second)
+			err = NewWebhookService().deliver(ctx, delivery)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sent != tt.wantSent {
+				t.Fatalf("sent = %v, want %v", sent, tt.wantSent)
+			}
+			if sent && signature != utils.SignBody(body, []byte("secret")) {
+				t.Errorf("signature %q does not match body %s", signature, body)
+			}
+
+			saved := &model.WebhookDelivery{}
+			err = ctx.DB.Where("uuid = ?", "dl1").First(saved).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+			if saved.Status != tt.wantStatus || saved.Attempts != tt.attempts+1 {
+				t.Errorf("status = %s, attempts = %d, want %s, %d", saved.Status, saved.Attempts, tt.wantStatus, tt.attempts+1)
+			}
+			if tt.wantDelay > 0 {
+				next, _ := time.ParseInLocation(time.DateTime, saved.NextAttemptAt, time.Local)
+				if delay := next.Sub(start); delay < tt.wantDelay || delay > tt.wantDelay+2*time.Second {
+					t.Errorf("next attempt after %v, want %v", delay, tt.wantDelay)
+				}
+			}
+
+			var count int64
+			err = ctx.DB.Model(&model.WebhookDeliveryAttempt{}).Where("delivery_uuid = ?", "dl1").Count(&count).Error
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != 1 {
+				t.Errorf("attempt records = %d, want 1", count)
+			}
+		})
+	}
+}